@@ -2,48 +2,850 @@ package main
 
 import (
 	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
+	"io"
+	"math/rand"
 	"os"
-	"pokerclientv1/internal/game"
+	"os/signal"
+	"path/filepath"
+	"pokerclientv1/internal/commentary"
+	"pokerclientv1/internal/dashboard"
+	"pokerclientv1/internal/drill"
+	"pokerclientv1/internal/i18n"
+	"pokerclientv1/internal/notify"
 	"pokerclientv1/internal/player"
-	"pokerclientv1/internal/types"
+	"pokerclientv1/internal/replay"
+	"pokerclientv1/internal/server"
+	"pokerclientv1/internal/setup"
+	"pokerclientv1/internal/sim"
+	"pokerclientv1/internal/sound"
+	"pokerclientv1/internal/stats"
+	"pokerclientv1/internal/store"
 	"pokerclientv1/internal/ui"
-	"strconv"
+	"pokerclientv1/pkg/game"
+	"pokerclientv1/pkg/gametest"
+	"pokerclientv1/pkg/types"
 	"strings"
+	"syscall"
 	"time"
 )
 
+// subcommands run instead of a local game when named as the first argument.
+var subcommands = map[string]func(args []string) error{
+	"replay":    runReplay,
+	"serve":     runServe,
+	"join":      runJoin,
+	"drill":     runDrill,
+	"audit":     runAudit,
+	"stats":     runStats,
+	"dashboard": runDashboard,
+	"simulate":  runSimulate,
+	"sweep":     runSweep,
+	"arena":     runArena,
+}
+
 func main() {
+	if len(os.Args) > 1 {
+		if run, ok := subcommands[os.Args[1]]; ok {
+			if err := run(os.Args[2:]); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(1)
+			}
+			return
+		}
+	}
+
+	resumePath := flag.String("resume", "", "resume a game saved with the save command from this file")
+	showBetAdvice := flag.Bool("bet-advice", false, "show fold equity and equity when called before confirming a raise")
+	showPotOdds := flag.Bool("pot-odds", false, "show the price of calling as pot odds on your turn")
+	coach := flag.Bool("coach", false, "show pot odds and your hand's estimated equity on your turn")
+	dealLogPath := flag.String("deal-log", "", "append each hand's shuffle seed and dealt cards to this audit file")
+	withCommentary := flag.Bool("commentary", true, "comment on winning streaks, coolers and bad beats")
+	withDealer := flag.Bool("dealer", false, "have the dealer announce streets, the pot and whose action it is, with rules reminders on your turn")
+	withBanter := flag.Bool("banter", true, "let bots chat at the table, like a word to the winner or a taunt after a bluff")
+	soundMode := flag.String("sound", "off", "sound effects for cards, bets and your wins ("+strings.Join(sound.Modes, ", ")+"), bell rings the terminal bell")
+	reportPath := flag.String("report", "", "write a per-hand report of your equity, results and chip count to this file on exit, or - to print it")
+	showHUD := flag.Bool("hud", false, "show each opponent's VPIP, PFR, cold-call, squeeze, fold to c-bet and check-raise stats")
+	avatar := flag.String("avatar", "", "emoji or short glyph shown next to your name")
+	speedRun := flag.Bool("speed-run", false, "grind hands fast: no pauses, one-line table, single-key actions (f k c r a)")
+	turnClock := flag.Duration("turn-clock", 0, "time you and the bots have to act, e.g. 30s, before checking or folding automatically (0 for no clock)")
+	explain := flag.Bool("why", false, "training mode: the why command explains the last bot decision")
+	lang := flag.String("lang", i18n.FromEnv(), "language of bot explanations ("+strings.Join(i18n.Languages(), ", ")+")")
+	logHands := flag.Int("log-hands", 20, "hands kept for the log command; the whole session is saved to a log file on exit")
+	reveal := flag.String("reveal", "", "which hands are shown at showdown ("+strings.Join(game.RevealPolicyNames(), ", ")+"), choice by default: losers show or muck")
+	cashOuts := flag.Bool("cash-outs", false, "experimental: in a tournament, offer everyone their ICM value to cash out between hands")
+	jsonLines := flag.String("json-lines", "", "write the table, actions and events as JSON lines to this file, or - for stdout, instead of drawing the table")
+	seed := flag.Int64("seed", 0, "seed the shuffles and bot decisions to replay a game exactly (0 seeds from the clock)")
+	configPath := flag.String("config", "", "read the game's setup from this JSON or YAML file instead of asking for it; flags override it")
+	var flagSetup setup.Game
+	flag.Func("humans", fmt.Sprintf("comma-separated names of up to %d humans taking turns at this terminal", setup.MaxHumans), func(names string) error {
+		flagSetup.Humans = nil
+		for _, name := range strings.Split(names, ",") {
+			flagSetup.Humans = append(flagSetup.Humans, strings.TrimSpace(name))
+		}
+		return nil
+	})
+	flag.IntVar(&flagSetup.Bots, "bots", 0, fmt.Sprintf("number of bot opponents, 1 to %d", setup.MaxBots))
+	flag.Var(&flagSetup.Chips, "chips", "starting chips for every player, e.g. 1000 or 1.5k")
+	flag.StringVar(&flagSetup.Speed, "speed", "", "game speed ("+strings.Join(setup.Speeds, ", ")+")")
+	flag.StringVar(&flagSetup.Difficulty, "difficulty", "", "difficulty or personality of every bot (easy, medium, hard, "+strings.Join(player.PersonalityNames(), ", ")+")")
+	flag.StringVar(&flagSetup.Stakes, "stakes", "", "stakes ("+strings.Join(setup.StakesNames(), ", ")+")")
+	flag.StringVar(&flagSetup.Mode, "mode", "", "game mode (cash, tournament)")
+	var invariants game.InvariantMode
+	flag.Var(&invariants, "check-invariants", "debug: check the chips after every action and panic, or with =log dump the table, if they do not add up")
+	webhook := flag.String("webhook", "", "post a small JSON message to this URL when it is your turn and when a hand is over")
+	colors := colorFlags(flag.CommandLine)
+	flag.Parse()
+	consoleUI, err := colors()
+	if err == nil {
+		err = types.ValidateAvatar(*avatar)
+	}
+	if _, ok := game.LookupRevealPolicy(*reveal); err == nil && !ok {
+		err = fmt.Errorf("unknown reveal policy %q, choose from %s", *reveal, strings.Join(game.RevealPolicyNames(), ", "))
+	}
+	var effects sound.Sound
+	if err == nil {
+		effects, err = sound.New(*soundMode, os.Stdout)
+	}
+	var gameSetup setup.Game
+	if err == nil && *configPath != "" {
+		gameSetup, err = setup.Load(*configPath)
+	}
+	if err == nil {
+		flagSetup.Difficulty = strings.ToLower(flagSetup.Difficulty)
+		gameSetup = gameSetup.Override(flagSetup)
+		err = gameSetup.Validate()
+	}
+	if *seed == 0 {
+		*seed = gameSetup.Seed
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
 	fmt.Println("Welcome to Poker Client V1!")
 	reader := bufio.NewReader(os.Stdin)
+	var gameUI types.GameUI = consoleUI
+	if *speedRun {
+		gameUI = &ui.CompactUI{Out: consoleUI.Out, Theme: consoleUI.Theme}
+	}
+	var jsonUI *ui.JSONLinesUI
+	if *jsonLines != "" {
+		jsonUI, err = openJSONLines(*jsonLines)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		gameUI = jsonUI
+	}
 
-	// Get game settings from user
-	numBots := promptForInt(reader, "Enter the number of bot opponents: ", 1, 5) // Limit bots for simplicity
-	startingChips := promptForInt(reader, "Enter the starting chip amount for each player: ", 100, 10000)
-	gameSpeedChoice := promptForGameSpeed(reader, "Select game speed (instant, fast, default, slow): ")
-	gameSpeed := getSpeedDuration(gameSpeedChoice)
+	bankroll := openBankroll()
+	if bankroll != nil {
+		if *avatar == "" {
+			*avatar = bankroll.profile.Avatar
+		}
+		bankroll.profile.Avatar = *avatar
+	}
 
-	// Initialize the UI
-	consoleUI := ui.NewConsoleUI()
+	var pokerGame *game.Game
+	var tournament *game.Tournament
+	savePath := *resumePath
+	if savePath != "" {
+		pokerGame, tournament, err = resumeGame(reader, savePath, gameUI, *speedRun, bankroll)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	} else {
+		pokerGame, tournament, err = newGame(reader, gameSetup, gameUI, *speedRun, bankroll)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		if dir, err := store.DefaultDir(); err == nil {
+			savePath = filepath.Join(dir, "save.json")
+		}
+	}
+
+	// The first human owns the bankroll and profile; others share the
+	// terminal with them in hot-seat play
+	settings := loadSettings()
+	input := player.NewReaderInput(reader) // Shares the setup prompts' buffer
+	var humans []*player.HumanPlayer
+	for _, p := range pokerGame.Players {
+		if h, ok := p.(*player.HumanPlayer); ok {
+			h.Input = input
+			h.BetAdvice = *showBetAdvice
+			h.PotOdds = *showPotOdds
+			h.Coach = *coach
+			h.SpeedRun = *speedRun
+			h.Keys = settings.Keys
+			humans = append(humans, h)
+		}
+	}
+	var human *player.HumanPlayer
+	if len(humans) > 0 {
+		human = humans[0]
+		human.Avatar = *avatar
+		if bankroll != nil {
+			// Kept with the profile, which is written when the game ends
+			human.Prefs = bankroll.profile.Preferences
+			human.SavePrefs = func(prefs types.Preferences) { bankroll.profile.Preferences = prefs }
+		}
+	}
+	hotSeat := len(humans) > 1
+	if hotSeat {
+		seats := player.NewHotSeat(input, humans...)
+		seats.Clear = gameUI.ClearScreen
+		compact, _ := gameUI.(*ui.CompactUI)
+		consoleUI.SharedScreen = true
+		if compact != nil {
+			compact.SharedScreen = true
+		}
+		seats.OnPass = func(playerID string) {
+			consoleUI.Viewer = playerID
+			if compact != nil {
+				compact.Viewer = playerID
+			}
+			gameUI.DisplayGameState(pokerGame.Table, pokerGame.Players, pokerGame.Table.Pot, pokerGame.Table.Round)
+		}
+	}
+	if *seed != 0 {
+		seedGame(pokerGame, *seed)
+	}
+	pokerGame.TurnClock = *turnClock
+	pokerGame.CheckInvariants = invariants
+	if *reveal != "" {
+		pokerGame.Config.Reveal = *reveal
+	}
+	if tournament != nil {
+		tournament.CashOuts = *cashOuts
+	}
+
+	// Record every hand so the session can be reviewed with `poker replay`
+	historyPath, recorder := openHandHistory(settings.History)
+	if recorder != nil {
+		pokerGame.OnEvent(recorder.HandleEvent)
+	}
+
+	if *dealLogPath != "" {
+		f, err := os.OpenFile(*dealLogPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		defer f.Close()
+		pokerGame.DealLog = f
+	}
+
+	evTracker := stats.NewEVTracker()
+	pokerGame.OnEvent(evTracker.HandleEvent)
+	statsTracker := stats.NewTracker()
+	pokerGame.OnEvent(statsTracker.HandleEvent)
+	var reports []*stats.SessionReport
+	if *reportPath != "" {
+		for _, h := range humans {
+			report := stats.NewSessionReport(h.ID)
+			pokerGame.OnEvent(report.HandleEvent)
+			reports = append(reports, report)
+		}
+	}
+	for _, h := range humans {
+		h.Stats = func() string { return stats.Report(statsTracker.Stats()) }
+	}
+	if human != nil {
+		if bankroll != nil && bankroll.stake > 0 {
+			// Re-buys and add-ons come out of the bankroll the game was bought in from
+			human.Budget = func() int { return bankroll.profile.Chips }
+			pokerGame.OnEvent(func(e game.Event) {
+				if e.Type == game.EventRebuy && e.PlayerID == human.ID {
+					bankroll.buyIn(e.Amount)
+				}
+			})
+		}
+	}
+	if *showHUD {
+		consoleUI.HUD = statsTracker.HUD
+	}
+	humanID, viewer := "", "" // Whose cards the logs show, no one's at a shared screen
+	if human != nil {
+		humanID = human.ID
+		if !hotSeat {
+			viewer = humanID
+		}
+	}
+	if jsonUI != nil {
+		jsonUI.Viewer = viewer
+		pokerGame.OnEvent(jsonUI.HandleEvent)
+		if *jsonLines == "-" {
+			pokerGame.Out = os.Stderr // The engine's own messages would break up the JSON
+		}
+	}
+	actionLog := replay.NewActionLog(*logHands, viewer)
+	pokerGame.OnEvent(actionLog.HandleEvent)
+	var why func() string
+	if *explain {
+		why = whyCommand(pokerGame, i18n.Lang(*lang))
+	}
+	for _, h := range humans {
+		h.Log = actionLog.Page
+		h.Why = why
+	}
+	timing := stats.NewTiming()
+	pokerGame.OnEvent(timing.HandleEvent)
+	if *withCommentary {
+		pokerGame.OnEvent(commentary.New(os.Stdout).HandleEvent)
+	}
+	if *withDealer {
+		pokerGame.OnEvent(commentary.NewDealer(os.Stdout, viewer).HandleEvent)
+	}
+
+	// Table chat: the humans' say command and the bots' banter
+	switch u := gameUI.(type) {
+	case *ui.ConsoleUI:
+		pokerGame.OnEvent(u.HandleEvent)
+	case *ui.CompactUI:
+		pokerGame.OnEvent(u.HandleEvent)
+	}
+	for _, h := range humans {
+		id := h.ID
+		h.Chat = func(text string) { pokerGame.Chat(id, text) }
+	}
+	if *withBanter {
+		pokerGame.OnEvent(player.NewTableTalk(pokerGame.Players, pokerGame.Chat).HandleEvent)
+	}
+
+	// Sound effects, with the humans' own hole cards and wins
+	humanIDs := make([]string, len(humans))
+	for i, h := range humans {
+		humanIDs[i] = h.ID
+	}
+	pokerGame.OnEvent(sound.NewEffects(effects, humanIDs...).HandleEvent)
+	if *webhook != "" {
+		hook, err := notify.NewWebhook(*webhook, humanID)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		hook.Errors = pokerGame.Out
+		pokerGame.OnEvent(hook.HandleEvent)
+		defer hook.Close(5 * time.Second)
+	}
+
+	// The first Ctrl+C finishes the hand in progress, a second abandons it
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	interrupts := make(chan os.Signal, 2)
+	signal.Notify(interrupts, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-interrupts
+		fmt.Println("\nStopping after this hand. Press Ctrl+C again to stop now.")
+		pokerGame.Stop()
+		<-interrupts
+		fmt.Println("\nStopping now.")
+		cancel()
+	}()
+	for _, h := range humans {
+		h.Context = ctx
+	}
+	pokerGame.Start(ctx)
+	signal.Stop(interrupts)
+
+	if pokerGame.SaveRequested() {
+		saveGame(pokerGame, tournament, savePath)
+	} else if tournament != nil {
+		printStandings(tournament.Standings(pokerGame.Players))
+	}
+	if totals := evTracker.Totals(); len(totals) > 0 {
+		fmt.Print(stats.EVChart(totals))
+	}
+	if all := statsTracker.Stats(); len(all) > 0 {
+		fmt.Print(stats.Report(all))
+	}
+	fmt.Print(timing.Report(3))
+	writeSessionReports(reports, *reportPath)
+	if bankroll != nil && human != nil {
+		played, _ := statsTracker.Player(human.ID)
+		bankroll.settle(human.Chips, played.HandsDealt)
+	}
+
+	if recorder != nil {
+		if recorder.Err != nil {
+			fmt.Printf("Warning: could not save hand history: %v\n", recorder.Err)
+		} else {
+			fmt.Printf("Hand history saved. Review it with: poker replay %s\n", historyPath)
+		}
+	}
+	saveActionLog(actionLog, settings.History)
+
+	fmt.Println("Thank you for playing!")
+}
+
+// newGame asks for the game settings cfg leaves out and seats the players,
+// the bots as cfg's table describes them when it has one. A speed run plays
+// at instant speed without asking. With a bankroll the player is offered to
+// buy in from it.
+func newGame(reader *bufio.Reader, cfg setup.Game, gameUI types.GameUI, speedRun bool, bankroll *bankrollSession) (*game.Game, *game.Tournament, error) {
+	if cfg.Bots == 0 && len(cfg.Table) == 0 {
+		cfg.Bots = promptForInt(reader, "Enter the number of bot opponents: ", 1, setup.MaxBots)
+	}
+	config, ok := setup.Rules(cfg.Stakes)
+	if !ok {
+		config = promptForStakes(reader, "Select stakes (micro 1/2, low 5/10, mid 25/50, high 100/200): ")
+	}
+	if cfg.Ante != nil {
+		config.Ante = *cfg.Ante
+	} else {
+		config.Ante = promptForInt(reader, "Enter the ante (0 for none): ", 0, config.BigBlind)
+	}
+	config.Variant = cfg.Variant
+	if config.Variant == "" {
+		config.Variant = promptForVariant(reader, fmt.Sprintf("Select variant (%s): ", strings.Join(game.VariantNames(), ", ")))
+	}
+	if variant, _ := game.LookupVariant(config.Variant); !variant.PotLimit() {
+		limit := cfg.Limit
+		if limit == "" {
+			limit = promptForBettingLimit(reader, "Select betting structure (no-limit, pot-limit): ")
+		}
+		config.PotLimit = limit == "pot-limit"
+	}
+	if err := cfg.Fits(config); err != nil {
+		return nil, nil, err
+	}
+	config.Reveal = "choice" // Losing hands may be mucked, as at a live table
+	fmt.Printf("Playing %s.\n", config)
+	minChips, maxChips := config.BuyInRange()
+	fromBankroll := false
+	if bankroll != nil {
+		if bankroll.profile.Chips < minChips {
+			fmt.Printf("Your bankroll of %d chips is below the minimum buy-in of %d; playing without it.\n", bankroll.profile.Chips, minChips)
+		} else if cfg.Bankroll != nil {
+			fromBankroll = *cfg.Bankroll
+		} else {
+			fromBankroll = promptForYesNo(reader, fmt.Sprintf("Buy in from your bankroll of %d chips? [Y/n]: ", bankroll.profile.Chips))
+		}
+		if fromBankroll {
+			maxChips = min(maxChips, bankroll.profile.Chips)
+		}
+	}
+	startingChips := int(cfg.Chips)
+	if startingChips == 0 || startingChips > maxChips {
+		startingChips = promptForInt(reader, fmt.Sprintf("Enter the starting chip amount for each player (%d-%d): ", minChips, maxChips), minChips, maxChips)
+	}
+	if fromBankroll {
+		bankroll.buyIn(startingChips)
+	}
+	speed := cfg.Speed
+	if speedRun {
+		speed = "instant"
+	} else if speed == "" {
+		speed = promptForGameSpeed(reader, "Select game speed (instant, fast, default, slow): ")
+	}
+	gameSpeed := getSpeedDuration(speed)
+	gameMode := cfg.Mode
+	if gameMode == "" {
+		gameMode = promptForGameMode(reader, "Select game mode (cash, tournament): ")
+	}
+	if gameMode == "cash" {
+		rebuy := cfg.Rebuy != nil && *cfg.Rebuy
+		if cfg.Rebuy == nil {
+			rebuy = promptForYesNo(reader, fmt.Sprintf("Allow re-buys and add-ons up to %d chips between hands? [Y/n]: ", startingChips))
+		}
+		if rebuy {
+			config.Rebuy = game.RebuyPolicy{UpTo: startingChips, AddOn: true, Bots: true}
+		}
+	}
 
 	// Create players
 	players := []types.Player{}
-	humanPlayer := player.NewHumanPlayer("Player 1", startingChips)
-	players = append(players, humanPlayer)
-
-	for i := 0; i < numBots; i++ {
-		botID := fmt.Sprintf("Bot %d", i+1)
-		difficulty := promptForDifficulty(reader, fmt.Sprintf("Enter difficulty for %s (easy, medium, hard): ", botID))
-		// Use a default turn delay for now
-		botPlayer := player.NewBotPlayer(botID, startingChips, difficulty, 500*time.Millisecond)
+	for _, name := range humanNames(reader, cfg) {
+		players = append(players, player.NewHumanPlayer(name, startingChips))
+	}
+
+	for _, bot := range cfg.Players() {
+		if bot.Difficulty == "" {
+			bot.Difficulty = promptForDifficulty(reader, fmt.Sprintf("Enter difficulty or personality for %s (easy, medium, hard, %s): ", bot.Name, strings.Join(player.PersonalityNames(), ", ")))
+		}
+		if bot.Chips == 0 {
+			bot.Chips = types.Amount(startingChips)
+		}
+		botPlayer := player.NewBotPlayer(bot.Name, int(bot.Chips), bot.Difficulty, time.Duration(bot.Delay))
+		if bot.Avatar != "" {
+			botPlayer.Avatar = bot.Avatar
+		}
 		players = append(players, botPlayer)
 	}
 
-	// Create and start the game
-	pokerGame := game.NewGame(players, consoleUI, gameSpeed) // Pass game speed
-	pokerGame.Start()
+	// Create the game
+	pokerGame := game.NewGame(players, gameUI, gameSpeed, config) // Pass game speed and stakes
 
-	fmt.Println("Thank you for playing!")
+	// In tournament mode the blinds go up on a schedule and the chips are the buy-in
+	var tournament *game.Tournament
+	if gameMode == "tournament" {
+		handsPerLevel := cfg.HandsPerLevel
+		if handsPerLevel == 0 {
+			handsPerLevel = promptForInt(reader, "Enter the number of hands per blind level: ", 1, 100)
+		}
+		schedule := game.DoublingSchedule(config.SmallBlind, config.BigBlind, handsPerLevel, 12)
+		tournament = game.NewTournament(startingChips, schedule, game.DefaultPayouts(len(players)))
+		tournament.Attach(pokerGame)
+	}
+	return pokerGame, tournament, nil
+}
+
+// resumeGame loads a saved game and seats its players again. The human's
+// saved stack is bought in from the bankroll again when it covers it.
+func resumeGame(reader *bufio.Reader, path string, gameUI types.GameUI, speedRun bool, bankroll *bankrollSession) (*game.Game, *game.Tournament, error) {
+	saved, err := game.LoadGame(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	fmt.Printf("Resuming %s from hand %d.\n", saved.Config, saved.HandNumber+1)
+	gameSpeed := getSpeedDuration("instant")
+	if !speedRun {
+		gameSpeed = getSpeedDuration(promptForGameSpeed(reader, "Select game speed (instant, fast, default, slow): "))
+	}
+
+	players := []types.Player{}
+	owner := true // The first human plays from the bankroll
+	for _, sp := range saved.Players {
+		if sp.Human {
+			if owner && bankroll != nil && bankroll.profile.Chips >= sp.Chips {
+				bankroll.buyIn(sp.Chips)
+			}
+			owner = false
+			players = append(players, player.NewHumanPlayer(sp.ID, sp.Chips))
+		} else {
+			players = append(players, player.NewBotPlayer(sp.ID, sp.Chips, sp.Difficulty, 500*time.Millisecond))
+		}
+	}
+
+	pokerGame := game.NewGame(players, gameUI, gameSpeed, saved.Config)
+	if err := pokerGame.Restore(saved); err != nil {
+		return nil, nil, err
+	}
+	var tournament *game.Tournament
+	if saved.Tournament != nil {
+		tournament = game.ResumeTournament(saved.Tournament, pokerGame.Schedule)
+		tournament.Attach(pokerGame)
+	}
+	return pokerGame, tournament, nil
+}
+
+// bankrollSession is the human player's profile for one game and the chips
+// taken from it to buy in.
+type bankrollSession struct {
+	path    string
+	profile store.Bankroll
+	stake   int // Chips bought in with, 0 when playing without the bankroll
+}
+
+// openBankroll loads the profile, creating one with store.DefaultBankroll
+// chips on the first run. It returns nil with a warning if the profile
+// cannot be used.
+func openBankroll() *bankrollSession {
+	path, err := store.ProfilePath()
+	if err != nil {
+		fmt.Printf("Warning: bankroll disabled: %v\n", err)
+		return nil
+	}
+	profile, err := store.LoadProfile(path)
+	if errors.Is(err, store.ErrNotFound) {
+		profile = store.Bankroll{Profile: "Player 1", Chips: store.DefaultBankroll}
+		fmt.Printf("Created a new profile with a bankroll of %d chips.\n", profile.Chips)
+	} else if err != nil {
+		fmt.Printf("Warning: bankroll disabled: %v\n", err)
+		return nil
+	} else {
+		fmt.Printf("Bankroll: %d chips, %+d over %d hands.\n", profile.Chips, profile.NetResult, profile.LifetimeHands)
+	}
+	return &bankrollSession{path: path, profile: profile}
+}
+
+// buyIn takes chips from the bankroll for the human's stack.
+func (b *bankrollSession) buyIn(chips int) {
+	b.profile.Chips -= chips
+	b.stake += chips
+}
+
+// settle returns the human's chips to the bankroll, adds the session to the
+// career totals and writes the profile. Chips in a saved game are returned
+// too and bought in again when it is resumed.
+func (b *bankrollSession) settle(chips, hands int) {
+	if b.stake > 0 {
+		b.profile.Chips += chips
+		b.profile.NetResult += chips - b.stake
+		fmt.Printf("Bankroll: %d chips (%+d this game).\n", b.profile.Chips, chips-b.stake)
+	}
+	b.profile.LifetimeHands += hands
+	b.profile.Updated = time.Now()
+	if err := store.SaveProfile(b.path, b.profile); err != nil {
+		fmt.Printf("Warning: could not save your bankroll: %v\n", err)
+	}
+}
+
+// seedGame makes the deals and bot decisions follow seed, so the same seed
+// and the same human actions replay the same game.
+func seedGame(pokerGame *game.Game, seed int64) {
+	pokerGame.Rng = rand.New(rand.NewSource(seed))
+	for i, p := range pokerGame.Players {
+		if bot, ok := p.(*player.BotPlayer); ok {
+			bot.AI.Rng = rand.New(rand.NewSource(seed + int64(i) + 1))
+		}
+	}
+}
+
+// saveGame writes the stopped game to path so it can be resumed with --resume.
+func saveGame(pokerGame *game.Game, tournament *game.Tournament, path string) {
+	saved := pokerGame.Snapshot()
+	if tournament != nil {
+		saved.Tournament = tournament.Save()
+	}
+	if path == "" {
+		fmt.Println("Could not save the game: no save location available.")
+		return
+	}
+	if err := game.SaveGame(path, saved); err != nil {
+		fmt.Printf("Could not save the game: %v\n", err)
+		return
+	}
+	fmt.Printf("Game saved. Resume it with: poker --resume %s\n", path)
+}
+
+// writeSessionReports prints the humans' session reports, or writes them to
+// path unless it is "-".
+func writeSessionReports(reports []*stats.SessionReport, path string) {
+	var b strings.Builder
+	for _, report := range reports {
+		b.WriteString(report.Report())
+	}
+	if b.Len() == 0 {
+		return
+	}
+	if path == "-" {
+		fmt.Print(b.String())
+		return
+	}
+	if err := os.WriteFile(path, []byte(b.String()), 0o644); err != nil {
+		fmt.Printf("Warning: could not save the session report: %v\n", err)
+		return
+	}
+	fmt.Printf("Session report saved to %s\n", path)
+}
+
+// saveActionLog writes the session's action log to a new file in the logs
+// directory of the local store, warning if it cannot. Old logs are deleted and
+// the new one is gzipped as the history's retention settings say.
+func saveActionLog(log *replay.ActionLog, retention store.Retention) {
+	if log.Hands() == 0 {
+		return
+	}
+	dir, err := store.DefaultDir()
+	if err == nil {
+		dir = filepath.Join(dir, "logs")
+		err = os.MkdirAll(dir, 0o700)
+	}
+	if err != nil {
+		fmt.Printf("Warning: could not save the action log: %v\n", err)
+		return
+	}
+	path := filepath.Join(dir, "session-"+time.Now().Format("20060102-150405")+".log")
+	if retention.Compress {
+		path += ".gz"
+	}
+	f, err := os.Create(path)
+	if err == nil {
+		var w io.Writer = f
+		var gz *gzip.Writer
+		if retention.Compress {
+			gz = gzip.NewWriter(f)
+			w = gz
+		}
+		_, err = log.WriteTo(w)
+		if gz != nil {
+			if cerr := gz.Close(); err == nil {
+				err = cerr
+			}
+		}
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+	}
+	if err != nil {
+		fmt.Printf("Warning: could not save the action log: %v\n", err)
+		return
+	}
+	fmt.Printf("Action log saved to %s\n", path)
+	if err := retention.Prune(filepath.Join(dir, "session-*.log*")); err != nil {
+		fmt.Printf("Warning: could not delete old action logs: %v\n", err)
+	}
+}
+
+// openJSONLines creates the JSON lines UI of --json-lines, writing to stdout
+// for "-" and otherwise appending to a file that is left open until exit.
+func openJSONLines(path string) (*ui.JSONLinesUI, error) {
+	if path == "-" {
+		return ui.NewJSONLinesUI(os.Stdout), nil
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	return ui.NewJSONLinesUI(f), nil
+}
+
+// loadSettings reads the config file of the local store, warning and using
+// the defaults if it cannot.
+func loadSettings() store.Settings {
+	dir, err := store.DefaultDir()
+	if err != nil {
+		return store.Settings{History: store.DefaultRetention}
+	}
+	settings, err := store.LoadSettings(dir)
+	if err != nil {
+		fmt.Printf("Warning: using default settings: %v\n", err)
+	}
+	return settings
+}
+
+// openHandHistory opens the local hand history store. Recording is skipped
+// with a warning if the store cannot be opened.
+func openHandHistory(retention store.Retention) (string, *store.HandRecorder) {
+	dir, err := store.DefaultDir()
+	if err != nil {
+		fmt.Printf("Warning: hand history disabled: %v\n", err)
+		return "", nil
+	}
+	dir = filepath.Join(dir, "data")
+	s, err := store.OpenJSON(dir)
+	if err != nil {
+		fmt.Printf("Warning: hand history disabled: %v\n", err)
+		return "", nil
+	}
+	s.Retention = retention
+	sessionID := time.Now().Format("20060102-150405")
+	return filepath.Join(dir, "hands.jsonl"), store.NewHandRecorder(s, sessionID)
+}
+
+// runReplay implements `poker replay [--speed d] [--session id] <file>`, and
+// `poker replay --diff STRATEGY --player id <file>`, which shows what
+// another strategy would have done at each of a player's decisions.
+func runReplay(args []string) error {
+	fs := flag.NewFlagSet("replay", flag.ContinueOnError)
+	colors := colorFlags(fs)
+	speed := fs.Duration("speed", time.Second, "pause between events when playing")
+	session := fs.String("session", "", "only replay hands from this session")
+	diff := fs.String("diff", "", "compare the player's decisions with a bot difficulty or personality, or script:FILE for a decision file")
+	playerID := fs.String("player", "", "player whose decisions --diff compares")
+	seed := fs.Int64("seed", 0, "seed the equity estimates and the bot's decisions for --diff (0 seeds from the clock)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 || (*diff == "") != (*playerID == "") {
+		return fmt.Errorf("usage: poker replay [--speed 1s] [--session id] <file>\n" +
+			"       poker replay --diff tag --player \"Player 1\" [--session id] [--seed s] <file>")
+	}
+
+	hands, err := replay.Load(fs.Arg(0), *session)
+	if err != nil {
+		return err
+	}
+	if *diff != "" {
+		if *seed == 0 {
+			*seed = time.Now().UnixNano()
+		}
+		alt, err := newStrategy(*diff, *seed)
+		if err != nil {
+			return err
+		}
+		decisions := replay.Diff(hands, *playerID, alt, 0, rand.New(rand.NewSource(*seed)))
+		fmt.Print(replay.DiffSummary(decisions))
+		return nil
+	}
+	consoleUI, err := colors()
+	if err != nil {
+		return err
+	}
+	r := replay.NewReplayer(replay.Frames(hands), consoleUI, os.Stdout, *speed)
+	return r.Run(os.Stdin)
+}
+
+// newStrategy creates a player for a strategy named as in `poker simulate
+// --bots`: a bot difficulty or personality, or script:FILE for a decision
+// file. Bots decide from a source seeded with seed.
+func newStrategy(config string, seed int64) (replay.Strategy, error) {
+	if path, ok := strings.CutPrefix(config, sim.ScriptPrefix); ok {
+		scripted, err := gametest.LoadScriptedPlayer("Alternate", 0, path)
+		if err != nil {
+			return nil, err
+		}
+		return scripted, nil
+	}
+	config = strings.ToLower(config)
+	if !player.ValidDifficulty(config) {
+		return nil, fmt.Errorf("unknown strategy %q, choose a difficulty (easy, medium, hard), a personality (%s) or script:FILE", config, strings.Join(player.PersonalityNames(), ", "))
+	}
+	bot := player.NewBotPlayer("Alternate", 0, config, 0)
+	bot.AI.Rng = rand.New(rand.NewSource(seed))
+	return bot, nil
+}
+
+// humanNames returns the names of the humans at the terminal, asking how
+// many there are and what they are called unless cfg says.
+func humanNames(reader *bufio.Reader, cfg setup.Game) []string {
+	if len(cfg.Humans) > 0 {
+		return cfg.Humans
+	}
+	taken := map[string]bool{}
+	for _, bot := range cfg.Players() {
+		taken[bot.Name] = true
+	}
+	n := promptForInt(reader, fmt.Sprintf("Enter the number of human players at this terminal (1-%d): ", setup.MaxHumans), 1, setup.MaxHumans)
+	names := make([]string, n)
+	for i := range names {
+		fallback := fmt.Sprintf("Player %d", i+1)
+		names[i] = promptForName(reader, fmt.Sprintf("Enter the name of player %d [%s]: ", i+1, fallback), fallback, taken)
+		taken[names[i]] = true
+	}
+	return names
+}
+
+// Helper function to prompt for a player's name not yet taken, fallback when
+// left empty
+func promptForName(reader *bufio.Reader, prompt string, fallback string, taken map[string]bool) string {
+	for {
+		fmt.Print(prompt)
+		input, _ := reader.ReadString('\n')
+		name := strings.TrimSpace(input)
+		if name == "" {
+			name = fallback
+		}
+		if !taken[name] {
+			return name
+		}
+		fmt.Printf("%s is already at the table. Please choose another name.\n", name)
+	}
+}
+
+// Helper function to prompt for a yes or no answer, yes by default
+func promptForYesNo(reader *bufio.Reader, prompt string) bool {
+	for {
+		fmt.Print(prompt)
+		input, _ := reader.ReadString('\n')
+		switch strings.TrimSpace(strings.ToLower(input)) {
+		case "", "y", "yes":
+			return true
+		case "n", "no":
+			return false
+		}
+		fmt.Println("Invalid input. Please enter 'y' or 'n'.")
+	}
 }
 
 // Helper function to prompt for integer input
@@ -52,7 +854,7 @@ func promptForInt(reader *bufio.Reader, prompt string, min int, max int) int {
 		fmt.Print(prompt)
 		input, _ := reader.ReadString('\n')
 		input = strings.TrimSpace(input)
-		val, err := strconv.Atoi(input)
+		val, err := types.ParseAmount(input)
 		if err == nil && val >= min && val <= max {
 			return val
 		}
@@ -60,16 +862,16 @@ func promptForInt(reader *bufio.Reader, prompt string, min int, max int) int {
 	}
 }
 
-// Helper function to prompt for difficulty
+// Helper function to prompt for difficulty or a personality preset
 func promptForDifficulty(reader *bufio.Reader, prompt string) string {
 	for {
 		fmt.Print(prompt)
 		input, _ := reader.ReadString('\n')
 		input = strings.TrimSpace(strings.ToLower(input))
-		if input == "easy" || input == "medium" || input == "hard" {
+		if player.ValidDifficulty(input) {
 			return input
 		}
-		fmt.Println("Invalid input. Please enter 'easy', 'medium', or 'hard'.")
+		fmt.Printf("Invalid input. Please enter 'easy', 'medium', 'hard' or one of: %s.\n", strings.Join(player.PersonalityNames(), ", "))
 	}
 }
 
@@ -87,6 +889,101 @@ func promptForGameSpeed(reader *bufio.Reader, prompt string) string {
 	}
 }
 
+// whyCommand returns the human's why command: it explains the decision of
+// the bot that acted last, in lang.
+func whyCommand(g *game.Game, lang string) func() string {
+	var last *player.BotPlayer
+	g.OnEvent(func(e game.Event) {
+		if e.Type != game.EventPlayerAction {
+			return
+		}
+		for _, p := range g.Players {
+			if bot, ok := p.(*player.BotPlayer); ok && bot.ID == e.PlayerID {
+				last = bot
+			}
+		}
+	})
+	return func() string {
+		if last == nil {
+			return i18n.T(lang, "why.none") + "\n"
+		}
+		return last.AI.Last.Explain(last.ID, last.AI.Difficulty, lang)
+	}
+}
+
+// Helper function to prompt for the table stakes
+func promptForStakes(reader *bufio.Reader, prompt string) game.GameConfig {
+	for {
+		fmt.Print(prompt)
+		input, _ := reader.ReadString('\n')
+		input = strings.TrimSpace(strings.ToLower(input))
+		if config, ok := setup.Rules(input); ok {
+			return config
+		}
+		fmt.Println("Invalid input. Please enter 'micro', 'low', 'mid', or 'high'.")
+	}
+}
+
+// Helper function to prompt for the betting structure
+func promptForBettingLimit(reader *bufio.Reader, prompt string) string {
+	for {
+		fmt.Print(prompt)
+		input, _ := reader.ReadString('\n')
+		input = strings.TrimSpace(strings.ToLower(input))
+		if input == "no-limit" || input == "pot-limit" {
+			return input
+		}
+		fmt.Println("Invalid input. Please enter 'no-limit' or 'pot-limit'.")
+	}
+}
+
+// Helper function to prompt for a registered poker variant
+func promptForVariant(reader *bufio.Reader, prompt string) string {
+	for {
+		fmt.Print(prompt)
+		input, _ := reader.ReadString('\n')
+		input = strings.TrimSpace(strings.ToLower(input))
+		if _, ok := game.LookupVariant(input); ok && input != "" {
+			return input
+		}
+		fmt.Printf("Invalid input. Please enter one of: %s.\n", strings.Join(game.VariantNames(), ", "))
+	}
+}
+
+// Helper function to prompt for game mode
+func promptForGameMode(reader *bufio.Reader, prompt string) string {
+	for {
+		fmt.Print(prompt)
+		input, _ := reader.ReadString('\n')
+		input = strings.TrimSpace(strings.ToLower(input))
+		if input == "cash" || input == "tournament" {
+			return input
+		}
+		fmt.Println("Invalid input. Please enter 'cash' or 'tournament'.")
+	}
+}
+
+// Helper function to print tournament results
+func printStandings(standings []game.Standing) {
+	fmt.Println("\n--- Tournament Standings ---")
+	for _, s := range standings {
+		if s.CashedOut {
+			fmt.Printf("-  %s (cashed out after hand %d) - takes %d\n", s.PlayerID, s.Hand, s.Payout)
+			continue
+		}
+		line := fmt.Sprintf("%d. %s", s.Place, s.PlayerID)
+		if s.Hand > 0 {
+			line += fmt.Sprintf(" (eliminated hand %d)", s.Hand)
+		} else {
+			line += fmt.Sprintf(" (%d chips)", s.Chips)
+		}
+		if s.Payout > 0 {
+			line += fmt.Sprintf(" - wins %d", s.Payout)
+		}
+		fmt.Println(line)
+	}
+}
+
 // Helper function to get duration from speed choice
 func getSpeedDuration(speed string) time.Duration {
 	switch speed {
@@ -102,3 +999,504 @@ func getSpeedDuration(speed string) time.Duration {
 		return 1 * time.Second // Default speed
 	}
 }
+
+// runServe implements `poker serve`: host a table for remote players.
+func runServe(args []string) error {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	port := fs.Int("port", 9000, "port to accept players on")
+	humans := fs.Int("players", 2, "number of remote players to wait for")
+	bots := fs.Int("bots", 0, "number of bots to seat after the players")
+	difficulty := fs.String("difficulty", "medium", "difficulty or personality of the bots")
+	banter := fs.Bool("banter", true, "let the bots chat at the table")
+	chips := types.Amount(200)
+	fs.Var(&chips, "chips", "starting chips for every player, e.g. 200 or 1.5k")
+	speed := fs.String("speed", "default", "game speed (instant, fast, default, slow)")
+	turnTimeout := fs.Duration("turn-timeout", 60*time.Second, "time a player has to act, 0 for no limit")
+	healthAddr := fs.String("health", "", "address for the /healthz and /readyz endpoints, e.g. :8080")
+	historyDir := fs.String("history", "", "directory to keep a hash-chained hand history in")
+	wsAddr := fs.String("ws", "", "address to accept browser clients on at /ws, e.g. :9001")
+	reconnect := fs.Duration("reconnect", time.Minute, "how long a disconnected player keeps their seat")
+	timebank := fs.Duration("timebank", 0, "extra time each player may draw on over the whole game once --turn-timeout runs out, 0 for none")
+	timebankFile := fs.String("timebank-file", "", "file to keep time banks in, so they survive a restart of the server")
+	socket := fs.String("socket", "", "accept players on a local Unix socket at this path instead of --port, for GUIs and scripts")
+	latency := fs.Duration("simulate-latency", 0, "for testing: delay every message to and from players by this much")
+	jitter := fs.Duration("simulate-jitter", 0, "for testing: delay each message by up to this much more, at random")
+	accountsPath := fs.String("accounts", "", "file of registered players, who join with a password and keep their chips between games")
+	registeredOnly := fs.Bool("registered-only", false, "turn away guests who join without a password (needs --accounts)")
+	rules := game.DefaultGameConfig()
+	fs.IntVar(&rules.MinBuyInBB, "min-buyin", rules.MinBuyInBB, "smallest buy-in in big blinds, 0 for no minimum")
+	fs.IntVar(&rules.MaxBuyInBB, "max-buyin", rules.MaxBuyInBB, "largest buy-in in big blinds, 0 for no maximum")
+	fs.StringVar(&rules.Reveal, "reveal", "", "which hands are shown at showdown ("+strings.Join(game.RevealPolicyNames(), ", ")+"), all by default")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *humans < 1 || *humans+*bots < 2 {
+		return fmt.Errorf("a table needs at least 2 players and 1 of them remote")
+	}
+	if err := rules.Validate(); err != nil {
+		return err
+	}
+	if err := rules.CheckBuyIn(int(chips)); err != nil {
+		return fmt.Errorf("--chips: %w", err)
+	}
+	if *registeredOnly && *accountsPath == "" {
+		return fmt.Errorf("--registered-only needs --accounts")
+	}
+
+	cfg := server.Config{
+		Addr:          fmt.Sprintf(":%d", *port),
+		Humans:        *humans,
+		Rules:         rules,
+		StartingChips: int(chips),
+		GameSpeed:     getSpeedDuration(*speed),
+		TurnTimeout:   *turnTimeout,
+		Reconnect:     *reconnect,
+		WebSocketAddr: *wsAddr,
+		Audit:         server.NewAuditLog(os.Stderr),
+		Health:        server.NewHealth(),
+		Latency:       server.Latency{Delay: *latency, Jitter: *jitter},
+		Log:           os.Stdout,
+		Banter:        *banter,
+
+		RegisteredOnly: *registeredOnly,
+	}
+	if *timebank > 0 {
+		timebanks, err := server.NewTimebankStore(*timebankFile, *timebank)
+		if err != nil {
+			return err
+		}
+		cfg.Timebanks = timebanks
+	}
+	if *accountsPath != "" {
+		accounts, err := server.OpenAccounts(*accountsPath)
+		if err != nil {
+			return err
+		}
+		cfg.Accounts = accounts
+	}
+	if *socket != "" {
+		cfg.Addr = "unix:" + *socket
+	}
+	for i := 0; i < *bots; i++ {
+		cfg.Bots = append(cfg.Bots, player.NewBotPlayer(fmt.Sprintf("Bot %d", i+1), int(chips), *difficulty, 500*time.Millisecond))
+	}
+	if *historyDir != "" {
+		s, err := store.OpenJSON(*historyDir)
+		if err != nil {
+			return err
+		}
+		s.Retention = loadSettings().History
+		hands, err := s.Hands("")
+		if err != nil {
+			return err
+		}
+		if err := store.VerifyChain(hands); err != nil {
+			return fmt.Errorf("hand history in %s failed verification: %w", *historyDir, err)
+		}
+		sessionID := time.Now().Format("20060102-150405")
+		cfg.OnEvent = store.NewChainedHandRecorder(s, sessionID, store.LastHash(hands)).HandleEvent
+	}
+
+	// SIGTERM or Ctrl+C stops new hands; the hand in progress is played out
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if *healthAddr != "" {
+		go server.ServeHealth(ctx, *healthAddr, cfg.Health, 5*time.Minute)
+	}
+	return server.NewServer(cfg).Run(ctx)
+}
+
+// runJoin implements `poker join host:port`: play at a remote table. A
+// unix:/path address joins a table served with --socket.
+func runJoin(args []string) error {
+	fs := flag.NewFlagSet("join", flag.ContinueOnError)
+	colors := colorFlags(fs)
+	name := fs.String("name", "Player", "name to play under")
+	avatar := fs.String("avatar", "", "emoji or short glyph shown next to your name")
+	var buyIn types.Amount
+	fs.Var(&buyIn, "buyin", "chips to sit down with, within the table's buy-in range; the table default when unset")
+	password := fs.String("password", os.Getenv("POKER_PASSWORD"), "password of your registered name, registering it on first use; $POKER_PASSWORD by default")
+	webhook := fs.String("webhook", "", "post a small JSON message to this URL when it is your turn and when a hand is over")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := types.ValidateAvatar(*avatar); err != nil {
+		return err
+	}
+	var hook *notify.Webhook
+	if *webhook != "" {
+		var err error
+		if hook, err = notify.NewWebhook(*webhook, *name); err != nil {
+			return err
+		}
+		hook.Errors = os.Stdout
+		defer hook.Close(5 * time.Second)
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("usage: poker join [--name you] host:port|unix:/path/to/socket")
+	}
+	consoleUI, err := colors()
+	if err != nil {
+		return err
+	}
+	join := server.Message{Type: server.MsgJoin, Player: *name, Avatar: *avatar, BuyIn: buyIn, Password: *password}
+	client, err := server.DialJoin(fs.Arg(0), join, consoleUI, os.Stdin, os.Stdout)
+	if err != nil {
+		return err
+	}
+	client.Keys = loadSettings().Keys
+	if hook != nil {
+		client.OnEvent = hook.HandleEvent
+	}
+	return client.Run()
+}
+
+// runDrill plays the same hole cards and board once from every seat.
+func runDrill(args []string) error {
+	fs := flag.NewFlagSet("drill", flag.ContinueOnError)
+	colors := colorFlags(fs)
+	hand := fs.String("hand", "", "your hole cards, e.g. AsKd")
+	board := fs.String("board", "", "up to five board cards, e.g. 2c7h9d; the rest are dealt at random once")
+	opponents := fs.Int("opponents", 5, "number of bot opponents")
+	chips := types.Amount(200)
+	fs.Var(&chips, "chips", "starting chips for every player each round, e.g. 200 or 1.5k")
+	speed := fs.String("speed", "default", "game speed (instant, fast, default, slow)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	hole, err := types.ParseCards(*hand)
+	if err != nil || len(hole) != 2 {
+		return fmt.Errorf("usage: poker drill --hand AsKd [--board 2c7h9d] [--opponents 5]")
+	}
+	boardCards, err := types.ParseCards(*board)
+	if err != nil {
+		return err
+	}
+	if len(boardCards) > 5 {
+		return fmt.Errorf("the board has at most five cards, got %d", len(boardCards))
+	}
+	if *opponents < 1 || *opponents > 9 {
+		return fmt.Errorf("opponents must be between 1 and 9, got %d", *opponents)
+	}
+
+	consoleUI, err := colors()
+	if err != nil {
+		return err
+	}
+	d := &drill.Drill{
+		Hole:      hole,
+		Board:     boardCards,
+		Opponents: *opponents,
+		Chips:     int(chips),
+		Rules:     game.DefaultGameConfig(),
+		GameSpeed: getSpeedDuration(*speed),
+	}
+	input := player.NewReaderInput(os.Stdin) // Shared by the human of every round
+	results := d.Run(func(chips int) types.Player {
+		human := player.NewHumanPlayer("You", chips)
+		human.Input = input
+		return human
+	}, consoleUI, os.Stdout)
+	fmt.Print(drill.Summary(results))
+	return nil
+}
+
+// runSimulate plays bots against each other without pauses and reports how
+// each configuration did.
+func runSimulate(args []string) error {
+	fs := flag.NewFlagSet("simulate", flag.ContinueOnError)
+	bots := fs.String("bots", "easy,medium,hard", "comma-separated difficulty or personality of each bot ("+strings.Join(player.PersonalityNames(), ", ")+"), or script:FILE for a decision file")
+	chips := types.Amount(1000)
+	fs.Var(&chips, "chips", "starting chips for every bot each game, e.g. 1000 or 1.5k")
+	hands := fs.Int("hands", 0, "hands per game (0 plays each game until one bot has every chip)")
+	games := fs.Int("games", 1, "number of games to play")
+	seed := fs.Int64("seed", 0, "seed the shuffles and bot decisions to repeat a simulation (0 seeds from the clock)")
+	seatDraw := fs.String("draw-seats", "", "draw seats at random every hand or every orbit, so positions even out across bots")
+	invariants := fs.Bool("check-invariants", false, "debug: check the chips after every action and panic with a dump of the table if they do not add up")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 || *games < 1 || *hands < 0 {
+		return fmt.Errorf("usage: poker simulate [--bots easy,tag,lag] [--hands n] [--games m] [--chips 1000] [--seed s] [--draw-seats hand|orbit] [--check-invariants]")
+	}
+	s := &sim.Simulation{Chips: int(chips), Hands: *hands, Games: *games, Seed: *seed, SeatDraw: *seatDraw, CheckInvariants: *invariants}
+	for _, bot := range strings.Split(*bots, ",") {
+		bot = strings.TrimSpace(bot)
+		if !strings.HasPrefix(bot, sim.ScriptPrefix) {
+			bot = strings.ToLower(bot) // File names keep their case
+		}
+		s.Bots = append(s.Bots, bot)
+	}
+	report, err := s.Run()
+	if err != nil {
+		return err
+	}
+	fmt.Print(sim.Summary(report))
+	return nil
+}
+
+// runSweep implements `poker sweep --param bluffFreq=0.1:0.5:0.05`: it plays
+// a bot personality at each value of one of its parameters against the same
+// opponents and reports which value did best.
+func runSweep(args []string) error {
+	fs := flag.NewFlagSet("sweep", flag.ContinueOnError)
+	param := fs.String("param", "", "parameter to vary and its range as name=from:to:step, e.g. bluffFreq=0.1:0.5:0.05 (bluffFreq, vpip, pfr or aggression)")
+	base := fs.String("base", "tag", "personality the swept bot starts from ("+strings.Join(player.PersonalityNames(), ", ")+")")
+	against := fs.String("against", "tag,lag,nit", "comma-separated opponent pool, as for simulate --bots")
+	chips := types.Amount(1000)
+	fs.Var(&chips, "chips", "starting chips for every bot each game, e.g. 1000 or 1.5k")
+	hands := fs.Int("hands", 200, "hands per game (0 plays each game until one bot has every chip)")
+	games := fs.Int("games", 10, "games to play at each value")
+	seed := fs.Int64("seed", 0, "seed every value's games alike to repeat a sweep (0 seeds from the clock)")
+	seatDraw := fs.String("draw-seats", "", "draw seats at random every hand or every orbit, so positions even out across bots")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 || *param == "" || *games < 1 || *hands < 0 {
+		return fmt.Errorf("usage: poker sweep --param bluffFreq=0.1:0.5:0.05 [--base tag] [--against tag,lag,nit] [--hands n] [--games m] [--chips 1000] [--seed s] [--draw-seats hand|orbit]")
+	}
+	name, from, to, step, err := sim.ParseSweepParam(*param)
+	if err != nil {
+		return err
+	}
+	s := &sim.Sweep{
+		Simulation: sim.Simulation{Chips: int(chips), Hands: *hands, Games: *games, Seed: *seed, SeatDraw: *seatDraw},
+		Base:       strings.ToLower(*base),
+		Param:      name,
+		From:       from,
+		To:         to,
+		Step:       step,
+	}
+	for _, bot := range strings.Split(*against, ",") {
+		bot = strings.TrimSpace(bot)
+		if !strings.HasPrefix(bot, sim.ScriptPrefix) {
+			bot = strings.ToLower(bot) // File names keep their case
+		}
+		s.Opponents = append(s.Opponents, bot)
+	}
+	report, err := s.Run()
+	if err != nil {
+		return err
+	}
+	fmt.Print(sim.SweepSummary(report))
+	return nil
+}
+
+// runArena implements `poker arena`, which plays bots against each other
+// and rates them on a ladder kept between runs, and `poker arena ladder`,
+// which prints the ladder's standings.
+func runArena(args []string) error {
+	usage := fmt.Errorf("usage: poker arena [--bots tag,lag,script:FILE] [--hands n] [--games m] [--chips 1000] [--seed s] [--draw-seats hand|orbit] [--ladder file]\n" +
+		"       poker arena ladder [--ladder file]")
+	standings := len(args) > 0 && args[0] == "ladder"
+	if standings {
+		args = args[1:]
+	}
+	fs := flag.NewFlagSet("arena", flag.ContinueOnError)
+	ladderPath := fs.String("ladder", "", "ladder file to rate the bots in (default: arena-ladder.json in the data directory)")
+	bots := fs.String("bots", "easy,medium,hard,tag,lag,nit", "comma-separated difficulty or personality of each bot ("+strings.Join(player.PersonalityNames(), ", ")+"), or script:FILE for a decision file")
+	chips := types.Amount(1000)
+	fs.Var(&chips, "chips", "starting chips for every bot each game, e.g. 1000 or 1.5k")
+	hands := fs.Int("hands", 200, "hands per game (0 plays each game until one bot has every chip)")
+	games := fs.Int("games", 10, "number of games to play")
+	seed := fs.Int64("seed", 0, "seed the shuffles and bot decisions to repeat a run (0 seeds from the clock)")
+	seatDraw := fs.String("draw-seats", "", "draw seats at random every hand or every orbit, so positions even out across bots")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 || *games < 1 || *hands < 0 {
+		return usage
+	}
+	if *ladderPath == "" {
+		dir, err := store.DefaultDir()
+		if err != nil {
+			return err
+		}
+		*ladderPath = filepath.Join(dir, "data", "arena-ladder.json")
+	}
+	ladder, err := sim.LoadLadder(*ladderPath)
+	if err != nil {
+		return err
+	}
+	if standings {
+		fmt.Print(sim.LadderSummary(ladder, nil))
+		return nil
+	}
+
+	s := &sim.Simulation{Chips: int(chips), Hands: *hands, Games: *games, Seed: *seed, SeatDraw: *seatDraw}
+	for _, bot := range strings.Split(*bots, ",") {
+		bot = strings.TrimSpace(bot)
+		if path, ok := strings.CutPrefix(bot, sim.ScriptPrefix); ok {
+			// The same file is the same bot on the ladder wherever it is run from
+			if abs, err := filepath.Abs(path); err == nil {
+				bot = sim.ScriptPrefix + abs
+			}
+		} else {
+			bot = strings.ToLower(bot)
+		}
+		s.Bots = append(s.Bots, bot)
+	}
+	report, err := s.Run()
+	if err != nil {
+		return err
+	}
+	changes := ladder.Record(report)
+	if err := sim.SaveLadder(*ladderPath, ladder); err != nil {
+		return err
+	}
+	fmt.Print(sim.Summary(report))
+	fmt.Println()
+	fmt.Print(sim.LadderSummary(ladder, changes))
+	return nil
+}
+
+// colorFlags adds --no-color and --theme to fs. The returned function builds
+// the console UI they select once fs is parsed. Setting NO_COLOR in the
+// environment also turns colors off.
+func colorFlags(fs *flag.FlagSet) func() (*ui.ConsoleUI, error) {
+	noColor := fs.Bool("no-color", false, "plain text output for terminals without ANSI colors")
+	themeName := fs.String("theme", ui.DefaultTheme, "color theme ("+strings.Join(ui.ThemeNames(), ", ")+")")
+	return func() (*ui.ConsoleUI, error) {
+		consoleUI := ui.NewConsoleUI()
+		if *noColor || os.Getenv("NO_COLOR") != "" {
+			consoleUI.Theme = nil
+			return consoleUI, nil
+		}
+		theme, ok := ui.LookupTheme(*themeName)
+		if !ok {
+			return nil, fmt.Errorf("unknown theme %q, choose from %s", *themeName, strings.Join(ui.ThemeNames(), ", "))
+		}
+		consoleUI.Theme = theme
+		return consoleUI, nil
+	}
+}
+
+// runAudit checks a deal audit log written with --deal-log against the shuffle.
+func runAudit(args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: poker audit deals.jsonl")
+	}
+	f, err := os.Open(args[0])
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	records, err := game.ReadDealLog(f)
+	if err != nil {
+		return err
+	}
+	failed := 0
+	for _, rec := range records {
+		if err := rec.Verify(); err != nil {
+			fmt.Println(err)
+			failed++
+		}
+	}
+	fmt.Printf("%d hands checked, %d failed verification.\n", len(records), failed)
+	if failed > 0 {
+		return fmt.Errorf("deal audit failed")
+	}
+	return nil
+}
+
+// runStats implements `poker stats export --player id`, which writes one
+// opponent's observed actions and stat line from the hand history as JSON,
+// and `poker stats csv`, which writes every player's stats per session in
+// the CSV layout spreadsheets and trackers import.
+func runStats(args []string) error {
+	usage := fmt.Errorf("usage: poker stats export --player \"Bot 3\" [--history hands.jsonl] [--out file]\n" +
+		"       poker stats csv [--history hands.jsonl] [--out file] [--schema]")
+	if len(args) == 0 || args[0] != "export" && args[0] != "csv" {
+		return usage
+	}
+	fs := flag.NewFlagSet("stats "+args[0], flag.ContinueOnError)
+	historyPath := fs.String("history", "", "hand history to read (default: the one every local game records to)")
+	outPath := fs.String("out", "", "file to write to instead of standard output")
+	playerID, schema := new(string), new(bool)
+	if args[0] == "export" {
+		playerID = fs.String("player", "", "player to export")
+	} else {
+		schema = fs.Bool("schema", false, "describe the CSV columns instead of exporting")
+	}
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+	if args[0] == "export" && *playerID == "" || fs.NArg() != 0 {
+		return usage
+	}
+	if *schema {
+		fmt.Print(stats.CSVSchema())
+		return nil
+	}
+	if *historyPath == "" {
+		dir, err := store.DefaultDir()
+		if err != nil {
+			return err
+		}
+		*historyPath = filepath.Join(dir, "data", "hands.jsonl")
+	}
+
+	hands, err := replay.Load(*historyPath, "")
+	if err != nil {
+		return err
+	}
+	var history stats.PlayerHistory
+	if args[0] == "export" {
+		if history, err = stats.Export(hands, *playerID); err != nil {
+			return err
+		}
+	}
+	out := io.Writer(os.Stdout)
+	if *outPath != "" {
+		f, err := os.Create(*outPath)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		out = f
+	}
+	if args[0] == "csv" {
+		return stats.WriteCSV(out, stats.BySession(hands))
+	}
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(history)
+}
+
+// runDashboard implements `poker dashboard [--player id] [--history file]`:
+// browse a player's results across every recorded session.
+func runDashboard(args []string) error {
+	fs := flag.NewFlagSet("dashboard", flag.ContinueOnError)
+	colors := colorFlags(fs)
+	playerID := fs.String("player", "Player 1", "player to summarize")
+	historyPath := fs.String("history", "", "hand history to read (default: the one every local game records to)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return fmt.Errorf("usage: poker dashboard [--player \"Player 1\"] [--history hands.jsonl]")
+	}
+	if *historyPath == "" {
+		dir, err := store.DefaultDir()
+		if err != nil {
+			return err
+		}
+		*historyPath = filepath.Join(dir, "data", "hands.jsonl")
+	}
+
+	hands, err := replay.Load(*historyPath, "")
+	if err != nil {
+		return err
+	}
+	summary, err := dashboard.Build(hands, *playerID)
+	if err != nil {
+		return err
+	}
+	consoleUI, err := colors()
+	if err != nil {
+		return err
+	}
+	view := dashboard.NewView(summary, consoleUI.Out)
+	view.Clear = consoleUI.ClearScreen
+	return view.Run(os.Stdin)
+}
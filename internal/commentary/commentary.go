@@ -0,0 +1,155 @@
+// Package commentary adds flavor messages to a game: winning streaks,
+// coolers and bad beats, worked out from the game's events.
+package commentary
+
+import (
+	"fmt"
+	"io"
+
+	"pokerclientv1/internal/stats"
+	"pokerclientv1/pkg/game"
+)
+
+// StreakLength is the number of pots in a row that earns a mention.
+const StreakLength = 3
+
+// BadBeatEquity is how big a favourite a player must have been all-in to
+// call losing the hand a bad beat.
+const BadBeatEquity = 0.75
+
+// coolerRank is the weakest losing hand at showdown that makes a cooler.
+const coolerRank = game.Flush
+
+// Commentator writes remarks about the game to Out. Register HandleEvent
+// with Game.OnEvent; leave it unregistered to play without commentary.
+type Commentator struct {
+	Out io.Writer
+
+	ev           *stats.EVTracker
+	streakPlayer string
+	streak       int
+	won          map[string]int
+	shown        map[string]game.HandRank
+}
+
+// New creates a commentator that writes to out.
+func New(out io.Writer) *Commentator {
+	return &Commentator{Out: out, ev: stats.NewEVTracker()}
+}
+
+// HandleEvent follows the game and comments when a hand ends.
+func (c *Commentator) HandleEvent(e game.Event) {
+	c.ev.HandleEvent(e)
+	switch e.Type {
+	case game.EventHandStarted:
+		c.won = make(map[string]int)
+		c.shown = make(map[string]game.HandRank)
+	case game.EventShowdown:
+		if rank, ok := parseRank(e.Action); ok && c.shown != nil {
+			c.shown[e.PlayerID] = rank
+		}
+	case game.EventPotAwarded:
+		if c.won != nil {
+			c.won[e.PlayerID] += e.Amount
+		}
+	case game.EventHandEnded:
+		if c.won != nil {
+			c.handEnded(e.HandNumber)
+		}
+	}
+}
+
+func (c *Commentator) handEnded(hand int) {
+	for _, line := range c.badBeats(hand) {
+		fmt.Fprintln(c.Out, line)
+	}
+	if line := c.cooler(); line != "" {
+		fmt.Fprintln(c.Out, line)
+	}
+	if line := c.updateStreak(); line != "" {
+		fmt.Fprintln(c.Out, line)
+	}
+}
+
+// updateStreak counts pots won in a row by the same player. Split pots end
+// every streak.
+func (c *Commentator) updateStreak() string {
+	if len(c.won) != 1 {
+		c.streakPlayer, c.streak = "", 0
+		return ""
+	}
+	for winner := range c.won {
+		if winner == c.streakPlayer {
+			c.streak++
+		} else {
+			c.streakPlayer, c.streak = winner, 1
+		}
+	}
+	if c.streak < StreakLength {
+		return ""
+	}
+	return fmt.Sprintf("~ %s pot in a row for %s!", ordinal(c.streak), c.streakPlayer)
+}
+
+// cooler finds a showdown where the losing hand was strong enough to be
+// played to the end by anyone.
+func (c *Commentator) cooler() string {
+	winner, loser := "", ""
+	for id, rank := range c.shown {
+		if c.won[id] > 0 {
+			if winner == "" || rank > c.shown[winner] {
+				winner = id
+			}
+		} else if rank >= coolerRank && (loser == "" || rank > c.shown[loser]) {
+			loser = id
+		}
+	}
+	if winner == "" || loser == "" {
+		return ""
+	}
+	return fmt.Sprintf("~ What a cooler! %s's %s runs into %s's %s.",
+		loser, c.shown[loser], winner, c.shown[winner])
+}
+
+// badBeats reports players who lost a hand they were big favourites to win
+// when the money went in.
+func (c *Commentator) badBeats(hand int) []string {
+	lines := []string{}
+	for _, h := range c.ev.Hands {
+		if h.HandNumber == hand && h.AllIn && h.Equity >= BadBeatEquity && h.Result < 0 {
+			lines = append(lines, fmt.Sprintf("~ Bad beat! %s was %.0f%% to win that pot and lost %d chips.",
+				h.PlayerID, 100*h.Equity, -h.Result))
+		}
+	}
+	c.ev.Hands = c.ev.Hands[:0] // Only the latest hand is needed
+	return lines
+}
+
+// parseRank turns a hand rank's name back into the rank.
+func parseRank(name string) (game.HandRank, bool) {
+	for r := game.HighCard; r <= game.StraightFlush; r++ {
+		if r.String() == name {
+			return r, true
+		}
+	}
+	return 0, false
+}
+
+// ordinal spells out small ordinal numbers: "Third", "Fourth", then "5th".
+func ordinal(n int) string {
+	words := []string{"", "First", "Second", "Third", "Fourth", "Fifth", "Sixth", "Seventh", "Eighth", "Ninth", "Tenth"}
+	if n < len(words) {
+		return words[n]
+	}
+	switch {
+	case n%100 >= 11 && n%100 <= 13:
+		return fmt.Sprintf("%dth", n)
+	case n%10 == 1:
+		return fmt.Sprintf("%dst", n)
+	case n%10 == 2:
+		return fmt.Sprintf("%dnd", n)
+	case n%10 == 3:
+		return fmt.Sprintf("%drd", n)
+	}
+	return fmt.Sprintf("%dth", n)
+}
@@ -0,0 +1,68 @@
+package commentary
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"pokerclientv1/pkg/game"
+	"pokerclientv1/pkg/gametest"
+)
+
+// play feeds one hand's worth of events to c.
+func play(c *Commentator, hand int, events ...game.Event) {
+	c.HandleEvent(game.Event{Type: game.EventHandStarted, HandNumber: hand})
+	for _, e := range events {
+		e.HandNumber = hand
+		c.HandleEvent(e)
+	}
+	c.HandleEvent(game.Event{Type: game.EventHandEnded, HandNumber: hand})
+}
+
+func TestStreak(t *testing.T) {
+	var out bytes.Buffer
+	c := New(&out)
+	for hand := 1; hand <= 4; hand++ {
+		play(c, hand, game.Event{Type: game.EventPotAwarded, PlayerID: "Bot 2", Amount: 10})
+	}
+	play(c, 5, game.Event{Type: game.EventPotAwarded, PlayerID: "Bot 1", Amount: 10})
+
+	got := out.String()
+	for _, want := range []string{"Third pot in a row for Bot 2", "Fourth pot in a row for Bot 2"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing %q in:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "Bot 1") {
+		t.Errorf("Bot 1 won one pot and should not be mentioned:\n%s", got)
+	}
+}
+
+func TestCooler(t *testing.T) {
+	var out bytes.Buffer
+	c := New(&out)
+	play(c, 1,
+		game.Event{Type: game.EventShowdown, PlayerID: "Alice", Action: game.Flush.String()},
+		game.Event{Type: game.EventShowdown, PlayerID: "Bob", Action: game.FullHouse.String()},
+		game.Event{Type: game.EventPotAwarded, PlayerID: "Bob", Amount: 400})
+	if want := "Alice's Flush runs into Bob's Full House"; !strings.Contains(out.String(), want) {
+		t.Errorf("missing %q in:\n%s", want, out.String())
+	}
+}
+
+// TestBadBeat: aces get all-in against kings and lose to a king on the flop.
+func TestBadBeat(t *testing.T) {
+	aces := gametest.NewScriptedPlayer("Aces", 100, gametest.AllIn())
+	kings := gametest.NewScriptedPlayer("Kings", 100, gametest.Call())
+	deck := gametest.Deck(t,
+		[][]string{{"As", "Ah"}, {"Ks", "Kh"}},
+		[]string{"Kd", "7c", "2s", "9h", "3d"})
+	h := gametest.New(t, deck, aces, kings)
+	var out bytes.Buffer
+	h.Engine.OnEvent(New(&out).HandleEvent)
+	h.PlayHand()
+
+	if want := "Bad beat! Aces was"; !strings.Contains(out.String(), want) {
+		t.Errorf("missing %q in:\n%s", want, out.String())
+	}
+}
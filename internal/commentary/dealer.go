@@ -0,0 +1,134 @@
+package commentary
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"pokerclientv1/pkg/game"
+	"pokerclientv1/pkg/types"
+)
+
+// Dealer announces the game the way a live dealer would: each street with
+// the pot, whose action it is and what it costs, all-ins and who takes the
+// pot. When the action is on the human player it adds a short reminder of
+// their options, which helps players new to the rules. Register HandleEvent
+// with Game.OnEvent.
+type Dealer struct {
+	Out   io.Writer
+	Human string // Player addressed as "you" and given rules reminders, "" for none
+
+	pot    int
+	stacks map[string]int // Chips behind, followed from the events
+}
+
+// NewDealer creates a dealer that writes to out and speaks to human.
+func NewDealer(out io.Writer, human string) *Dealer {
+	return &Dealer{Out: out, Human: human}
+}
+
+// HandleEvent announces the events a dealer would call out at the table.
+func (d *Dealer) HandleEvent(e game.Event) {
+	switch e.Type {
+	case game.EventHandStarted:
+		d.pot = 0
+		d.stacks = make(map[string]int)
+		d.say("Hand #%d, %d players. Shuffle up and deal.", e.HandNumber, e.Amount)
+	case game.EventPlayerSeated:
+		if d.stacks != nil {
+			d.stacks[e.PlayerID] = e.Amount
+		}
+	case game.EventBlindPosted:
+		d.bet(e.PlayerID, e.Amount)
+		d.say("%s %s the %s of %d.", d.name(e.PlayerID), d.verb("posts", "post", e.PlayerID), e.Action, e.Amount)
+	case game.EventStreetDealt:
+		d.say("The %s: %s. The pot is %d.", strings.ToLower(e.Round), cardsString(e.Cards), d.pot)
+	case game.EventActionOn:
+		d.actionOn(e.PlayerID, e.Amount)
+	case game.EventPlayerAction:
+		d.bet(e.PlayerID, e.Amount)
+		if chips, ok := d.stacks[e.PlayerID]; ok && chips == 0 && e.Amount > 0 {
+			d.say("%s %s all-in.", d.name(e.PlayerID), d.verb("is", "are", e.PlayerID))
+		}
+	case game.EventBetReturned:
+		d.bet(e.PlayerID, -e.Amount)
+		d.say("Uncalled %d goes back to %s.", e.Amount, d.object(e.PlayerID))
+	case game.EventShowdown:
+		d.say("%s %s %s.", d.name(e.PlayerID), d.verb("shows", "show", e.PlayerID), e.Action)
+	case game.EventHandMucked:
+		d.say("%s %s.", d.name(e.PlayerID), d.verb("mucks", "muck", e.PlayerID))
+	case game.EventPotAwarded:
+		if d.stacks != nil {
+			d.stacks[e.PlayerID] += e.Amount
+		}
+		d.say("%d from the %s to %s.", e.Amount, e.Action, d.object(e.PlayerID))
+	case game.EventHandEnded:
+		d.stacks = nil
+	}
+}
+
+// actionOn names the player to act and, for the human, what they may do.
+func (d *Dealer) actionOn(playerID string, toCall int) {
+	if toCall > 0 {
+		d.say("Action is on %s, %d to call.", d.object(playerID), toCall)
+	} else {
+		d.say("Action is on %s.", d.object(playerID))
+	}
+	if playerID != d.Human {
+		return
+	}
+	chips := d.stacks[playerID]
+	switch {
+	case toCall == 0:
+		d.say("You can check, or bet to make the others pay to stay in.")
+	case chips <= toCall:
+		d.say("Calling puts you all-in for %d; you can still win the chips you match.", chips)
+	default:
+		d.say("You can fold, call %d, or raise by at least the last bet or raise.", toCall)
+	}
+}
+
+// bet moves chips from a player's stack into the pot, or back out of it
+// when amount is negative.
+func (d *Dealer) bet(playerID string, amount int) {
+	d.pot += amount
+	if d.stacks != nil {
+		d.stacks[playerID] -= amount
+	}
+}
+
+// name returns how a player is addressed at the start of a sentence.
+func (d *Dealer) name(playerID string) string {
+	if playerID == d.Human {
+		return "You"
+	}
+	return playerID
+}
+
+// object returns how a player is addressed later in a sentence.
+func (d *Dealer) object(playerID string) string {
+	if playerID == d.Human {
+		return "you"
+	}
+	return playerID
+}
+
+// verb picks the third person or the "you" form of a verb for a player.
+func (d *Dealer) verb(third, you, playerID string) string {
+	if playerID == d.Human {
+		return you
+	}
+	return third
+}
+
+func (d *Dealer) say(format string, args ...interface{}) {
+	fmt.Fprintf(d.Out, "Dealer: "+format+"\n", args...)
+}
+
+func cardsString(cards []types.Card) string {
+	parts := make([]string, len(cards))
+	for i, c := range cards {
+		parts[i] = c.String()
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,42 @@
+package commentary
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"pokerclientv1/pkg/gametest"
+)
+
+// TestDealerAnnouncements: BTN raises, the human in the big blind calls and
+// both check the hand down.
+func TestDealerAnnouncements(t *testing.T) {
+	btn := gametest.NewScriptedPlayer("BTN", 100, gametest.RaiseTo(6), gametest.Check(), gametest.Check(), gametest.Check())
+	sb := gametest.NewScriptedPlayer("SB", 100, gametest.Fold())
+	you := gametest.NewScriptedPlayer("You", 100, gametest.Call(), gametest.Check(), gametest.Check(), gametest.Check())
+	deck := gametest.Deck(t,
+		[][]string{{"As", "Ah"}, {"7c", "2d"}, {"Ks", "Kh"}},
+		[]string{"3c", "8d", "9s", "Jh", "Qc"})
+	h := gametest.New(t, deck, btn, sb, you)
+	var out bytes.Buffer
+	h.Engine.OnEvent(NewDealer(&out, "You").HandleEvent)
+	h.PlayHand()
+
+	got := out.String()
+	for _, want := range []string{
+		"Dealer: You post the big blind of 2.",
+		"Dealer: Action is on BTN, 2 to call.",
+		"Dealer: Action is on you, 4 to call.",
+		"Dealer: You can fold, call 4, or raise",
+		"Dealer: The flop: 3♣ 8♦ 9♠. The pot is 13.",
+		"Dealer: You can check",
+		"Dealer: 13 from the main pot to BTN.",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing %q in:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "Action is on You") {
+		t.Errorf("the human should be addressed as \"you\":\n%s", got)
+	}
+}
@@ -0,0 +1,379 @@
+// Package dashboard summarizes a player's whole hand history, across every
+// recorded session, and shows it as a full-screen text view: win rate over
+// time, the swings of their results, how they do against each bot difficulty
+// and their most notable hands.
+package dashboard
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"pokerclientv1/internal/stats"
+	"pokerclientv1/internal/store"
+	"pokerclientv1/pkg/game"
+	"pokerclientv1/pkg/types"
+)
+
+// notableHands is how many of the biggest wins and losses are kept.
+const notableHands = 5
+
+// Session is the player's result over one recorded session.
+type Session struct {
+	ID       string
+	Hands    int
+	Net      int
+	BBPer100 float64 // Big blinds won per 100 hands
+}
+
+// DifficultyResult is the player's result in hands with at least one bot of
+// a difficulty at the table. A hand against a mixed table counts towards
+// every difficulty in it.
+type DifficultyResult struct {
+	Difficulty string
+	Hands      int
+	Net        int
+	BBPer100   float64
+}
+
+// NotableHand is one of the player's biggest wins or losses.
+type NotableHand struct {
+	Session string
+	Hand    int
+	Played  time.Time
+	Result  int
+	Pot     int          // Chips awarded in the hand
+	Board   []types.Card // Community cards dealt
+	Shown   []types.Card // The player's hole cards, only when shown at showdown
+}
+
+// Summary is everything the dashboard shows about one player.
+type Summary struct {
+	PlayerID     string
+	Hands        int
+	Net          int
+	BBPer100     float64
+	StdDev100    float64 // Standard deviation of results over 100 hands, in big blinds
+	Sessions     []Session
+	Running      []int // Net chips after each hand, in the order played
+	Difficulties []DifficultyResult
+	Notable      []NotableHand // Largest results first, wins or losses
+}
+
+// handInfo is what the dashboard needs from a hand beyond the player's result.
+type handInfo struct {
+	bigBlind     int
+	pot          int
+	difficulties []string // Difficulties of the bots dealt in, without repeats
+}
+
+// Build summarizes the hands a player was dealt into. It fails if there are none.
+func Build(hands []store.HandRecord, playerID string) (Summary, error) {
+	history, err := stats.Export(hands, playerID)
+	if err != nil {
+		return Summary{}, err
+	}
+	info := map[string]handInfo{}
+	for _, hand := range hands {
+		info[handKey(hand.SessionID, hand.HandNumber)] = describeHand(hand, playerID)
+	}
+
+	s := Summary{PlayerID: playerID, Hands: len(history.Hands)}
+	sessions := map[string]int{} // Index in s.Sessions
+	difficulties := map[string]*DifficultyResult{}
+	var bbResults []float64
+	for _, h := range history.Hands {
+		hi := info[handKey(h.Session, h.Hand)]
+		bb := float64(h.Result) / float64(hi.bigBlind)
+		bbResults = append(bbResults, bb)
+		s.Net += h.Result
+		s.Running = append(s.Running, s.Net)
+
+		i, ok := sessions[h.Session]
+		if !ok {
+			i = len(s.Sessions)
+			sessions[h.Session] = i
+			s.Sessions = append(s.Sessions, Session{ID: h.Session})
+		}
+		session := &s.Sessions[i]
+		session.Hands++
+		session.Net += h.Result
+		session.BBPer100 += bb
+
+		for _, d := range hi.difficulties {
+			dr := difficulties[d]
+			if dr == nil {
+				dr = &DifficultyResult{Difficulty: d}
+				difficulties[d] = dr
+			}
+			dr.Hands++
+			dr.Net += h.Result
+			dr.BBPer100 += bb
+		}
+
+		s.Notable = append(s.Notable, NotableHand{Session: h.Session, Hand: h.Hand, Played: h.Played,
+			Result: h.Result, Pot: hi.pot, Board: h.Board, Shown: h.Shown})
+	}
+
+	// The sums of big blinds won become rates
+	for i := range s.Sessions {
+		s.Sessions[i].BBPer100 *= 100 / float64(s.Sessions[i].Hands)
+	}
+	for _, dr := range difficulties {
+		dr.BBPer100 *= 100 / float64(dr.Hands)
+		s.Difficulties = append(s.Difficulties, *dr)
+	}
+	sort.Slice(s.Difficulties, func(i, j int) bool { return s.Difficulties[i].Difficulty < s.Difficulties[j].Difficulty })
+	s.BBPer100, s.StdDev100 = rates(bbResults)
+
+	sort.SliceStable(s.Notable, func(i, j int) bool { return abs(s.Notable[i].Result) > abs(s.Notable[j].Result) })
+	if len(s.Notable) > notableHands {
+		s.Notable = s.Notable[:notableHands]
+	}
+	return s, nil
+}
+
+func handKey(session string, hand int) string {
+	return fmt.Sprintf("%s#%d", session, hand)
+}
+
+// describeHand finds a hand's big blind, the chips awarded and the
+// difficulties of the bots the player faced.
+func describeHand(hand store.HandRecord, playerID string) handInfo {
+	hi := handInfo{bigBlind: game.DefaultBigBlind}
+	seen := map[string]bool{}
+	for _, e := range hand.Events {
+		switch e.Type {
+		case game.EventPlayerSeated:
+			if e.PlayerID != playerID && e.Action != "" && !seen[e.Action] {
+				seen[e.Action] = true
+				hi.difficulties = append(hi.difficulties, e.Action)
+			}
+		case game.EventBlindPosted:
+			if e.Action == "big blind" && e.Amount > 0 {
+				hi.bigBlind = e.Amount
+			}
+		case game.EventPotAwarded:
+			hi.pot += e.Amount
+		}
+	}
+	return hi
+}
+
+// rates returns the mean result per 100 hands and its standard deviation,
+// from results in big blinds per hand.
+func rates(results []float64) (mean, stdDev float64) {
+	if len(results) == 0 {
+		return 0, 0
+	}
+	for _, r := range results {
+		mean += r
+	}
+	mean /= float64(len(results))
+	variance := 0.0
+	for _, r := range results {
+		variance += (r - mean) * (r - mean)
+	}
+	variance /= float64(len(results))
+	return mean * 100, math.Sqrt(variance) * 10 // Deviation grows with the square root of the hands
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// Overview is the headline numbers over the whole history.
+func (s Summary) Overview() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "=== %s: %d hands over %d sessions ===\n", s.PlayerID, s.Hands, len(s.Sessions))
+	fmt.Fprintf(&b, "Net %+d chips, %+.1f bb/100, standard deviation %.1f bb/100\n", s.Net, s.BBPer100, s.StdDev100)
+	return b.String()
+}
+
+// sparks draws values low to high from the lowest to the highest block.
+var sparks = []rune("▁▂▃▄▅▆▇█")
+
+// Trend shows the win rate of each session in order as a sparkline, then
+// a line per session.
+func (s Summary) Trend() string {
+	var b strings.Builder
+	b.WriteString("--- Win rate by session (bb/100) ---\n")
+	if len(s.Sessions) == 0 {
+		return b.String()
+	}
+	low, high := s.Sessions[0].BBPer100, s.Sessions[0].BBPer100
+	for _, session := range s.Sessions {
+		low, high = math.Min(low, session.BBPer100), math.Max(high, session.BBPer100)
+	}
+	line := make([]rune, len(s.Sessions))
+	for i, session := range s.Sessions {
+		level := 0
+		if high > low {
+			level = int((session.BBPer100 - low) / (high - low) * float64(len(sparks)-1))
+		}
+		line[i] = sparks[level]
+	}
+	fmt.Fprintf(&b, "%s  (%+.1f to %+.1f)\n", string(line), low, high)
+	for _, session := range s.Sessions {
+		fmt.Fprintf(&b, "%-16s %5d hands %+7d chips %+8.1f bb/100\n", session.ID, session.Hands, session.Net, session.BBPer100)
+	}
+	return b.String()
+}
+
+// Variance draws the running net result, hand by hand, as a text graph of
+// the given size. Long histories are sampled to fit the width.
+func (s Summary) Variance(width, height int) string {
+	var b strings.Builder
+	b.WriteString("--- Running result (chips) ---\n")
+	if len(s.Running) == 0 || width <= 0 || height <= 0 {
+		return b.String()
+	}
+	points := make([]int, min(width, len(s.Running)))
+	for i := range points {
+		points[i] = s.Running[i*len(s.Running)/len(points)]
+	}
+	points[len(points)-1] = s.Running[len(s.Running)-1]
+	low, high := 0, 0 // The graph always includes the zero line
+	for _, p := range points {
+		low, high = min(low, p), max(high, p)
+	}
+	row := func(v int) int {
+		if high == low {
+			return 0
+		}
+		return ((high-v)*(height-1) + (high-low)/2) / (high - low) // Rounded to the nearest row
+	}
+	grid := make([][]rune, height)
+	for r := range grid {
+		grid[r] = []rune(strings.Repeat(" ", len(points)))
+	}
+	zero := row(0)
+	for c := range points {
+		grid[zero][c] = '-'
+	}
+	for c, p := range points {
+		grid[row(p)][c] = '*'
+	}
+	for r, line := range grid {
+		label := ""
+		switch r {
+		case 0:
+			label = fmt.Sprintf("%+d", high)
+		case zero:
+			label = "0"
+		case height - 1:
+			label = fmt.Sprintf("%+d", low)
+		}
+		fmt.Fprintf(&b, "%7s |%s\n", label, string(line))
+	}
+	fmt.Fprintf(&b, "%7s  %d hands\n", "", len(s.Running))
+	return b.String()
+}
+
+// ByDifficulty lists the player's results against each bot difficulty.
+func (s Summary) ByDifficulty() string {
+	var b strings.Builder
+	b.WriteString("--- Results by bot difficulty ---\n")
+	if len(s.Difficulties) == 0 {
+		b.WriteString("No hands against bots were recorded.\n")
+	}
+	for _, d := range s.Difficulties {
+		fmt.Fprintf(&b, "%-12s %5d hands %+7d chips %+8.1f bb/100\n", d.Difficulty, d.Hands, d.Net, d.BBPer100)
+	}
+	return b.String()
+}
+
+// NotableHands lists the biggest wins and losses.
+func (s Summary) NotableHands() string {
+	var b strings.Builder
+	b.WriteString("--- Notable hands ---\n")
+	for _, h := range s.Notable {
+		fmt.Fprintf(&b, "%s hand #%-4d %+6d chips, pot %d, board %s", h.Session, h.Hand, h.Result, h.Pot, cardsString(h.Board))
+		if len(h.Shown) > 0 {
+			fmt.Fprintf(&b, ", showed %s", cardsString(h.Shown))
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+func cardsString(cards []types.Card) string {
+	if len(cards) == 0 {
+		return "-"
+	}
+	parts := make([]string, len(cards))
+	for i, c := range cards {
+		parts[i] = c.String()
+	}
+	return strings.Join(parts, " ")
+}
+
+// View shows a Summary one panel at a time.
+type View struct {
+	Summary Summary
+	Out     io.Writer
+	Clear   func() // Clears the screen before each panel, optional
+	Width   int    // Columns of the variance graph
+	Height  int    // Rows of the variance graph
+}
+
+// NewView creates a view of s writing to out with a 60 by 12 variance graph.
+func NewView(s Summary, out io.Writer) *View {
+	return &View{Summary: s, Out: out, Width: 60, Height: 12}
+}
+
+// panels maps the keys of the view to the panels they show.
+func (v *View) panels() map[string]func() string {
+	return map[string]func() string{
+		"o": func() string {
+			return v.Summary.Overview() + "\n" + v.Summary.Trend() + "\n" + v.Summary.Variance(v.Width, v.Height/2)
+		},
+		"t": v.Summary.Trend,
+		"v": func() string { return v.Summary.Variance(v.Width, v.Height) },
+		"d": v.Summary.ByDifficulty,
+		"n": v.Summary.NotableHands,
+	}
+}
+
+// Run shows the overview, then the panel picked by each command read from in
+// until the user quits or input ends.
+func (v *View) Run(in io.Reader) error {
+	panels := v.panels()
+	reader := bufio.NewReader(in)
+	show, note := "o", ""
+	for {
+		if v.Clear != nil {
+			v.Clear()
+		}
+		fmt.Fprint(v.Out, panels[show]())
+		if note != "" {
+			fmt.Fprintln(v.Out, note)
+			note = ""
+		}
+		fmt.Fprint(v.Out, "\n(o)verview, (t)rend, (v)ariance, (d)ifficulty, (n)otable hands, (q)uit: ")
+		line, err := reader.ReadString('\n')
+		if err != nil && line == "" {
+			if err == io.EOF {
+				fmt.Fprintln(v.Out)
+				return nil
+			}
+			return err
+		}
+		key := strings.TrimSpace(strings.ToLower(line))
+		switch {
+		case key == "q" || key == "quit":
+			return nil
+		case panels[key] != nil:
+			show = key
+		case key != "":
+			note = "Unknown command."
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package dashboard
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"pokerclientv1/internal/store"
+	"pokerclientv1/pkg/game"
+)
+
+// hand records a heads-up hand between Hero and a bot of the given difficulty
+// in which Hero ends up result chips ahead or behind, with 2 chip big blinds.
+func hand(session string, number int, difficulty string, result int) store.HandRecord {
+	winner, loser := "Hero", "Bot"
+	if result < 0 {
+		winner, loser = loser, winner
+		result = -result
+	}
+	return store.HandRecord{SessionID: session, HandNumber: number, Events: []game.Event{
+		{Type: game.EventHandStarted, Amount: 2},
+		{Type: game.EventPlayerSeated, PlayerID: "Hero", Amount: 100},
+		{Type: game.EventPlayerSeated, PlayerID: "Bot", Action: difficulty, Amount: 100},
+		{Type: game.EventBlindPosted, PlayerID: loser, Action: "big blind", Amount: 2},
+		{Type: game.EventHoleCardsDealt, PlayerID: "Hero"},
+		{Type: game.EventPlayerAction, PlayerID: loser, Action: "call", Amount: result - 2},
+		{Type: game.EventPlayerAction, PlayerID: winner, Action: "call", Amount: result},
+		{Type: game.EventPotAwarded, PlayerID: winner, Action: "pot", Amount: 2 * result},
+		{Type: game.EventHandEnded},
+	}}
+}
+
+// TestBuild checks the totals, sessions, per-difficulty results and notable hands.
+func TestBuild(t *testing.T) {
+	hands := []store.HandRecord{
+		hand("s1", 1, "easy", 10),
+		hand("s1", 2, "easy", -4),
+		hand("s2", 1, "hard", -20),
+		hand("s2", 2, "hard", 6),
+	}
+	s, err := Build(hands, "Hero")
+	if err != nil {
+		t.Fatalf("Build() error: %v", err)
+	}
+	if s.Hands != 4 || s.Net != -8 {
+		t.Errorf("Build() = %d hands net %d, want 4 hands net -8", s.Hands, s.Net)
+	}
+	if s.BBPer100 != -100 {
+		t.Errorf("BBPer100 = %.1f, want -100 (-4 big blinds over 4 hands)", s.BBPer100)
+	}
+	wantRunning := []int{10, 6, -14, -8}
+	for i, want := range wantRunning {
+		if s.Running[i] != want {
+			t.Errorf("Running = %v, want %v", s.Running, wantRunning)
+			break
+		}
+	}
+	if len(s.Sessions) != 2 || s.Sessions[0].Net != 6 || s.Sessions[1].BBPer100 != -350 {
+		t.Errorf("Sessions = %+v, want s1 +6 and s2 at -350 bb/100", s.Sessions)
+	}
+	if len(s.Difficulties) != 2 || s.Difficulties[0].Difficulty != "easy" || s.Difficulties[1].Net != -14 {
+		t.Errorf("Difficulties = %+v, want easy then hard at -14", s.Difficulties)
+	}
+	if s.Notable[0].Result != -20 || s.Notable[0].Pot != 40 {
+		t.Errorf("Notable[0] = %+v, want the -20 loss in a 40 chip pot", s.Notable[0])
+	}
+	if _, err := Build(hands, "Nobody"); err == nil {
+		t.Errorf("Build() for a player never dealt in returned nil, want an error")
+	}
+}
+
+// TestVarianceGraph checks that the graph marks the extremes and the zero line.
+func TestVarianceGraph(t *testing.T) {
+	s := Summary{Running: []int{10, 6, -14, -8}}
+	lines := strings.Split(strings.TrimRight(s.Variance(4, 5), "\n"), "\n")
+	if len(lines) != 7 {
+		t.Fatalf("Variance() drew %d lines, want a title, 5 rows and a footer:\n%s", len(lines), strings.Join(lines, "\n"))
+	}
+	if !strings.HasSuffix(lines[1], "|*   ") || !strings.HasPrefix(strings.TrimSpace(lines[1]), "+10") {
+		t.Errorf("top row = %q, want +10 with the first hand plotted", lines[1])
+	}
+	if !strings.HasSuffix(lines[5], "|  * ") || !strings.HasPrefix(strings.TrimSpace(lines[5]), "-14") {
+		t.Errorf("bottom row = %q, want -14 with the third hand plotted", lines[5])
+	}
+}
+
+// TestViewRun checks that commands switch panels and that input ending quits.
+func TestViewRun(t *testing.T) {
+	s, err := Build([]store.HandRecord{hand("s1", 1, "medium", 10)}, "Hero")
+	if err != nil {
+		t.Fatalf("Build() error: %v", err)
+	}
+	var out bytes.Buffer
+	if err := NewView(s, &out).Run(strings.NewReader("d\nx\nn\n")); err != nil {
+		t.Fatalf("Run() error: %v", err)
+	}
+	for _, want := range []string{"=== Hero: 1 hands", "medium", "Unknown command.", "--- Notable hands ---"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("Run() output is missing %q:\n%s", want, out.String())
+		}
+	}
+}
@@ -0,0 +1,144 @@
+// Package drill runs practice drills: the same hand played from every seat at
+// the table, so a player can see how position changes the right decisions.
+package drill
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+	"time"
+
+	"pokerclientv1/internal/player"
+	"pokerclientv1/pkg/game"
+	"pokerclientv1/pkg/types"
+)
+
+// DefaultStyles are the villain personalities rotated through a drill.
+var DefaultStyles = []string{"tag", "calling station", "lag", "nit"}
+
+// Drill deals the hero the same hole cards and board once from each seat.
+// Villains get random cards and their personalities rotate between rounds.
+type Drill struct {
+	Hole      []types.Card
+	Board     []types.Card // Up to five cards; missing ones are random but the same every round
+	Opponents int
+	Chips     int      // Stack everyone starts each round with
+	Styles    []string // Villain personalities, DefaultStyles when empty
+	Rules     game.GameConfig
+	GameSpeed time.Duration
+	Rng       *rand.Rand // Source for villain cards, seeded from the clock when nil
+}
+
+// Result is the outcome of one round of a drill.
+type Result struct {
+	Seat     int // Seats after the button, 0 is the button
+	Position string
+	Villains []string // Villain personalities in seat order
+	Net      int      // Chips the hero won or lost
+}
+
+// Position names a seat counted from the button at a table of n players.
+func Position(seat, n int) string {
+	switch {
+	case seat == 0 && n == 2:
+		return "Button (small blind)"
+	case seat == 0:
+		return "Button"
+	case seat == 1 && n == 2:
+		return "Big blind"
+	case seat == 1:
+		return "Small blind"
+	case seat == 2:
+		return "Big blind"
+	case seat == n-1:
+		return "Cutoff"
+	default:
+		return fmt.Sprintf("Seat %d after the button", seat)
+	}
+}
+
+// Run plays one hand per seat, starting with the hero on the button. newHero
+// creates the hero with the given stack each round; ui and out may be nil.
+func (d *Drill) Run(newHero func(chips int) types.Player, ui types.GameUI, out io.Writer) []Result {
+	styles := d.Styles
+	if len(styles) == 0 {
+		styles = DefaultStyles
+	}
+	rng := d.Rng
+	if rng == nil {
+		rng = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	if out == nil {
+		out = io.Discard
+	}
+	board := d.completeBoard(rng)
+
+	n := d.Opponents + 1
+	results := []Result{}
+	for seat := 0; seat < n; seat++ {
+		hero := newHero(d.Chips)
+		players := make([]types.Player, n)
+		holes := make([][]types.Card, n)
+		result := Result{Seat: seat, Position: Position(seat, n)}
+		villain := 0
+		for i := range players {
+			if i == seat {
+				players[i] = hero
+				holes[i] = d.Hole
+				continue
+			}
+			style := styles[(seat+villain)%len(styles)]
+			players[i] = player.NewBotPlayer(fmt.Sprintf("Villain %d", villain+1), d.Chips, style, 500*time.Millisecond)
+			result.Villains = append(result.Villains, style)
+			villain++
+		}
+
+		fmt.Fprintf(out, "\n=== Drill round %d of %d: you are on the %s ===\n", seat+1, n, result.Position)
+		engine := game.NewEngine(game.Config{
+			Players:   players,
+			Rules:     d.Rules,
+			UI:        ui,
+			Output:    out,
+			GameSpeed: d.GameSpeed,
+			Deck:      func() *game.Deck { return game.NewPresetDeck(holes, board, rng) },
+		})
+		engine.PlayHand()
+
+		result.Net = hero.GetChips() - d.Chips
+		results = append(results, result)
+	}
+	return results
+}
+
+// completeBoard fills in the board cards that were not given, once, so every
+// round runs out the same board.
+func (d *Drill) completeBoard(rng *rand.Rand) []types.Card {
+	board := append([]types.Card(nil), d.Board...)
+	if len(board) >= 5 {
+		return board[:5]
+	}
+	used := make(map[types.Card]bool)
+	for _, c := range append(append([]types.Card(nil), d.Hole...), board...) {
+		used[c] = true
+	}
+	for len(board) < 5 {
+		c := types.Card{Suit: types.Suit(rng.Intn(4)), Rank: types.Two + types.Rank(rng.Intn(13))}
+		if !used[c] {
+			used[c] = true
+			board = append(board, c)
+		}
+	}
+	return board
+}
+
+// Summary formats drill results as a table.
+func Summary(results []Result) string {
+	s := "--- Drill Summary ---\n"
+	total := 0
+	for _, r := range results {
+		s += fmt.Sprintf("%-28s %+6d  (villains: %v)\n", r.Position, r.Net, r.Villains)
+		total += r.Net
+	}
+	s += fmt.Sprintf("%-28s %+6d\n", "Total", total)
+	return s
+}
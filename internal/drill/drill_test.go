@@ -0,0 +1,76 @@
+package drill
+
+import (
+	"math/rand"
+	"testing"
+
+	"pokerclientv1/pkg/game"
+	"pokerclientv1/pkg/gametest"
+	"pokerclientv1/pkg/types"
+)
+
+func mustCards(t *testing.T, notations ...string) []types.Card {
+	t.Helper()
+	cards := []types.Card{}
+	for _, n := range notations {
+		c, err := types.ParseCard(n)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cards = append(cards, c)
+	}
+	return cards
+}
+
+// TestDrillRotatesHeroThroughEverySeat checks that the hero sees the same hole
+// cards at every seat and that each round starts from a fresh stack.
+func TestDrillRotatesHeroThroughEverySeat(t *testing.T) {
+	d := &Drill{
+		Hole:      mustCards(t, "As", "Kd"),
+		Board:     mustCards(t, "2c", "7h", "9d"),
+		Opponents: 3,
+		Chips:     200,
+		Rng:       rand.New(rand.NewSource(1)),
+	}
+	heroes := []*gametest.ScriptedPlayer{}
+	results := d.Run(func(chips int) types.Player {
+		hero := gametest.NewScriptedPlayer("Hero", chips, gametest.Fold())
+		heroes = append(heroes, hero)
+		return hero
+	}, nil, nil)
+
+	if len(results) != 4 {
+		t.Fatalf("got %d rounds, want one per seat (4)", len(results))
+	}
+	wantPositions := []string{"Button", "Small blind", "Big blind", "Cutoff"}
+	for i, r := range results {
+		if r.Seat != i || r.Position != wantPositions[i] {
+			t.Errorf("round %d: seat %d %q, want seat %d %q", i, r.Seat, r.Position, i, wantPositions[i])
+		}
+		if len(r.Villains) != 3 {
+			t.Errorf("round %d: %d villains, want 3", i, len(r.Villains))
+		}
+		if hand := heroes[i].Hand; len(hand.Cards) != 2 || hand.Cards[0] != d.Hole[0] || hand.Cards[1] != d.Hole[1] {
+			t.Errorf("round %d: hero was dealt %v, want %v", i, hand.Cards, d.Hole)
+		}
+	}
+	// Folding costs the blind posted from that seat, and nothing elsewhere
+	wantNet := map[int]int{0: 0, 1: -game.DefaultSmallBlind, 3: 0}
+	for seat, want := range wantNet {
+		if results[seat].Net != want {
+			t.Errorf("%s: net %d after folding, want %d", results[seat].Position, results[seat].Net, want)
+		}
+	}
+	if results[0].Villains[0] == results[1].Villains[0] {
+		t.Errorf("villain styles did not rotate between rounds: %v then %v", results[0].Villains, results[1].Villains)
+	}
+}
+
+func TestPositionHeadsUp(t *testing.T) {
+	if got := Position(0, 2); got != "Button (small blind)" {
+		t.Errorf("Position(0, 2) = %q", got)
+	}
+	if got := Position(1, 2); got != "Big blind" {
+		t.Errorf("Position(1, 2) = %q", got)
+	}
+}
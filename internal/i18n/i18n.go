@@ -0,0 +1,145 @@
+// Package i18n translates the messages shown to players. Messages are looked
+// up by key in the catalog of the player's language, falling back to English
+// for languages or keys that have no translation.
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// Default is the language used when none is chosen or the choice is unknown.
+const Default = "en"
+
+// catalogs holds the format strings of each language by message key.
+var catalogs = map[string]map[string]string{
+	"en": {
+		"action.fold":  "fold",
+		"action.check": "check",
+		"action.call":  "call",
+		"action.raise": "raise",
+
+		"strength.weak":     "weak",
+		"strength.marginal": "marginal",
+		"strength.good":     "good",
+		"strength.strong":   "strong",
+
+		"why.none":       "No bot has acted yet.",
+		"why.header":     "Why %s chose to %s:",
+		"why.random":     "It plays by chance: it rolled %d out of 100, and folds below %d, calls below %d and raises otherwise.",
+		"why.randomPick": "It picks fold, call or raise at random.",
+		"why.strength":   "Hand strength: %s, %.0f%% equity against %d opponents where a fair share is %.0f%%.",
+		"why.preflop":    "Before the flop it plays hands with at least %.0f%% heads-up equity and raises with %.0f%%.",
+		"why.chart":      "Before the flop it follows its chart for %s from %s against %s.",
+		"raises.0":       "no raise",
+		"raises.1":       "a raise",
+		"raises.2":       "a re-raise",
+		"why.potOdds":    "Pot odds: calling %d into a pot of %d needs %.0f%% equity.",
+		"why.free":       "There was nothing to call, so checking was free.",
+		"why.raiseAt":    "It raises with at least %.0f%% equity.",
+		"why.roll":       "Random roll: %d out of 100, and it raises a strong hand below %d.",
+		"why.bluff":      "It bluffed: the random roll came in under its bluffing rate of %d in 100.",
+	},
+	"es": {
+		"action.fold":  "retirarse",
+		"action.check": "pasar",
+		"action.call":  "igualar",
+		"action.raise": "subir",
+
+		"strength.weak":     "débil",
+		"strength.marginal": "marginal",
+		"strength.good":     "buena",
+		"strength.strong":   "fuerte",
+
+		"why.none":       "Ningún bot ha actuado todavía.",
+		"why.header":     "Por qué %s decidió %s:",
+		"why.random":     "Juega al azar: sacó %d de 100; se retira por debajo de %d, iguala por debajo de %d y si no, sube.",
+		"why.randomPick": "Elige al azar entre retirarse, igualar o subir.",
+		"why.strength":   "Fuerza de la mano: %s, %.0f%% de equidad contra %d rivales, donde la parte justa es %.0f%%.",
+		"why.preflop":    "Antes del flop juega manos con al menos %.0f%% de equidad mano a mano y sube con %.0f%%.",
+		"why.chart":      "Antes del flop sigue su tabla para %s desde %s ante %s.",
+		"raises.0":       "ninguna subida",
+		"raises.1":       "una subida",
+		"raises.2":       "una resubida",
+		"why.potOdds":    "Probabilidades del bote: igualar %d en un bote de %d requiere %.0f%% de equidad.",
+		"why.free":       "No había nada que igualar, así que pasar era gratis.",
+		"why.raiseAt":    "Sube con al menos %.0f%% de equidad.",
+		"why.roll":       "Tirada al azar: %d de 100; sube una mano fuerte por debajo de %d.",
+		"why.bluff":      "Fue un farol: la tirada al azar quedó por debajo de su tasa de farol de %d de 100.",
+	},
+	"ru": {
+		"action.fold":  "сбросить",
+		"action.check": "пропустить",
+		"action.call":  "уравнять",
+		"action.raise": "повысить",
+
+		"strength.weak":     "слабая",
+		"strength.marginal": "пограничная",
+		"strength.good":     "хорошая",
+		"strength.strong":   "сильная",
+
+		"why.none":       "Боты ещё не ходили.",
+		"why.header":     "Почему %s решил %s:",
+		"why.random":     "Играет наугад: выпало %d из 100; сбрасывает ниже %d, уравнивает ниже %d, иначе повышает.",
+		"why.randomPick": "Выбирает наугад: сбросить, уравнять или повысить.",
+		"why.strength":   "Сила руки: %s, эквити %.0f%% против %d соперников при справедливой доле %.0f%%.",
+		"why.preflop":    "До флопа играет руки с эквити один на один не меньше %.0f%% и повышает от %.0f%%.",
+		"why.chart":      "До флопа следует своей таблице для %s с позиции %s, когда перед ним %s.",
+		"raises.0":       "не было повышений",
+		"raises.1":       "одно повышение",
+		"raises.2":       "повторное повышение",
+		"why.potOdds":    "Шансы банка: чтобы уравнять %d в банк %d, нужно эквити %.0f%%.",
+		"why.free":       "Уравнивать было нечего, поэтому чек ничего не стоил.",
+		"why.raiseAt":    "Повышает с эквити от %.0f%%.",
+		"why.roll":       "Случайный бросок: %d из 100; сильную руку повышает ниже %d.",
+		"why.bluff":      "Это блеф: случайный бросок оказался ниже его частоты блефа, %d из 100.",
+	},
+}
+
+// T formats the message with the given key in lang, falling back to English
+// and then to the key itself when there is no translation.
+func T(lang, key string, args ...interface{}) string {
+	format, ok := catalogs[lang][key]
+	if !ok {
+		if format, ok = catalogs[Default][key]; !ok {
+			return key
+		}
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+// Lang picks a supported language from a code such as "ru", "es_ES.UTF-8"
+// or "en-GB", returning Default for unsupported or empty codes.
+func Lang(code string) string {
+	code = strings.ToLower(code)
+	if i := strings.IndexAny(code, "_-."); i >= 0 {
+		code = code[:i]
+	}
+	if _, ok := catalogs[code]; ok {
+		return code
+	}
+	return Default
+}
+
+// FromEnv picks the language from the LC_ALL, LC_MESSAGES and LANG
+// environment variables, in that order.
+func FromEnv() string {
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if v := os.Getenv(name); v != "" {
+			return Lang(v)
+		}
+	}
+	return Default
+}
+
+// Languages returns the supported language codes in alphabetical order.
+func Languages() []string {
+	langs := make([]string, 0, len(catalogs))
+	for lang := range catalogs {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	return langs
+}
@@ -0,0 +1,30 @@
+package i18n
+
+import "testing"
+
+// TestCatalogsComplete checks that every language translates every English key.
+func TestCatalogsComplete(t *testing.T) {
+	for lang, catalog := range catalogs {
+		for key := range catalogs[Default] {
+			if _, ok := catalog[key]; !ok {
+				t.Errorf("%s has no translation for %s", lang, key)
+			}
+		}
+	}
+}
+
+// TestLangAndFallback checks language codes and falling back to English.
+func TestLangAndFallback(t *testing.T) {
+	tests := map[string]string{"ru_RU.UTF-8": "ru", "es-ES": "es", "EN": "en", "fr_FR": "en", "": "en", "C": "en"}
+	for code, want := range tests {
+		if got := Lang(code); got != want {
+			t.Errorf("Lang(%q) = %q, want %q", code, got, want)
+		}
+	}
+	if got := T("fr", "action.fold"); got != "fold" {
+		t.Errorf("T(fr) = %q, want the English fallback", got)
+	}
+	if got := T("ru", "no.such.key"); got != "no.such.key" {
+		t.Errorf("T() of an unknown key = %q, want the key", got)
+	}
+}
@@ -0,0 +1,141 @@
+// Package notify tells a player's own automations, such as a phone
+// notification or a home dashboard, when a slow game needs them.
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"pokerclientv1/pkg/game"
+)
+
+// Kinds of notification.
+const (
+	KindTurn          = "turn"           // It is the player's turn, ToCall is the price of calling
+	KindHandCompleted = "hand_completed" // A hand at the player's table is over
+)
+
+// Payload is the JSON body posted to the webhook.
+type Payload struct {
+	Kind   string    `json:"event"`
+	Player string    `json:"player"`
+	Table  string    `json:"table,omitempty"`
+	Hand   int       `json:"hand"`
+	ToCall int       `json:"to_call,omitempty"`
+	Time   time.Time `json:"time"`
+}
+
+// Webhook posts a Payload to a URL when it becomes the player's turn and
+// when a hand is over. Posts are made in the background, one at a time, so
+// a slow or unreachable endpoint never holds up the game; when too many are
+// waiting the newest are dropped. Register HandleEvent with Game.OnEvent.
+type Webhook struct {
+	URL    string
+	Player string       // Whose turns are notified
+	Client *http.Client // Used for the posts, one with a 5 second timeout when nil
+	Errors io.Writer    // Where the first failed post is reported, discarded when nil
+
+	once   sync.Once
+	queue  chan Payload
+	done   chan struct{}
+	failed bool
+}
+
+// NewWebhook creates a webhook posting to rawURL for player's turns. The
+// URL must be http or https.
+func NewWebhook(rawURL, player string) (*Webhook, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("webhook: %w", err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("webhook: %q is not an http or https URL", rawURL)
+	}
+	return &Webhook{URL: rawURL, Player: player}, nil
+}
+
+// HandleEvent notifies the player's turns and the end of each hand.
+func (w *Webhook) HandleEvent(e game.Event) {
+	switch {
+	case e.Type == game.EventActionOn && e.PlayerID == w.Player:
+		w.Notify(Payload{Kind: KindTurn, Table: e.Table, Hand: e.HandNumber, ToCall: e.Amount, Time: e.Time})
+	case e.Type == game.EventHandEnded:
+		w.Notify(Payload{Kind: KindHandCompleted, Table: e.Table, Hand: e.HandNumber, Time: e.Time})
+	}
+}
+
+// Notify queues a payload to be posted. Player and Time are filled in when
+// left empty.
+func (w *Webhook) Notify(p Payload) {
+	w.once.Do(w.start)
+	if p.Player == "" {
+		p.Player = w.Player
+	}
+	if p.Time.IsZero() {
+		p.Time = time.Now()
+	}
+	select {
+	case w.queue <- p:
+	default: // Too far behind, drop it
+	}
+}
+
+// Close waits for queued posts to be made, for at most timeout. Nothing may
+// be notified after Close.
+func (w *Webhook) Close(timeout time.Duration) {
+	w.once.Do(w.start)
+	close(w.queue)
+	select {
+	case <-w.done:
+	case <-time.After(timeout):
+	}
+}
+
+func (w *Webhook) start() {
+	w.queue = make(chan Payload, 16)
+	w.done = make(chan struct{})
+	go func() {
+		defer close(w.done)
+		for p := range w.queue {
+			if err := w.post(p); err != nil && !w.failed {
+				w.failed = true
+				if w.Errors != nil {
+					fmt.Fprintf(w.Errors, "Warning: webhook %s failed: %v\n", w.URL, err)
+				}
+			}
+		}
+	}()
+}
+
+// post sends one payload, failing on any status other than 2xx.
+func (w *Webhook) post(p Payload) error {
+	body, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+	client := w.Client
+	if client == nil {
+		client = &http.Client{Timeout: 5 * time.Second}
+	}
+	req, err := http.NewRequest(http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("%s", resp.Status)
+	}
+	return nil
+}
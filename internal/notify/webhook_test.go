@@ -0,0 +1,82 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"pokerclientv1/pkg/gametest"
+)
+
+// TestWebhookTurnsAndHands: the human folds the small blind, so the hook
+// hears of one turn and the end of the hand.
+func TestWebhookTurnsAndHands(t *testing.T) {
+	var mu sync.Mutex
+	got := []Payload{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var p Payload
+		if err := json.NewDecoder(r.Body).Decode(&p); err != nil {
+			t.Errorf("bad payload: %v", err)
+		}
+		mu.Lock()
+		got = append(got, p)
+		mu.Unlock()
+	}))
+	defer srv.Close()
+
+	hook, err := NewWebhook(srv.URL, "You")
+	if err != nil {
+		t.Fatal(err)
+	}
+	you := gametest.NewScriptedPlayer("You", 100, gametest.Fold())
+	bb := gametest.NewScriptedPlayer("BB", 100)
+	h := gametest.New(t, nil, you, bb)
+	h.Engine.OnEvent(hook.HandleEvent)
+	h.PlayHand()
+	hook.Close(5 * time.Second)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(got) != 2 {
+		t.Fatalf("webhook got %+v, want a turn and a completed hand", got)
+	}
+	if got[0].Kind != KindTurn || got[0].Player != "You" || got[0].Hand != 1 || got[0].ToCall != 1 {
+		t.Errorf("first post = %+v, want You's turn in hand 1 with 1 to call", got[0])
+	}
+	if got[1].Kind != KindHandCompleted || got[1].Hand != 1 {
+		t.Errorf("second post = %+v, want hand 1 completed", got[1])
+	}
+}
+
+// TestWebhookReportsFailureOnce checks that an endpoint refusing every post
+// is reported once and does not stop the game.
+func TestWebhookReportsFailureOnce(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "nope", http.StatusForbidden)
+	}))
+	defer srv.Close()
+
+	hook, _ := NewWebhook(srv.URL, "You")
+	var errs bytes.Buffer
+	hook.Errors = &errs
+	for i := 0; i < 3; i++ {
+		hook.Notify(Payload{Kind: KindHandCompleted, Hand: i + 1})
+	}
+	hook.Close(5 * time.Second)
+	if n := strings.Count(errs.String(), "Warning"); n != 1 || !strings.Contains(errs.String(), "403") {
+		t.Errorf("errors reported:\n%s\nwant a single 403 warning", errs.String())
+	}
+}
+
+func TestNewWebhookNeedsHTTP(t *testing.T) {
+	for _, bad := range []string{"", "example.com/hook", "ftp://example.com/hook", "http://"} {
+		if _, err := NewWebhook(bad, "You"); err == nil {
+			t.Errorf("NewWebhook(%q) succeeded, want an error", bad)
+		}
+	}
+}
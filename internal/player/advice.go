@@ -0,0 +1,50 @@
+package player
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	"pokerclientv1/pkg/game"
+	"pokerclientv1/pkg/types"
+)
+
+// adviceIterations is how many deals the bet advice simulation runs.
+const adviceIterations = 1000
+
+// betAdvice describes a bet or raise that adds amount chips to the pot: how
+// often it must fold the opponent out to profit as a bluff, and the equity of
+// the hand when called by the range a calling opponent is modeled with.
+func betAdvice(hand *types.Hand, table *types.Table, amount int) string {
+	pot := table.Pot
+	callRange := game.CallingRange(pot, amount)
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	equity := game.EquityWhenCalled(hand.Cards, table.CommunityCards, callRange, adviceIterations, rng)
+	return fmt.Sprintf("Betting %d into %d: as a bluff it needs %.0f%% folds to profit. "+
+		"Called by the top %.0f%% of hands, you have about %.0f%% equity.",
+		amount, pot, 100*game.FoldEquityNeeded(pot, amount), 100*callRange, 100*equity)
+}
+
+// potOddsHint describes the price of calling toCall chips into the pot, e.g.
+// "Call 20 into 140: pot odds 7.0 to 1, you need 12.5% equity."
+func potOddsHint(table *types.Table, toCall int) string {
+	return fmt.Sprintf("Call %d into %d: pot odds %.1f to 1, you need %.1f%% equity.",
+		toCall, table.Pot, float64(table.Pot)/float64(toCall), 100*game.PotOdds(table.Pot, toCall))
+}
+
+// coachHint estimates the hand's equity against the opponents still in the
+// hand, each holding random cards, and compares it with the price of calling.
+func coachHint(hand *types.Hand, table *types.Table, toCall int) string {
+	opponents := max(table.PlayersInHand-1, 1)
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	equity := game.Equity(hand.Cards, table.CommunityCards, opponents, adviceIterations, rng)
+	hint := fmt.Sprintf("Coach: your hand has about %.0f%% equity against %d random hand(s).", 100*equity, opponents)
+	switch {
+	case toCall <= 0:
+	case equity >= game.PotOdds(table.Pot, toCall):
+		hint += " That beats the pot odds, so calling pays in the long run."
+	default:
+		hint += " That is short of the pot odds; calling loses in the long run unless you expect to win more later."
+	}
+	return hint
+}
@@ -0,0 +1,20 @@
+package player
+
+import (
+	"strings"
+	"testing"
+
+	"pokerclientv1/pkg/types"
+)
+
+// TestPotOddsAndCoachHints checks the pot odds wording and that the coach
+// compares the equity of a strong hand with the price of calling.
+func TestPotOddsAndCoachHints(t *testing.T) {
+	table := &types.Table{Pot: 140, PlayersInHand: 2}
+	if got, want := potOddsHint(table, 20), "Call 20 into 140: pot odds 7.0 to 1, you need 12.5% equity."; got != want {
+		t.Errorf("potOddsHint() = %q, want %q", got, want)
+	}
+	if got := coachHint(hand(t, "As", "Ah"), table, 20); !strings.Contains(got, "beats the pot odds") {
+		t.Errorf("coachHint() for pocket aces = %q, want calling to beat the pot odds", got)
+	}
+}
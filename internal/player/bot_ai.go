@@ -3,30 +3,77 @@ package player
 
 import (
 	"math/rand"
-	"pokerclientv1/internal/types"
+	"pokerclientv1/pkg/game"
+	"pokerclientv1/pkg/ranges"
+	"pokerclientv1/pkg/types"
 	"time"
 )
 
+// equityIterations is how many deals the equity simulation runs per decision.
+var equityIterations = map[string]int{"medium": 300, "hard": 1000}
+
+// ValidDifficulty reports whether a bot can be created with a difficulty:
+// easy, medium, hard or the name of a personality preset.
+func ValidDifficulty(difficulty string) bool {
+	if _, ok := LookupPersonality(difficulty); ok {
+		return true
+	}
+	_, ok := equityIterations[difficulty]
+	return ok || difficulty == "easy"
+}
+
 // BotAI defines the structure for bot decision logic.
 type BotAI struct {
-	Difficulty string        // easy, medium, hard
-	TurnDelay  time.Duration // How long the bot "thinks" before acting
+	Difficulty  string        // easy, medium, hard, or the name of a personality
+	Personality *Personality  // Playing style, overrides the difficulty when set
+	TurnDelay   time.Duration // How long the bot appears to "think", applied by the game loop
+	Rng         *rand.Rand    // Optional source of decisions for reproducible play, the clock when nil
+	Last        Decision      // What the last decision was based on, see Decision.Explain
 }
 
 // DecideAction determines the bot's action based on its AI settings.
-func (ai *BotAI) DecideAction(hand *types.Hand, table *types.Table, currentBet int, chips int, minRaise int) (action string, amount int) {
-	time.Sleep(ai.TurnDelay) // Simulate thinking
+// It returns immediately; the game loop is responsible for pacing (see ThinkTime).
+// Bots with a personality play to its style, medium and hard bots play from
+// a pre-flop chart and then weigh their equity against the pot odds, other
+// difficulties decide at random.
+func (ai *BotAI) DecideAction(hand *types.Hand, table *types.Table, currentBet int, playerBet int, chips int, minRaise int) (action string, amount int) {
+	ai.Last = Decision{Rule: "random", ToCall: currentBet - playerBet, Pot: table.Pot, Roll: -1}
+	action, amount = ai.decide(hand, table, currentBet, playerBet, chips, minRaise)
+	ai.Last.Action = action
+	if action == "call" && ai.Last.ToCall <= 0 {
+		ai.Last.Action = "check" // The game treats calling nothing as a check
+	}
+	return action, amount
+}
 
+// decide picks the action for DecideAction, noting its reasons in ai.Last.
+func (ai *BotAI) decide(hand *types.Hand, table *types.Table, currentBet int, playerBet int, chips int, minRaise int) (action string, amount int) {
 	// Current call amount
 	callAmount := currentBet
 
 	// Simple random strategy based on difficulty
-	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	r := ai.Rng
+	if r == nil {
+		r = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+
+	if ai.Personality != nil {
+		return ai.decideByPersonality(hand, table, currentBet, playerBet, chips, minRaise, r)
+	}
+	if _, ok := equityIterations[ai.Difficulty]; ok {
+		if len(table.CommunityCards) == 0 {
+			if action, amount, ok := ai.decideByChart(hand, table, currentBet, playerBet, chips, minRaise, r); ok {
+				return action, amount
+			}
+		}
+		return ai.decideByEquity(hand, table, currentBet, playerBet, chips, minRaise, r)
+	}
 
 	switch ai.Difficulty {
 	case "easy":
 		// Easy bot: 60% call, 20% fold, 20% raise (small)
 		decision := r.Intn(100)
+		ai.Last.Roll = decision
 
 		if decision < 20 {
 			return "fold", 0
@@ -51,6 +98,7 @@ func (ai *BotAI) DecideAction(hand *types.Hand, table *types.Table, currentBet i
 	case "medium":
 		// Medium bot: More strategic decisions
 		decision := r.Intn(100)
+		ai.Last.Roll = decision
 
 		if decision < 15 {
 			return "fold", 0
@@ -74,6 +122,7 @@ func (ai *BotAI) DecideAction(hand *types.Hand, table *types.Table, currentBet i
 	case "hard":
 		// Hard bot: Much more aggressive
 		decision := r.Intn(100)
+		ai.Last.Roll = decision
 
 		if decision < 10 {
 			return "fold", 0
@@ -118,3 +167,95 @@ func (ai *BotAI) DecideAction(hand *types.Hand, table *types.Table, currentBet i
 		}
 	}
 }
+
+// decideByEquity estimates the hand's equity against the players still in the
+// hand. It raises with a clearly better than fair share, calls when the equity
+// beats the pot odds and folds otherwise. Hard bots raise lighter, bet bigger
+// and sometimes bluff when checked to.
+func (ai *BotAI) decideByEquity(hand *types.Hand, table *types.Table, currentBet int, playerBet int, chips int, minRaise int, r *rand.Rand) (action string, amount int) {
+	toCall := currentBet - playerBet
+	opponents := table.PlayersInHand - 1
+	if opponents < 1 {
+		opponents = 1
+	}
+	equity := game.Equity(hand.Cards, table.CommunityCards, opponents, equityIterations[ai.Difficulty], r)
+	fairShare := 1 / float64(opponents+1)
+
+	raiseAt, betFraction, bluff := 1.3*fairShare, 0.5, false
+	if ai.Difficulty == "hard" {
+		raiseAt, betFraction = 1.15*fairShare, 0.75
+		bluff = toCall == 0 && r.Intn(100) < 10
+	}
+	ai.Last.Rule, ai.Last.Equity, ai.Last.Opponents, ai.Last.RaiseAt = "equity", equity, opponents, raiseAt
+	if bluff && equity < raiseAt {
+		ai.Last.Bluff = 10
+	}
+
+	if (equity >= raiseAt || bluff) && chips > toCall {
+		raiseAmount := int(float64(table.Pot+toCall) * betFraction)
+		if raiseAmount < minRaise {
+			raiseAmount = minRaise
+		}
+		totalBet := currentBet + raiseAmount
+		if totalBet-playerBet >= chips {
+			return "raise", playerBet + chips // All-in raise
+		}
+		return "raise", totalBet
+	}
+	if toCall <= 0 {
+		return "check", 0
+	}
+	if equity >= game.PotOdds(table.Pot, toCall) {
+		return "call", toCall
+	}
+	return "fold", 0
+}
+
+// decideByChart plays a hand before the flop as ranges.Default says from the
+// bot's position. The table does not count raises, so a bet of more than the
+// big blind is taken as a raise and one of more than five as a re-raise.
+// Hard bots raise bigger and now and then re-raise a hand the chart only
+// calls with. ok is false when the table does not give the bot's position
+// or the blinds, as outside a game.
+func (ai *BotAI) decideByChart(hand *types.Hand, table *types.Table, currentBet int, playerBet int, chips int, minRaise int, r *rand.Rand) (action string, amount int, ok bool) {
+	position := table.Positions[table.Acting]
+	if len(hand.Cards) != 2 || table.BigBlind <= 0 || ranges.Group(position) == "" {
+		return "", 0, false
+	}
+	raises := 0
+	switch {
+	case currentBet > 5*table.BigBlind:
+		raises = 2
+	case currentBet > table.BigBlind:
+		raises = 1
+	}
+	play, _ := ranges.Default.Play(position, hand.Cards[0], hand.Cards[1], raises)
+	toCall := currentBet - playerBet
+	ai.Last.Rule, ai.Last.Position, ai.Last.Hand, ai.Last.Raises = "chart", position, ranges.Class(hand.Cards[0], hand.Cards[1]), raises
+	if play == "call" && raises == 1 && ai.Difficulty == "hard" && r.Intn(100) < 10 {
+		play, ai.Last.Bluff = "raise", 10
+	}
+
+	if play == "raise" && chips > toCall {
+		// Open to 2.5 big blinds, 3 for hard bots, and re-raise to 3 times the bet
+		totalBet := int(2.5 * float64(table.BigBlind))
+		if ai.Difficulty == "hard" {
+			totalBet = 3 * table.BigBlind
+		}
+		if raises > 0 {
+			totalBet = 3 * currentBet
+		}
+		totalBet = max(totalBet, currentBet+minRaise)
+		if totalBet-playerBet >= chips {
+			return "raise", playerBet + chips, true // All-in raise
+		}
+		return "raise", totalBet, true
+	}
+	if toCall <= 0 {
+		return "check", 0, true
+	}
+	if play != "fold" {
+		return "call", toCall, true
+	}
+	return "fold", 0, true
+}
@@ -0,0 +1,112 @@
+package player
+
+import (
+	"math/rand"
+	"slices"
+	"strings"
+	"time"
+
+	"pokerclientv1/pkg/game"
+	"pokerclientv1/pkg/types"
+)
+
+// banter is what bots say, by personality name, "" for bots without one.
+var banter = map[string]struct {
+	chance   float64  // Of speaking up when there is something to say
+	niceHand []string // To the player who beat them at showdown
+	taunts   []string // After taking down a pot with a bluff
+}{
+	"":                {0.2, []string{"Nice hand."}, []string{"Sorry, had to."}},
+	"nit":             {0.3, []string{"Nice hand.", "Well played."}, []string{"I always have it."}},
+	"tag":             {0.3, []string{"nh", "Good call."}, []string{"Maybe I had it, maybe not."}},
+	"lag":             {0.5, []string{"nh, I'll get it back.", "Lucky river."}, []string{"Did you fold the best hand?", "Keep folding, I'll keep betting."}},
+	"calling station": {0.4, []string{"I had to see it.", "How did you have that?"}, []string{"Got there!"}},
+	"maniac":          {0.7, []string{"Enjoy it while it lasts.", "Whatever. Next hand."}, []string{"Pure air. 😈", "Too scared to call?", "I'll show you one day."}},
+}
+
+// TableTalk has bots say a word now and then, in the style of their
+// personality: "nice hand" to a player who beat them at showdown, or a
+// taunt after taking down a pot with a bluff. At most one bot speaks a
+// hand. Register HandleEvent with Game.OnEvent; Say is usually Game.Chat.
+type TableTalk struct {
+	Say func(playerID, text string)
+	Rng *rand.Rand // Optional source of when bots speak and what they say, the clock when nil
+
+	bots     map[string]*BotPlayer
+	board    []types.Card
+	bettors  map[string]bool // Players who bet or raised this hand
+	showdown []string        // Players who went to showdown this hand
+	spoke    bool
+}
+
+// NewTableTalk gives a voice to the bots among players.
+func NewTableTalk(players []types.Player, say func(playerID, text string)) *TableTalk {
+	t := &TableTalk{Say: say, bots: map[string]*BotPlayer{}}
+	for _, p := range players {
+		if bot, ok := p.(*BotPlayer); ok {
+			t.bots[bot.ID] = bot
+		}
+	}
+	return t
+}
+
+// HandleEvent follows the hand and lets a bot speak when it is won.
+func (t *TableTalk) HandleEvent(e game.Event) {
+	switch e.Type {
+	case game.EventHandStarted:
+		t.board, t.bettors, t.showdown, t.spoke = nil, map[string]bool{}, nil, false
+	case game.EventStreetDealt:
+		t.board = append(t.board, e.Cards...)
+	case game.EventPlayerAction:
+		if strings.HasPrefix(e.Action, "raises") && t.bettors != nil {
+			t.bettors[e.PlayerID] = true
+		}
+	case game.EventShowdown, game.EventHandMucked:
+		t.showdown = append(t.showdown, e.PlayerID)
+	case game.EventPotAwarded:
+		if t.bettors != nil && !t.spoke {
+			t.potWon(e.PlayerID)
+		}
+	}
+}
+
+// potWon has a losing bot at the showdown greet the winner, or the winner
+// taunt the table when it won without a showdown holding less than a pair.
+func (t *TableTalk) potWon(winner string) {
+	if len(t.showdown) == 0 {
+		bot := t.bots[winner]
+		if bot != nil && t.bettors[winner] && len(t.board) >= 3 &&
+			game.EvaluateHand(slices.Concat(bot.Hand.Cards, t.board)).Rank == game.HighCard {
+			t.speak(bot, true)
+		}
+		return
+	}
+	for _, id := range t.showdown {
+		if bot := t.bots[id]; bot != nil && id != winner {
+			t.speak(bot, false)
+			return
+		}
+	}
+}
+
+// speak has bot say one of its nice-hand lines, or taunts, if its
+// personality is in the mood. Either way no other bot speaks this hand.
+func (t *TableTalk) speak(bot *BotPlayer, taunt bool) {
+	name := ""
+	if bot.AI.Personality != nil {
+		name = bot.AI.Personality.Name
+	}
+	style := banter[name]
+	lines := style.niceHand
+	if taunt {
+		lines = style.taunts
+	}
+	r := t.Rng
+	if r == nil {
+		r = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	t.spoke = true
+	if len(lines) > 0 && r.Float64() < style.chance {
+		t.Say(bot.ID, lines[r.Intn(len(lines))])
+	}
+}
@@ -0,0 +1,89 @@
+package player
+
+import (
+	"fmt"
+	"strings"
+
+	"pokerclientv1/internal/i18n"
+)
+
+// randomOdds are the rolls out of 100 below which the chance-based
+// difficulties fold and call; higher rolls raise.
+var randomOdds = map[string][2]int{"easy": {20, 80}, "medium": {15, 70}, "hard": {10, 50}}
+
+// Decision records what a bot weighed when it last decided, so players can
+// ask why it acted as it did.
+type Decision struct {
+	Action    string  // fold, check, call or raise
+	Rule      string  // "random", "chart", "equity", "preflop" or "postflop"
+	Equity    float64 // Estimated share of the pot, for every rule but random and chart
+	Opponents int     // Players the equity was estimated against
+	RaiseAt   float64 // Equity the bot raises with
+	PlayAt    float64 // Equity the bot plays a hand with before the flop
+	ToCall    int
+	Pot       int
+	Roll      int // Random roll out of 100 that decided, -1 when none
+	RollUnder int // Roll below which a strong hand is raised, for personalities
+	Bluff     int // Bluffing rate out of 100 when the bot bluffed, 0 otherwise
+
+	// The starting hand, such as AKs, the bot's position and the raises
+	// before it, 0 to 2 for a re-raise, for the chart rule
+	Hand     string
+	Position string
+	Raises   int
+}
+
+// strength buckets the equity against a fair share of the pot.
+func (d Decision) strength() string {
+	fair := 1 / float64(d.Opponents+1)
+	switch {
+	case d.Equity < 0.75*fair:
+		return "weak"
+	case d.Equity < fair:
+		return "marginal"
+	case d.Equity < 1.3*fair:
+		return "good"
+	}
+	return "strong"
+}
+
+// strengthLine describes the hand's equity against a fair share of the pot.
+func (d Decision) strengthLine(lang string) string {
+	fair := 100 / float64(d.Opponents+1)
+	return i18n.T(lang, "why.strength", i18n.T(lang, "strength."+d.strength()), 100*d.Equity, d.Opponents, fair)
+}
+
+// Explain describes the decision of the bot named bot in lang, one reason
+// per line.
+func (d Decision) Explain(bot, difficulty, lang string) string {
+	lines := []string{i18n.T(lang, "why.header", bot, i18n.T(lang, "action."+d.Action))}
+	if d.Rule == "random" {
+		if odds, ok := randomOdds[difficulty]; ok && d.Roll >= 0 {
+			lines = append(lines, i18n.T(lang, "why.random", d.Roll, odds[0], odds[1]))
+		} else {
+			lines = append(lines, i18n.T(lang, "why.randomPick"))
+		}
+		return strings.Join(lines, "\n") + "\n"
+	}
+
+	switch d.Rule {
+	case "chart":
+		lines = append(lines, i18n.T(lang, "why.chart", d.Hand, d.Position, i18n.T(lang, fmt.Sprintf("raises.%d", d.Raises))))
+	case "preflop":
+		lines = append(lines, d.strengthLine(lang), i18n.T(lang, "why.preflop", 100*d.PlayAt, 100*d.RaiseAt))
+	default:
+		lines = append(lines, d.strengthLine(lang), i18n.T(lang, "why.raiseAt", 100*d.RaiseAt))
+	}
+	if d.ToCall > 0 {
+		lines = append(lines, i18n.T(lang, "why.potOdds", d.ToCall, d.Pot, 100*float64(d.ToCall)/float64(d.Pot+d.ToCall)))
+	} else {
+		lines = append(lines, i18n.T(lang, "why.free"))
+	}
+	if d.Roll >= 0 && d.RollUnder > 0 {
+		lines = append(lines, i18n.T(lang, "why.roll", d.Roll, d.RollUnder))
+	}
+	if d.Bluff > 0 {
+		lines = append(lines, i18n.T(lang, "why.bluff", d.Bluff))
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
@@ -0,0 +1,66 @@
+package player
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"pokerclientv1/pkg/types"
+)
+
+// TestExplainDecision checks that bots record what they decided from and
+// that the explanation is given in the chosen language.
+func TestExplainDecision(t *testing.T) {
+	table := &types.Table{Pot: 30, PlayersInHand: 2, Seats: 2}
+
+	easy := &BotAI{Difficulty: "easy", Rng: rand.New(rand.NewSource(1))}
+	action, _ := easy.DecideAction(hand(t, "7c", "2d"), table, 10, 0, 100, 2)
+	if easy.Last.Rule != "random" || easy.Last.Roll < 0 || easy.Last.Action != action {
+		t.Errorf("easy decision = %+v, want a random roll deciding %s", easy.Last, action)
+	}
+	if why := easy.Last.Explain("Bot", "easy", "en"); !strings.Contains(why, "out of 100, and folds below 20, calls below 80") {
+		t.Errorf("easy explanation = %q, want the roll and its odds", why)
+	}
+
+	hard := &BotAI{Difficulty: "hard", Rng: rand.New(rand.NewSource(1))}
+	hard.DecideAction(hand(t, "As", "Ah"), table, 10, 0, 100, 2)
+	if hard.Last.Rule != "equity" || hard.Last.Equity < 0.7 {
+		t.Errorf("hard decision = %+v, want pocket aces weighed by equity", hard.Last)
+	}
+	why := hard.Last.Explain("Bot", "hard", "en")
+	for _, want := range []string{"Why Bot chose to raise:", "Hand strength: strong", "calling 10 into a pot of 30 needs 25% equity"} {
+		if !strings.Contains(why, want) {
+			t.Errorf("hard explanation is missing %q:\n%s", want, why)
+		}
+	}
+	if why := hard.Last.Explain("Bot", "hard", "ru"); !strings.Contains(why, "Почему Bot решил повысить") {
+		t.Errorf("Russian explanation = %q", why)
+	}
+}
+
+// TestChartDecision checks that medium and hard bots play from the pre-flop
+// chart for their position once the table gives it.
+func TestChartDecision(t *testing.T) {
+	table := func(position string, bet int) *types.Table {
+		return &types.Table{CurrentBet: bet, Pot: 3 + bet, PlayersInHand: 6, BigBlind: 2, Acting: "Bot", Positions: map[string]string{"Bot": position}}
+	}
+
+	medium := &BotAI{Difficulty: "medium", Rng: rand.New(rand.NewSource(1))}
+	if action, _ := medium.DecideAction(hand(t, "7c", "2d"), table("UTG", 2), 2, 0, 100, 2); action != "fold" || medium.Last.Rule != "chart" {
+		t.Errorf("medium 72o under the gun = %s by %+v, want a fold by the chart", action, medium.Last)
+	}
+	if why := medium.Last.Explain("Bot", "medium", "en"); !strings.Contains(why, "its chart for 72o from UTG against no raise") {
+		t.Errorf("chart explanation = %q", why)
+	}
+	if action, amount := medium.DecideAction(hand(t, "Kd", "8c"), table("BTN", 2), 2, 0, 100, 2); action != "raise" || amount != 5 {
+		t.Errorf("medium K8o on the button = %s %d, want a raise to 5", action, amount)
+	}
+
+	hard := &BotAI{Difficulty: "hard", Rng: rand.New(rand.NewSource(1))}
+	if action, amount := hard.DecideAction(hand(t, "Js", "Jh"), table("CO", 6), 6, 0, 100, 4); action != "raise" || amount != 18 {
+		t.Errorf("hard JJ in the cutoff facing a raise = %s %d, want a re-raise to 18", action, amount)
+	}
+	if action, _ := hard.DecideAction(hand(t, "Ts", "Th"), table("BTN", 20), 20, 6, 100, 14); action != "call" || hard.Last.Raises != 2 {
+		t.Errorf("hard TT facing a re-raise = %s by %+v, want a call", action, hard.Last)
+	}
+}
@@ -0,0 +1,69 @@
+package player
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// HotSeat lets several humans play at one terminal. Before a human's turn,
+// unless they also took the last one, it blanks the screen and waits for them
+// to take the device, so no one sees another's cards.
+type HotSeat struct {
+	Out   io.Writer     // Where the pass screen is written, os.Stdout when nil
+	In    InputProvider // Input shared by every human, read for the Enter that takes the device
+	Clear func()        // Blanks the screen, such as the UI's ClearScreen
+
+	// Called with the ID of the human who took the device, for the UI to
+	// show only their cards
+	OnPass func(playerID string)
+
+	current string
+	reader  *lineReader // Shared by the pass screen and every human's prompts
+}
+
+// NewHotSeat seats the humans at one terminal, sharing in for their input.
+func NewHotSeat(in InputProvider, humans ...*HumanPlayer) *HotSeat {
+	h := &HotSeat{In: in}
+	for _, p := range humans {
+		p.HotSeat = h
+		p.Input = in
+	}
+	return h
+}
+
+// Pass hands the device to the player with the given ID if someone else
+// had it, returning false if input ended before they took it.
+func (h *HotSeat) Pass(playerID string) bool {
+	if h.current == playerID {
+		return true
+	}
+	out := h.Out
+	if out == nil {
+		out = os.Stdout
+	}
+	if h.Clear != nil {
+		h.Clear()
+	}
+	fmt.Fprintf(out, "Pass the device to %s. %s, press Enter when ready.", playerID, playerID)
+	if _, err := h.lines().read(context.Background(), time.Now(), time.Time{}); err != nil {
+		return false
+	}
+	h.current = playerID
+	if h.OnPass != nil {
+		h.OnPass(playerID)
+	}
+	return true
+}
+
+// lines returns the reader of In that the pass screen and the humans share,
+// so a read left waiting when a turn clock runs out is picked up by the next
+// prompt instead of racing it for a line.
+func (h *HotSeat) lines() *lineReader {
+	if h.reader == nil || h.reader.in != h.In {
+		h.reader = &lineReader{in: h.In}
+	}
+	return h.reader
+}
@@ -1,185 +1,525 @@
 package player
 
 import (
-	"bufio"
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
-	"pokerclientv1/internal/types"
+	"pokerclientv1/pkg/game"
+	"pokerclientv1/pkg/types"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // HumanPlayer represents a player controlled by user input.
 type HumanPlayer struct {
-	ID         string
-	Chips      int
-	Hand       *types.Hand
-	Folded     bool
-	CurrentBet int // Amount bet in the current round
+	types.BasePlayer
+	BetAdvice bool   // Show fold equity and equity when called before confirming a raise
+	PotOdds   bool   // Show the price of calling as pot odds on each turn
+	Coach     bool   // Also estimate the hand's equity on each turn, for beginners
+	Avatar    string // Emoji or short glyph shown next to the name, see types.ValidateAvatar
+	SpeedRun  bool   // One-line prompt and single-key actions, see types.DefaultBindings
+
+	// Extra keys and aliases for commands, such as "f" for fold.
+	// types.DefaultBindings are used when there are none.
+	Keys types.Bindings
+
+	Stats  func() string // Optional session stats report shown by the stats command
+	Budget func() int    // Optional cap on chips bought between hands, such as the bankroll left
+	Why    func() string // Optional explanation of the last bot decision shown by the why command
+
+	// Optional log of recent hands shown by the log command, back hands
+	// before the current one
+	Log func(back int) string
+
+	// Optional; the say command speaks at the table through it, as
+	// Game.Chat does
+	Chat func(text string)
+
+	// Standing instructions, toggled with the auto command, and where to
+	// keep them when they change, such as the profile; SavePrefs is optional
+	Prefs     types.Preferences
+	SavePrefs func(types.Preferences)
+
+	// Optional; once it is done the turn ends at once with a fold, so a game
+	// being stopped is not kept waiting for a valid action
+	Context context.Context
+
+	Input InputProvider // Where answers come from, standard input when nil
+	Out   io.Writer     // Where prompts are written, os.Stdout when nil
+
+	// Optional; shared with the other humans at the same terminal, who are
+	// asked to pass the device before this player's turn
+	HotSeat *HotSeat
+
+	deadline time.Time   // Turn clock deadline set by the game, zero for none
+	lines    *lineReader // Reads Input, unless the player is at a hot seat
 }
 
 // NewHumanPlayer creates a new human player.
 func NewHumanPlayer(id string, startingChips int) *HumanPlayer {
-	return &HumanPlayer{
-		ID:         id,
-		Chips:      startingChips,
-		Hand:       &types.Hand{},
-		Folded:     false,
-		CurrentBet: 0,
-	}
+	return &HumanPlayer{BasePlayer: types.NewBasePlayer(id, startingChips)}
 }
 
-// Implement all the methods required by the types.Player interface
-// Most method implementations remain the same, just update any type references to use types.Hand, types.Table, etc.
-
-func (p *HumanPlayer) GetID() string            { return p.ID }
-func (p *HumanPlayer) GetHand() *types.Hand     { return p.Hand }
-func (p *HumanPlayer) SetHand(hand *types.Hand) { p.Hand = hand }
-func (p *HumanPlayer) AddChips(amount int)      { p.Chips += amount }
-func (p *HumanPlayer) GetChips() int            { return p.Chips }
-func (p *HumanPlayer) IsFolded() bool           { return p.Folded }
-func (p *HumanPlayer) SetFolded(folded bool)    { p.Folded = folded }
-func (p *HumanPlayer) GetCurrentBet() int       { return p.CurrentBet }
-func (p *HumanPlayer) SetCurrentBet(amount int) { p.CurrentBet = amount }
-func (p *HumanPlayer) ResetBet()                { p.CurrentBet = 0 }
+// GetAvatar implements types.AvatarHolder.
+func (p *HumanPlayer) GetAvatar() string {
+	return p.Avatar
+}
 
 // IsHuman returns true for HumanPlayer
 func (p *HumanPlayer) IsHuman() bool { return true }
 
-func (p *HumanPlayer) RemoveChips(amount int) error {
-	if amount > p.Chips {
-		return fmt.Errorf("%s cannot remove %d chips, only has %d", p.ID, amount, p.Chips)
+// SetTurnDeadline implements types.Clocked.
+func (p *HumanPlayer) SetTurnDeadline(deadline time.Time) {
+	p.deadline = deadline
+}
+
+// out returns where prompts are written.
+func (p *HumanPlayer) out() io.Writer {
+	if p.Out == nil {
+		return os.Stdout
+	}
+	return p.Out
+}
+
+// readLine waits for the next line of input, giving up at deadline (unless
+// it is zero) with errTimeUp, or with the context's error once it is done.
+// At a hot seat it reads through the seat's reader, shared by every human
+// there, see lineReader.
+func (p *HumanPlayer) readLine(since, deadline time.Time) (string, error) {
+	if p.HotSeat != nil {
+		return p.HotSeat.lines().read(p.Context, since, deadline)
+	}
+	if p.Input == nil {
+		p.Input = NewReaderInput(os.Stdin)
 	}
-	p.Chips -= amount
-	return nil
+	if p.lines == nil || p.lines.in != p.Input {
+		p.lines = &lineReader{in: p.Input}
+	}
+	return p.lines.read(p.Context, since, deadline)
 }
 
-func (p *HumanPlayer) ResetForNewHand() {
-	p.Hand = &types.Hand{}
-	p.Folded = false
-	p.CurrentBet = 0
+// timeLeft describes the time left on the turn clock for a prompt, or
+// returns "" when there is no clock.
+func (p *HumanPlayer) timeLeft() string {
+	if p.deadline.IsZero() {
+		return ""
+	}
+	return fmt.Sprintf(" (%ds left)", int(time.Until(p.deadline).Round(time.Second).Seconds()))
 }
 
 // TakeTurn prompts the human player for their action via the console.
 func (p *HumanPlayer) TakeTurn(table *types.Table, currentBet int, minRaise int) (action string, amount int) {
-	reader := bufio.NewReader(os.Stdin)
-	callAmount := currentBet - p.CurrentBet // Amount needed to call
+	legal := p.Legal(table, currentBet, minRaise)
+	if p.Prefs.AutoCheck && legal.Can("check") {
+		fmt.Fprintf(p.out(), "%s checks (auto-check is on; type auto check on a later turn to turn it off).\n", p.ID)
+		return "check", 0
+	}
+	if p.HotSeat != nil && !p.HotSeat.Pass(p.ID) {
+		return "exit", 0 // Input ended, nobody is left to play
+	}
+	started := time.Now()
+	callAmount := legal.ToCall // Amount needed to call, at most the stack
+	// readLine reads an answer to this turn, or says why there is none
+	readLine := func() (string, string) {
+		input, err := p.readLine(started, p.deadline)
+		switch {
+		case errors.Is(err, errTimeUp):
+			fmt.Fprintln(p.out(), "\nTime is up.")
+			if legal.Can("check") {
+				return "", "check"
+			}
+			return "", "fold"
+		case p.Context != nil && p.Context.Err() != nil:
+			return "", "fold" // The game is stopping and ignores the action
+		case err != nil && input == "":
+			return "", "exit" // Input ended, nobody is left to play
+		}
+		return input, ""
+	}
+
+	if p.Prefs.AutoFoldJunk && !legal.Can("check") && len(table.CommunityCards) == 0 && isJunk(p.Hand) {
+		fmt.Fprintf(p.out(), "%s, fold %s? (auto-fold junk) [Y/n]: ", p.ID, p.Hand)
+		confirm, ended := readLine()
+		if ended != "" {
+			return ended, 0
+		}
+		if !strings.HasPrefix(strings.TrimSpace(strings.ToLower(confirm)), "n") {
+			return "fold", 0
+		}
+	}
+
+	hints := "" // Worked out once, so asking again shows the same estimate
+	if (p.PotOdds || p.Coach) && callAmount > 0 && table.Pot > 0 {
+		hints += potOddsHint(table, callAmount) + "\n"
+	}
+	if p.Coach {
+		hints += coachHint(p.Hand, table, callAmount) + "\n"
+	}
+
+	// A key bound to call checks when there is nothing to call, and in
+	// speed-run mode a raise without an amount makes the minimum raise
+	keys := p.Keys
+	if keys == nil {
+		keys = types.DefaultBindings
+	}
 
 	for {
-		fmt.Printf("%s's turn (Chips: %d, Current Bet: %d). Hand: %s\n", p.ID, p.Chips, p.CurrentBet, p.Hand)
-		fmt.Printf("Community Cards: %v | Current High Bet: %d\n", table.CommunityCards, currentBet)
+		if !p.SpeedRun {
+			fmt.Fprintf(p.out(), "%s's turn (Chips: %d, Current Bet: %d). Hand: %s\n", p.ID, p.Chips, p.CurrentBet, p.Hand)
+			fmt.Fprintf(p.out(), "Community Cards: %v | Current High Bet: %d\n", table.CommunityCards, currentBet)
+		}
+
+		// All-in is a raise to the whole stack, or a call when the stack
+		// does not cover more; under a table cap only raise goes higher
+		allIn := ""
+		switch {
+		case legal.Can("raise") && legal.MaxRaise == p.CurrentBet+p.Chips:
+			allIn = "raise"
+		case legal.Can("call") && callAmount == p.Chips:
+			allIn = "call"
+		}
 
 		options := []string{"fold"}
-		if p.Chips >= callAmount {
-			if callAmount == 0 {
-				options = append(options, "check")
-			} else {
-				options = append(options, fmt.Sprintf("call (%d)", callAmount))
-			}
+		switch {
+		case legal.Can("check"):
+			options = append(options, "check")
+		case allIn == "call":
+			options = append(options, fmt.Sprintf("all-in (%d)", p.Chips))
+		default:
+			options = append(options, fmt.Sprintf("call (%d)", callAmount))
 		}
-		// Can only raise if they can at least match the current bet and raise by minRaise, or go all-in
-		canAffordMinRaise := p.Chips >= callAmount+minRaise
-		canGoAllIn := p.Chips > callAmount // Must have more chips than needed to call to raise/go all-in
-		if canAffordMinRaise {
-			options = append(options, "raise")
+		if legal.Can("raise") {
+			options = append(options, fmt.Sprintf("raise (%d-%d, pot, half, 3x)", legal.MinRaise, legal.MaxRaise))
 		}
-		if canGoAllIn {
+		if allIn == "raise" {
 			options = append(options, "all-in")
-		} else if callAmount > 0 && p.Chips < callAmount {
-			// If cannot afford call, only option is fold or all-in (which acts as a call here)
-			options = []string{"fold", fmt.Sprintf("all-in (%d)", p.Chips)}
 		}
 
-		fmt.Printf("Options: [%s, exit]\n", strings.Join(options, ", ")) // Add exit option
-		fmt.Print("Enter action: ")
+		fmt.Fprint(p.out(), hints)
 
-		input, _ := reader.ReadString('\n')
+		if p.SpeedRun {
+			fmt.Fprintf(p.out(), "%d to call [%s %s %s %s %s]%s: ", callAmount, keys.Key("fold"), keys.Key("check"),
+				keys.Key("call"), keys.Key("raise"), keys.Key("all-in"), p.timeLeft())
+		} else {
+			if p.Stats != nil {
+				options = append(options, "stats")
+			}
+			if p.Why != nil {
+				options = append(options, "why")
+			}
+			if p.Log != nil {
+				options = append(options, "log [n]")
+			}
+			if p.Chat != nil {
+				options = append(options, "say <text>")
+			}
+			options = append(options, "auto [check|fold|muck]")
+			fmt.Fprintf(p.out(), "Options: [%s, save, exit]\n", strings.Join(options, ", ")) // Add save and exit options
+			var shortcuts []string
+			for _, command := range []string{"fold", "check", "call", "raise", "all-in"} {
+				if key := keys.Key(command); key != command {
+					shortcuts = append(shortcuts, key+" = "+command)
+				}
+			}
+			if len(shortcuts) > 0 {
+				fmt.Fprintf(p.out(), "Shortcuts: %s\n", strings.Join(shortcuts, ", "))
+			}
+			fmt.Fprintf(p.out(), "Enter action%s: ", p.timeLeft())
+		}
+
+		input, ended := readLine()
+		if ended != "" {
+			return ended, 0
+		}
+		_, said, _ := strings.Cut(strings.TrimSpace(input), " ") // Chat keeps its case
 		input = strings.TrimSpace(strings.ToLower(input))
 		parts := strings.Fields(input) // Split input by space
+		if len(parts) == 0 {
+			continue
+		}
 		actionCmd := parts[0]
+		if command, ok := keys.Resolve(actionCmd); ok {
+			actionCmd = command
+			if actionCmd == "call" && callAmount == 0 {
+				actionCmd = "check"
+			}
+		}
 
 		switch actionCmd {
 		case "fold":
 			return "fold", 0
 		case "check":
-			if callAmount == 0 {
+			if legal.Can("check") {
 				return "check", 0
 			}
-			fmt.Println("Invalid action: Cannot check, there is a bet to call.")
+			fmt.Fprintln(p.out(), "Invalid action: Cannot check, there is a bet to call.")
 		case "call":
-			if callAmount == 0 {
-				fmt.Println("Invalid action: Cannot call, you can check.")
+			if !legal.Can("call") {
+				fmt.Fprintln(p.out(), "Invalid action: Cannot call, you can check.")
 				continue
 			}
-			if p.Chips >= callAmount {
-				return "call", callAmount // Return the amount needed *to add* to the pot
+			if owed := currentBet - p.CurrentBet; callAmount < owed {
+				fmt.Fprintf(p.out(), "Not enough chips to call %d. Going all-in with %d.\n", owed, callAmount)
 			}
-			// If not enough chips to call the full amount, they go all-in
-			fmt.Printf("Not enough chips to call %d. Going all-in with %d.\n", callAmount, p.Chips)
-			return "call", p.Chips // Go all-in (effectively a call for their remaining chips)
+			return "call", callAmount // Return the amount needed *to add* to the pot
 		case "raise":
-			if !canGoAllIn {
-				fmt.Println("Invalid action: Cannot raise.")
+			if !legal.Can("raise") {
+				fmt.Fprintln(p.out(), "Invalid action: Cannot raise.")
 				continue
 			}
 
 			var raiseAmount int
-			if len(parts) > 1 {
-				parsedAmount, err := strconv.Atoi(parts[1])
-				if err != nil {
-					fmt.Println("Invalid raise amount. Please enter a number (e.g., 'raise 50').")
+			switch {
+			case len(parts) > 1:
+				// The amount entered is the TOTAL amount the player wants to bet in this round
+				parsedAmount, ok := p.raiseTo(strings.Join(parts[1:], " "), table, legal)
+				if !ok {
+					fmt.Fprintln(p.out(), "Invalid raise amount. Please enter a number or a size (e.g., 'raise 50', 'raise 1.5k', 'raise pot', 'raise half' or 'raise 3x').")
 					continue
 				}
-				// The amount entered is the TOTAL amount the player wants to bet in this round
 				raiseAmount = parsedAmount
-			} else {
-				// Ask for amount if not provided
-				fmt.Printf("Enter total raise amount (min %d, max %d): ", currentBet+minRaise, p.CurrentBet+p.Chips)
-				amountInput, _ := reader.ReadString('\n')
-				parsedAmount, err := strconv.Atoi(strings.TrimSpace(amountInput))
-				if err != nil {
-					fmt.Println("Invalid amount.")
+				if problem := p.raiseProblem(raiseAmount, legal); problem != "" {
+					fmt.Fprintln(p.out(), problem)
 					continue
 				}
-				raiseAmount = parsedAmount
-			} // Validate raise amount
-			actualRaise := raiseAmount - currentBet        // The amount *above* the current bet
+			case p.SpeedRun:
+				raiseAmount = legal.MinRaise // Minimum raise, or all-in if short
+			default:
+				// Ask for amount if not provided, until it is valid or the
+				// player goes back to the other actions
+				amount, ended := p.askRaise(table, legal, readLine)
+				if ended != "" {
+					return ended, 0
+				}
+				if amount == 0 {
+					continue
+				}
+				raiseAmount = amount
+			}
 			totalBetRequired := raiseAmount - p.CurrentBet // Amount to add to pot
 
-			if totalBetRequired > p.Chips {
-				fmt.Printf("Invalid raise: You only have %d chips (need %d).\n", p.Chips, totalBetRequired)
+			if p.BetAdvice {
+				fmt.Fprintln(p.out(), betAdvice(p.Hand, table, totalBetRequired))
+				fmt.Fprintf(p.out(), "Raise to %d? [Y/n, back]: ", raiseAmount)
+				confirm, ended := readLine()
+				if ended != "" {
+					return ended, 0
+				}
+				if confirm = strings.TrimSpace(strings.ToLower(confirm)); strings.HasPrefix(confirm, "n") || isBack(confirm) {
+					continue
+				}
+			}
+			return "raise", totalBetRequired // Return the amount to *add* to the pot
+
+		case "all-in":
+			if allIn == "" {
+				fmt.Fprintln(p.out(), "Invalid action: Cannot go all-in.")
 				continue
 			}
-			// Validate minimum raise amount, but allow smaller raises if going all-in
-			if actualRaise < minRaise && p.Chips > totalBetRequired {
-				fmt.Printf("Invalid raise: Minimum raise amount is %d.\n", minRaise)
+			fmt.Fprintf(p.out(), "Going all-in with %d chips.\n", p.Chips)
+			return allIn, p.Chips // A raise or a call of all remaining chips
+
+		case "stats": // Show the session stats and ask again
+			if p.Stats == nil {
+				fmt.Fprintln(p.out(), "Invalid action. Please choose from the available options.")
 				continue
 			}
-			if raiseAmount <= currentBet {
-				fmt.Printf("Invalid raise: Must raise higher than the current bet of %d.\n", currentBet)
+			fmt.Fprint(p.out(), p.Stats())
+
+		case "why": // Explain the last bot decision and ask again
+			if p.Why == nil {
+				fmt.Fprintln(p.out(), "Invalid action. Please choose from the available options.")
 				continue
 			}
+			fmt.Fprint(p.out(), p.Why())
 
-			return "raise", totalBetRequired // Return the amount to *add* to the pot
+		case "log": // Show a past hand's actions and ask again
+			if p.Log == nil {
+				fmt.Fprintln(p.out(), "Invalid action. Please choose from the available options.")
+				continue
+			}
+			back := 0
+			if len(parts) > 1 {
+				n, err := strconv.Atoi(parts[1])
+				if err != nil || n < 0 {
+					fmt.Fprintln(p.out(), "Usage: log [n], where n is how many hands back to show.")
+					continue
+				}
+				back = n
+			}
+			fmt.Fprint(p.out(), p.Log(back))
 
-		case "all-in":
-			if !canGoAllIn && !(callAmount > 0 && p.Chips < callAmount) { // Allow all-in if cannot afford call
-				fmt.Println("Invalid action: Cannot go all-in.")
+		case "say": // Speak at the table and ask again
+			if p.Chat == nil {
+				fmt.Fprintln(p.out(), "Invalid action. Please choose from the available options.")
 				continue
 			}
-			allInAmount := p.Chips // The amount to add to the pot is all remaining chips
-			actionType := "call"   // Default to call if all-in amount is less than or equal to call amount
-			if p.CurrentBet+allInAmount > currentBet {
-				actionType = "raise" // It's a raise if the total bet exceeds the current highest bet
+			if strings.TrimSpace(said) == "" {
+				fmt.Fprintln(p.out(), "Usage: say <text>, e.g. 'say good luck'.")
+				continue
 			}
-			fmt.Printf("Going all-in with %d chips.\n", allInAmount)
-			return actionType, allInAmount // Return "raise" or "call" depending on context, and the amount added
+			p.Chat(said)
+
+		case "auto": // Show or toggle a preference and ask again
+			if len(parts) > 1 {
+				if !p.Prefs.Toggle(parts[1]) {
+					fmt.Fprintf(p.out(), "Usage: auto [%s], e.g. 'auto muck' to muck losing hands without being asked.\n", strings.Join(types.PreferenceNames, "|"))
+					continue
+				}
+				if p.SavePrefs != nil {
+					p.SavePrefs(p.Prefs)
+				}
+			}
+			fmt.Fprintf(p.out(), "Preferences: %s.\n", p.Prefs)
+
+		case "save": // Save the game and stop; the current hand is replayed on resume
+			return "save", 0
 
 		case "exit": // Handle exit command
 			return "exit", 0
 
 		default:
-			fmt.Println("Invalid action. Please choose from the available options.")
+			fmt.Fprintln(p.out(), "Invalid action. Please choose from the available options.")
+		}
+	}
+}
+
+// askRaise asks for the total to raise to until it is a legal raise, and
+// returns it, or 0 when the player typed back or cancel. ended is set, as
+// by TakeTurn's readLine, when no answer came.
+func (p *HumanPlayer) askRaise(table *types.Table, legal types.LegalActions, readLine func() (string, string)) (amount int, ended string) {
+	for {
+		fmt.Fprintf(p.out(), "Enter total raise amount (min %d, max %d), pot, half, 3x, or back: ", legal.MinRaise, legal.MaxRaise)
+		input, ended := readLine()
+		if ended != "" {
+			return 0, ended
+		}
+		if isBack(input) {
+			return 0, ""
+		}
+		amount, ok := p.raiseTo(input, table, legal)
+		if !ok {
+			fmt.Fprintln(p.out(), "Invalid amount. Enter a number (e.g., 50 or 1.5k), a size (pot, half or 3x), or back to choose another action.")
+			continue
+		}
+		if problem := p.raiseProblem(amount, legal); problem != "" {
+			fmt.Fprintln(p.out(), problem)
+			continue
 		}
+		return amount, ""
+	}
+}
+
+// raiseTo reads the total a raise bets from a number of chips, or from a
+// size relative to the pot or the bet, see game.BetSize. The total a size
+// comes to is shown.
+func (p *HumanPlayer) raiseTo(input string, table *types.Table, legal types.LegalActions) (int, bool) {
+	if amount, err := types.ParseAmount(input); err == nil {
+		return amount, true
+	}
+	total, ok := game.BetSize(input, table.Pot, legal)
+	if ok {
+		fmt.Fprintf(p.out(), "Raise %s is a raise to %d.\n", strings.TrimSpace(input), total)
+	}
+	return total, ok
+}
+
+// raiseProblem explains why raising to total is not allowed, or returns ""
+// when it is.
+func (p *HumanPlayer) raiseProblem(total int, legal types.LegalActions) string {
+	switch {
+	case total > legal.MaxRaise && total-p.CurrentBet > p.Chips:
+		return fmt.Sprintf("Invalid raise: You only have %d chips (need %d).", p.Chips, total-p.CurrentBet)
+	case total > legal.MaxRaise:
+		return fmt.Sprintf("Invalid raise: Maximum raise is to %d.", legal.MaxRaise)
+	case total < legal.MinRaise:
+		return fmt.Sprintf("Invalid raise: Minimum raise is to %d.", legal.MinRaise)
+	}
+	return ""
+}
+
+// isJunk reports whether a starting hand is weak enough to fold to a raise
+// from anywhere at the table: below 5 on game.ChenScore, such as 72o or J4o.
+func isJunk(hand *types.Hand) bool {
+	if hand == nil || len(hand.Cards) != 2 {
+		return false
+	}
+	return game.ChenScore(hand.Cards[0], hand.Cards[1]) < 5
+}
+
+// isBack reports whether an answer to a follow-up question asks to go back
+// to choosing an action.
+func isBack(input string) bool {
+	switch strings.TrimSpace(strings.ToLower(input)) {
+	case "back", "cancel":
+		return true
+	}
+	return false
+}
+
+// Rebuy implements types.Rebuyer by asking how many chips to buy, up to max
+// or the budget if that is smaller. Broke players are told they leave the
+// table if they decline.
+func (p *HumanPlayer) Rebuy(max int) int {
+	if p.Budget != nil {
+		max = min(max, p.Budget())
+	}
+	if max <= 0 {
+		return 0
+	}
+	for {
+		asked := time.Now()
+		if p.Chips == 0 {
+			fmt.Fprintf(p.out(), "You are out of chips. Re-buy for how many (up to %d, 0 to leave the table)? ", max)
+		} else {
+			fmt.Fprintf(p.out(), "Add on how many chips (up to %d, 0 for none)? ", max)
+		}
+		input, err := p.readLine(asked, time.Time{})
+		if err != nil && input == "" {
+			return 0 // Input ended, nobody is left to play
+		}
+		input = strings.TrimSpace(input)
+		if input == "" {
+			if p.Chips == 0 {
+				continue
+			}
+			return 0
+		}
+		amount, err := types.ParseAmount(input)
+		if err != nil || amount < 0 || amount > max {
+			fmt.Fprintf(p.out(), "Please enter a number from 0 to %d.\n", max)
+			continue
+		}
+		return amount
+	}
+}
+
+// CashOut implements types.CashOuter by asking whether to take the offer.
+// Anything but yes keeps the player in the tournament.
+func (p *HumanPlayer) CashOut(value, chipValue int) bool {
+	asked := time.Now()
+	fmt.Fprintf(p.out(), "Cash out of the tournament for %d? Your %d chips are worth %d at face value. [y/N]: ", value, p.Chips, chipValue)
+	input, _ := p.readLine(asked, time.Time{})
+	answer := strings.ToLower(strings.TrimSpace(input))
+	return answer == "y" || answer == "yes"
+}
+
+// ShowHand implements types.Shower by asking whether to show a losing hand
+// or muck it. Anything but show mucks, as at a live table.
+func (p *HumanPlayer) ShowHand() bool {
+	if p.Prefs.AutoMuck {
+		fmt.Fprintf(p.out(), "%s mucks (auto-muck).\n", p.ID)
+		return false
+	}
+	if p.HotSeat != nil && !p.HotSeat.Pass(p.ID) {
+		return false
 	}
+	asked := time.Now()
+	fmt.Fprintf(p.out(), "%s, your %s does not win. Show or muck? [s/M]: ", p.ID, p.Hand)
+	input, _ := p.readLine(asked, time.Time{})
+	answer := strings.ToLower(strings.TrimSpace(input))
+	return answer == "s" || answer == "show"
 }
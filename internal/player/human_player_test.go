@@ -0,0 +1,197 @@
+package player
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"testing"
+	"time"
+
+	"pokerclientv1/pkg/types"
+)
+
+// TestHumanTurnClock checks that a human who does not answer in time checks
+// or folds, and that a line typed between turns is not taken as an action.
+func TestHumanTurnClock(t *testing.T) {
+	in, typed := io.Pipe()
+	defer typed.Close()
+	p := NewHumanPlayer("You", 100)
+	p.Input = NewReaderInput(in)
+	table := &types.Table{}
+
+	p.SetTurnDeadline(time.Now().Add(20 * time.Millisecond))
+	if action, _ := p.TakeTurn(table, 10, 2); action != "fold" {
+		t.Errorf("TakeTurn() facing a bet = %q after the clock ran out, want fold", action)
+	}
+	p.SetTurnDeadline(time.Now().Add(20 * time.Millisecond))
+	if action, _ := p.TakeTurn(table, 0, 2); action != "check" {
+		t.Errorf("TakeTurn() with nothing to call = %q after the clock ran out, want check", action)
+	}
+
+	io.WriteString(typed, "all-in\n") // Too late for the last turn
+	time.Sleep(10 * time.Millisecond)
+	p.SetTurnDeadline(time.Now().Add(time.Second))
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		io.WriteString(typed, "call\n")
+	}()
+	if action, amount := p.TakeTurn(table, 10, 2); action != "call" || amount != 10 {
+		t.Errorf("TakeTurn() = %s %d, want the call typed during the turn", action, amount)
+	}
+}
+
+// TestHumanInputAndOutput checks that a scripted front end can play a turn,
+// with the prompts written where it asks.
+func TestHumanInputAndOutput(t *testing.T) {
+	var out bytes.Buffer
+	p := NewHumanPlayer("You", 100)
+	p.Input, p.Out = Script("dance", "call"), &out
+	if action, amount := p.TakeTurn(&types.Table{}, 10, 2); action != "call" || amount != 10 {
+		t.Errorf("TakeTurn() = %s %d, want call 10", action, amount)
+	}
+	for _, want := range []string{"Options: [fold, call (10)", "Invalid action"} {
+		if !bytes.Contains(out.Bytes(), []byte(want)) {
+			t.Errorf("output is missing %q:\n%s", want, out.String())
+		}
+	}
+}
+
+// TestHumanKeyBindings checks that bound keys stand for their commands at
+// the full prompt, and that a key bound to call checks when nothing is owed.
+func TestHumanKeyBindings(t *testing.T) {
+	p := NewHumanPlayer("You", 100)
+	p.Keys = types.Bindings{"call": {"c"}, "raise": {"bet"}}
+	p.Input = Script("c", "bet 30")
+	table := &types.Table{}
+
+	if action, _ := p.TakeTurn(table, 0, 2); action != "check" {
+		t.Errorf("TakeTurn() with nothing to call = %q for c, want check", action)
+	}
+	if action, amount := p.TakeTurn(table, 10, 2); action != "raise" || amount != 30 {
+		t.Errorf("TakeTurn() = %s %d for bet 30, want raise 30", action, amount)
+	}
+}
+
+// TestHumanRaiseBack checks that back leaves the raise amount prompt for
+// the other actions, that a bad amount there is asked for again, and that
+// raises may be sized from the pot.
+func TestHumanRaiseBack(t *testing.T) {
+	p := NewHumanPlayer("You", 100)
+	p.Input = Script("raise", "back", "f", "r", "lots", "5", "30", "raise pot")
+	table := &types.Table{}
+
+	if action, _ := p.TakeTurn(table, 10, 2); action != "fold" {
+		t.Errorf("TakeTurn() = %q after going back from the raise, want the fold typed next", action)
+	}
+	if action, amount := p.TakeTurn(table, 10, 2); action != "raise" || amount != 30 {
+		t.Errorf("TakeTurn() = %s %d, want raise 30 once a valid amount is entered", action, amount)
+	}
+	table.Pot = 30
+	if action, amount := p.TakeTurn(table, 10, 10); action != "raise" || amount != 50 {
+		t.Errorf("TakeTurn() = %s %d for raise pot facing 10 into 30, want raise 50", action, amount)
+	}
+}
+
+// TestHumanPreferences checks auto-check, auto-fold of junk hands with its
+// confirmation, and auto-muck toggled at the prompt and saved.
+func TestHumanPreferences(t *testing.T) {
+	p := NewHumanPlayer("You", 100)
+	p.Hand = hand(t, "7c", "2d")
+	p.Input = Script("auto muck", "fold", "n", "call", "")
+	var saved types.Preferences
+	p.SavePrefs = func(prefs types.Preferences) { saved = prefs }
+	table := &types.Table{}
+
+	if action, _ := p.TakeTurn(table, 10, 2); action != "fold" || !saved.AutoMuck {
+		t.Errorf("TakeTurn() = %q, saved %+v, want the fold after turning on auto-muck", action, saved)
+	}
+	if p.ShowHand() {
+		t.Error("ShowHand() with auto-muck on = true, want a muck without asking")
+	}
+
+	p.Prefs = types.Preferences{AutoCheck: true, AutoFoldJunk: true}
+	if action, _ := p.TakeTurn(table, 0, 2); action != "check" {
+		t.Errorf("TakeTurn() with auto-check and nothing to call = %q, want check", action)
+	}
+	if action, amount := p.TakeTurn(table, 10, 2); action != "call" || amount != 10 {
+		t.Errorf("TakeTurn() = %s %d after declining the auto-fold, want the call typed next", action, amount)
+	}
+	if action, _ := p.TakeTurn(table, 10, 2); action != "fold" {
+		t.Errorf("TakeTurn() = %q after confirming the auto-fold, want fold", action)
+	}
+}
+
+// TestHumanShowOrMuck checks that a losing hand is shown only when asked
+// to, and mucked by default.
+func TestHumanShowOrMuck(t *testing.T) {
+	p := NewHumanPlayer("You", 100)
+	p.Input = Script("s", "", "show", "muck")
+	for i, want := range []bool{true, false, true, false, false} {
+		if got := p.ShowHand(); got != want {
+			t.Errorf("ShowHand() #%d = %v, want %v", i+1, got, want)
+		}
+	}
+}
+
+// TestHotSeat checks that the device is passed before another human's turn,
+// but not between two turns of the same human.
+func TestHotSeat(t *testing.T) {
+	ann, bob := NewHumanPlayer("Ann", 100), NewHumanPlayer("Bob", 100)
+	seats := NewHotSeat(Script("", "check", "check", "", "fold"), ann, bob)
+	seats.Out = io.Discard
+	var passes []string
+	seats.OnPass = func(id string) { passes = append(passes, id) }
+	table := &types.Table{}
+
+	for _, turn := range []struct {
+		p    *HumanPlayer
+		want string
+	}{{ann, "check"}, {ann, "check"}, {bob, "fold"}} {
+		if action, _ := turn.p.TakeTurn(table, 0, 2); action != turn.want {
+			t.Errorf("%s's TakeTurn() = %q, want %q", turn.p.ID, action, turn.want)
+		}
+	}
+	if want := []string{"Ann", "Bob"}; !reflect.DeepEqual(passes, want) {
+		t.Errorf("device passed to %v, want %v", passes, want)
+	}
+	if action, _ := ann.TakeTurn(table, 0, 2); action != "exit" {
+		t.Errorf("TakeTurn() when input ends before the device is taken = %q, want exit", action)
+	}
+}
+
+// TestHotSeatAfterTimeout checks that the read left waiting when one human's
+// clock runs out does not take the Enter the next human presses to take the
+// device.
+func TestHotSeatAfterTimeout(t *testing.T) {
+	in, typed := io.Pipe()
+	defer typed.Close()
+	ann, bob := NewHumanPlayer("Ann", 100), NewHumanPlayer("Bob", 100)
+	ann.Out, bob.Out = io.Discard, io.Discard
+	seats := NewHotSeat(NewReaderInput(in), ann, bob)
+	seats.Out = io.Discard
+	seats.current = "Ann"
+	table := &types.Table{}
+
+	ann.SetTurnDeadline(time.Now().Add(20 * time.Millisecond))
+	if action, _ := ann.TakeTurn(table, 0, 2); action != "check" {
+		t.Fatalf("Ann's TakeTurn() = %q after the clock ran out, want check", action)
+	}
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		io.WriteString(typed, "\n")
+		io.WriteString(typed, "fold\n")
+	}()
+	done := make(chan string, 1)
+	go func() {
+		action, _ := bob.TakeTurn(table, 0, 2)
+		done <- action
+	}()
+	select {
+	case action := <-done:
+		if action != "fold" {
+			t.Errorf("Bob's TakeTurn() = %q, want fold", action)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Bob's TakeTurn() is still waiting after Enter and fold were typed")
+	}
+}
@@ -0,0 +1,115 @@
+package player
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"time"
+)
+
+// InputProvider is where a human's answers come from: the terminal, another
+// front end such as a TUI or a network client, or a script in tests.
+// ReadLine blocks until the next line is ready and returns it, with or
+// without its newline, or an error once there are no more.
+type InputProvider interface {
+	ReadLine() (string, error)
+}
+
+// ReaderInput reads answers a line at a time from a reader, such as
+// os.Stdin.
+type ReaderInput struct {
+	r *bufio.Reader
+}
+
+// NewReaderInput reads lines from r. A *bufio.Reader is used as it is, so
+// whatever else reads from it, like the setup prompts, shares its buffer.
+func NewReaderInput(r io.Reader) *ReaderInput {
+	br, ok := r.(*bufio.Reader)
+	if !ok {
+		br = bufio.NewReader(r)
+	}
+	return &ReaderInput{r: br}
+}
+
+// ReadLine implements InputProvider.
+func (in *ReaderInput) ReadLine() (string, error) {
+	return in.r.ReadString('\n')
+}
+
+// ScriptedInput answers with its lines in order and then io.EOF, for tests
+// and for front ends that collect a player's answers ahead of time.
+type ScriptedInput []string
+
+// Script returns input answering with lines.
+func Script(lines ...string) *ScriptedInput {
+	s := ScriptedInput(lines)
+	return &s
+}
+
+// ReadLine implements InputProvider.
+func (s *ScriptedInput) ReadLine() (string, error) {
+	if len(*s) == 0 {
+		return "", io.EOF
+	}
+	line := (*s)[0]
+	*s = (*s)[1:]
+	return line, nil
+}
+
+// inputLine is one line of input and when it was read.
+type inputLine struct {
+	text string
+	err  error
+	at   time.Time
+}
+
+// errTimeUp is returned by lineReader.read when the turn clock runs out.
+var errTimeUp = errors.New("time is up")
+
+// lineReader reads an InputProvider with at most one read waiting at a time.
+// A read still waiting when a prompt gives up is picked up by the next
+// prompt, so everyone reading the same input, such as the humans at a hot
+// seat and its pass screen, must share one lineReader.
+type lineReader struct {
+	in      InputProvider
+	pending chan inputLine // Read still waiting for a line
+}
+
+// read waits for the next line, giving up at deadline (unless it is zero)
+// with errTimeUp, or with ctx's error once it is done; ctx may be nil. A line
+// read before since, when the prompt was shown, is dropped rather than taken
+// as the answer.
+func (r *lineReader) read(ctx context.Context, since, deadline time.Time) (string, error) {
+	var timeout <-chan time.Time
+	if !deadline.IsZero() {
+		timer := time.NewTimer(time.Until(deadline))
+		defer timer.Stop()
+		timeout = timer.C
+	}
+	var done <-chan struct{}
+	if ctx != nil {
+		done = ctx.Done()
+	}
+	for {
+		if r.pending == nil {
+			r.pending = make(chan inputLine, 1)
+			go func(in InputProvider, out chan<- inputLine) {
+				text, err := in.ReadLine()
+				out <- inputLine{text, err, time.Now()}
+			}(r.in, r.pending)
+		}
+		select {
+		case line := <-r.pending:
+			r.pending = nil
+			if line.err == nil && line.at.Before(since) {
+				continue // Typed between turns
+			}
+			return line.text, line.err
+		case <-timeout:
+			return "", errTimeUp
+		case <-done:
+			return "", ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package player
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"strings"
+
+	"pokerclientv1/pkg/game"
+	"pokerclientv1/pkg/types"
+)
+
+// Personality describes a bot's playing style in the terms of common player
+// statistics. Bots with a personality decide from their equity, like medium
+// and hard bots, but the thresholds come from these parameters.
+type Personality struct {
+	Name       string
+	VPIP       float64 // Share of hands played voluntarily pre-flop, 0 to 1
+	PFR        float64 // Share of hands raised pre-flop, 0 to 1, at most VPIP
+	Aggression float64 // Bets and raises per call with a strong hand after the flop
+	Bluff      float64 // Chance of betting a weak hand when checked to, 0 to 1
+	Positional bool    // Plays more hands on the button and cutoff
+	Risk       float64 // Appetite for a gamble over sure money, 0 to 1, see BotPlayer.CashOut
+	Avatar     string  // Glyph shown next to bots with this personality
+}
+
+// Personalities are the named presets selectable for bots.
+var Personalities = map[string]Personality{
+	"nit":             {Name: "nit", VPIP: 0.12, PFR: 0.09, Aggression: 1.5, Bluff: 0.02, Positional: false, Risk: 0.1, Avatar: "🐢"},
+	"tag":             {Name: "tag", VPIP: 0.22, PFR: 0.18, Aggression: 3, Bluff: 0.10, Positional: true, Risk: 0.4, Avatar: "🦉"},
+	"lag":             {Name: "lag", VPIP: 0.35, PFR: 0.28, Aggression: 4, Bluff: 0.25, Positional: true, Risk: 0.6, Avatar: "🦊"},
+	"calling station": {Name: "calling station", VPIP: 0.60, PFR: 0.05, Aggression: 0.5, Bluff: 0.02, Positional: false, Risk: 0.5, Avatar: "🐟"},
+	"maniac":          {Name: "maniac", VPIP: 0.70, PFR: 0.50, Aggression: 6, Bluff: 0.45, Positional: false, Risk: 0.9, Avatar: "🦈"},
+}
+
+// LookupPersonality returns the preset with the given name, ignoring case.
+func LookupPersonality(name string) (Personality, bool) {
+	p, ok := Personalities[strings.ToLower(strings.TrimSpace(name))]
+	return p, ok
+}
+
+// PersonalityNames returns the preset names in alphabetical order.
+func PersonalityNames() []string {
+	names := make([]string, 0, len(Personalities))
+	for name := range Personalities {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// preflopThresholds maps the share of starting hands played to the heads-up
+// equity the weakest of them has, from the best hands to all of them.
+var preflopThresholds = []struct {
+	share  float64
+	equity float64
+}{
+	{0, 0.85}, {0.05, 0.66}, {0.10, 0.62}, {0.20, 0.58}, {0.30, 0.55},
+	{0.50, 0.50}, {0.70, 0.45}, {0.85, 0.40}, {1, 0.29},
+}
+
+// preflopThreshold returns the heads-up equity a hand needs to be among the
+// best share of starting hands, interpolating between known points.
+func preflopThreshold(share float64) float64 {
+	if share <= 0 {
+		return preflopThresholds[0].equity
+	}
+	for i := 1; i < len(preflopThresholds); i++ {
+		lo, hi := preflopThresholds[i-1], preflopThresholds[i]
+		if share <= hi.share {
+			return lo.equity + (share-lo.share)/(hi.share-lo.share)*(hi.equity-lo.equity)
+		}
+	}
+	return preflopThresholds[len(preflopThresholds)-1].equity
+}
+
+// latePosition reports whether the player to act is on the button or in the cutoff.
+func latePosition(table *types.Table) bool {
+	return table.Position == 0 || (table.Seats > 3 && table.Position == table.Seats-1)
+}
+
+// decideByPersonality plays pre-flop by hand ranking against the VPIP and PFR
+// targets, and after the flop by equity, raising strong hands at a rate set by
+// the aggression factor and bluffing at the bluff frequency.
+func (ai *BotAI) decideByPersonality(hand *types.Hand, table *types.Table, currentBet int, playerBet int, chips int, minRaise int, r *rand.Rand) (action string, amount int) {
+	p := ai.Personality
+	toCall := currentBet - playerBet
+	opponents := table.PlayersInHand - 1
+	if opponents < 1 {
+		opponents = 1
+	}
+
+	raise := func(potFraction float64) (string, int) {
+		raiseAmount := int(float64(table.Pot+toCall) * potFraction)
+		if raiseAmount < minRaise {
+			raiseAmount = minRaise
+		}
+		totalBet := currentBet + raiseAmount
+		if totalBet-playerBet >= chips {
+			return "raise", playerBet + chips // All-in raise
+		}
+		return "raise", totalBet
+	}
+	passive := func(callIf bool) (string, int) {
+		if toCall <= 0 {
+			return "check", 0
+		}
+		if callIf {
+			return "call", toCall
+		}
+		return "fold", 0
+	}
+
+	if len(table.CommunityCards) == 0 {
+		vpip, pfr := p.VPIP, p.PFR
+		if p.Positional && latePosition(table) {
+			vpip, pfr = vpip*1.4, pfr*1.4
+		}
+		strength := game.Equity(hand.Cards, nil, 1, 200, r)
+		ai.Last.Rule, ai.Last.Equity, ai.Last.Opponents = "preflop", strength, 1
+		ai.Last.RaiseAt, ai.Last.PlayAt = preflopThreshold(pfr), preflopThreshold(vpip)
+		if strength >= preflopThreshold(pfr) && chips > toCall {
+			return raise(1)
+		}
+		return passive(strength >= preflopThreshold(vpip))
+	}
+
+	equity := game.Equity(hand.Cards, table.CommunityCards, opponents, 500, r)
+	strong := equity >= 1.3/float64(opponents+1)
+	ai.Last.Rule, ai.Last.Equity, ai.Last.Opponents, ai.Last.RaiseAt = "postflop", equity, opponents, 1.3/float64(opponents+1)
+	switch {
+	case strong && chips > toCall:
+		roll := r.Float64()
+		ai.Last.Roll, ai.Last.RollUnder = int(100*roll), int(math.Round(100*p.Aggression/(p.Aggression+1)))
+		if roll < p.Aggression/(p.Aggression+1) {
+			return raise(0.75)
+		}
+	case !strong && toCall <= 0 && r.Float64() < p.Bluff:
+		ai.Last.Bluff = int(math.Round(100 * p.Bluff))
+		return raise(0.5)
+	}
+	// Loose players need less equity to continue: a calling station calls
+	// with a bit less than the pot odds, a nit wants a good margin
+	required := game.PotOdds(table.Pot, toCall) * (1.5 - p.VPIP)
+	return passive(strong || equity >= required)
+}
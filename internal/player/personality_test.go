@@ -0,0 +1,97 @@
+package player
+
+import (
+	"math/rand"
+	"testing"
+
+	"pokerclientv1/pkg/types"
+)
+
+func hand(t *testing.T, codes ...string) *types.Hand {
+	t.Helper()
+	h := &types.Hand{}
+	for _, code := range codes {
+		c, err := types.ParseCard(code)
+		if err != nil {
+			t.Fatalf("ParseCard(%q) error: %v", code, err)
+		}
+		h.AddCard(c)
+	}
+	return h
+}
+
+// TestPreflopThresholdIsMonotonic checks that playing more hands never needs
+// a stronger hand.
+func TestPreflopThresholdIsMonotonic(t *testing.T) {
+	prev := preflopThreshold(0)
+	for share := 0.01; share <= 1; share += 0.01 {
+		got := preflopThreshold(share)
+		if got > prev {
+			t.Fatalf("preflopThreshold(%.2f) = %.3f, above %.3f for a smaller share", share, got, prev)
+		}
+		prev = got
+	}
+}
+
+// TestPersonalitiesPreflop checks that presets play the hands their style implies
+// when facing a raise to 6 in the big blind.
+func TestPersonalitiesPreflop(t *testing.T) {
+	tests := []struct {
+		personality string
+		cards       []string
+		want        string
+	}{
+		{"nit", []string{"As", "Ah"}, "raise"},
+		{"nit", []string{"Qd", "9c"}, "fold"},
+		{"calling station", []string{"Qd", "9c"}, "call"},
+		{"calling station", []string{"7c", "2d"}, "fold"},
+		{"maniac", []string{"Kd", "Tc"}, "raise"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.personality+" "+tt.cards[0]+tt.cards[1], func(t *testing.T) {
+			personality, ok := LookupPersonality(tt.personality)
+			if !ok {
+				t.Fatalf("LookupPersonality(%q) not found", tt.personality)
+			}
+			ai := &BotAI{Difficulty: tt.personality, Personality: &personality}
+			table := &types.Table{CurrentBet: 6, Pot: 9, PlayersInHand: 2, Position: 2, Seats: 3}
+			got, _ := ai.decideByPersonality(hand(t, tt.cards...), table, 6, 2, 100, 4, rand.New(rand.NewSource(1)))
+			if got != tt.want {
+				t.Errorf("decideByPersonality() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestNewBotPlayerPersonality checks that presets are picked up by name.
+func TestNewBotPlayerPersonality(t *testing.T) {
+	bot := NewBotPlayer("Bot", 100, "LAG", 0)
+	if bot.AI.Personality == nil || bot.AI.Personality.Name != "lag" {
+		t.Errorf("NewBotPlayer(\"LAG\") personality = %+v, want the lag preset", bot.AI.Personality)
+	}
+	if bot := NewBotPlayer("Bot", 100, "hard", 0); bot.AI.Personality != nil {
+		t.Errorf("NewBotPlayer(\"hard\") personality = %+v, want none", bot.AI.Personality)
+	}
+}
+
+// TestBotCashOutFollowsRisk checks that cautious bots take sure money that
+// gamblers turn down.
+func TestBotCashOutFollowsRisk(t *testing.T) {
+	tests := []struct {
+		difficulty string
+		value      int
+		want       bool
+	}{
+		{"nit", 90, true},
+		{"maniac", 110, false},
+		{"maniac", 130, true},
+		{"hard", 99, false},
+		{"hard", 100, true},
+	}
+	for _, tt := range tests {
+		bot := NewBotPlayer("Bot", 100, tt.difficulty, 0)
+		if got := bot.CashOut(tt.value, 100); got != tt.want {
+			t.Errorf("%s bot CashOut(%d, 100) = %v, want %v", tt.difficulty, tt.value, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,220 @@
+package replay
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strings"
+
+	"pokerclientv1/internal/store"
+	"pokerclientv1/pkg/game"
+	"pokerclientv1/pkg/types"
+)
+
+// DefaultDiffIterations is how many deals Diff runs per equity estimate.
+const DefaultDiffIterations = 1000
+
+// Strategy is a player whose decisions Diff asks for, such as a bot or a
+// player following a decision file. Its cards, chips and bet are set to
+// those of the recorded player before each decision.
+type Strategy interface {
+	types.Player
+	types.Decider
+}
+
+// Decision is one recorded decision next to what an alternate strategy
+// would have done in its place, each valued in chips.
+//
+// An action's EV is the chips it is expected to win from the pot, minus the
+// chips it puts in, from the equity of the player's cards against random
+// hands for each opponent still in: folding is worth nothing, checking the
+// player's share of the pot and calling their share of the pot with the
+// call in it, less the call. A raise wins the pot when the opponents fold
+// and is called as often as the pot odds it gives them demand, by a hand
+// from the best share of starting hands, see game.CallingRange.
+type Decision struct {
+	HandNumber  int
+	Round       string
+	PlayerID    string
+	Pot         int // Chips in the pot before the decision
+	ToCall      int
+	Equity      float64
+	Actual      types.Action
+	Alternate   types.Action
+	ActualEV    float64
+	AlternateEV float64
+}
+
+// Gain is what the alternate action is worth over the one taken.
+func (d Decision) Gain() float64 { return d.AlternateEV - d.ActualEV }
+
+// Diff replays the recorded hands and asks alt what it would have done at
+// each of playerID's decisions, valuing both actions. Hands the player was
+// not dealt into with known cards are skipped. Iterations is the number of
+// deals per equity estimate, DefaultDiffIterations when zero, and rng seeds
+// them.
+func Diff(hands []store.HandRecord, playerID string, alt Strategy, iterations int, rng *rand.Rand) []Decision {
+	if iterations <= 0 {
+		iterations = DefaultDiffIterations
+	}
+	decisions := []Decision{}
+	for _, hand := range hands {
+		b := &builder{}
+		alt.ResetForNewHand()
+		for _, e := range hand.Events {
+			if e.Type == game.EventPlayerAction && e.PlayerID == playerID {
+				if d, ok := b.decide(e, alt, iterations, rng); ok {
+					decisions = append(decisions, d)
+				}
+			}
+			b.apply(e)
+		}
+	}
+	return decisions
+}
+
+// decide asks alt for its action in the recorded player's place, before
+// their action e is applied, and values both.
+func (b *builder) decide(e game.Event, alt Strategy, iterations int, rng *rand.Rand) (Decision, bool) {
+	seat := b.seat(e.PlayerID)
+	if len(seat.Hand.Cards) != 2 {
+		return Decision{}, false
+	}
+	table, minRaise := b.view(e.PlayerID)
+	if e.Round != "" {
+		table.Round = e.Round
+	}
+	legal := types.NewLegalActions(e.PlayerID, table.CurrentBet, seat.CurrentBet, seat.Chips, minRaise)
+	table.Legal = &legal
+
+	alt.SetHand(&types.Hand{Cards: append([]types.Card(nil), seat.Hand.Cards...)})
+	alt.SetFolded(false)
+	alt.SetCurrentBet(seat.CurrentBet)
+	alt.AddChips(seat.Chips - alt.GetChips())
+	decision, err := alt.Decide(context.Background(), types.TurnView{
+		Table:      &table,
+		CurrentBet: table.CurrentBet,
+		ToCall:     legal.ToCall,
+		MinRaise:   minRaise,
+		Chips:      seat.Chips,
+		Legal:      legal,
+	})
+	if err != nil {
+		decision.Type, decision.Amount = legal.Fit("check", 0)
+	}
+
+	v := valuer{
+		hole:       seat.Hand.Cards,
+		board:      table.CommunityCards,
+		opponents:  max(table.PlayersInHand-1, 1),
+		pot:        b.pot,
+		toCall:     legal.ToCall,
+		iterations: iterations,
+		rng:        rng,
+	}
+	v.equity = game.Equity(v.hole, v.board, v.opponents, iterations, rng)
+	actual := types.Action{Type: actionKind(e.Action), Amount: e.Amount}
+	return Decision{
+		HandNumber:  e.HandNumber,
+		Round:       table.Round,
+		PlayerID:    e.PlayerID,
+		Pot:         b.pot,
+		ToCall:      legal.ToCall,
+		Equity:      v.equity,
+		Actual:      actual,
+		Alternate:   decision,
+		ActualEV:    v.ev(actual),
+		AlternateEV: v.ev(decision),
+	}, true
+}
+
+// view returns the table as the player to act would see it and the least a
+// raise must add above the current bet.
+func (b *builder) view(playerID string) (types.Table, int) {
+	table := b.table
+	table.CommunityCards = append([]types.Card(nil), b.table.CommunityCards...)
+	table.Pot = b.pot
+	table.Acting = playerID
+	table.Seats = len(b.seats)
+	button, acting := 0, 0
+	for i, s := range b.seats {
+		if !s.Folded {
+			table.PlayersInHand++
+		}
+		if table.Positions[s.ID] == "BTN" {
+			button = i
+		}
+		if s.ID == playerID {
+			acting = i
+		}
+	}
+	table.Position = (acting - button + len(b.seats)) % len(b.seats)
+	return table, max(b.raise, b.table.BigBlind)
+}
+
+// actionKind reduces an action as logged by the game, such as "raises to
+// 40", to fold, check, call or raise.
+func actionKind(action string) string {
+	for _, kind := range []string{"fold", "check", "call", "raise"} {
+		if strings.HasPrefix(action, kind) {
+			return kind
+		}
+	}
+	return action
+}
+
+// valuer values actions at one decision, see Decision.
+type valuer struct {
+	hole, board []types.Card
+	opponents   int
+	pot, toCall int
+	equity      float64
+	iterations  int
+	rng         *rand.Rand
+}
+
+// ev returns the chips an action is expected to win less what it puts in.
+func (v valuer) ev(a types.Action) float64 {
+	switch a.Type {
+	case "check":
+		return v.equity * float64(v.pot)
+	case "call":
+		return v.equity*float64(v.pot+a.Amount) - float64(a.Amount)
+	case "raise":
+		bet := a.Amount - v.toCall // What the opponents must call
+		share := game.CallingRange(v.pot+v.toCall, bet)
+		called := game.EquityWhenCalled(v.hole, v.board, share, v.iterations, v.rng)
+		return (1-share)*float64(v.pot) + share*(called*float64(v.pot+a.Amount+bet)-float64(a.Amount))
+	}
+	return 0
+}
+
+// DiffSummary formats decisions as a table, one line each, with the total
+// the alternate strategy gains over the recorded play.
+func DiffSummary(decisions []Decision) string {
+	var b strings.Builder
+	if len(decisions) == 0 {
+		return "No decisions to compare.\n"
+	}
+	fmt.Fprintf(&b, "--- %s: %d decisions ---\n", decisions[0].PlayerID, len(decisions))
+	fmt.Fprintf(&b, "%-6s %-8s %6s %6s %7s  %-12s %8s  %-12s %8s %8s\n", "Hand", "Round", "Pot", "Call", "Equity", "Played", "EV", "Alternate", "EV", "Gain")
+	total, changed := 0.0, 0
+	for _, d := range decisions {
+		fmt.Fprintf(&b, "%-6d %-8s %6d %6d %6.1f%%  %-12s %+8.1f  %-12s %+8.1f %+8.1f\n",
+			d.HandNumber, d.Round, d.Pot, d.ToCall, 100*d.Equity,
+			actionString(d.Actual), d.ActualEV, actionString(d.Alternate), d.AlternateEV, d.Gain())
+		total += d.Gain()
+		if d.Alternate.Type != d.Actual.Type || d.Alternate.Amount != d.Actual.Amount {
+			changed++
+		}
+	}
+	fmt.Fprintf(&b, "The alternate strategy plays %d of %d decisions differently, for %+.1f chips in EV.\n", changed, len(decisions), total)
+	return b.String()
+}
+
+func actionString(a types.Action) string {
+	if a.Amount > 0 {
+		return fmt.Sprintf("%s %d", a.Type, a.Amount)
+	}
+	return a.Type
+}
@@ -0,0 +1,62 @@
+package replay
+
+import (
+	"context"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"pokerclientv1/pkg/types"
+)
+
+// TestDiffAsksAlternateAtEachDecision checks that the alternate strategy is
+// shown the recorded player's seat at each of their decisions and that both
+// actions are valued.
+func TestDiffAsksAlternateAtEachDecision(t *testing.T) {
+	path, _ := recordHand(t)
+	hands, err := Load(path, "test")
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+
+	var views []types.TurnView
+	alt := types.NewStrategyPlayer("Alt", 0, types.DecideFunc(func(ctx context.Context, view types.TurnView) (types.Action, error) {
+		views = append(views, view)
+		if view.Table.Round == "Flop" {
+			return types.Action{Type: "raise", Amount: 20}, nil
+		}
+		return types.Action{Type: "fold"}, nil
+	}))
+	decisions := Diff(hands, "BTN", alt, 200, rand.New(rand.NewSource(1)))
+	if len(decisions) != 4 {
+		t.Fatalf("Diff() returned %d decisions, want BTN's raise and three checks", len(decisions))
+	}
+
+	pre := decisions[0]
+	if pre.Actual != (types.Action{Type: "raise", Amount: 10}) || pre.Alternate.Type != "fold" || pre.Pot != 3 || pre.ToCall != 2 {
+		t.Errorf("pre-flop decision = %+v, want a raise of 10 against a fold into 3 with 2 to call", pre)
+	}
+	if pre.AlternateEV != 0 || pre.ActualEV <= 0 || pre.Gain() >= 0 {
+		t.Errorf("pre-flop EVs = %.1f played and %.1f alternate, want raising aces to beat folding them", pre.ActualEV, pre.AlternateEV)
+	}
+	view := views[0]
+	if view.Chips != 100 || view.MinRaise != 2 || view.Table.BigBlind != 2 || view.Table.Position != 0 || view.Table.PlayersInHand != 3 {
+		t.Errorf("pre-flop view = %+v with table %+v, want BTN's 100 chips on the button facing the big blind of 2", view, *view.Table)
+	}
+
+	flop := decisions[1]
+	if flop.Actual.Type != "check" || flop.Alternate != (types.Action{Type: "raise", Amount: 20}) || flop.Pot != 21 {
+		t.Errorf("flop decision = %+v, want a check against a bet of 20 into 21", flop)
+	}
+	if flop.ActualEV <= 0 || flop.AlternateEV <= 0 {
+		t.Errorf("flop EVs = %.1f played and %.1f alternate, want both positive with aces", flop.ActualEV, flop.AlternateEV)
+	}
+	if views[1].Chips != 90 || len(views[1].Table.CommunityCards) != 3 {
+		t.Errorf("flop view = %+v, want 90 chips and three cards on the board", views[1])
+	}
+
+	summary := DiffSummary(decisions)
+	if !strings.Contains(summary, "plays 4 of 4 decisions differently") {
+		t.Errorf("DiffSummary() =\n%s\nwant every decision played differently", summary)
+	}
+}
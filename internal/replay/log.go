@@ -0,0 +1,103 @@
+package replay
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"pokerclientv1/pkg/game"
+)
+
+// ActionLog keeps a readable log of the session as it is played, so hands
+// cleared from the screen can be looked at again. The last Keep hands stay
+// in memory for paging; the whole session can be written out with WriteTo.
+type ActionLog struct {
+	Keep   int    // Hands kept for paging, every hand when zero or less
+	Viewer string // Player whose hole cards are shown; other players' stay hidden
+
+	hands []loggedHand // Kept hands, oldest first
+	lines []string     // Every line of the session
+}
+
+// loggedHand is the log of one hand.
+type loggedHand struct {
+	number int
+	lines  []string
+}
+
+// NewActionLog creates a log keeping the last keep hands for the viewer.
+func NewActionLog(keep int, viewer string) *ActionLog {
+	return &ActionLog{Keep: keep, Viewer: viewer}
+}
+
+// HandleEvent adds an event to the log. It can be passed to Game.OnEvent.
+func (l *ActionLog) HandleEvent(e game.Event) {
+	if e.Type == game.EventHandStarted || len(l.hands) == 0 {
+		l.hands = append(l.hands, loggedHand{number: e.HandNumber})
+		if l.Keep > 0 && len(l.hands) > l.Keep {
+			l.hands = l.hands[len(l.hands)-l.Keep:]
+		}
+	}
+	line := l.describe(e)
+	if line == "" {
+		return
+	}
+	hand := &l.hands[len(l.hands)-1]
+	hand.lines = append(hand.lines, line)
+	l.lines = append(l.lines, line)
+}
+
+// describe returns the log line for an event, or "" to leave it out.
+func (l *ActionLog) describe(e game.Event) string {
+	switch e.Type {
+	case game.EventPlayerAction, game.EventBlindPosted:
+		if e.Amount > 0 {
+			return fmt.Sprintf("%s %s (%d)", e.PlayerID, e.Action, e.Amount)
+		}
+		return fmt.Sprintf("%s %s", e.PlayerID, e.Action)
+	case game.EventHoleCardsDealt:
+		if e.PlayerID != l.Viewer {
+			return ""
+		}
+	case game.EventPlayerSeated:
+		return ""
+	}
+	return Describe(e)
+}
+
+// Hands returns how many hands can be paged through.
+func (l *ActionLog) Hands() int { return len(l.hands) }
+
+// Page returns the log of the hand back hands before the latest one, so 0
+// is the hand being played, with a footer saying how to page further.
+func (l *ActionLog) Page(back int) string {
+	if len(l.hands) == 0 {
+		return "No hands played yet.\n"
+	}
+	if back < 0 || back >= len(l.hands) {
+		return fmt.Sprintf("Only the last %d hands are kept: log 0 to log %d.\n", len(l.hands), len(l.hands)-1)
+	}
+	hand := l.hands[len(l.hands)-1-back]
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- Hand #%d ---\n", hand.number)
+	for _, line := range hand.lines {
+		b.WriteString(line + "\n")
+	}
+	if back+1 < len(l.hands) {
+		fmt.Fprintf(&b, "--- log %d for the hand before ---\n", back+1)
+	}
+	return b.String()
+}
+
+// WriteTo writes the log of the whole session to w, one line per event.
+func (l *ActionLog) WriteTo(w io.Writer) (int64, error) {
+	var written int64
+	for _, line := range l.lines {
+		n, err := io.WriteString(w, line+"\n")
+		written += int64(n)
+		if err != nil {
+			return written, err
+		}
+	}
+	return written, nil
+}
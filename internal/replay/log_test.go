@@ -0,0 +1,76 @@
+package replay
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"pokerclientv1/pkg/game"
+	"pokerclientv1/pkg/types"
+)
+
+// playLoggedHand feeds a short hand in which Hero raises and Villain folds.
+func playLoggedHand(t *testing.T, log *ActionLog, number int) {
+	t.Helper()
+	hole := func(a, b string) []types.Card {
+		cards := []types.Card{}
+		for _, code := range []string{a, b} {
+			c, err := types.ParseCard(code)
+			if err != nil {
+				t.Fatalf("ParseCard(%q) error: %v", code, err)
+			}
+			cards = append(cards, c)
+		}
+		return cards
+	}
+	for _, e := range []game.Event{
+		{Type: game.EventHandStarted, HandNumber: number, Amount: 2},
+		{Type: game.EventPlayerSeated, HandNumber: number, PlayerID: "Hero", Amount: 100},
+		{Type: game.EventHoleCardsDealt, HandNumber: number, PlayerID: "Hero", Cards: hole("As", "Ah")},
+		{Type: game.EventHoleCardsDealt, HandNumber: number, PlayerID: "Villain", Cards: hole("Kc", "Kd")},
+		{Type: game.EventPlayerAction, HandNumber: number, PlayerID: "Hero", Action: "raises", Amount: 10},
+		{Type: game.EventPlayerAction, HandNumber: number, PlayerID: "Villain", Action: "folds"},
+		{Type: game.EventHandEnded, HandNumber: number},
+	} {
+		log.HandleEvent(e)
+	}
+}
+
+// TestActionLogPages checks that the log keeps only the last hands for
+// paging, hides other players' hole cards and saves the whole session.
+func TestActionLogPages(t *testing.T) {
+	log := NewActionLog(2, "Hero")
+	if got := log.Page(0); got != "No hands played yet.\n" {
+		t.Errorf("Page(0) before any hand = %q", got)
+	}
+	for n := 1; n <= 3; n++ {
+		playLoggedHand(t, log, n)
+	}
+
+	if log.Hands() != 2 {
+		t.Fatalf("Hands() = %d, want 2", log.Hands())
+	}
+	latest := log.Page(0)
+	for _, want := range []string{"--- Hand #3 ---", "Hero raises (10)", "Villain folds", "Hero is dealt [ A♠ A♥ ]", "log 1 for the hand before"} {
+		if !strings.Contains(latest, want) {
+			t.Errorf("Page(0) = %q, want it to contain %q", latest, want)
+		}
+	}
+	if strings.Contains(latest, "Villain is dealt") || strings.Contains(latest, "sits in") {
+		t.Errorf("Page(0) = %q, want Villain's cards and seating left out", latest)
+	}
+	if earlier := log.Page(1); !strings.Contains(earlier, "--- Hand #2 ---") || strings.Contains(earlier, "log 2") {
+		t.Errorf("Page(1) = %q, want hand #2 as the last page", earlier)
+	}
+	if got := log.Page(2); !strings.Contains(got, "log 0 to log 1") {
+		t.Errorf("Page(2) = %q, want the pages that are kept", got)
+	}
+
+	var out bytes.Buffer
+	if _, err := log.WriteTo(&out); err != nil {
+		t.Fatalf("WriteTo() error: %v", err)
+	}
+	if got := strings.Count(out.String(), "raises (10)"); got != 3 {
+		t.Errorf("WriteTo() wrote %d raises, want all 3 hands:\n%s", got, out.String())
+	}
+}
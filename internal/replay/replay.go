@@ -0,0 +1,334 @@
+// Package replay plays recorded hand histories back through a GameUI so past
+// hands can be reviewed one event at a time.
+package replay
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"pokerclientv1/internal/store"
+	"pokerclientv1/pkg/game"
+	"pokerclientv1/pkg/types"
+)
+
+// Load reads the hands of a hand history file, such as the hands.jsonl written
+// by the JSON store. An empty sessionID loads every session in the file.
+// The files rotated from it are read first, and gzipped files are read as
+// they are.
+func Load(path, sessionID string) ([]store.HandRecord, error) {
+	hands, err := store.ReadHistory(path, sessionID)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("replay: reading %s: %w", path, err)
+	}
+	return hands, nil
+}
+
+// Frame is the table as it looked right after an event.
+type Frame struct {
+	Event   game.Event
+	Table   types.Table
+	Players []types.Player
+	Pot     int
+}
+
+// Frames rebuilds the table state after every event of the given hands.
+func Frames(hands []store.HandRecord) []Frame {
+	frames := []Frame{}
+	for _, hand := range hands {
+		b := &builder{}
+		for _, e := range hand.Events {
+			b.apply(e)
+			frames = append(frames, b.frame(e))
+		}
+	}
+	return frames
+}
+
+// builder tracks the state of one hand as its events are applied.
+type builder struct {
+	table types.Table
+	seats []*Seat
+	pot   int
+	raise int // Largest raise this round, the least the next one must add
+}
+
+func (b *builder) seat(id string) *Seat {
+	for _, s := range b.seats {
+		if s.ID == id {
+			return s
+		}
+	}
+	s := &Seat{ID: id, Hand: &types.Hand{}}
+	b.seats = append(b.seats, s)
+	return s
+}
+
+func (b *builder) apply(e game.Event) {
+	switch e.Type {
+	case game.EventPlayerSeated:
+		b.seat(e.PlayerID).Chips = e.Amount
+		if e.Position != "" {
+			if b.table.Positions == nil {
+				b.table.Positions = map[string]string{}
+			}
+			b.table.Positions[e.PlayerID] = e.Position
+		}
+	case game.EventBlindPosted, game.EventPlayerAction:
+		s := b.seat(e.PlayerID)
+		if e.Action == "fold" {
+			s.Folded = true
+		}
+		s.Chips -= e.Amount
+		b.pot += e.Amount
+		if e.Type == game.EventBlindPosted && (e.Action == "ante" || e.Action == "dead small blind") {
+			break // Antes and dead blinds are dead money and do not count towards the bet to call
+		}
+		s.CurrentBet += e.Amount
+		if e.Type == game.EventBlindPosted && e.Action == "big blind" {
+			b.table.BigBlind = max(b.table.BigBlind, e.Amount)
+		}
+		if s.CurrentBet > b.table.CurrentBet {
+			b.raise = max(b.raise, s.CurrentBet-b.table.CurrentBet)
+			b.table.CurrentBet = s.CurrentBet
+		}
+	case game.EventHoleCardsDealt:
+		b.seat(e.PlayerID).Hand = &types.Hand{Cards: e.Cards}
+	case game.EventStreetDealt:
+		b.table.CommunityCards = append(b.table.CommunityCards, e.Cards...)
+		b.table.CurrentBet, b.raise = 0, 0
+		for _, s := range b.seats {
+			s.CurrentBet = 0
+		}
+	case game.EventBetReturned:
+		s := b.seat(e.PlayerID)
+		s.Chips += e.Amount
+		s.CurrentBet -= e.Amount
+		b.table.CurrentBet = s.CurrentBet
+		b.pot -= e.Amount
+	case game.EventPotAwarded:
+		b.seat(e.PlayerID).Chips += e.Amount
+		b.pot -= e.Amount
+		if b.pot < 0 {
+			b.pot = 0
+		}
+	}
+	if e.Round != "" {
+		b.table.Round = e.Round
+	}
+	b.table.HandNumber = e.HandNumber
+}
+
+// frame copies the current state so later events do not change it.
+func (b *builder) frame(e game.Event) Frame {
+	f := Frame{Event: e, Table: b.table, Pot: b.pot}
+	f.Table.CommunityCards = append([]types.Card(nil), b.table.CommunityCards...)
+	for _, s := range b.seats {
+		c := *s
+		f.Players = append(f.Players, &c)
+	}
+	return f
+}
+
+// Replayer steps through frames and shows them on a GameUI.
+type Replayer struct {
+	UI     types.GameUI
+	Out    io.Writer     // Where event descriptions and prompts are written
+	Speed  time.Duration // Pause between frames while playing
+	frames []Frame
+	pos    int
+}
+
+// NewReplayer creates a replayer positioned on the first frame.
+func NewReplayer(frames []Frame, ui types.GameUI, out io.Writer, speed time.Duration) *Replayer {
+	return &Replayer{UI: ui, Out: out, Speed: speed, frames: frames}
+}
+
+// Position returns the index of the current frame.
+func (r *Replayer) Position() int { return r.pos }
+
+// Current returns the frame being shown.
+func (r *Replayer) Current() Frame { return r.frames[r.pos] }
+
+// Next moves forward one frame, returning false at the end of the history.
+func (r *Replayer) Next() bool {
+	if r.pos+1 >= len(r.frames) {
+		return false
+	}
+	r.pos++
+	return true
+}
+
+// Back moves back one frame, returning false at the start of the history.
+func (r *Replayer) Back() bool {
+	if r.pos == 0 {
+		return false
+	}
+	r.pos--
+	return true
+}
+
+// Show draws the current frame and describes its event.
+func (r *Replayer) Show() {
+	f := r.Current()
+	r.UI.ClearScreen()
+	table := f.Table
+	r.UI.DisplayGameState(&table, f.Players, f.Pot, fmt.Sprintf("Hand #%d %s", f.Event.HandNumber, f.Table.Round))
+	switch f.Event.Type {
+	case game.EventPlayerAction, game.EventBlindPosted:
+		r.UI.LogAction(f.Event.PlayerID, f.Event.Action, f.Event.Amount)
+	default:
+		fmt.Fprintln(r.Out, Describe(f.Event))
+	}
+	fmt.Fprintf(r.Out, "[%d/%d]\n", r.pos+1, len(r.frames))
+}
+
+// Play shows frames from the current one to the end, pausing Speed between them.
+func (r *Replayer) Play() {
+	r.Show()
+	for r.Next() {
+		time.Sleep(r.Speed)
+		r.Show()
+	}
+}
+
+// Run reads commands from in until the user quits or input ends: enter or "n"
+// steps forward, "b" steps back, "p" plays to the end and "q" quits.
+func (r *Replayer) Run(in io.Reader) error {
+	if len(r.frames) == 0 {
+		fmt.Fprintln(r.Out, "The hand history is empty.")
+		return nil
+	}
+	reader := bufio.NewReader(in)
+	r.Show()
+	for {
+		fmt.Fprint(r.Out, "(n)ext, (b)ack, (p)lay, (q)uit: ")
+		line, err := reader.ReadString('\n')
+		if err != nil && line == "" {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		switch strings.TrimSpace(strings.ToLower(line)) {
+		case "", "n", "next":
+			if !r.Next() {
+				fmt.Fprintln(r.Out, "End of history.")
+				continue
+			}
+		case "b", "back":
+			if !r.Back() {
+				fmt.Fprintln(r.Out, "Start of history.")
+				continue
+			}
+		case "p", "play":
+			r.Play()
+			continue
+		case "q", "quit":
+			return nil
+		default:
+			fmt.Fprintln(r.Out, "Unknown command.")
+			continue
+		}
+		r.Show()
+	}
+}
+
+// Describe returns a one-line description of an event that is not a player action.
+func Describe(e game.Event) string {
+	switch e.Type {
+	case game.EventHandStarted:
+		return fmt.Sprintf("Hand #%d starts with %d players", e.HandNumber, e.Amount)
+	case game.EventPlayerSeated:
+		if e.Position != "" {
+			return fmt.Sprintf("%s (%s) sits in with %d chips", e.PlayerID, e.Position, e.Amount)
+		}
+		return fmt.Sprintf("%s sits in with %d chips", e.PlayerID, e.Amount)
+	case game.EventHoleCardsDealt:
+		return fmt.Sprintf("%s is dealt %s", e.PlayerID, cardsString(e.Cards))
+	case game.EventStreetDealt:
+		return fmt.Sprintf("%s: %s", e.Round, cardsString(e.Cards))
+	case game.EventShowdown:
+		hand := e.Action
+		if len(e.Cards) == 5 {
+			hand = game.EvaluateHand(e.Cards).Describe()
+		}
+		return fmt.Sprintf("%s shows %s with %s", e.PlayerID, hand, cardsString(e.Cards))
+	case game.EventHandMucked:
+		return fmt.Sprintf("%s mucks", e.PlayerID)
+	case game.EventBetReturned:
+		return fmt.Sprintf("Uncalled bet of %d returned to %s", e.Amount, e.PlayerID)
+	case game.EventPotAwarded:
+		return fmt.Sprintf("%s wins %d from the %s", e.PlayerID, e.Amount, e.Action)
+	case game.EventRebuy:
+		return fmt.Sprintf("%s buys %d chips (%s)", e.PlayerID, e.Amount, e.Action)
+	case game.EventCashedOut:
+		return fmt.Sprintf("%s cashes out of the tournament for %d", e.PlayerID, e.Amount)
+	case game.EventPlayerMoved:
+		return fmt.Sprintf("%s moves from table %s to table %s", e.PlayerID, e.Table, e.Action)
+	case game.EventTableBroken:
+		return fmt.Sprintf("Table %s is broken", e.Table)
+	case game.EventFinalTable:
+		return fmt.Sprintf("Final table: %d players at table %s", e.Amount, e.Table)
+	case game.EventChat:
+		return fmt.Sprintf("%s says: %s", e.PlayerID, e.Action)
+	case game.EventHandEnded:
+		if e.Elapsed > 0 {
+			return fmt.Sprintf("Hand #%d is over after %s", e.HandNumber, e.Elapsed.Round(time.Second))
+		}
+		return fmt.Sprintf("Hand #%d is over", e.HandNumber)
+	default:
+		return string(e.Type)
+	}
+}
+
+func cardsString(cards []types.Card) string {
+	s := make([]string, len(cards))
+	for i, c := range cards {
+		s[i] = c.String()
+	}
+	return "[ " + strings.Join(s, " ") + " ]"
+}
+
+// Seat is a recorded player. It implements types.Player for display only.
+type Seat struct {
+	ID         string
+	Chips      int
+	Hand       *types.Hand
+	Folded     bool
+	CurrentBet int
+}
+
+func (s *Seat) GetID() string            { return s.ID }
+func (s *Seat) GetHand() *types.Hand     { return s.Hand }
+func (s *Seat) SetHand(hand *types.Hand) { s.Hand = hand }
+func (s *Seat) AddChips(amount int)      { s.Chips += amount }
+func (s *Seat) GetChips() int            { return s.Chips }
+func (s *Seat) IsFolded() bool           { return s.Folded }
+func (s *Seat) SetFolded(folded bool)    { s.Folded = folded }
+func (s *Seat) IsAllIn() bool            { return !s.Folded && s.Chips == 0 }
+func (s *Seat) ResetForNewHand()         {}
+func (s *Seat) GetCurrentBet() int       { return s.CurrentBet }
+func (s *Seat) SetCurrentBet(amount int) { s.CurrentBet = amount }
+func (s *Seat) ResetBet()                { s.CurrentBet = 0 }
+
+func (s *Seat) Bet(amount int) int {
+	amount = min(amount, s.Chips)
+	s.Chips -= amount
+	return amount
+}
+
+// IsHuman is true so that UIs show every hole card during a replay.
+func (s *Seat) IsHuman() bool { return true }
+
+// TakeTurn is never called during a replay; a seat folds if it is.
+func (s *Seat) TakeTurn(table *types.Table, currentBet int, minRaise int) (string, int) {
+	return "fold", 0
+}
@@ -0,0 +1,101 @@
+package replay
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"pokerclientv1/internal/store"
+	"pokerclientv1/pkg/gametest"
+)
+
+// recordHand plays a scripted hand to showdown and saves it to a JSON store.
+func recordHand(t *testing.T) (string, *gametest.Harness) {
+	t.Helper()
+	btn := gametest.NewScriptedPlayer("BTN", 100, gametest.RaiseTo(10), gametest.Check(), gametest.Check(), gametest.Check())
+	sb := gametest.NewScriptedPlayer("SB", 100, gametest.Fold())
+	bb := gametest.NewScriptedPlayer("BB", 100, gametest.Call(), gametest.Check(), gametest.Check(), gametest.Check())
+	deck := gametest.Deck(t,
+		[][]string{{"As", "Ah"}, {"8h", "3s"}, {"Kd", "Kc"}},
+		[]string{"2c", "7d", "9s", "Jh", "4d"})
+
+	dir := t.TempDir()
+	s, err := store.OpenJSON(dir)
+	if err != nil {
+		t.Fatalf("OpenJSON() error: %v", err)
+	}
+	recorder := store.NewHandRecorder(s, "test")
+
+	h := gametest.New(t, deck, btn, sb, bb)
+	h.Engine.OnEvent(recorder.HandleEvent)
+	h.PlayHand()
+	if recorder.Err != nil {
+		t.Fatalf("HandRecorder error: %v", recorder.Err)
+	}
+	return filepath.Join(dir, "hands.jsonl"), h
+}
+
+// TestReplayEndsWithRecordedStacks checks that the last frame of a replay
+// matches the chips the players ended the hand with.
+func TestReplayEndsWithRecordedStacks(t *testing.T) {
+	path, h := recordHand(t)
+	hands, err := Load(path, "test")
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if len(hands) != 1 {
+		t.Fatalf("Load() returned %d hands, want 1", len(hands))
+	}
+
+	frames := Frames(hands)
+	last := frames[len(frames)-1]
+	if len(last.Players) != len(h.Players) {
+		t.Fatalf("last frame has %d players, want %d", len(last.Players), len(h.Players))
+	}
+	for i, p := range last.Players {
+		if want := h.Players[i].Chips; p.GetChips() != want {
+			t.Errorf("last frame %s chips = %d, want %d", p.GetID(), p.GetChips(), want)
+		}
+	}
+	if last.Pot != 0 {
+		t.Errorf("last frame pot = %d, want 0 after the pot is awarded", last.Pot)
+	}
+	if len(last.Table.CommunityCards) != 5 {
+		t.Errorf("last frame has %d community cards, want 5", len(last.Table.CommunityCards))
+	}
+}
+
+// TestReplayerStepsBackAndForth drives the interactive controls.
+func TestReplayerStepsBackAndForth(t *testing.T) {
+	path, _ := recordHand(t)
+	hands, err := Load(path, "")
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	frames := Frames(hands)
+
+	ui := &gametest.CaptureUI{}
+	out := &bytes.Buffer{}
+	r := NewReplayer(frames, ui, out, 0)
+	if err := r.Run(strings.NewReader("n\nn\nb\nq\n")); err != nil {
+		t.Fatalf("Run() error: %v", err)
+	}
+	if r.Position() != 1 {
+		t.Errorf("Position() = %d after next, next, back, want 1", r.Position())
+	}
+	if len(ui.Stages) != 4 {
+		t.Errorf("Run() drew %d frames, want 4", len(ui.Stages))
+	}
+	if r.Back(); r.Back() {
+		t.Errorf("Back() = true at the first frame, want false")
+	}
+
+	r.Play()
+	if r.Position() != len(frames)-1 {
+		t.Errorf("Play() stopped at frame %d, want %d", r.Position(), len(frames)-1)
+	}
+	if !strings.Contains(out.String(), "wins") {
+		t.Errorf("replay output does not describe the pot being won:\n%s", out.String())
+	}
+}
@@ -0,0 +1,162 @@
+package server
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Account errors.
+var (
+	ErrWrongPassword      = errors.New("wrong password")
+	ErrPasswordTooShort   = errors.New("passwords must be at least 6 characters")
+	ErrNameRegistered     = errors.New("name is registered, join with its password")
+	ErrRegisteredOnly     = errors.New("this table is for registered players, join with a password")
+	ErrInsufficientChips  = errors.New("not enough chips in the account")
+	ErrNoAccountsOnServer = errors.New("this server has no registered accounts, join without a password")
+	ErrNoAccount          = errors.New("no such account")
+)
+
+// passwordRounds is how many times a password is hashed, to slow down
+// guessing from a stolen accounts file.
+const passwordRounds = 100_000
+
+// Accounts are the registered players of a server: names protected by a
+// password, each with a chip balance kept between games. Joining with a
+// password for a name that is not registered yet registers it. Players who
+// join without a password are guests, whose chips are gone when they leave.
+// Accounts are saved to a JSON file after every change. It is safe for
+// concurrent use.
+type Accounts struct {
+	NewBalance int // Chips a new account starts with
+
+	mu       sync.Mutex
+	path     string
+	accounts map[string]account
+}
+
+// account is one registered player as saved.
+type account struct {
+	Salt       string    `json:"salt"`
+	Hash       string    `json:"hash"`
+	Chips      int       `json:"chips"`
+	Registered time.Time `json:"registered"`
+}
+
+// OpenAccounts loads the accounts kept in the file at path, which is created
+// with the first registration.
+func OpenAccounts(path string) (*Accounts, error) {
+	a := &Accounts{NewBalance: 10000, path: path, accounts: map[string]account{}}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return a, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &a.accounts); err != nil {
+		return nil, fmt.Errorf("accounts %s: %w", path, err)
+	}
+	return a, nil
+}
+
+// Registered reports whether a name belongs to a registered player.
+func (a *Accounts) Registered(name string) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	_, ok := a.accounts[name]
+	return ok
+}
+
+// Login checks a registered player's password, or registers the name with
+// the password and NewBalance chips if it is new.
+func (a *Accounts) Login(name, password string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	acct, ok := a.accounts[name]
+	if ok {
+		salt, _ := hex.DecodeString(acct.Salt)
+		if subtle.ConstantTimeCompare([]byte(hashPassword(salt, password)), []byte(acct.Hash)) != 1 {
+			return ErrWrongPassword
+		}
+		return nil
+	}
+	if len(password) < 6 {
+		return ErrPasswordTooShort
+	}
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return err
+	}
+	a.accounts[name] = account{Salt: hex.EncodeToString(salt), Hash: hashPassword(salt, password), Chips: a.NewBalance, Registered: time.Now()}
+	return a.save()
+}
+
+// Balance returns a registered player's chips off the table.
+func (a *Accounts) Balance(name string) int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.accounts[name].Chips
+}
+
+// Withdraw takes chips from an account to buy in with.
+func (a *Accounts) Withdraw(name string, chips int) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	acct, ok := a.accounts[name]
+	if !ok {
+		return ErrNoAccount
+	}
+	if acct.Chips < chips {
+		return fmt.Errorf("%w: %d left", ErrInsufficientChips, acct.Chips)
+	}
+	acct.Chips -= chips
+	a.accounts[name] = acct
+	return a.save()
+}
+
+// Deposit puts the chips a player leaves the table with back in their account.
+func (a *Accounts) Deposit(name string, chips int) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	acct, ok := a.accounts[name]
+	if !ok {
+		return ErrNoAccount
+	}
+	acct.Chips += chips
+	a.accounts[name] = acct
+	return a.save()
+}
+
+// save writes the accounts file atomically. Callers must hold a.mu.
+func (a *Accounts) save() error {
+	if err := os.MkdirAll(filepath.Dir(a.path), 0o700); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(a.accounts, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := a.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, a.path)
+}
+
+// hashPassword stretches a salted password with repeated SHA-256.
+func hashPassword(salt []byte, password string) string {
+	sum := sha256.Sum256(append(salt, password...))
+	for i := 1; i < passwordRounds; i++ {
+		sum = sha256.Sum256(sum[:])
+	}
+	return hex.EncodeToString(sum[:])
+}
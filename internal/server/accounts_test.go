@@ -0,0 +1,52 @@
+package server
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+// TestAccounts registers a player, checks their password and balance, and
+// reopens the accounts file to check nothing is lost.
+func TestAccounts(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "accounts.json")
+	accounts, err := OpenAccounts(path)
+	if err != nil {
+		t.Fatalf("OpenAccounts() error: %v", err)
+	}
+	if err := accounts.Login("Alice", "short"); !errors.Is(err, ErrPasswordTooShort) {
+		t.Errorf("Login() with a 5 character password = %v, want ErrPasswordTooShort", err)
+	}
+	if accounts.Registered("Alice") {
+		t.Fatalf("Alice is registered after a rejected password")
+	}
+	if err := accounts.Login("Alice", "hunter22"); err != nil {
+		t.Fatalf("Login() registering Alice error: %v", err)
+	}
+	if err := accounts.Withdraw("Alice", 300); err != nil {
+		t.Fatalf("Withdraw(300) error: %v", err)
+	}
+	if err := accounts.Withdraw("Alice", 1_000_000); !errors.Is(err, ErrInsufficientChips) {
+		t.Errorf("Withdraw(1000000) = %v, want ErrInsufficientChips", err)
+	}
+	if err := accounts.Deposit("Alice", 450); err != nil {
+		t.Fatalf("Deposit(450) error: %v", err)
+	}
+	if err := accounts.Deposit("Bob", 10); !errors.Is(err, ErrNoAccount) {
+		t.Errorf("Deposit() to an unregistered name = %v, want ErrNoAccount", err)
+	}
+
+	reopened, err := OpenAccounts(path)
+	if err != nil {
+		t.Fatalf("OpenAccounts() reopening error: %v", err)
+	}
+	if !reopened.Registered("Alice") || reopened.Balance("Alice") != 10150 {
+		t.Errorf("reopened Alice: registered %v with %d chips, want registered with 10150", reopened.Registered("Alice"), reopened.Balance("Alice"))
+	}
+	if err := reopened.Login("Alice", "hunter2"); !errors.Is(err, ErrWrongPassword) {
+		t.Errorf("Login() with the wrong password = %v, want ErrWrongPassword", err)
+	}
+	if err := reopened.Login("Alice", "hunter22"); err != nil {
+		t.Errorf("Login() with the right password error: %v", err)
+	}
+}
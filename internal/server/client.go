@@ -0,0 +1,257 @@
+package server
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+
+	"pokerclientv1/internal/replay"
+	"pokerclientv1/pkg/game"
+	"pokerclientv1/pkg/types"
+)
+
+// Client connects a human to a server and renders the game through a GameUI.
+type Client struct {
+	UI  types.GameUI
+	In  io.Reader // Where the player's actions are read from
+	Out io.Writer // Prompts and messages that are not part of the table display
+	// Session is the token from the server's welcome, which rejoins the same
+	// seat after a dropped connection when sent with the join message.
+	Session string
+	// Extra keys and aliases for actions typed at the prompt, as for a
+	// player at a local table
+	Keys types.Bindings
+	// Optional callback for each event the server forwards, and for an
+	// action_on event when it is this player's turn, such as a
+	// notify.Webhook's HandleEvent
+	OnEvent game.EventHandler
+
+	name string
+	last game.Event // Latest event forwarded, for the hand and table of a turn
+	conn net.Conn
+	enc  *json.Encoder
+}
+
+// Dial connects to a server and joins as name. Addresses of the form
+// "unix:/path/to/socket" connect to a server on a local socket.
+func Dial(addr, name string, ui types.GameUI, in io.Reader, out io.Writer) (*Client, error) {
+	return DialSession(addr, name, "", ui, in, out)
+}
+
+// DialSession connects to a server and rejoins the seat held by session, as
+// returned in Client.Session before the connection dropped.
+func DialSession(addr, name, session string, ui types.GameUI, in io.Reader, out io.Writer) (*Client, error) {
+	return DialJoin(addr, Message{Type: MsgJoin, Player: name, Session: session}, ui, in, out)
+}
+
+// DialJoin connects to a server and sends join, a MsgJoin message that may
+// also carry a session and an avatar.
+func DialJoin(addr string, join Message, ui types.GameUI, in io.Reader, out io.Writer) (*Client, error) {
+	network, address := splitAddr(addr)
+	c, err := net.Dial(network, address)
+	if err != nil {
+		return nil, err
+	}
+	client := &Client{UI: ui, In: in, Out: out, Session: join.Session, name: join.Player, conn: c, enc: json.NewEncoder(c)}
+	if err := client.enc.Encode(join); err != nil {
+		c.Close()
+		return nil, err
+	}
+	return client, nil
+}
+
+// Run shows the game until the server says goodbye or the connection drops.
+func (c *Client) Run() error {
+	defer c.conn.Close()
+	input := bufio.NewReader(c.In)
+	scanner := bufio.NewScanner(c.conn)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var m ServerMessage
+		if err := json.Unmarshal(scanner.Bytes(), &m); err != nil {
+			return fmt.Errorf("bad message from server: %w", err)
+		}
+		switch m.Type {
+		case SrvWelcome:
+			c.Session = m.Session
+			fmt.Fprintln(c.Out, m.Text)
+		case SrvError:
+			fmt.Fprintln(c.Out, m.Text)
+		case SrvBye:
+			fmt.Fprintln(c.Out, m.Text)
+			return nil
+		case SrvState:
+			c.showState(m.State)
+		case SrvLog:
+			c.UI.LogAction(m.PlayerID, m.Action, m.Amount)
+		case SrvClear:
+			c.UI.ClearScreen()
+		case SrvEvent:
+			if m.Event != nil {
+				fmt.Fprintln(c.Out, replay.Describe(*m.Event))
+				c.last = *m.Event
+				c.emit(*m.Event)
+			}
+		case SrvNotice:
+			if m.Notice != nil {
+				c.showNotice(*m.Notice)
+			}
+		case SrvTurn:
+			if m.Turn != nil {
+				c.emit(game.Event{Type: game.EventActionOn, Table: c.last.Table, HandNumber: c.last.HandNumber, PlayerID: c.name, Amount: m.Turn.ToCall, Time: time.Now()})
+			}
+			action, err := c.promptAction(input, m.Turn)
+			if err != nil {
+				c.enc.Encode(Message{Type: MsgLeave})
+				return nil // Input closed, leave the table
+			}
+			if err := c.enc.Encode(action); err != nil {
+				return err
+			}
+		}
+	}
+	return scanner.Err()
+}
+
+func (c *Client) emit(e game.Event) {
+	if c.OnEvent != nil {
+		c.OnEvent(e)
+	}
+}
+
+func (c *Client) showState(state *TableState) {
+	if state == nil {
+		return
+	}
+	table := &types.Table{CommunityCards: state.CommunityCards, CurrentBet: state.CurrentBet, Acting: state.Acting, Pots: state.Pots,
+		HandNumber: state.Hand, SmallBlind: state.SmallBlind, BigBlind: state.BigBlind, Ante: state.Ante, BlindLevel: state.BlindLevel}
+	if state.Elapsed > 0 {
+		table.SessionStart = time.Now().Add(-time.Duration(state.Elapsed) * time.Millisecond)
+	}
+	if state.Deadline != nil {
+		// Count down from the time the server gave, whatever our clock says
+		table.Deadline = time.Now().Add(time.Duration(state.TimeLeft) * time.Millisecond)
+	}
+	players := make([]types.Player, len(state.Players))
+	for i, seat := range state.Players {
+		players[i] = &seatView{seat: seat}
+		if seat.Position != "" {
+			if table.Positions == nil {
+				table.Positions = map[string]string{}
+			}
+			table.Positions[seat.ID] = seat.Position
+		}
+	}
+	c.UI.DisplayGameState(table, players, state.Pot, state.Stage)
+}
+
+func (c *Client) showNotice(n Notice) {
+	switch n.Kind {
+	case NoticeSeated:
+		fmt.Fprintln(c.Out, "You have a seat and will be dealt in shortly.")
+	case NoticeWaitlisted, NoticeMoved:
+		fmt.Fprintf(c.Out, "The table is full. You are number %d on the waitlist.\n", n.Position)
+	}
+}
+
+// promptAction reads the player's action, asking again until it is well formed.
+// Raise amounts are entered as the total bet, like at the local table, and
+// when the server sent the legal actions they are checked before sending.
+func (c *Client) promptAction(input *bufio.Reader, turn *TurnInfo) (Message, error) {
+	for {
+		if legal := turn.Legal; legal != nil {
+			fmt.Fprintf(c.Out, "Your turn (Chips: %d, Bet: %d, To call: %d)\n", turn.Chips, turn.PlayerBet, legal.ToCall)
+			options := strings.Join(legal.Actions, ", ")
+			if legal.Can("raise") {
+				options += fmt.Sprintf(" <%d-%d>, all-in", legal.MinRaise, legal.MaxRaise)
+			}
+			fmt.Fprintf(c.Out, "Enter action (%s, say <text>): ", options)
+		} else {
+			fmt.Fprintf(c.Out, "Your turn (Chips: %d, Bet: %d, To call: %d, Min raise to: %d)\n",
+				turn.Chips, turn.PlayerBet, turn.ToCall, turn.CurrentBet+turn.MinRaise)
+			fmt.Fprint(c.Out, "Enter action (fold, check, call, raise <total>, all-in, say <text>): ")
+		}
+		line, err := input.ReadString('\n')
+		if err != nil && line == "" {
+			return Message{}, err
+		}
+		parts := strings.Fields(strings.ToLower(line))
+		if len(parts) == 0 {
+			continue
+		}
+		if parts[0] == "say" { // Chat, then ask again
+			_, text, _ := strings.Cut(strings.TrimSpace(line), " ")
+			if err := c.enc.Encode(Message{Type: MsgChat, Text: text}); err != nil {
+				return Message{}, err
+			}
+			continue
+		}
+		action, bound := c.Keys.Resolve(parts[0])
+		if bound && action == "call" && turn.ToCall == 0 {
+			action = "check"
+		}
+		legal := turn.Legal
+		switch action {
+		case "fold", "check", "call", "all-in":
+			if legal != nil && action != "all-in" && !legal.Can(action) {
+				fmt.Fprintf(c.Out, "You cannot %s now.\n", action)
+				continue
+			}
+			return Message{Type: MsgAction, Action: action}, nil
+		case "raise":
+			if legal != nil && !legal.Can("raise") {
+				fmt.Fprintln(c.Out, "You cannot raise now.")
+				continue
+			}
+			if len(parts) < 2 {
+				fmt.Fprintln(c.Out, "Enter the total to raise to, e.g. 'raise 50'.")
+				continue
+			}
+			total, err := types.ParseAmount(strings.Join(parts[1:], " "))
+			if err != nil || total <= turn.PlayerBet {
+				fmt.Fprintln(c.Out, "Invalid raise amount.")
+				continue
+			}
+			if legal != nil && (total < legal.MinRaise || total > legal.MaxRaise) {
+				fmt.Fprintf(c.Out, "Raise to between %d and %d.\n", legal.MinRaise, legal.MaxRaise)
+				continue
+			}
+			return Message{Type: MsgAction, Action: "raise", Amount: types.Amount(total - turn.PlayerBet)}, nil
+		default:
+			fmt.Fprintln(c.Out, "Invalid action.")
+		}
+	}
+}
+
+// seatView adapts a SeatState to types.Player so the client can reuse the
+// local GameUI. Only the fields a UI reads are meaningful.
+type seatView struct {
+	seat SeatState
+}
+
+func (v *seatView) GetID() string            { return v.seat.ID }
+func (v *seatView) GetHand() *types.Hand     { return &types.Hand{Cards: v.seat.Cards} }
+func (v *seatView) SetHand(hand *types.Hand) {}
+func (v *seatView) AddChips(amount int)      {}
+func (v *seatView) Bet(amount int) int       { return 0 }
+func (v *seatView) GetChips() int            { return v.seat.Chips }
+func (v *seatView) IsFolded() bool           { return v.seat.Folded }
+func (v *seatView) SetFolded(folded bool)    {}
+func (v *seatView) IsAllIn() bool            { return v.seat.AllIn }
+func (v *seatView) ResetForNewHand()         {}
+func (v *seatView) GetCurrentBet() int       { return v.seat.CurrentBet }
+func (v *seatView) SetCurrentBet(amount int) {}
+func (v *seatView) ResetBet()                {}
+
+// IsHuman is true for seats whose cards are known, so UIs show them.
+func (v *seatView) IsHuman() bool { return len(v.seat.Cards) > 0 }
+
+func (v *seatView) GetAvatar() string { return v.seat.Avatar }
+
+func (v *seatView) TakeTurn(table *types.Table, currentBet int, minRaise int) (string, int) {
+	return "fold", 0
+}
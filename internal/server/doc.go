@@ -0,0 +1,90 @@
+// Package server hosts a poker table for remote players over TCP and
+// WebSockets, and provides the matching command-line client.
+//
+// # Protocol
+//
+// Clients and the server exchange JSON objects. Over TCP each object is one
+// line; over a WebSocket (the /ws path of Config.WebSocketAddr) each object
+// is one text message. Every object has a "type" field.
+//
+// Client to server (Message):
+//
+//	{"type":"join","player":"Alice"}                   take a seat
+//	{"type":"join","player":"Alice","session":"9f.."}  rejoin after a disconnect
+//	{"type":"join","player":"Alice","avatar":"🦁"}     avatar shown next to the name
+//	{"type":"join","player":"Alice","password":"..."}  join as a registered player
+//	{"type":"action","action":"fold"}                  also check, call, all-in
+//	{"type":"action","action":"raise","amount":40}     amount is chips to add
+//	{"type":"chat","text":"good luck"}                 say something to the table
+//	{"type":"ping"}                                    keep the connection alive
+//	{"type":"leave"}                                   give up the seat
+//
+// Amounts and blinds may also be strings in the notation of
+// types.ParseAmount, such as "1,500" or "1.5k".
+//
+// The first message must be a join. Messages are limited to MaxMessageSize
+// bytes, unknown fields are rejected, and clients that flood the server are
+// disconnected (see GuardConfig).
+//
+// Server to client (ServerMessage):
+//
+//	{"type":"welcome","text":"Welcome, Alice!","session":"9f.."}
+//	{"type":"state","state":{"stage":"Flop","pot":40,"currentBet":0,
+//	    "communityCards":[...],"players":[{"id":"Alice","chips":180,
+//	    "currentBet":0,"cards":[...]}, ...],"acting":"Bob",
+//	    "deadline":"2024-05-01T20:15:34.5Z","timeLeft":30000}}
+//	{"type":"turn","turn":{"currentBet":20,"playerBet":10,"toCall":10,
+//	    "minRaise":10,"chips":170,"legal":{"player":"Alice",
+//	    "actions":["fold","call","raise"],"toCall":10,"minRaise":30,"maxRaise":180}}}
+//	{"type":"log","player":"Bob","action":"raise","amount":30}
+//	{"type":"event","event":{"type":"pot_awarded","hand":3,"player":"Bob","amount":60,
+//	    "time":"2024-05-01T20:15:04.5Z"}}
+//	{"type":"notice","notice":{"table":"main","player":"Carol","kind":"waitlisted","position":1}}
+//	{"type":"clear"}
+//	{"type":"error","text":"minimum raise is to 40"}
+//	{"type":"bye","text":"The game is over."}
+//
+// A state message only includes the hole cards of the player it is sent to.
+// With a turn timeout, acting and deadline tell every client whose turn it is
+// and when it runs out by the server's clock; timeLeft is the milliseconds
+// left when the message was sent, for counting down without trusting the
+// client's own clock. The state is sent again as each player's clock starts.
+// The legal field of a turn lists the allowed actions and raise totals as
+// computed by game.LegalActions, so clients need not redo the betting math.
+// A turn message asks for one action message; an invalid action is answered
+// with an error and the turn is sent again.
+//
+// Chat from a seated player is relayed to every client as a chat event,
+// {"type":"event","event":{"type":"chat","player":"Alice","action":"good luck",...}},
+// as is the bots' banter with Config.Banter. Control characters are dropped
+// and messages are limited to game.MaxChatLength characters.
+//
+// # Local sockets
+//
+// A Config.Addr of the form "unix:/path/to/socket" serves the same protocol
+// on a Unix socket instead of TCP, and Dial accepts the same form. This lets
+// a native GUI, an editor plugin or a script drive a game on the same machine
+// without opening a network port: `poker serve --socket /tmp/poker.sock
+// --players 1 --bots 3` seats one local front end against three bots.
+//
+// # Sessions
+//
+// The welcome message carries a session token. If the connection drops after
+// the game has started, the player keeps their seat for Config.Reconnect and
+// checks or folds when it is their turn. Joining again under the same name
+// with the token moves the seat to the new connection.
+//
+// With Config.Timebanks each player also has a time bank to draw on once
+// Config.TurnTimeout runs out. It is kept by name in a TimebankStore, not
+// with the connection, so disconnecting and joining again does not refill
+// it, and the store counts how often a player was folded for running out.
+//
+// # Guests and accounts
+//
+// Anyone may join without a password as a guest, whose chips are gone when
+// they leave. A server with Config.Accounts also seats registered players:
+// joining with a password registers the name, and from then on the buy-in is
+// taken from the account and whatever the player leaves with is put back.
+// Guests cannot use a registered name, and Config.RegisteredOnly turns them
+// away altogether.
+package server
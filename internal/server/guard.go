@@ -0,0 +1,143 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// ErrDisconnect is returned by Guard.Inspect once a client has misbehaved
+// often enough that the server should close the connection.
+var ErrDisconnect = errors.New("client disconnected for misbehaving")
+
+// ErrRateLimited is returned for messages sent faster than the rate limit.
+var ErrRateLimited = errors.New("rate limit exceeded")
+
+// GuardConfig sets how much traffic a connection may send.
+type GuardConfig struct {
+	MessagesPerSecond float64 // Sustained message rate
+	Burst             int     // Messages allowed at once before the rate applies
+	MaxViolations     int     // Invalid or rate-limited messages before disconnecting
+}
+
+// DefaultGuardConfig allows plenty of headroom for a human or bot client.
+func DefaultGuardConfig() GuardConfig {
+	return GuardConfig{MessagesPerSecond: 5, Burst: 10, MaxViolations: 5}
+}
+
+// RateLimiter is a token bucket: it holds up to burst tokens and refills at
+// rate tokens per second. Each allowed message takes one token.
+type RateLimiter struct {
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+	now    func() time.Time
+}
+
+// NewRateLimiter creates a full bucket.
+func NewRateLimiter(perSecond float64, burst int) *RateLimiter {
+	return &RateLimiter{rate: perSecond, burst: float64(burst), tokens: float64(burst), now: time.Now}
+}
+
+// Allow reports whether a message may be sent now, taking a token if so.
+func (l *RateLimiter) Allow() bool {
+	now := l.now()
+	if !l.last.IsZero() {
+		l.tokens += now.Sub(l.last).Seconds() * l.rate
+		if l.tokens > l.burst {
+			l.tokens = l.burst
+		}
+	}
+	l.last = now
+	if l.tokens < 1 {
+		return false
+	}
+	l.tokens--
+	return true
+}
+
+// AuditEntry is one line of the audit log.
+type AuditEntry struct {
+	Time   time.Time `json:"time"`
+	Remote string    `json:"remote"`
+	Event  string    `json:"event"` // invalid_message, rate_limited or disconnected
+	Detail string    `json:"detail,omitempty"`
+}
+
+// AuditLog writes audit entries as JSON lines. It is safe for concurrent use.
+type AuditLog struct {
+	mu  sync.Mutex
+	w   io.Writer
+	now func() time.Time
+}
+
+// NewAuditLog creates an audit log writing to w.
+func NewAuditLog(w io.Writer) *AuditLog {
+	return &AuditLog{w: w, now: time.Now}
+}
+
+// Record appends an entry, filling in the time.
+func (a *AuditLog) Record(remote, event, detail string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	data, err := json.Marshal(AuditEntry{Time: a.now(), Remote: remote, Event: event, Detail: detail})
+	if err != nil {
+		return
+	}
+	a.w.Write(append(data, '\n'))
+}
+
+// Guard validates and rate limits the messages of one connection.
+type Guard struct {
+	remote     string
+	cfg        GuardConfig
+	limiter    *RateLimiter
+	audit      *AuditLog
+	violations int
+}
+
+// NewGuard creates a guard for the connection from remote. audit may be nil.
+func NewGuard(remote string, cfg GuardConfig, audit *AuditLog) *Guard {
+	return &Guard{
+		remote:  remote,
+		cfg:     cfg,
+		limiter: NewRateLimiter(cfg.MessagesPerSecond, cfg.Burst),
+		audit:   audit,
+	}
+}
+
+// Inspect rate limits and decodes one message line. Rejected messages count
+// as violations; once MaxViolations is reached the error wraps ErrDisconnect.
+func (g *Guard) Inspect(line []byte) (Message, error) {
+	if !g.limiter.Allow() {
+		return Message{}, g.violation("rate_limited", ErrRateLimited)
+	}
+	m, err := DecodeMessage(line)
+	if err != nil {
+		return Message{}, g.violation("invalid_message", err)
+	}
+	return m, nil
+}
+
+// Violations returns the number of rejected messages so far.
+func (g *Guard) Violations() int { return g.violations }
+
+func (g *Guard) violation(event string, err error) error {
+	g.violations++
+	g.record(event, err.Error())
+	if g.violations >= g.cfg.MaxViolations {
+		g.record("disconnected", fmt.Sprintf("%d violations", g.violations))
+		return fmt.Errorf("%w: %v", ErrDisconnect, err)
+	}
+	return err
+}
+
+func (g *Guard) record(event, detail string) {
+	if g.audit != nil {
+		g.audit.Record(g.remote, event, detail)
+	}
+}
@@ -0,0 +1,100 @@
+package server
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestDecodeMessage checks the schema validation of incoming messages.
+func TestDecodeMessage(t *testing.T) {
+	tests := []struct {
+		line  string
+		valid bool
+	}{
+		{`{"type":"action","action":"call"}`, true},
+		{`{"type":"action","action":"raise","amount":20}`, true},
+		{`{"type":"action","action":"raise","amount":"1.5k"}`, true},
+		{`{"type":"action","action":"raise","amount":"1.5"}`, false},
+		{`{"type":"action","action":"raise","amount":true}`, false},
+		{`{"type":"join","player":"alice"}`, true},
+		{`{"type":"join","player":"alice","avatar":"🦁"}`, true},
+		{`{"type":"join","player":"alice","avatar":"too long"}`, false},
+		{`{"type":"ping"}`, true},
+		{`{"type":"set_blinds","smallBlind":5,"bigBlind":10}`, true},
+		{`{"type":"set_blinds","smallBlind":10,"bigBlind":5}`, false},
+		{`{"type":"kick"}`, false},
+		{`{"type":"action","action":"raise"}`, false},
+		{`{"type":"action","action":"call","amount":5}`, false},
+		{`{"type":"action","action":"raise","amount":-5}`, false},
+		{`{"type":"action","action":"steal"}`, false},
+		{`{"type":"join"}`, false},
+		{`{"type":"action","action":"fold","extra":1}`, false},
+		{`{"type":"chat","text":"good luck"}`, true},
+		{`{"type":"chat","text":" \u001b "}`, false},
+		{`{"type":"chat","text":"` + strings.Repeat("x", 201) + `"}`, false},
+		{`{"type":"ping"} {"type":"ping"}`, false},
+		{`not json`, false},
+		{`{"type":"join","player":"` + strings.Repeat("x", MaxMessageSize) + `"}`, false},
+	}
+	for _, tt := range tests {
+		_, err := DecodeMessage([]byte(tt.line))
+		if tt.valid && err != nil {
+			t.Errorf("DecodeMessage(%.40s) error: %v, want valid", tt.line, err)
+		}
+		if !tt.valid && !errors.Is(err, ErrInvalidMessage) {
+			t.Errorf("DecodeMessage(%.40s) = %v, want ErrInvalidMessage", tt.line, err)
+		}
+	}
+}
+
+// TestRateLimiterRefills checks the burst and the refill rate.
+func TestRateLimiterRefills(t *testing.T) {
+	now := time.Unix(0, 0)
+	l := NewRateLimiter(2, 3)
+	l.now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		if !l.Allow() {
+			t.Fatalf("Allow() = false for burst message %d", i+1)
+		}
+	}
+	if l.Allow() {
+		t.Errorf("Allow() = true after the burst was used up")
+	}
+	now = now.Add(500 * time.Millisecond) // One token at 2 per second
+	if !l.Allow() {
+		t.Errorf("Allow() = false after refilling one token")
+	}
+	if l.Allow() {
+		t.Errorf("Allow() = true with an empty bucket")
+	}
+}
+
+// TestGuardDisconnectsAfterViolations checks that a spamming client is
+// dropped and that each rejection is audited.
+func TestGuardDisconnectsAfterViolations(t *testing.T) {
+	var log bytes.Buffer
+	g := NewGuard("10.0.0.1:5000", GuardConfig{MessagesPerSecond: 1, Burst: 2, MaxViolations: 3}, NewAuditLog(&log))
+
+	if _, err := g.Inspect([]byte(`{"type":"ping"}`)); err != nil {
+		t.Fatalf("Inspect(valid) error: %v", err)
+	}
+	if _, err := g.Inspect([]byte(`garbage`)); err == nil || errors.Is(err, ErrDisconnect) {
+		t.Fatalf("Inspect(garbage) = %v, want a validation error without disconnect", err)
+	}
+	if _, err := g.Inspect([]byte(`{"type":"ping"}`)); !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("Inspect() past the burst = %v, want ErrRateLimited", err)
+	}
+	if _, err := g.Inspect([]byte(`{"type":"ping"}`)); !errors.Is(err, ErrDisconnect) {
+		t.Fatalf("Inspect() at the violation limit = %v, want ErrDisconnect", err)
+	}
+
+	for _, event := range []string{"invalid_message", "rate_limited", "disconnected"} {
+		if !strings.Contains(log.String(), `"event":"`+event+`"`) {
+			t.Errorf("audit log has no %s entry:\n%s", event, log.String())
+		}
+	}
+}
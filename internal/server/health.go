@@ -0,0 +1,139 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Health tracks whether the server can take new hands and how many hands are
+// in progress. It backs the /healthz and /readyz endpoints and lets a shutdown
+// wait for running hands to finish.
+type Health struct {
+	mu          sync.Mutex
+	ready       bool
+	draining    bool
+	activeHands int
+	idle        chan struct{} // Closed when draining and no hands are active
+}
+
+// NewHealth creates a tracker that is not ready until SetReady(true) is called.
+func NewHealth() *Health {
+	return &Health{idle: make(chan struct{})}
+}
+
+// SetReady marks the server as able (or unable) to accept players.
+func (h *Health) SetReady(ready bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.ready = ready
+}
+
+// Ready reports whether the server accepts new hands.
+func (h *Health) Ready() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.ready && !h.draining
+}
+
+// BeginHand registers a hand that is about to start. It returns false once
+// the server is draining, in which case the hand must not be dealt.
+func (h *Health) BeginHand() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.draining {
+		return false
+	}
+	h.activeHands++
+	return true
+}
+
+// EndHand marks a hand started with BeginHand as finished.
+func (h *Health) EndHand() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.activeHands > 0 {
+		h.activeHands--
+	}
+	h.signalIdle()
+}
+
+// ActiveHands returns the number of hands in progress.
+func (h *Health) ActiveHands() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.activeHands
+}
+
+// Drain refuses new hands and waits until the running ones finish or ctx is done.
+func (h *Health) Drain(ctx context.Context) error {
+	h.mu.Lock()
+	if !h.draining {
+		h.draining = true
+		h.signalIdle()
+	}
+	h.mu.Unlock()
+
+	select {
+	case <-h.idle:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// signalIdle closes the idle channel once draining has no hands left. Callers must hold h.mu.
+func (h *Health) signalIdle() {
+	if h.draining && h.activeHands == 0 {
+		select {
+		case <-h.idle:
+		default:
+			close(h.idle)
+		}
+	}
+}
+
+// Handler serves /healthz (the process is up) and /readyz (new players and
+// hands are accepted; 503 while starting or draining).
+func (h *Health) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("ok\n"))
+	})
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		if !h.Ready() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte("not ready\n"))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("ready\n"))
+	})
+	return mux
+}
+
+// ServeHealth runs the health endpoints on addr until ctx is cancelled (for
+// example by SIGTERM through signal.NotifyContext). It then stops accepting
+// hands, waits up to drainTimeout for running hands and shuts the listener down.
+func ServeHealth(ctx context.Context, addr string, h *Health, drainTimeout time.Duration) error {
+	srv := &http.Server{Addr: addr, Handler: h.Handler()}
+	errCh := make(chan error, 1)
+	go func() { errCh <- srv.ListenAndServe() }()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	}
+
+	drainCtx, cancel := context.WithTimeout(context.Background(), drainTimeout)
+	defer cancel()
+	drainErr := h.Drain(drainCtx)
+	if err := srv.Shutdown(drainCtx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return drainErr
+}
@@ -0,0 +1,77 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// TestReadyzFollowsLifecycle checks readiness while starting, serving and draining.
+func TestReadyzFollowsLifecycle(t *testing.T) {
+	h := NewHealth()
+	srv := httptest.NewServer(h.Handler())
+	defer srv.Close()
+
+	status := func(path string) int {
+		resp, err := http.Get(srv.URL + path)
+		if err != nil {
+			t.Fatalf("GET %s: %v", path, err)
+		}
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	if got := status("/healthz"); got != http.StatusOK {
+		t.Errorf("/healthz = %d, want 200", got)
+	}
+	if got := status("/readyz"); got != http.StatusServiceUnavailable {
+		t.Errorf("/readyz before ready = %d, want 503", got)
+	}
+	h.SetReady(true)
+	if got := status("/readyz"); got != http.StatusOK {
+		t.Errorf("/readyz when ready = %d, want 200", got)
+	}
+
+	// Drain while a hand is running: new hands are refused and Drain waits
+	if !h.BeginHand() {
+		t.Fatalf("BeginHand() = false before draining, want true")
+	}
+	done := make(chan error)
+	go func() { done <- h.Drain(context.Background()) }()
+	time.Sleep(10 * time.Millisecond)
+
+	if got := status("/readyz"); got != http.StatusServiceUnavailable {
+		t.Errorf("/readyz while draining = %d, want 503", got)
+	}
+	if h.BeginHand() {
+		t.Errorf("BeginHand() = true while draining, want false")
+	}
+	select {
+	case <-done:
+		t.Fatalf("Drain() returned before the running hand finished")
+	default:
+	}
+
+	h.EndHand()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Drain() = %v, want nil", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("Drain() did not return after the last hand ended")
+	}
+}
+
+// TestDrainTimeout checks that Drain gives up when the context expires.
+func TestDrainTimeout(t *testing.T) {
+	h := NewHealth()
+	h.BeginHand()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := h.Drain(ctx); err == nil {
+		t.Errorf("Drain() with a stuck hand = nil, want a timeout error")
+	}
+}
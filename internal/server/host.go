@@ -0,0 +1,138 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"pokerclientv1/pkg/game"
+)
+
+// Private table errors.
+var (
+	ErrNotHost         = errors.New("only the table host can do that")
+	ErrAwaitingHost    = errors.New("waiting for the host to approve the join")
+	ErrNoJoinRequest   = errors.New("player has not asked to join")
+	ErrKicked          = errors.New("player was removed from this table by the host")
+	ErrTableClosed     = errors.New("table is closed")
+	ErrNotHostMessage  = errors.New("message is not a host control")
+	ErrKickHostRefused = errors.New("the host cannot kick themselves")
+)
+
+// PrivateTable enforces the host's control over a private game: joins need
+// approval, kicked players cannot come back, and pausing, blind changes and
+// closing take effect between hands. It is safe for concurrent use.
+type PrivateTable struct {
+	mu      sync.Mutex
+	Host    string
+	Seats   *SeatManager
+	pending []string
+	kicked  map[string]bool
+	paused  bool
+	closed  bool
+	blinds  *[2]int // Blinds to apply before the next hand, if changed
+}
+
+// NewPrivateTable creates a table owned by host, who is seated right away.
+func NewPrivateTable(host string, seats *SeatManager) *PrivateTable {
+	t := &PrivateTable{Host: host, Seats: seats, kicked: make(map[string]bool)}
+	seats.Join(host)
+	return t
+}
+
+// RequestJoin asks the host for a seat. It returns ErrAwaitingHost while the
+// request is pending.
+func (t *PrivateTable) RequestJoin(playerID string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	switch {
+	case t.closed:
+		return ErrTableClosed
+	case t.kicked[playerID]:
+		return ErrKicked
+	case indexOf(t.pending, playerID) < 0:
+		t.pending = append(t.pending, playerID)
+	}
+	return ErrAwaitingHost
+}
+
+// Pending returns the players waiting for the host's approval.
+func (t *PrivateTable) Pending() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]string(nil), t.pending...)
+}
+
+// HandleHostMessage applies a host control message sent by from.
+func (t *PrivateTable) HandleHostMessage(from string, m Message) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	switch m.Type {
+	case MsgApprove, MsgKick, MsgPause, MsgResume, MsgSetBlinds, MsgClose:
+	default:
+		return ErrNotHostMessage
+	}
+	if from != t.Host {
+		return ErrNotHost
+	}
+	if t.closed {
+		return ErrTableClosed
+	}
+
+	switch m.Type {
+	case MsgApprove:
+		i := indexOf(t.pending, m.Player)
+		if i < 0 {
+			return ErrNoJoinRequest
+		}
+		t.pending = append(t.pending[:i], t.pending[i+1:]...)
+		_, _, err := t.Seats.Join(m.Player)
+		return err
+	case MsgKick:
+		if m.Player == t.Host {
+			return ErrKickHostRefused
+		}
+		t.kicked[m.Player] = true
+		if i := indexOf(t.pending, m.Player); i >= 0 {
+			t.pending = append(t.pending[:i], t.pending[i+1:]...)
+			return nil
+		}
+		return t.Seats.Leave(m.Player)
+	case MsgPause:
+		t.paused = true
+	case MsgResume:
+		t.paused = false
+	case MsgSetBlinds:
+		t.blinds = &[2]int{int(m.SmallBlind), int(m.BigBlind)}
+	case MsgClose:
+		t.closed = true
+	}
+	return nil
+}
+
+// Paused reports whether the host has paused the game.
+func (t *PrivateTable) Paused() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.paused
+}
+
+// Closed reports whether the host has closed the table.
+func (t *PrivateTable) Closed() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.closed
+}
+
+// BeforeHand is called by the table loop between hands. It applies a pending
+// blind change to the game and reports whether the next hand may be dealt.
+func (t *PrivateTable) BeforeHand(g *game.Game) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.blinds != nil {
+		g.SmallBlind, g.BigBlind = t.blinds[0], t.blinds[1]
+		fmt.Fprintf(g.Out, "\n*** The host changed the blinds to %d/%d ***\n", g.SmallBlind, g.BigBlind)
+		t.blinds = nil
+	}
+	return !t.paused && !t.closed
+}
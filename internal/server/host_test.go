@@ -0,0 +1,63 @@
+package server
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+
+	"pokerclientv1/pkg/game"
+)
+
+// TestPrivateTableHostControls walks through a private game: a join is approved,
+// a guest cannot use host controls, a kicked player cannot rejoin, and pausing
+// and blind changes only take effect between hands.
+func TestPrivateTableHostControls(t *testing.T) {
+	table := NewPrivateTable("host", NewSeatManager("private", 6, nil))
+
+	if err := table.RequestJoin("guest"); !errors.Is(err, ErrAwaitingHost) {
+		t.Fatalf("RequestJoin(guest) = %v, want ErrAwaitingHost", err)
+	}
+	if err := table.HandleHostMessage("guest", Message{Type: MsgApprove, Player: "guest"}); !errors.Is(err, ErrNotHost) {
+		t.Errorf("guest approving themselves = %v, want ErrNotHost", err)
+	}
+	if err := table.HandleHostMessage("host", Message{Type: MsgApprove, Player: "guest"}); err != nil {
+		t.Fatalf("host approving guest error: %v", err)
+	}
+	if got := table.Seats.Seated(); !reflect.DeepEqual(got, []string{"host", "guest"}) {
+		t.Errorf("Seated() = %v, want [host guest]", got)
+	}
+
+	if err := table.HandleHostMessage("host", Message{Type: MsgKick, Player: "guest"}); err != nil {
+		t.Fatalf("kick error: %v", err)
+	}
+	if err := table.RequestJoin("guest"); !errors.Is(err, ErrKicked) {
+		t.Errorf("RequestJoin() after a kick = %v, want ErrKicked", err)
+	}
+	if err := table.HandleHostMessage("host", Message{Type: MsgKick, Player: "host"}); !errors.Is(err, ErrKickHostRefused) {
+		t.Errorf("host kicking themselves = %v, want ErrKickHostRefused", err)
+	}
+
+	g := game.NewGame(nil, nil, 0, game.DefaultGameConfig())
+	g.Out = io.Discard
+	table.HandleHostMessage("host", Message{Type: MsgSetBlinds, SmallBlind: 5, BigBlind: 10})
+	table.HandleHostMessage("host", Message{Type: MsgPause})
+	if table.BeforeHand(g) {
+		t.Errorf("BeforeHand() = true while paused, want false")
+	}
+	if g.SmallBlind != 5 || g.BigBlind != 10 {
+		t.Errorf("blinds after BeforeHand() = %d/%d, want 5/10", g.SmallBlind, g.BigBlind)
+	}
+	table.HandleHostMessage("host", Message{Type: MsgResume})
+	if !table.BeforeHand(g) {
+		t.Errorf("BeforeHand() = false after resuming, want true")
+	}
+
+	table.HandleHostMessage("host", Message{Type: MsgClose})
+	if table.BeforeHand(g) || !table.Closed() {
+		t.Errorf("table still deals after being closed")
+	}
+	if err := table.RequestJoin("late"); !errors.Is(err, ErrTableClosed) {
+		t.Errorf("RequestJoin() on a closed table = %v, want ErrTableClosed", err)
+	}
+}
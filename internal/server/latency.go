@@ -0,0 +1,195 @@
+package server
+
+import (
+	"math/rand"
+	"net"
+	"os"
+	"sync"
+	"time"
+)
+
+// Latency is a simulated network delay, used to try turn timeouts,
+// reconnects and slow clients locally before a real deployment.
+type Latency struct {
+	Delay  time.Duration // Added to everything sent in either direction
+	Jitter time.Duration // Up to this much more, picked at random for each write
+	Rng    *rand.Rand    // Optional source of the jitter, the clock when nil
+}
+
+// Enabled reports whether the latency delays anything.
+func (l Latency) Enabled() bool {
+	return l.Delay > 0 || l.Jitter > 0
+}
+
+// WithLatency wraps c so that data in both directions arrives after the
+// simulated delay. Data keeps its order, as on a TCP connection, so jitter
+// delays later writes rather than reordering them. Writes return at once and
+// are delivered in the background; Close delivers what was written first.
+func WithLatency(c net.Conn, l Latency) net.Conn {
+	if !l.Enabled() {
+		return c
+	}
+	if l.Rng == nil {
+		l.Rng = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	lc := &latencyConn{
+		Conn:    c,
+		latency: l,
+		writes:  make(chan delayed, 256),
+		reads:   make(chan delayed, 256),
+		closed:  make(chan struct{}),
+	}
+	go lc.deliverWrites()
+	go lc.receive()
+	return lc
+}
+
+// delayed is a chunk of data held back until at.
+type delayed struct {
+	data []byte
+	err  error
+	at   time.Time
+}
+
+type latencyConn struct {
+	net.Conn
+	latency Latency
+	writes  chan delayed
+	reads   chan delayed
+
+	mu           sync.Mutex
+	lastWrite    time.Time // Delivery time of the last write, so writes stay in order
+	lastRead     time.Time
+	readDeadline time.Time
+	pending      delayed // Rest of the chunk being read
+	closed       chan struct{}
+	closeOnce    sync.Once
+
+	errMu    sync.Mutex // Separate from mu, which Write holds while the queue is full
+	writeErr error      // First error delivering a write
+}
+
+// due returns when data sent now arrives, no earlier than last.
+func (c *latencyConn) due(last *time.Time) time.Time {
+	at := time.Now().Add(c.latency.Delay)
+	if c.latency.Jitter > 0 {
+		at = at.Add(time.Duration(c.latency.Rng.Int63n(int64(c.latency.Jitter))))
+	}
+	if at.Before(*last) {
+		at = *last
+	}
+	*last = at
+	return at
+}
+
+func (c *latencyConn) Write(p []byte) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	select {
+	case <-c.closed:
+		return 0, net.ErrClosed
+	default:
+	}
+	c.errMu.Lock()
+	err := c.writeErr
+	c.errMu.Unlock()
+	if err != nil {
+		return 0, err
+	}
+	c.writes <- delayed{data: append([]byte(nil), p...), at: c.due(&c.lastWrite)}
+	return len(p), nil
+}
+
+// deliverWrites writes each chunk to the connection once it is due, then
+// closes the connection after the last one.
+func (c *latencyConn) deliverWrites() {
+	defer c.Conn.Close()
+	for d := range c.writes {
+		time.Sleep(time.Until(d.at))
+		if _, err := c.Conn.Write(d.data); err != nil {
+			c.errMu.Lock()
+			if c.writeErr == nil {
+				c.writeErr = err
+			}
+			c.errMu.Unlock()
+		}
+	}
+}
+
+// receive reads from the connection and holds each chunk back until it is due.
+func (c *latencyConn) receive() {
+	buf := make([]byte, 32*1024)
+	for {
+		n, err := c.Conn.Read(buf)
+		c.mu.Lock()
+		d := delayed{data: append([]byte(nil), buf[:n]...), err: err, at: c.due(&c.lastRead)}
+		c.mu.Unlock()
+		select {
+		case c.reads <- d:
+		case <-c.closed:
+			return
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+func (c *latencyConn) Read(p []byte) (int, error) {
+	c.mu.Lock()
+	deadline := c.readDeadline
+	c.mu.Unlock()
+	if len(c.pending.data) == 0 && c.pending.err == nil {
+		var timeout <-chan time.Time
+		if !deadline.IsZero() {
+			timer := time.NewTimer(time.Until(deadline))
+			defer timer.Stop()
+			timeout = timer.C
+		}
+		select {
+		case c.pending = <-c.reads:
+		case <-timeout:
+			return 0, os.ErrDeadlineExceeded
+		case <-c.closed:
+			return 0, net.ErrClosed
+		}
+	}
+	// The data has been received but is not due yet
+	if !deadline.IsZero() && deadline.Before(c.pending.at) {
+		time.Sleep(time.Until(deadline))
+		return 0, os.ErrDeadlineExceeded
+	}
+	time.Sleep(time.Until(c.pending.at))
+	n := copy(p, c.pending.data)
+	c.pending.data = c.pending.data[n:]
+	if n == 0 && c.pending.err != nil {
+		return 0, c.pending.err
+	}
+	return n, nil
+}
+
+// SetDeadline sets the read deadline, which applies to delayed data, and the
+// write deadline of the underlying connection.
+func (c *latencyConn) SetDeadline(t time.Time) error {
+	c.SetReadDeadline(t)
+	return c.Conn.SetWriteDeadline(t)
+}
+
+func (c *latencyConn) SetReadDeadline(t time.Time) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.readDeadline = t
+	return nil
+}
+
+// Close stops reading at once and closes the connection once everything
+// written has been delivered.
+func (c *latencyConn) Close() error {
+	c.closeOnce.Do(func() {
+		c.mu.Lock()
+		close(c.closed)
+		close(c.writes)
+		c.mu.Unlock()
+	})
+	return nil
+}
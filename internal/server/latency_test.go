@@ -0,0 +1,95 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"math/rand"
+	"net"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"pokerclientv1/pkg/game"
+	"pokerclientv1/pkg/gametest"
+	"pokerclientv1/pkg/types"
+)
+
+// TestWithLatency checks that messages arrive late but in order in both
+// directions, and that read deadlines still apply.
+func TestWithLatency(t *testing.T) {
+	near, far := net.Pipe()
+	defer far.Close()
+	c := WithLatency(near, Latency{Delay: 30 * time.Millisecond, Jitter: 20 * time.Millisecond, Rng: rand.New(rand.NewSource(1))})
+	defer c.Close()
+
+	sent := time.Now()
+	go func() {
+		for _, line := range []string{"one\n", "two\n", "three\n"} {
+			c.Write([]byte(line))
+		}
+	}()
+	lines := bufio.NewReader(far)
+	for _, want := range []string{"one\n", "two\n", "three\n"} {
+		got, err := lines.ReadString('\n')
+		if err != nil || got != want {
+			t.Fatalf("read %q, %v, want %q", got, err, want)
+		}
+	}
+	if elapsed := time.Since(sent); elapsed < 30*time.Millisecond {
+		t.Errorf("writes arrived after %s, want at least the 30ms delay", elapsed)
+	}
+
+	c.SetReadDeadline(time.Now().Add(10 * time.Millisecond))
+	go far.Write([]byte("late\n"))
+	buf := make([]byte, 16)
+	if _, err := c.Read(buf); !errors.Is(err, os.ErrDeadlineExceeded) {
+		t.Errorf("Read() before the delayed data arrived = %v, want a deadline error", err)
+	}
+	c.SetReadDeadline(time.Time{})
+	if n, err := c.Read(buf); err != nil || string(buf[:n]) != "late\n" {
+		t.Errorf("Read() = %q, %v, want the delayed line", buf[:n], err)
+	}
+}
+
+// TestServerLatencyTimesOut checks that a client slowed down by the network
+// runs out of time to act and is folded for.
+func TestServerLatencyTimesOut(t *testing.T) {
+	bot := gametest.NewScriptedPlayer("Bot", 100)
+	srv := NewServer(Config{
+		Addr:          "127.0.0.1:0",
+		WebSocketAddr: "127.0.0.1:0",
+		Humans:        1,
+		Bots:          []types.Player{bot},
+		Rules:         game.DefaultGameConfig(),
+		StartingChips: 100,
+		TurnTimeout:   50 * time.Millisecond,
+		Latency:       Latency{Delay: 40 * time.Millisecond},
+	})
+	if err := srv.Listen(); err != nil {
+		t.Fatalf("Listen() error: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	serverDone := make(chan error)
+	go func() { serverDone <- srv.Run(ctx) }()
+
+	alice := dialWebSocket(t, srv.WebSocketAddr().String())
+	alice.send(Message{Type: MsgJoin, Player: "Alice"})
+	alice.next(SrvTurn)
+	alice.send(Message{Type: MsgAction, Action: "call"}) // Arrives after the 50ms turn
+	if m := alice.next(SrvError); !strings.Contains(m.Text, "ran out") {
+		t.Errorf("error = %q, want the time to act to have run out", m.Text)
+	}
+	alice.send(Message{Type: MsgLeave})
+
+	select {
+	case err := <-serverDone:
+		if err != nil {
+			t.Errorf("Run() error: %v", err)
+		}
+	case <-ctx.Done():
+		t.Fatalf("server did not stop after the only player left")
+	}
+}
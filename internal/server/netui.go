@@ -0,0 +1,89 @@
+package server
+
+import (
+	"sync"
+
+	"pokerclientv1/pkg/game"
+	"pokerclientv1/pkg/types"
+)
+
+// NetworkUI implements types.GameUI by sending the game to every connected
+// client. Each client only sees its own hole cards.
+type NetworkUI struct {
+	mu      sync.Mutex
+	clients map[string]*conn // Keyed by player ID
+
+	// The table last displayed, sent again when a player's turn clock starts
+	table   *types.Table
+	players []types.Player
+	pot     int
+	stage   string
+}
+
+// NewNetworkUI creates a UI with no clients.
+func NewNetworkUI() *NetworkUI {
+	return &NetworkUI{clients: make(map[string]*conn)}
+}
+
+// add starts sending the game to a player's connection.
+func (ui *NetworkUI) add(playerID string, c *conn) {
+	ui.mu.Lock()
+	defer ui.mu.Unlock()
+	ui.clients[playerID] = c
+}
+
+// remove stops sending to a player.
+func (ui *NetworkUI) remove(playerID string) {
+	ui.mu.Lock()
+	defer ui.mu.Unlock()
+	delete(ui.clients, playerID)
+}
+
+// Broadcast sends a message to every client.
+func (ui *NetworkUI) Broadcast(m ServerMessage) {
+	ui.mu.Lock()
+	defer ui.mu.Unlock()
+	for _, c := range ui.clients {
+		c.send(m)
+	}
+}
+
+func (ui *NetworkUI) DisplayGameState(table *types.Table, players []types.Player, pot int, stage string) {
+	ui.mu.Lock()
+	defer ui.mu.Unlock()
+	ui.table, ui.players, ui.pot, ui.stage = table, players, pot, stage
+	ui.sendState()
+}
+
+// sendState sends each client the table last displayed, as they may see it.
+// The caller holds ui.mu.
+func (ui *NetworkUI) sendState() {
+	for viewer, c := range ui.clients {
+		c.send(ServerMessage{Type: SrvState, State: game.TableStateFor(viewer, ui.table, ui.players, ui.pot, ui.stage)})
+	}
+}
+
+func (ui *NetworkUI) LogAction(playerID string, action string, amount int) {
+	ui.Broadcast(ServerMessage{Type: SrvLog, PlayerID: playerID, Action: action, Amount: amount})
+}
+
+func (ui *NetworkUI) ClearScreen() {
+	ui.Broadcast(ServerMessage{Type: SrvClear})
+}
+
+// HandleEvent forwards public game events. Hole cards are never forwarded,
+// players see their own in the table state. When the action comes to a
+// player on the turn clock, the state is sent again with their deadline.
+func (ui *NetworkUI) HandleEvent(e game.Event) {
+	switch e.Type {
+	case game.EventActionOn:
+		ui.mu.Lock()
+		defer ui.mu.Unlock()
+		if ui.table != nil && !ui.table.Deadline.IsZero() {
+			ui.sendState()
+		}
+	case game.EventHandStarted, game.EventStreetDealt, game.EventBetReturned, game.EventShowdown, game.EventHandMucked, game.EventPotAwarded, game.EventHandEnded, game.EventHandAbandoned,
+		game.EventPlayerEliminated, game.EventRebuy, game.EventCashedOut, game.EventGameOver, game.EventChat:
+		ui.Broadcast(ServerMessage{Type: SrvEvent, Event: &e})
+	}
+}
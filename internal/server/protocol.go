@@ -0,0 +1,123 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"unicode/utf8"
+
+	"pokerclientv1/pkg/game"
+	"pokerclientv1/pkg/types"
+)
+
+// MaxMessageSize is the longest protocol message a client may send, in bytes.
+const MaxMessageSize = 4096
+
+// Message types a client may send.
+const (
+	MsgJoin   = "join"   // Take a seat as Player
+	MsgAction = "action" // Act on the current turn
+	MsgPing   = "ping"   // Keep the connection alive
+	MsgLeave  = "leave"  // Give up the seat
+	MsgChat   = "chat"   // Say Text to the table
+
+	// Host-only messages for private tables
+	MsgApprove   = "approve"    // Seat Player, who asked to join
+	MsgKick      = "kick"       // Remove Player from the table for good
+	MsgPause     = "pause"      // Stop dealing after the current hand
+	MsgResume    = "resume"     // Continue dealing
+	MsgSetBlinds = "set_blinds" // Change the blinds from the next hand
+	MsgClose     = "close"      // Close the table after the current hand
+)
+
+// validActions are the betting actions accepted in an action message.
+var validActions = map[string]bool{"fold": true, "check": true, "call": true, "raise": true, "all-in": true}
+
+// ErrInvalidMessage is wrapped by every validation error.
+var ErrInvalidMessage = errors.New("invalid message")
+
+// Message is one line of the client/server protocol, encoded as JSON.
+type Message struct {
+	Type    string       `json:"type"`
+	Player  string       `json:"player,omitempty"`  // Player ID for join
+	Session string       `json:"session,omitempty"` // Token from the welcome message, to rejoin after a disconnect
+	Avatar  string       `json:"avatar,omitempty"`  // Emoji or short glyph shown next to the player's name, for join
+	Action  string       `json:"action,omitempty"`  // fold, check, call, raise or all-in
+	Amount  types.Amount `json:"amount,omitempty"`  // Chips to add to the pot for raise, a number or a string like "1.5k"
+	BuyIn   types.Amount `json:"buyIn,omitempty"`   // Chips to sit down with for join, the table's starting stack when 0
+
+	// Password for join as a registered player, which registers the name
+	// the first time it is used. Guests send none.
+	Password string `json:"password,omitempty"`
+
+	SmallBlind types.Amount `json:"smallBlind,omitempty"` // New blinds for set_blinds
+	BigBlind   types.Amount `json:"bigBlind,omitempty"`
+
+	Text string `json:"text,omitempty"` // What to say for chat, up to game.MaxChatLength characters
+}
+
+// DecodeMessage parses a message strictly: unknown fields, trailing data and
+// oversized messages are rejected, and the result must pass Validate.
+func DecodeMessage(line []byte) (Message, error) {
+	var m Message
+	if len(line) > MaxMessageSize {
+		return m, fmt.Errorf("%w: %d bytes exceeds the %d byte limit", ErrInvalidMessage, len(line), MaxMessageSize)
+	}
+	dec := json.NewDecoder(bytes.NewReader(line))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&m); err != nil {
+		return m, fmt.Errorf("%w: %v", ErrInvalidMessage, err)
+	}
+	if dec.More() {
+		return m, fmt.Errorf("%w: trailing data after message", ErrInvalidMessage)
+	}
+	return m, m.Validate()
+}
+
+// Validate checks that the fields make sense for the message type.
+func (m Message) Validate() error {
+	switch m.Type {
+	case MsgJoin, MsgApprove, MsgKick:
+		if m.Player == "" || len(m.Player) > 32 {
+			return fmt.Errorf("%w: player ID must be 1-32 characters", ErrInvalidMessage)
+		}
+		if len(m.Session) > 64 {
+			return fmt.Errorf("%w: session token is too long", ErrInvalidMessage)
+		}
+		if len(m.Password) > 64 {
+			return fmt.Errorf("%w: password is too long", ErrInvalidMessage)
+		}
+		if err := types.ValidateAvatar(m.Avatar); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidMessage, err)
+		}
+		if m.BuyIn < 0 {
+			return fmt.Errorf("%w: negative buy-in %d", ErrInvalidMessage, m.BuyIn)
+		}
+	case MsgAction:
+		if !validActions[m.Action] {
+			return fmt.Errorf("%w: unknown action %q", ErrInvalidMessage, m.Action)
+		}
+		if m.Amount < 0 {
+			return fmt.Errorf("%w: negative amount %d", ErrInvalidMessage, m.Amount)
+		}
+		if m.Action == "raise" && m.Amount == 0 {
+			return fmt.Errorf("%w: raise without an amount", ErrInvalidMessage)
+		}
+		if m.Action != "raise" && m.Amount != 0 {
+			return fmt.Errorf("%w: %s does not take an amount", ErrInvalidMessage, m.Action)
+		}
+	case MsgSetBlinds:
+		if m.SmallBlind <= 0 || m.BigBlind < m.SmallBlind {
+			return fmt.Errorf("%w: blinds %d/%d are not valid", ErrInvalidMessage, m.SmallBlind, m.BigBlind)
+		}
+	case MsgChat:
+		if game.CleanChat(m.Text) == "" || utf8.RuneCountInString(m.Text) > game.MaxChatLength {
+			return fmt.Errorf("%w: chat must be 1-%d characters", ErrInvalidMessage, game.MaxChatLength)
+		}
+	case MsgPing, MsgLeave, MsgPause, MsgResume, MsgClose:
+	default:
+		return fmt.Errorf("%w: unknown type %q", ErrInvalidMessage, m.Type)
+	}
+	return nil
+}
@@ -0,0 +1,214 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"pokerclientv1/pkg/game"
+	"pokerclientv1/pkg/types"
+)
+
+// RemotePlayer implements types.Player for a human connected over the
+// network. TakeTurn sends the options to the client and waits for its answer.
+type RemotePlayer struct {
+	types.BasePlayer
+	Avatar      string         // Sent by the client when joining, shown next to the name
+	TurnTimeout time.Duration  // Time to act before checking or folding, 0 waits forever
+	session     string         // Token the client presents to reconnect
+	registered  bool           // Bought in from an account, which gets the chips back on leaving
+	timebanks   *TimebankStore // Optional; time bank used once TurnTimeout runs out, kept by player ID
+	log         io.Writer      // Where time bank save errors are reported, the server's Log
+
+	mu   sync.Mutex
+	conn *conn
+	left bool // The player sent leave and may not reconnect
+}
+
+// newRemotePlayer creates a player for an accepted connection.
+func newRemotePlayer(id string, chips int, c *conn, turnTimeout time.Duration) *RemotePlayer {
+	return &RemotePlayer{BasePlayer: types.NewBasePlayer(id, chips), TurnTimeout: turnTimeout, conn: c}
+}
+
+func (p *RemotePlayer) IsHuman() bool     { return true }
+func (p *RemotePlayer) GetAvatar() string { return p.Avatar }
+
+// Connected reports whether the client is still connected.
+func (p *RemotePlayer) Connected() bool { return !p.connection().closed() }
+
+// Disconnected reports how long ago the client's connection ended, or zero
+// while it is connected.
+func (p *RemotePlayer) Disconnected() time.Duration {
+	c := p.connection()
+	if !c.closed() {
+		return 0
+	}
+	return time.Since(c.closedAt)
+}
+
+// Left reports whether the player gave up their seat with a leave message.
+func (p *RemotePlayer) Left() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.left
+}
+
+// leave marks the player as gone for good.
+func (p *RemotePlayer) leave() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.left = true
+}
+
+// connection returns the player's current connection.
+func (p *RemotePlayer) connection() *conn {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.conn
+}
+
+// attach moves the player to a new connection, closing the old one.
+func (p *RemotePlayer) attach(c *conn) {
+	p.mu.Lock()
+	old := p.conn
+	p.conn = c
+	p.mu.Unlock()
+	old.close()
+}
+
+// TimeBank implements types.TimeBanked with what is left of the player's
+// time bank. The server's TimebankStore keeps it by player ID, so leaving and
+// joining again does not refill it.
+func (p *RemotePlayer) TimeBank() time.Duration {
+	if p.timebanks == nil {
+		return 0
+	}
+	return p.timebanks.Restore(p.ID).Remaining
+}
+
+// TakeTurn asks the client for an action. Invalid actions are answered with
+// an error and the client is asked again; a disconnected or timed-out player
+// checks if possible and folds otherwise. Time taken past TurnTimeout comes
+// out of the time bank, and a player folded for running out of it has the
+// fold counted against them.
+func (p *RemotePlayer) TakeTurn(table *types.Table, currentBet int, minRaise int) (action string, amount int) {
+	legal := p.Legal(table, currentBet, minRaise)
+	bank := p.TimeBank()
+	turn := ServerMessage{Type: SrvTurn, Turn: &TurnInfo{
+		CurrentBet: currentBet,
+		PlayerBet:  p.CurrentBet,
+		ToCall:     currentBet - p.CurrentBet,
+		MinRaise:   minRaise,
+		Chips:      p.Chips,
+		TimeBank:   bank.Milliseconds(),
+		Legal:      &legal,
+	}}
+
+	var timeout <-chan time.Time
+	if p.TurnTimeout > 0 {
+		timeout = time.After(p.TurnTimeout + bank)
+	}
+	started := time.Now()
+	action, amount, timedOut := p.ask(turn, legal, timeout)
+	if p.timebanks != nil && p.TurnTimeout > 0 {
+		// The store keeps both in memory even when it cannot save them, but a
+		// restarted server would refill the bank, so failures are reported
+		if over := time.Since(started) - p.TurnTimeout; over > 0 {
+			if _, err := p.timebanks.Consume(p.ID, over); err != nil {
+				p.logf("Saving %s's time bank: %v\n", p.ID, err)
+			}
+		}
+		if timedOut && action == "fold" {
+			if _, err := p.timebanks.RecordAutoFold(p.ID); err != nil {
+				p.logf("Saving %s's auto-fold: %v\n", p.ID, err)
+			}
+		}
+	}
+	return action, amount
+}
+
+// logf writes to the player's log, if it has one.
+func (p *RemotePlayer) logf(format string, args ...interface{}) {
+	if p.log != nil {
+		fmt.Fprintf(p.log, format, args...)
+	}
+}
+
+// ask sends the turn to the client until it answers with an action legal
+// allows. timedOut is true when it had to check or fold for the client
+// because timeout fired.
+func (p *RemotePlayer) ask(turn ServerMessage, legal game.LegalActions, timeout <-chan time.Time) (action string, amount int, timedOut bool) {
+	c := p.connection()
+
+	// Actions sent before the turn started are stale
+	select {
+	case <-c.actions:
+	default:
+	}
+
+	for {
+		if err := c.send(turn); err != nil {
+			action, amount := legal.Fit("check", 0)
+			return action, amount, false
+		}
+		select {
+		case m := <-c.actions:
+			action, amount, err := p.resolve(m, legal)
+			if err != nil {
+				c.send(ServerMessage{Type: SrvError, Text: err.Error()})
+				continue
+			}
+			return action, amount, false
+		case <-c.done:
+			action, amount := legal.Fit("check", 0)
+			return action, amount, false
+		case <-timeout:
+			c.send(ServerMessage{Type: SrvError, Text: "time to act ran out"})
+			action, amount := legal.Fit("check", 0)
+			return action, amount, true
+		}
+	}
+}
+
+// resolve turns a client's action message into the action and chips to add
+// that the game expects, rejecting actions that legal does not allow.
+func (p *RemotePlayer) resolve(m Message, legal game.LegalActions) (string, int, error) {
+	switch m.Action {
+	case "fold":
+		return "fold", 0, nil
+	case "check":
+		if !legal.Can("check") {
+			return "", 0, fmt.Errorf("cannot check, there is a bet of %d to call", legal.ToCall)
+		}
+		return "check", 0, nil
+	case "call":
+		if !legal.Can("call") {
+			return "check", 0, nil
+		}
+		return "call", legal.ToCall, nil // All-in for less when short
+	case "raise":
+		total := legal.Bet + int(m.Amount)
+		switch {
+		case !legal.Can("raise"):
+			return "", 0, fmt.Errorf("you cannot raise now")
+		case total > legal.MaxRaise && int(m.Amount) > p.Chips:
+			return "", 0, fmt.Errorf("cannot raise %d, only %d chips left", int(m.Amount), p.Chips)
+		case total > legal.MaxRaise:
+			return "", 0, fmt.Errorf("maximum raise is to %d", legal.MaxRaise)
+		case total < legal.MinRaise:
+			return "", 0, fmt.Errorf("minimum raise is to %d", legal.MinRaise)
+		}
+		return "raise", int(m.Amount), nil
+	case "all-in":
+		if legal.Can("raise") && legal.MaxRaise == legal.Bet+p.Chips {
+			return "raise", p.Chips, nil
+		}
+		if legal.Can("call") && legal.ToCall == p.Chips {
+			return "call", p.Chips, nil
+		}
+		return "", 0, fmt.Errorf("cannot go all-in now")
+	}
+	return "", 0, errors.New("unknown action")
+}
@@ -0,0 +1,126 @@
+package server
+
+import (
+	"errors"
+	"sync"
+)
+
+// Seating errors.
+var (
+	ErrAlreadyJoined = errors.New("player is already seated or waiting at this table")
+	ErrNotAtTable    = errors.New("player is not seated or waiting at this table")
+)
+
+// NoticeKind says what a lobby notification is about.
+type NoticeKind string
+
+const (
+	NoticeSeated     NoticeKind = "seated"     // The player took a seat
+	NoticeWaitlisted NoticeKind = "waitlisted" // The table is full, the player is at Position in the waitlist
+	NoticeMoved      NoticeKind = "moved"      // The player's waitlist position changed to Position
+	NoticeLeft       NoticeKind = "left"       // The player left the table or the waitlist
+)
+
+// Notice is sent to the lobby when a table's seating changes.
+type Notice struct {
+	Table    string     `json:"table"`
+	PlayerID string     `json:"player"`
+	Kind     NoticeKind `json:"kind"`
+	Position int        `json:"position,omitempty"` // 1-based waitlist position
+}
+
+// SeatManager tracks who is seated at a table and who is waiting for a seat.
+// When a seated player leaves, the first player on the waitlist is seated
+// automatically. It is safe for concurrent use.
+type SeatManager struct {
+	mu       sync.Mutex
+	table    string
+	capacity int
+	seated   []string
+	waitlist []string
+	notify   func(Notice)
+}
+
+// NewSeatManager creates an empty table with the given number of seats.
+// notify receives every seating change and may be nil.
+func NewSeatManager(table string, capacity int, notify func(Notice)) *SeatManager {
+	if notify == nil {
+		notify = func(Notice) {}
+	}
+	return &SeatManager{table: table, capacity: capacity, notify: notify}
+}
+
+// Join seats the player if a seat is free, or adds them to the waitlist.
+// It returns whether the player was seated and otherwise their waitlist position.
+func (m *SeatManager) Join(playerID string) (seated bool, position int, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if indexOf(m.seated, playerID) >= 0 || indexOf(m.waitlist, playerID) >= 0 {
+		return false, 0, ErrAlreadyJoined
+	}
+	if len(m.seated) < m.capacity {
+		m.seated = append(m.seated, playerID)
+		m.notify(Notice{Table: m.table, PlayerID: playerID, Kind: NoticeSeated})
+		return true, 0, nil
+	}
+	m.waitlist = append(m.waitlist, playerID)
+	position = len(m.waitlist)
+	m.notify(Notice{Table: m.table, PlayerID: playerID, Kind: NoticeWaitlisted, Position: position})
+	return false, position, nil
+}
+
+// Leave removes a player from their seat or from the waitlist. A freed seat
+// goes to the first waiting player and everyone behind moves up.
+func (m *SeatManager) Leave(playerID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if i := indexOf(m.seated, playerID); i >= 0 {
+		m.seated = append(m.seated[:i], m.seated[i+1:]...)
+		m.notify(Notice{Table: m.table, PlayerID: playerID, Kind: NoticeLeft})
+		if len(m.waitlist) > 0 {
+			next := m.waitlist[0]
+			m.seated = append(m.seated, next)
+			m.notify(Notice{Table: m.table, PlayerID: next, Kind: NoticeSeated})
+			m.removeWaiting(0)
+		}
+		return nil
+	}
+	if i := indexOf(m.waitlist, playerID); i >= 0 {
+		m.notify(Notice{Table: m.table, PlayerID: playerID, Kind: NoticeLeft})
+		m.removeWaiting(i)
+		return nil
+	}
+	return ErrNotAtTable
+}
+
+// removeWaiting drops waitlist entry i and tells the players behind it their
+// new position. Callers must hold m.mu.
+func (m *SeatManager) removeWaiting(i int) {
+	m.waitlist = append(m.waitlist[:i], m.waitlist[i+1:]...)
+	for j := i; j < len(m.waitlist); j++ {
+		m.notify(Notice{Table: m.table, PlayerID: m.waitlist[j], Kind: NoticeMoved, Position: j + 1})
+	}
+}
+
+// Seated returns the seated players in the order they sat down.
+func (m *SeatManager) Seated() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]string(nil), m.seated...)
+}
+
+// Waitlist returns the waiting players, first in line first.
+func (m *SeatManager) Waitlist() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]string(nil), m.waitlist...)
+}
+
+func indexOf(ids []string, id string) int {
+	for i, v := range ids {
+		if v == id {
+			return i
+		}
+	}
+	return -1
+}
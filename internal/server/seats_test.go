@@ -0,0 +1,55 @@
+package server
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// TestWaitlistSeatsNextPlayer fills a two-seat table, queues two players and
+// checks that a leaving player's seat goes to the head of the waitlist.
+func TestWaitlistSeatsNextPlayer(t *testing.T) {
+	notices := []Notice{}
+	m := NewSeatManager("t1", 2, func(n Notice) { notices = append(notices, n) })
+
+	for _, id := range []string{"A", "B"} {
+		if seated, _, err := m.Join(id); err != nil || !seated {
+			t.Fatalf("Join(%s) = %v, %v, want seated", id, seated, err)
+		}
+	}
+	for i, id := range []string{"C", "D"} {
+		seated, pos, err := m.Join(id)
+		if err != nil || seated || pos != i+1 {
+			t.Fatalf("Join(%s) = %v, %d, %v, want waitlist position %d", id, seated, pos, err, i+1)
+		}
+	}
+	if _, _, err := m.Join("C"); !errors.Is(err, ErrAlreadyJoined) {
+		t.Errorf("Join(C) twice = %v, want ErrAlreadyJoined", err)
+	}
+
+	notices = nil
+	if err := m.Leave("A"); err != nil {
+		t.Fatalf("Leave(A) error: %v", err)
+	}
+	if got := m.Seated(); !reflect.DeepEqual(got, []string{"B", "C"}) {
+		t.Errorf("Seated() = %v, want [B C]", got)
+	}
+	if got := m.Waitlist(); !reflect.DeepEqual(got, []string{"D"}) {
+		t.Errorf("Waitlist() = %v, want [D]", got)
+	}
+	want := []Notice{
+		{Table: "t1", PlayerID: "A", Kind: NoticeLeft},
+		{Table: "t1", PlayerID: "C", Kind: NoticeSeated},
+		{Table: "t1", PlayerID: "D", Kind: NoticeMoved, Position: 1},
+	}
+	if !reflect.DeepEqual(notices, want) {
+		t.Errorf("notices = %+v, want %+v", notices, want)
+	}
+
+	if err := m.Leave("D"); err != nil || len(m.Waitlist()) != 0 {
+		t.Errorf("Leave(D) from the waitlist = %v, waitlist %v", err, m.Waitlist())
+	}
+	if err := m.Leave("Z"); !errors.Is(err, ErrNotAtTable) {
+		t.Errorf("Leave(Z) = %v, want ErrNotAtTable", err)
+	}
+}
@@ -0,0 +1,503 @@
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"pokerclientv1/internal/player"
+	"pokerclientv1/pkg/game"
+	"pokerclientv1/pkg/types"
+)
+
+// Config describes a networked table.
+type Config struct {
+	Addr          string          // Address to listen on, such as ":9000", or "unix:/path/to/socket" for a local socket
+	Humans        int             // Remote players to wait for before dealing
+	Bots          []types.Player  // Players seated after the humans
+	Rules         game.GameConfig // Stakes and betting rules
+	StartingChips int             // Stack for each remote player
+	GameSpeed     time.Duration   // Delay between steps
+	TurnTimeout   time.Duration   // Time a remote player has to act, 0 waits forever
+	JoinTimeout   time.Duration   // Time a new connection has to send its join message
+	Reconnect     time.Duration   // How long a disconnected player keeps their seat, 0 frees it at the next hand
+	Timebanks     *TimebankStore  // Optional extra time to act once TurnTimeout runs out, kept by name across reconnects
+	WebSocketAddr string          // Optional address to accept browser clients on at /ws
+	Guard         GuardConfig     // Validation and rate limits for client messages
+	Audit         *AuditLog       // Optional log of misbehaving clients
+	Health        *Health         // Optional readiness tracker, used to drain on shutdown
+	Latency       Latency         // Simulated network delay for every client, for testing
+	Log           io.Writer       // Server progress output, discarded when nil
+	Banter        bool            // Let the bots chat at the table, see player.TableTalk
+	OnEvent       game.EventHandler
+
+	// Registered players, who join with a password and buy in from a
+	// balance kept between games. Without accounts everyone is a guest.
+	// RegisteredOnly turns guests away.
+	Accounts       *Accounts
+	RegisteredOnly bool
+}
+
+// Server hosts one table for remote players and bots.
+type Server struct {
+	cfg      Config
+	listener net.Listener
+	wsServer *http.Server
+	wsListen net.Listener
+	ui       *NetworkUI
+	seats    *SeatManager
+	health   *Health
+
+	mu       sync.Mutex
+	remotes  map[string]*RemotePlayer // Every connected player, seated or waiting
+	arrivals []string                 // Players given a seat who are not yet in the game
+	started  bool
+	seated   chan struct{} // Signalled when a player takes a seat before the game starts
+}
+
+// NewServer creates a server; call Listen and then Run.
+func NewServer(cfg Config) *Server {
+	if cfg.Log == nil {
+		cfg.Log = io.Discard
+	}
+	if cfg.JoinTimeout == 0 {
+		cfg.JoinTimeout = 10 * time.Second
+	}
+	if cfg.Guard == (GuardConfig{}) {
+		cfg.Guard = DefaultGuardConfig()
+	}
+	health := cfg.Health
+	if health == nil {
+		health = NewHealth()
+	}
+	s := &Server{
+		cfg:     cfg,
+		ui:      NewNetworkUI(),
+		health:  health,
+		remotes: make(map[string]*RemotePlayer),
+		seated:  make(chan struct{}, 1),
+	}
+	s.seats = NewSeatManager("main", cfg.Humans, s.notice)
+	return s
+}
+
+// Listen binds the listening sockets. A stale Unix socket left behind by a
+// server that did not shut down cleanly is replaced.
+func (s *Server) Listen() error {
+	network, addr := splitAddr(s.cfg.Addr)
+	if network == "unix" {
+		if info, err := os.Stat(addr); err == nil && info.Mode()&os.ModeSocket != 0 {
+			os.Remove(addr)
+		}
+	}
+	l, err := net.Listen(network, addr)
+	if err != nil {
+		return err
+	}
+	s.listener = l
+	if s.cfg.WebSocketAddr != "" {
+		ws, err := net.Listen("tcp", s.cfg.WebSocketAddr)
+		if err != nil {
+			l.Close()
+			return err
+		}
+		mux := http.NewServeMux()
+		mux.Handle("/ws", s.WebSocketHandler())
+		s.wsListen = ws
+		s.wsServer = &http.Server{Handler: mux, ReadHeaderTimeout: s.cfg.JoinTimeout}
+	}
+	return nil
+}
+
+// splitAddr returns the network and address to dial or listen on: "unix"
+// for addresses of the form "unix:/path/to/socket", "tcp" otherwise.
+func splitAddr(addr string) (network, address string) {
+	if path, ok := strings.CutPrefix(addr, "unix:"); ok {
+		return "unix", path
+	}
+	return "tcp", addr
+}
+
+// Addr returns the address the server listens on.
+func (s *Server) Addr() net.Addr {
+	return s.listener.Addr()
+}
+
+// WebSocketAddr returns the address browser clients connect to, or nil when
+// WebSockets are not enabled.
+func (s *Server) WebSocketAddr() net.Addr {
+	if s.wsListen == nil {
+		return nil
+	}
+	return s.wsListen.Addr()
+}
+
+// WebSocketHandler accepts browser clients. Each WebSocket text message
+// carries one protocol message, in both directions.
+func (s *Server) WebSocketHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ws, err := upgradeWebSocket(w, r)
+		if err != nil {
+			fmt.Fprintf(s.cfg.Log, "WebSocket from %s refused: %v\n", r.RemoteAddr, err)
+			return
+		}
+		s.handle(newConn(WithLatency(ws, s.cfg.Latency)))
+	})
+}
+
+// TableRules describes the stakes and buy-in range for the lobby, e.g.
+// "Table: 1/2 no-limit, buy-in 40-1000 (20-500 bb), 200 chips by default."
+func (s *Server) TableRules() string {
+	rules := "Table: " + s.cfg.Rules.String()
+	if buyIn := s.cfg.Rules.BuyInString(); buyIn != "" {
+		rules += ", " + buyIn
+	}
+	return fmt.Sprintf("%s, %d chips by default.", rules, s.cfg.StartingChips)
+}
+
+// Run accepts players, waits for the table to fill and plays hands until the
+// game is over or ctx is cancelled. On cancellation the hand in progress is
+// finished before the server stops.
+func (s *Server) Run(ctx context.Context) error {
+	if err := s.cfg.Rules.CheckBuyIn(s.cfg.StartingChips); err != nil {
+		return fmt.Errorf("starting chips: %w", err)
+	}
+	if s.listener == nil {
+		if err := s.Listen(); err != nil {
+			return err
+		}
+	}
+	defer s.listener.Close()
+	go s.acceptLoop()
+	if s.wsServer != nil {
+		go s.wsServer.Serve(s.wsListen)
+		defer s.wsServer.Close()
+	}
+	s.health.SetReady(true)
+	fmt.Fprintf(s.cfg.Log, "Waiting for %d players on %s\n%s\n", s.cfg.Humans, s.Addr(), s.TableRules())
+
+	for len(s.seats.Seated()) < s.cfg.Humans {
+		select {
+		case <-s.seated:
+		case <-ctx.Done():
+			s.shutdown("The server is shutting down.")
+			return nil
+		}
+	}
+
+	g := s.startGame()
+	for ctx.Err() == nil && s.syncSeats(g) && s.health.BeginHand() {
+		more := g.PlayNextHand()
+		s.health.EndHand()
+		if !more {
+			break
+		}
+	}
+	s.ui.HandleEvent(game.Event{Type: game.EventGameOver, HandNumber: g.HandNumber})
+	s.shutdown("The game is over.")
+	return nil
+}
+
+// startGame seats the players who joined and the bots.
+func (s *Server) startGame() *game.Game {
+	seated := s.seats.Seated()
+	s.mu.Lock()
+	players := []types.Player{}
+	for _, id := range seated {
+		players = append(players, s.remotes[id])
+	}
+	s.arrivals = nil
+	s.started = true
+	s.mu.Unlock()
+	players = append(players, s.cfg.Bots...)
+
+	g := game.NewGame(players, s.ui, s.cfg.GameSpeed, s.cfg.Rules)
+	g.Out = s.cfg.Log
+	g.TurnClock = s.cfg.TurnTimeout
+	g.OnEvent(s.ui.HandleEvent)
+	if s.cfg.OnEvent != nil {
+		g.OnEvent(s.cfg.OnEvent)
+	}
+	if s.cfg.Banter {
+		g.OnEvent(player.NewTableTalk(players, g.Chat).HandleEvent)
+	}
+	fmt.Fprintln(s.cfg.Log, "Starting the game")
+	return g
+}
+
+// syncSeats runs between hands: busted remote players and those disconnected
+// for longer than Config.Reconnect leave, players who got their seat from the
+// waitlist sit down, waiting there for the big blind. It returns false once
+// no remote player is left.
+func (s *Server) syncSeats(g *game.Game) bool {
+	for _, seat := range g.Seats() {
+		remote, ok := seat.Player.(*RemotePlayer)
+		if !ok || (remote.Chips > 0 && !remote.Left() && (remote.Connected() || remote.Disconnected() < s.cfg.Reconnect)) {
+			continue
+		}
+		g.Unseat(remote.ID)
+		s.drop(remote.ID, "You have left the table.")
+	}
+
+	s.mu.Lock()
+	for _, id := range s.arrivals {
+		if remote, ok := s.remotes[id]; ok {
+			g.Seat(remote)
+			fmt.Fprintf(s.cfg.Log, "%s sits down\n", id)
+		}
+	}
+	s.arrivals = nil
+	s.mu.Unlock()
+
+	for _, seat := range g.Seats() {
+		if _, ok := seat.Player.(*RemotePlayer); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// notice forwards seating changes to the player concerned.
+func (s *Server) notice(n Notice) {
+	s.mu.Lock()
+	remote := s.remotes[n.PlayerID]
+	if n.Kind == NoticeSeated {
+		if s.started {
+			s.arrivals = append(s.arrivals, n.PlayerID)
+		} else {
+			select {
+			case s.seated <- struct{}{}:
+			default:
+			}
+		}
+	}
+	s.mu.Unlock()
+	fmt.Fprintf(s.cfg.Log, "%s: %s\n", n.PlayerID, n.Kind)
+	if remote != nil {
+		c := remote.connection()
+		c.send(ServerMessage{Type: SrvNotice, Notice: &n})
+		if n.Kind == NoticeSeated {
+			s.ui.add(n.PlayerID, c)
+		}
+	}
+}
+
+// drop removes a player from the server and closes their connection.
+func (s *Server) drop(playerID, reason string) {
+	s.mu.Lock()
+	remote, ok := s.remotes[playerID]
+	delete(s.remotes, playerID)
+	s.mu.Unlock()
+	if !ok {
+		return
+	}
+	s.ui.remove(playerID)
+	s.seats.Leave(playerID)
+	if remote.registered {
+		if err := s.cfg.Accounts.Deposit(playerID, remote.Chips); err != nil {
+			fmt.Fprintf(s.cfg.Log, "Could not return %d chips to %s's account: %v\n", remote.Chips, playerID, err)
+		}
+	}
+	c := remote.connection()
+	c.send(ServerMessage{Type: SrvBye, Text: reason})
+	c.close()
+}
+
+// shutdown says goodbye to every client.
+func (s *Server) shutdown(reason string) {
+	s.mu.Lock()
+	ids := make([]string, 0, len(s.remotes))
+	for id := range s.remotes {
+		ids = append(ids, id)
+	}
+	s.mu.Unlock()
+	for _, id := range ids {
+		s.drop(id, reason)
+	}
+}
+
+func (s *Server) acceptLoop() {
+	for {
+		raw, err := s.listener.Accept()
+		if err != nil {
+			return // Listener closed
+		}
+		go s.handle(newConn(WithLatency(raw, s.cfg.Latency)))
+	}
+}
+
+// handle runs one client connection: the join handshake, then reading actions.
+func (s *Server) handle(c *conn) {
+	defer c.close()
+	remote := c.raw.RemoteAddr().String()
+	guard := NewGuard(remote, s.cfg.Guard, s.cfg.Audit)
+	scanner := newLineScanner(c.raw)
+
+	c.raw.SetReadDeadline(time.Now().Add(s.cfg.JoinTimeout))
+	if !scanner.Scan() {
+		return
+	}
+	join, err := guard.Inspect(scanner.Bytes())
+	if err != nil || join.Type != MsgJoin {
+		c.send(ServerMessage{Type: SrvError, Text: "the first message must be a join"})
+		return
+	}
+	c.raw.SetReadDeadline(time.Time{})
+
+	player, ok := s.join(c, join)
+	if !ok {
+		return
+	}
+
+	defer func() {
+		if player.connection() != c {
+			return // The player reconnected on another connection
+		}
+		s.mu.Lock()
+		started := s.started
+		s.mu.Unlock()
+		// Once the game has started a seat is freed between hands, see syncSeats
+		if !started || indexOf(s.seats.Waitlist(), join.Player) >= 0 {
+			s.drop(join.Player, "Disconnected.")
+		}
+	}()
+	for scanner.Scan() {
+		m, err := guard.Inspect(scanner.Bytes())
+		if errors.Is(err, ErrDisconnect) {
+			c.send(ServerMessage{Type: SrvBye, Text: err.Error()})
+			return
+		}
+		if err != nil {
+			c.send(ServerMessage{Type: SrvError, Text: err.Error()})
+			continue
+		}
+		switch m.Type {
+		case MsgAction:
+			select {
+			case c.actions <- m:
+			default: // An action is already waiting; ignore the extra one
+			}
+		case MsgChat:
+			s.chat(c, join.Player, m.Text)
+		case MsgLeave:
+			player.leave()
+			return
+		}
+	}
+}
+
+// chat relays what a seated player says to everyone at the table. It comes
+// from the player's connection rather than the game, so it goes straight to
+// the clients; Config.OnEvent only hears the bots' banter.
+func (s *Server) chat(c *conn, playerID, text string) {
+	if indexOf(s.seats.Seated(), playerID) < 0 {
+		c.send(ServerMessage{Type: SrvError, Text: "only seated players can chat"})
+		return
+	}
+	text = game.CleanChat(text)
+	fmt.Fprintf(s.cfg.Log, "%s says: %s\n", playerID, text)
+	s.ui.HandleEvent(game.Event{Type: game.EventChat, PlayerID: playerID, Action: text, Time: time.Now()})
+}
+
+// join seats a new player, or moves a player who presents their session token
+// to the new connection. It returns false if the connection was turned away.
+func (s *Server) join(c *conn, join Message) (*RemotePlayer, bool) {
+	s.mu.Lock()
+	existing, taken := s.remotes[join.Player]
+	resumed := taken && join.Session != "" && !existing.Left() && subtle.ConstantTimeCompare([]byte(join.Session), []byte(existing.session)) == 1
+	buyIn := s.cfg.StartingChips
+	if join.BuyIn > 0 {
+		buyIn = int(join.BuyIn)
+	}
+	buyInErr := s.cfg.Rules.CheckBuyIn(buyIn)
+	if !taken && buyInErr == nil {
+		buyInErr = s.checkAccount(join, buyIn)
+	}
+	var player *RemotePlayer
+	if !taken && buyInErr == nil {
+		player = newRemotePlayer(join.Player, buyIn, c, s.cfg.TurnTimeout)
+		player.session = newSessionToken()
+		player.Avatar = join.Avatar
+		player.registered = join.Password != ""
+		player.timebanks = s.cfg.Timebanks
+		player.log = s.cfg.Log
+		s.remotes[join.Player] = player
+	}
+	s.mu.Unlock()
+
+	switch {
+	case resumed:
+		// Welcome first so the client hears nothing from the game before it
+		welcome := fmt.Sprintf("Welcome back, %s!", join.Player)
+		if s.cfg.Timebanks != nil {
+			bank := s.cfg.Timebanks.Restore(join.Player)
+			welcome += fmt.Sprintf(" %s is left in your time bank.", bank.Remaining.Round(time.Second))
+		}
+		c.send(ServerMessage{Type: SrvWelcome, Text: welcome, Session: existing.session})
+		existing.attach(c)
+		if indexOf(s.seats.Seated(), join.Player) >= 0 {
+			s.ui.add(join.Player, c)
+		}
+		fmt.Fprintf(s.cfg.Log, "%s reconnected\n", join.Player)
+		return existing, true
+	case taken:
+		c.send(ServerMessage{Type: SrvError, Text: fmt.Sprintf("the name %s is taken", join.Player)})
+		return nil, false
+	case buyInErr != nil:
+		c.send(ServerMessage{Type: SrvError, Text: buyInErr.Error()})
+		return nil, false
+	}
+
+	welcome := fmt.Sprintf("Welcome, %s! %s", join.Player, s.TableRules())
+	if player.registered {
+		welcome += fmt.Sprintf(" %d chips are left in your account.", s.cfg.Accounts.Balance(join.Player))
+	} else if s.cfg.Accounts != nil {
+		welcome += " You are playing as a guest; join with a password to keep your chips."
+	}
+	c.send(ServerMessage{Type: SrvWelcome, Text: welcome, Session: player.session})
+	if _, _, err := s.seats.Join(join.Player); err != nil {
+		s.drop(join.Player, err.Error())
+		return nil, false
+	}
+	return player, true
+}
+
+// checkAccount admits a registered player who gave the right password and
+// can afford the buy-in, taking it from their account, or a guest when the
+// table allows guests and the name is not registered.
+func (s *Server) checkAccount(join Message, buyIn int) error {
+	accounts := s.cfg.Accounts
+	switch {
+	case join.Password == "" && s.cfg.RegisteredOnly:
+		return ErrRegisteredOnly
+	case join.Password == "" && accounts != nil && accounts.Registered(join.Player):
+		return fmt.Errorf("the %w", ErrNameRegistered)
+	case join.Password == "":
+		return nil
+	case accounts == nil:
+		return ErrNoAccountsOnServer
+	}
+	if err := accounts.Login(join.Player, join.Password); err != nil {
+		return err
+	}
+	return accounts.Withdraw(join.Player, buyIn)
+}
+
+// newSessionToken returns a random token that lets a player reconnect.
+func newSessionToken() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(fmt.Sprintf("server: reading random session token: %v", err))
+	}
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,269 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"pokerclientv1/pkg/game"
+	"pokerclientv1/pkg/gametest"
+	"pokerclientv1/pkg/types"
+)
+
+// TestServerPlaysRemoteHands connects two clients over TCP and plays until one
+// of them is out of chips: Alice moves all-in every hand and Bob calls.
+func TestServerPlaysRemoteHands(t *testing.T) {
+	events := []game.Event{}
+	var mu sync.Mutex
+	srv := NewServer(Config{
+		Addr:          "127.0.0.1:0",
+		Humans:        2,
+		Rules:         game.DefaultGameConfig(),
+		StartingChips: 40,
+		OnEvent: func(e game.Event) {
+			mu.Lock()
+			events = append(events, e)
+			mu.Unlock()
+		},
+	})
+	if err := srv.Listen(); err != nil {
+		t.Fatalf("Listen() error: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	serverDone := make(chan error)
+	go func() { serverDone <- srv.Run(ctx) }()
+
+	type result struct {
+		ui  *gametest.CaptureUI
+		out *bytes.Buffer
+		err error
+	}
+	play := func(name, action string, done chan<- result) {
+		ui, out := &gametest.CaptureUI{}, &bytes.Buffer{}
+		input := "say Good luck, " + name + "\n" + strings.Repeat(action+"\n", 100)
+		client, err := Dial(srv.Addr().String(), name, ui, strings.NewReader(input), out)
+		if err != nil {
+			done <- result{err: err}
+			return
+		}
+		done <- result{ui, out, client.Run()}
+	}
+	aliceDone, bobDone := make(chan result), make(chan result)
+	go play("Alice", "all-in", aliceDone)
+	go play("Bob", "call", bobDone)
+
+	for _, done := range []chan result{aliceDone, bobDone} {
+		select {
+		case r := <-done:
+			if r.err != nil {
+				t.Fatalf("client error: %v", r.err)
+			}
+			if !strings.Contains(r.out.String(), "Alice says: Good luck, Alice") {
+				t.Errorf("Alice's chat was not relayed, output:\n%s", r.out)
+			}
+			if len(r.ui.Stages) == 0 {
+				t.Errorf("client was never shown the table, output:\n%s", r.out)
+			}
+			if !strings.Contains(r.out.String(), "wins") {
+				t.Errorf("client was not told who won, output:\n%s", r.out)
+			}
+		case <-ctx.Done():
+			t.Fatalf("clients did not finish")
+		}
+	}
+	if err := <-serverDone; err != nil {
+		t.Fatalf("Run() error: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	awarded := false
+	for _, e := range events {
+		if e.Type == game.EventHoleCardsDealt && e.PlayerID != "Alice" && e.PlayerID != "Bob" {
+			t.Errorf("cards dealt to unexpected player %s", e.PlayerID)
+		}
+		if e.Type == game.EventPotAwarded {
+			awarded = true
+		}
+	}
+	if !awarded {
+		t.Errorf("no pot was awarded")
+	}
+}
+
+// TestServerShutsDownWhileWaiting checks that cancelling the context stops a
+// server that is still waiting for players.
+func TestServerShutsDownWhileWaiting(t *testing.T) {
+	health := NewHealth()
+	srv := NewServer(Config{Addr: "127.0.0.1:0", Humans: 2, Rules: game.DefaultGameConfig(), StartingChips: 40, Health: health})
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- srv.Run(ctx) }()
+	time.Sleep(20 * time.Millisecond)
+	if !health.Ready() {
+		t.Errorf("Ready() = false while accepting players")
+	}
+	cancel()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Run() error: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("Run() did not return after cancel")
+	}
+}
+
+// TestServerUnixSocket plays a game between two clients on a local socket.
+func TestServerUnixSocket(t *testing.T) {
+	addr := "unix:" + filepath.Join(t.TempDir(), "poker.sock")
+	srv := NewServer(Config{Addr: addr, Humans: 2, Rules: game.DefaultGameConfig(), StartingChips: 40})
+	if err := srv.Listen(); err != nil {
+		t.Fatalf("Listen() error: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	serverDone := make(chan error)
+	go func() { serverDone <- srv.Run(ctx) }()
+
+	clientDone := make(chan error)
+	for _, player := range []struct{ name, action string }{{"Alice", "all-in"}, {"Bob", "call"}} {
+		go func(name, action string) {
+			client, err := Dial(addr, name, &gametest.CaptureUI{}, strings.NewReader(strings.Repeat(action+"\n", 100)), &bytes.Buffer{})
+			if err != nil {
+				clientDone <- err
+				return
+			}
+			clientDone <- client.Run()
+		}(player.name, player.action)
+	}
+	for i := 0; i < 2; i++ {
+		select {
+		case err := <-clientDone:
+			if err != nil {
+				t.Fatalf("client error: %v", err)
+			}
+		case <-ctx.Done():
+			t.Fatalf("clients did not finish")
+		}
+	}
+	if err := <-serverDone; err != nil {
+		t.Fatalf("Run() error: %v", err)
+	}
+}
+
+// TestServerBuyInRange checks that joins outside the table's buy-in range are
+// rejected and that the welcome lists the table rules.
+func TestServerBuyInRange(t *testing.T) {
+	bot := gametest.NewScriptedPlayer("Bot", 100)
+	srv := NewServer(Config{
+		Addr:          "127.0.0.1:0",
+		WebSocketAddr: "127.0.0.1:0",
+		Humans:        1,
+		Bots:          []types.Player{bot},
+		Rules:         game.DefaultGameConfig(),
+		StartingChips: 100,
+	})
+	if err := srv.Listen(); err != nil {
+		t.Fatalf("Listen() error: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	serverDone := make(chan error)
+	go func() { serverDone <- srv.Run(ctx) }()
+	addr := srv.WebSocketAddr().String()
+
+	greedy := dialWebSocket(t, addr)
+	greedy.send(Message{Type: MsgJoin, Player: "Alice", BuyIn: 5000})
+	if m := greedy.next(SrvError); !strings.Contains(m.Text, "above the table maximum of 1000") {
+		t.Errorf("5000 chip buy-in got %q, want it rejected above the maximum", m.Text)
+	}
+	greedy.conn.Close()
+
+	alice := dialWebSocket(t, addr)
+	alice.send(Message{Type: MsgJoin, Player: "Alice", BuyIn: 300})
+	if m := alice.next(SrvWelcome); !strings.Contains(m.Text, "buy-in 40-1000 (20-500 bb)") {
+		t.Errorf("welcome = %q, want the table's buy-in range", m.Text)
+	}
+	if state := alice.next(SrvState); state.State != nil {
+		for _, seat := range state.State.Players {
+			if seat.ID == "Alice" && seat.Chips+seat.CurrentBet != 300 {
+				t.Errorf("Alice sat down with %d chips, want the 300 chip buy-in", seat.Chips+seat.CurrentBet)
+			}
+		}
+	}
+	alice.next(SrvTurn)
+	alice.send(Message{Type: MsgAction, Action: "fold"})
+	alice.send(Message{Type: MsgLeave})
+
+	select {
+	case err := <-serverDone:
+		if err != nil {
+			t.Errorf("Run() error: %v", err)
+		}
+	case <-ctx.Done():
+		t.Fatalf("server did not stop after the only player left")
+	}
+}
+
+// TestServerRegisteredOnly checks that a registered-only table turns guests
+// away, buys a registered player in from their account and puts their chips
+// back when they leave.
+func TestServerRegisteredOnly(t *testing.T) {
+	accounts, err := OpenAccounts(filepath.Join(t.TempDir(), "accounts.json"))
+	if err != nil {
+		t.Fatalf("OpenAccounts() error: %v", err)
+	}
+	bot := gametest.NewScriptedPlayer("Bot", 100)
+	srv := NewServer(Config{
+		Addr:           "127.0.0.1:0",
+		WebSocketAddr:  "127.0.0.1:0",
+		Humans:         1,
+		Bots:           []types.Player{bot},
+		Rules:          game.DefaultGameConfig(),
+		StartingChips:  100,
+		Accounts:       accounts,
+		RegisteredOnly: true,
+	})
+	if err := srv.Listen(); err != nil {
+		t.Fatalf("Listen() error: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	serverDone := make(chan error)
+	go func() { serverDone <- srv.Run(ctx) }()
+	addr := srv.WebSocketAddr().String()
+
+	guest := dialWebSocket(t, addr)
+	guest.send(Message{Type: MsgJoin, Player: "Alice"})
+	if m := guest.next(SrvError); m.Text != ErrRegisteredOnly.Error() {
+		t.Errorf("guest join got %q, want %q", m.Text, ErrRegisteredOnly)
+	}
+	guest.conn.Close()
+
+	alice := dialWebSocket(t, addr)
+	alice.send(Message{Type: MsgJoin, Player: "Alice", Password: "hunter22"})
+	if m := alice.next(SrvWelcome); !strings.Contains(m.Text, "9900 chips are left in your account") {
+		t.Errorf("welcome = %q, want the account balance after the buy-in", m.Text)
+	}
+	alice.next(SrvTurn)
+	alice.send(Message{Type: MsgAction, Action: "fold"})
+	alice.send(Message{Type: MsgLeave})
+
+	select {
+	case err := <-serverDone:
+		if err != nil {
+			t.Errorf("Run() error: %v", err)
+		}
+	case <-ctx.Done():
+		t.Fatalf("server did not stop after the only player left")
+	}
+	if balance := accounts.Balance("Alice"); balance < 9990 || balance > 10000 {
+		t.Errorf("Alice's balance is %d after folding one hand, want her stack back in the account", balance)
+	}
+}
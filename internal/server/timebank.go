@@ -0,0 +1,102 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"sync"
+	"time"
+)
+
+// Timebank holds a player's extra decision time and how often they have been
+// auto-folded for running out of it.
+type Timebank struct {
+	Remaining time.Duration `json:"remaining"`
+	AutoFolds int           `json:"autoFolds"`
+}
+
+// TimebankStore keeps timebanks keyed by player ID on the server side, so a
+// player who disconnects and reconnects gets their old timebank back instead
+// of a fresh one. The store is persisted to a JSON file.
+type TimebankStore struct {
+	mu      sync.Mutex
+	path    string        // File the timebanks are saved to ("" keeps them in memory only)
+	initial time.Duration // Timebank given to players seen for the first time
+	banks   map[string]*Timebank
+}
+
+// NewTimebankStore creates a store backed by path, loading any saved timebanks.
+func NewTimebankStore(path string, initial time.Duration) (*TimebankStore, error) {
+	s := &TimebankStore{
+		path:    path,
+		initial: initial,
+		banks:   make(map[string]*Timebank),
+	}
+	if path == "" {
+		return s, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil // Nothing saved yet
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &s.banks); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Restore returns the player's timebank, creating a full one on first sight.
+// It is called when a player joins or reconnects.
+func (s *TimebankStore) Restore(playerID string) Timebank {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return *s.bank(playerID)
+}
+
+// Consume deducts used time from the player's timebank and saves the store.
+// Returns the remaining time, which is never negative.
+func (s *TimebankStore) Consume(playerID string, used time.Duration) (time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b := s.bank(playerID)
+	b.Remaining -= used
+	if b.Remaining < 0 {
+		b.Remaining = 0
+	}
+	return b.Remaining, s.save()
+}
+
+// RecordAutoFold counts an automatic fold against the player and saves the store.
+func (s *TimebankStore) RecordAutoFold(playerID string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b := s.bank(playerID)
+	b.AutoFolds++
+	return b.AutoFolds, s.save()
+}
+
+// bank returns the stored timebank for a player. Callers must hold s.mu.
+func (s *TimebankStore) bank(playerID string) *Timebank {
+	b, ok := s.banks[playerID]
+	if !ok {
+		b = &Timebank{Remaining: s.initial}
+		s.banks[playerID] = b
+	}
+	return b
+}
+
+// save writes all timebanks to disk. Callers must hold s.mu.
+func (s *TimebankStore) save() error {
+	if s.path == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(s.banks, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, data, 0o644)
+}
@@ -0,0 +1,74 @@
+package server
+
+import (
+	"bytes"
+	"io"
+	"net"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"pokerclientv1/pkg/types"
+)
+
+// TestTimebankSurvivesReconnect checks that a reloaded store restores used timebanks.
+func TestTimebankSurvivesReconnect(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "timebanks.json")
+	store, err := NewTimebankStore(path, 30*time.Second)
+	if err != nil {
+		t.Fatalf("NewTimebankStore() returned an unexpected error: %v", err)
+	}
+
+	if got := store.Restore("P1"); got.Remaining != 30*time.Second || got.AutoFolds != 0 {
+		t.Errorf("Restore() new player = %+v, want 30s and 0 auto-folds", got)
+	}
+	if _, err := store.Consume("P1", 12*time.Second); err != nil {
+		t.Fatalf("Consume() returned an unexpected error: %v", err)
+	}
+	if _, err := store.RecordAutoFold("P1"); err != nil {
+		t.Fatalf("RecordAutoFold() returned an unexpected error: %v", err)
+	}
+
+	// Simulate a server restart / reconnect by loading the file again
+	reloaded, err := NewTimebankStore(path, 30*time.Second)
+	if err != nil {
+		t.Fatalf("NewTimebankStore() reload returned an unexpected error: %v", err)
+	}
+	got := reloaded.Restore("P1")
+	if got.Remaining != 18*time.Second || got.AutoFolds != 1 {
+		t.Errorf("Restore() after reconnect = %+v, want 18s and 1 auto-fold", got)
+	}
+
+	// Timebank never goes negative
+	remaining, _ := reloaded.Consume("P1", time.Minute)
+	if remaining != 0 {
+		t.Errorf("Consume() past zero left %v, want 0", remaining)
+	}
+}
+
+// TestTimebankSaveErrorsLogged checks that a player whose time bank cannot
+// be saved still plays on, with the failure reported in the server log.
+func TestTimebankSaveErrorsLogged(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing", "timebanks.json") // Its directory is never made
+	store, err := NewTimebankStore(path, 20*time.Millisecond)
+	if err != nil {
+		t.Fatalf("NewTimebankStore() returned an unexpected error: %v", err)
+	}
+	near, far := net.Pipe()
+	defer far.Close()
+	go io.Copy(io.Discard, far)
+
+	var log bytes.Buffer
+	p := newRemotePlayer("P1", 100, newConn(near), 10*time.Millisecond)
+	p.timebanks, p.log = store, &log
+	if action, _ := p.TakeTurn(&types.Table{}, 10, 10); action != "fold" {
+		t.Fatalf("TakeTurn() = %s, want a fold once the time bank runs out", action)
+	}
+	if !strings.Contains(log.String(), "Saving P1's time bank") || !strings.Contains(log.String(), "Saving P1's auto-fold") {
+		t.Errorf("log = %q, want both save errors reported", log.String())
+	}
+	if got := store.Restore("P1"); got.Remaining != 0 || got.AutoFolds != 1 {
+		t.Errorf("Restore() = %+v, want the bank used up and the fold counted in memory", got)
+	}
+}
@@ -0,0 +1,247 @@
+package server
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// WebSocket opcodes (RFC 6455, section 5.2).
+const (
+	wsContinuation = 0x0
+	wsText         = 0x1
+	wsBinary       = 0x2
+	wsClose        = 0x8
+	wsPing         = 0x9
+	wsPong         = 0xA
+)
+
+// wsGUID is appended to the client's key to compute Sec-WebSocket-Accept.
+const wsGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// maxFrameSize bounds a message read from a browser. It leaves room over
+// MaxMessageSize so oversized messages reach validation like they do on TCP.
+const maxFrameSize = 2 * MaxMessageSize
+
+// errBadFrame ends a WebSocket connection that broke the framing rules.
+var errBadFrame = errors.New("websocket: protocol error")
+
+// wsConn adapts a WebSocket to the line protocol: every text message read is
+// followed by a newline, and every Write is sent as one text message. It
+// implements net.Conn so the server handles it like a TCP connection.
+type wsConn struct {
+	net.Conn
+	r       *bufio.Reader
+	wmu     sync.Mutex // Serializes frames written by Write, pongs and close
+	pending []byte     // Rest of the message being read
+}
+
+// upgradeWebSocket performs the server side of the opening handshake.
+func upgradeWebSocket(w http.ResponseWriter, r *http.Request) (*wsConn, error) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return nil, fmt.Errorf("websocket: method %s", r.Method)
+	}
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if !headerHasToken(r.Header, "Connection", "upgrade") || !headerHasToken(r.Header, "Upgrade", "websocket") || key == "" {
+		http.Error(w, "expected a WebSocket upgrade", http.StatusBadRequest)
+		return nil, errors.New("websocket: not an upgrade request")
+	}
+	if r.Header.Get("Sec-WebSocket-Version") != "13" {
+		w.Header().Set("Sec-WebSocket-Version", "13")
+		http.Error(w, "unsupported WebSocket version", http.StatusUpgradeRequired)
+		return nil, errors.New("websocket: unsupported version")
+	}
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, "connection cannot be upgraded", http.StatusInternalServerError)
+		return nil, errors.New("websocket: response does not support hijacking")
+	}
+	raw, rw, err := hijacker.Hijack()
+	if err != nil {
+		return nil, err
+	}
+	response := "HTTP/1.1 101 Switching Protocols\r\n" +
+		"Upgrade: websocket\r\n" +
+		"Connection: Upgrade\r\n" +
+		"Sec-WebSocket-Accept: " + wsAccept(key) + "\r\n\r\n"
+	if _, err := raw.Write([]byte(response)); err != nil {
+		raw.Close()
+		return nil, err
+	}
+	return &wsConn{Conn: raw, r: rw.Reader}, nil
+}
+
+// wsAccept computes the Sec-WebSocket-Accept value for a client key.
+func wsAccept(key string) string {
+	sum := sha1.Sum([]byte(key + wsGUID))
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+// headerHasToken reports whether a comma-separated header contains token.
+func headerHasToken(h http.Header, name, token string) bool {
+	for _, value := range h.Values(name) {
+		for _, part := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(part), token) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Read returns message data with a newline after each message. Pings are
+// answered and a close frame ends the stream with io.EOF.
+func (c *wsConn) Read(p []byte) (int, error) {
+	for len(c.pending) == 0 {
+		msg, err := c.readMessage()
+		if err != nil {
+			return 0, err
+		}
+		c.pending = append(msg, '\n')
+	}
+	n := copy(p, c.pending)
+	c.pending = c.pending[n:]
+	return n, nil
+}
+
+// readMessage reads frames until a complete data message has arrived.
+func (c *wsConn) readMessage() ([]byte, error) {
+	var msg []byte
+	started := false
+	for {
+		fin, opcode, payload, err := c.readFrame()
+		if err != nil {
+			return nil, err
+		}
+		switch opcode {
+		case wsPing:
+			c.writeFrame(wsPong, payload)
+			continue
+		case wsPong:
+			continue
+		case wsClose:
+			c.writeFrame(wsClose, payload)
+			return nil, io.EOF
+		case wsText, wsBinary:
+			if started {
+				return nil, c.fail(errBadFrame)
+			}
+			started = true
+		case wsContinuation:
+			if !started {
+				return nil, c.fail(errBadFrame)
+			}
+		default:
+			return nil, c.fail(errBadFrame)
+		}
+		msg = append(msg, payload...)
+		if len(msg) > maxFrameSize {
+			return nil, c.fail(fmt.Errorf("websocket: message over %d bytes", maxFrameSize))
+		}
+		if fin {
+			return msg, nil
+		}
+	}
+}
+
+// readFrame reads one frame and unmasks its payload. Clients must mask
+// every frame.
+func (c *wsConn) readFrame() (fin bool, opcode byte, payload []byte, err error) {
+	var header [2]byte
+	if _, err = io.ReadFull(c.r, header[:]); err != nil {
+		return
+	}
+	fin = header[0]&0x80 != 0
+	opcode = header[0] & 0x0F
+	if header[0]&0x70 != 0 || header[1]&0x80 == 0 {
+		return fin, opcode, nil, c.fail(errBadFrame) // Reserved bits set or unmasked
+	}
+
+	length := uint64(header[1] & 0x7F)
+	switch length {
+	case 126:
+		var ext [2]byte
+		if _, err = io.ReadFull(c.r, ext[:]); err != nil {
+			return
+		}
+		length = uint64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		if _, err = io.ReadFull(c.r, ext[:]); err != nil {
+			return
+		}
+		length = binary.BigEndian.Uint64(ext[:])
+	}
+	if opcode >= wsClose && (length > 125 || !fin) {
+		return fin, opcode, nil, c.fail(errBadFrame) // Control frames are short and never fragmented
+	}
+	if length > maxFrameSize {
+		return fin, opcode, nil, c.fail(fmt.Errorf("websocket: frame of %d bytes is too large", length))
+	}
+
+	var mask [4]byte
+	if _, err = io.ReadFull(c.r, mask[:]); err != nil {
+		return
+	}
+	payload = make([]byte, length)
+	if _, err = io.ReadFull(c.r, payload); err != nil {
+		return
+	}
+	for i := range payload {
+		payload[i] ^= mask[i%4]
+	}
+	return fin, opcode, payload, nil
+}
+
+// Write sends p as one text message. The newline ending each protocol line
+// is left out since the message boundary already separates them.
+func (c *wsConn) Write(p []byte) (int, error) {
+	if err := c.writeFrame(wsText, []byte(strings.TrimSuffix(string(p), "\n"))); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// writeFrame sends a single unmasked frame, as servers must.
+func (c *wsConn) writeFrame(opcode byte, payload []byte) error {
+	frame := []byte{0x80 | opcode}
+	switch n := len(payload); {
+	case n <= 125:
+		frame = append(frame, byte(n))
+	case n <= 0xFFFF:
+		frame = append(frame, 126)
+		frame = binary.BigEndian.AppendUint16(frame, uint16(n))
+	default:
+		frame = append(frame, 127)
+		frame = binary.BigEndian.AppendUint64(frame, uint64(n))
+	}
+	frame = append(frame, payload...)
+
+	c.wmu.Lock()
+	defer c.wmu.Unlock()
+	_, err := c.Conn.Write(frame)
+	return err
+}
+
+// fail sends a protocol error close frame and returns err.
+func (c *wsConn) fail(err error) error {
+	c.writeFrame(wsClose, []byte{0x03, 0xEA}) // 1002: protocol error
+	return err
+}
+
+// Close sends a normal close frame, then closes the connection.
+func (c *wsConn) Close() error {
+	c.Conn.SetWriteDeadline(time.Now().Add(time.Second))
+	c.writeFrame(wsClose, []byte{0x03, 0xE8}) // 1000: normal closure
+	return c.Conn.Close()
+}
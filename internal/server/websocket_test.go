@@ -0,0 +1,238 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"pokerclientv1/pkg/game"
+	"pokerclientv1/pkg/gametest"
+	"pokerclientv1/pkg/types"
+)
+
+// wsClient is a minimal browser stand-in: it masks what it sends and reads
+// the server's unmasked text frames.
+type wsClient struct {
+	t    *testing.T
+	conn net.Conn
+	r    *bufio.Reader
+}
+
+func dialWebSocket(t *testing.T, addr string) *wsClient {
+	t.Helper()
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+	key := "dGhlIHNhbXBsZSBub25jZQ=="
+	fmt.Fprintf(conn, "GET /ws HTTP/1.1\r\nHost: %s\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n"+
+		"Sec-WebSocket-Key: %s\r\nSec-WebSocket-Version: 13\r\n\r\n", addr, key)
+	r := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(r, nil)
+	if err != nil {
+		t.Fatalf("handshake: %v", err)
+	}
+	if resp.StatusCode != http.StatusSwitchingProtocols || resp.Header.Get("Sec-WebSocket-Accept") != wsAccept(key) {
+		t.Fatalf("handshake answered %s with accept %q", resp.Status, resp.Header.Get("Sec-WebSocket-Accept"))
+	}
+	return &wsClient{t: t, conn: conn, r: r}
+}
+
+func (c *wsClient) send(m Message) {
+	c.t.Helper()
+	payload, _ := json.Marshal(m)
+	mask := [4]byte{1, 2, 3, 4}
+	frame := []byte{0x80 | wsText, 0x80 | byte(len(payload))}
+	frame = append(frame, mask[:]...)
+	for i, b := range payload {
+		frame = append(frame, b^mask[i%4])
+	}
+	if _, err := c.conn.Write(frame); err != nil {
+		c.t.Fatalf("send: %v", err)
+	}
+}
+
+// next reads messages until one of the given type arrives.
+func (c *wsClient) next(msgType string) ServerMessage {
+	c.t.Helper()
+	for {
+		var header [2]byte
+		if _, err := io.ReadFull(c.r, header[:]); err != nil {
+			c.t.Fatalf("waiting for %s: %v", msgType, err)
+		}
+		length := int(header[1] & 0x7F)
+		switch length {
+		case 126:
+			var ext [2]byte
+			io.ReadFull(c.r, ext[:])
+			length = int(binary.BigEndian.Uint16(ext[:]))
+		case 127:
+			var ext [8]byte
+			io.ReadFull(c.r, ext[:])
+			length = int(binary.BigEndian.Uint64(ext[:]))
+		}
+		payload := make([]byte, length)
+		if _, err := io.ReadFull(c.r, payload); err != nil {
+			c.t.Fatalf("waiting for %s: %v", msgType, err)
+		}
+		if header[0]&0x0F != wsText {
+			continue
+		}
+		var m ServerMessage
+		if err := json.Unmarshal(payload, &m); err != nil {
+			c.t.Fatalf("bad message %q: %v", payload, err)
+		}
+		if m.Type == msgType {
+			return m
+		}
+	}
+}
+
+func TestWebSocketAccept(t *testing.T) {
+	// Example from RFC 6455, section 1.3
+	if got := wsAccept("dGhlIHNhbXBsZSBub25jZQ=="); got != "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=" {
+		t.Errorf("wsAccept() = %q", got)
+	}
+}
+
+// TestWebSocketReconnect plays a browser client against a bot, drops the
+// connection mid-game and rejoins the same seat with the session token.
+func TestWebSocketReconnect(t *testing.T) {
+	calls := make([]gametest.Step, 200)
+	for i := range calls {
+		calls[i] = gametest.Call()
+	}
+	bot := gametest.NewScriptedPlayer("Bot", 1000, calls...)
+	srv := NewServer(Config{
+		Addr:          "127.0.0.1:0",
+		WebSocketAddr: "127.0.0.1:0",
+		Humans:        1,
+		Bots:          []types.Player{bot},
+		Rules:         game.DefaultGameConfig(),
+		StartingChips: 1000,
+		Reconnect:     time.Minute,
+	})
+	if err := srv.Listen(); err != nil {
+		t.Fatalf("Listen() error: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	serverDone := make(chan error)
+	go func() { serverDone <- srv.Run(ctx) }()
+	addr := srv.WebSocketAddr().String()
+
+	alice := dialWebSocket(t, addr)
+	alice.send(Message{Type: MsgJoin, Player: "Alice"})
+	session := alice.next(SrvWelcome).Session
+	if session == "" {
+		t.Fatalf("welcome has no session token")
+	}
+	state := alice.next(SrvState)
+	for _, seat := range state.State.Players {
+		if seat.ID == "Bot" && len(seat.Cards) > 0 {
+			t.Errorf("Alice was shown the bot's cards")
+		}
+	}
+	alice.next(SrvTurn)
+	alice.conn.Close() // Drop without a close frame
+
+	impostor := dialWebSocket(t, addr)
+	impostor.send(Message{Type: MsgJoin, Player: "Alice", Session: "not-the-token"})
+	if m := impostor.next(SrvError); !strings.Contains(m.Text, "taken") {
+		t.Errorf("wrong session got %q, want the name to be taken", m.Text)
+	}
+	impostor.conn.Close()
+
+	alice = dialWebSocket(t, addr)
+	alice.send(Message{Type: MsgJoin, Player: "Alice", Session: session})
+	if m := alice.next(SrvWelcome); m.Session != session || !strings.Contains(m.Text, "back") {
+		t.Errorf("rejoin welcome = %+v, want the same session", m)
+	}
+	alice.next(SrvTurn)
+	alice.send(Message{Type: MsgAction, Action: "fold"})
+	alice.send(Message{Type: MsgLeave})
+
+	select {
+	case err := <-serverDone:
+		if err != nil {
+			t.Errorf("Run() error: %v", err)
+		}
+	case <-ctx.Done():
+		t.Fatalf("server did not stop after the only player left")
+	}
+}
+
+// TestTimebankAcrossReconnect lets a browser client's turn run out, time bank
+// and all, then rejoins: the time bank stays spent and the fold is counted.
+func TestTimebankAcrossReconnect(t *testing.T) {
+	calls := make([]gametest.Step, 200)
+	for i := range calls {
+		calls[i] = gametest.Call()
+	}
+	timebanks, err := NewTimebankStore("", 200*time.Millisecond)
+	if err != nil {
+		t.Fatalf("NewTimebankStore() error: %v", err)
+	}
+	srv := NewServer(Config{
+		Addr:          "127.0.0.1:0",
+		WebSocketAddr: "127.0.0.1:0",
+		Humans:        1,
+		Bots:          []types.Player{gametest.NewScriptedPlayer("Bot", 1000, calls...)},
+		Rules:         game.DefaultGameConfig(),
+		StartingChips: 1000,
+		TurnTimeout:   50 * time.Millisecond,
+		Reconnect:     time.Minute,
+		Timebanks:     timebanks,
+	})
+	if err := srv.Listen(); err != nil {
+		t.Fatalf("Listen() error: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	serverDone := make(chan error)
+	go func() { serverDone <- srv.Run(ctx) }()
+	addr := srv.WebSocketAddr().String()
+
+	alice := dialWebSocket(t, addr)
+	alice.send(Message{Type: MsgJoin, Player: "Alice"})
+	session := alice.next(SrvWelcome).Session
+	if turn := alice.next(SrvTurn).Turn; turn.TimeBank != 200 {
+		t.Errorf("first turn time bank = %dms, want 200ms", turn.TimeBank)
+	}
+	if m := alice.next(SrvError); !strings.Contains(m.Text, "ran out") {
+		t.Errorf("error = %q, want the time to act to run out", m.Text)
+	}
+	alice.conn.Close()
+
+	alice = dialWebSocket(t, addr)
+	alice.send(Message{Type: MsgJoin, Player: "Alice", Session: session})
+	if m := alice.next(SrvWelcome); !strings.Contains(m.Text, "0s is left in your time bank") {
+		t.Errorf("rejoin welcome = %q, want the spent time bank", m.Text)
+	}
+	if turn := alice.next(SrvTurn).Turn; turn.TimeBank != 0 {
+		t.Errorf("time bank after reconnecting = %dms, want it still spent", turn.TimeBank)
+	}
+	if got := timebanks.Restore("Alice"); got.AutoFolds != 1 {
+		t.Errorf("Restore() = %+v, want 1 auto-fold", got)
+	}
+	alice.send(Message{Type: MsgAction, Action: "fold"})
+	alice.send(Message{Type: MsgLeave})
+
+	select {
+	case err := <-serverDone:
+		if err != nil {
+			t.Errorf("Run() error: %v", err)
+		}
+	case <-ctx.Done():
+		t.Fatalf("server did not stop after the only player left")
+	}
+}
@@ -0,0 +1,127 @@
+package server
+
+import (
+	"bufio"
+	"encoding/json"
+	"net"
+	"sync"
+	"time"
+
+	"pokerclientv1/pkg/game"
+)
+
+// Message types the server sends to clients.
+const (
+	SrvWelcome = "welcome" // Joined; Text greets the player and Session is the reconnect token
+	SrvState   = "state"   // State holds the table as the player may see it
+	SrvLog     = "log"     // A player action: PlayerID, Action and Amount
+	SrvClear   = "clear"   // The client should clear its screen
+	SrvTurn    = "turn"    // It is the player's turn, Turn describes the options
+	SrvEvent   = "event"   // A public game event such as a showdown
+	SrvNotice  = "notice"  // A seating change from the lobby
+	SrvError   = "error"   // The last message was rejected, Text says why
+	SrvBye     = "bye"     // The server is closing the connection
+)
+
+// SeatState and TableState are the table as shown to one client, redacted
+// by game.TableStateFor.
+type (
+	SeatState  = game.SeatState
+	TableState = game.TableState
+)
+
+// TurnInfo tells a client what it may do on its turn.
+type TurnInfo struct {
+	CurrentBet int   `json:"currentBet"` // Highest bet this round
+	PlayerBet  int   `json:"playerBet"`  // What the player has already bet this round
+	ToCall     int   `json:"toCall"`
+	MinRaise   int   `json:"minRaise"` // Smallest raise above CurrentBet
+	Chips      int   `json:"chips"`
+	TimeBank   int64 `json:"timeBank,omitempty"` // Milliseconds left in the time bank, used once the turn timeout runs out
+
+	Legal *game.LegalActions `json:"legal,omitempty"` // Exact actions and raise totals, from Game.LegalActions
+}
+
+// ServerMessage is one line sent from the server to a client.
+type ServerMessage struct {
+	Type     string      `json:"type"`
+	Text     string      `json:"text,omitempty"`
+	Session  string      `json:"session,omitempty"`
+	PlayerID string      `json:"player,omitempty"`
+	Action   string      `json:"action,omitempty"`
+	Amount   int         `json:"amount,omitempty"`
+	State    *TableState `json:"state,omitempty"`
+	Turn     *TurnInfo   `json:"turn,omitempty"`
+	Event    *game.Event `json:"event,omitempty"`
+	Notice   *Notice     `json:"notice,omitempty"`
+}
+
+// conn is a client connection on the server. Writes are serialized so the
+// game and the lobby can both send to it.
+type conn struct {
+	raw       net.Conn
+	mu        sync.Mutex
+	enc       *json.Encoder
+	actions   chan Message  // Action messages waiting to be used by TakeTurn
+	done      chan struct{} // Closed when the connection ends
+	closedAt  time.Time     // When done was closed
+	closeOnce sync.Once
+}
+
+func newConn(raw net.Conn) *conn {
+	return &conn{
+		raw:     raw,
+		enc:     json.NewEncoder(raw),
+		actions: make(chan Message, 1),
+		done:    make(chan struct{}),
+	}
+}
+
+// sendTimeout is how long a client may take to accept a message. A client
+// that stops reading is disconnected rather than holding up the table.
+const sendTimeout = 10 * time.Second
+
+// send writes a message, failing once the connection is closed. A failed
+// write may leave half a message on the wire, so it closes the connection.
+func (c *conn) send(m ServerMessage) error {
+	select {
+	case <-c.done:
+		return net.ErrClosed
+	default:
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.raw.SetWriteDeadline(time.Now().Add(sendTimeout))
+	err := c.enc.Encode(m)
+	if err != nil {
+		c.close()
+	}
+	return err
+}
+
+// close ends the connection; it is safe to call more than once.
+func (c *conn) close() {
+	c.closeOnce.Do(func() {
+		c.closedAt = time.Now()
+		close(c.done)
+		c.raw.Close()
+	})
+}
+
+// closed reports whether the connection has ended.
+func (c *conn) closed() bool {
+	select {
+	case <-c.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// newLineScanner reads protocol lines, allowing one byte past MaxMessageSize
+// so oversized messages reach validation instead of stopping the scanner.
+func newLineScanner(c net.Conn) *bufio.Scanner {
+	scanner := bufio.NewScanner(c)
+	scanner.Buffer(make([]byte, 0, 1024), MaxMessageSize+1)
+	return scanner
+}
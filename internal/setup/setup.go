@@ -0,0 +1,327 @@
+// Package setup describes a local game before it starts: the bots, stakes,
+// stacks and mode that are otherwise asked for at the console. A game read
+// from a file with --config, or given by flags, starts without prompts for
+// whatever it sets.
+package setup
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"pokerclientv1/internal/player"
+	"pokerclientv1/pkg/game"
+	"pokerclientv1/pkg/types"
+)
+
+// MaxBots is the most bot opponents a local game seats.
+const MaxBots = 5
+
+// HumanName is the name of the player at the console when they give none.
+const HumanName = "Player 1"
+
+// MaxHumans is the most humans who can share one terminal.
+const MaxHumans = 4
+
+// Game holds the answers to the setup prompts. Fields left at their zero
+// value, or nil for those where zero is an answer, are still asked for.
+//
+// A config file is Game as JSON:
+//
+//	{"bots": 3, "difficulty": "tag", "stakes": "low", "ante": 0, "variant": "holdem",
+//	 "limit": "no-limit", "chips": 1000, "speed": "fast", "mode": "cash", "rebuy": false}
+//
+// A table lists the bots one by one instead, for stacks and styles that
+// differ from seat to seat:
+//
+//	{"stakes": "low", "chips": 1000, "table": [
+//	  {"name": "Shark", "chips": "5k", "difficulty": "lag", "delay": "1s"},
+//	  {"name": "Rock", "chips": 300, "difficulty": "nit"},
+//	  {"chips": 300, "difficulty": "nit"}]}
+//
+// A file named .yaml or .yml is read as YAML with the same keys:
+//
+//	stakes: low
+//	chips: 1000
+//	table:
+//	  - {name: Shark, chips: 5k, difficulty: lag, delay: 1s}
+//	  - {name: Rock, chips: 300, difficulty: nit}
+type Game struct {
+	Humans        []string     `json:"humans,omitempty"`        // Names of the humans sharing the terminal, in seat order
+	Bots          int          `json:"bots,omitempty"`          // Bot opponents, 1 to MaxBots
+	Difficulty    string       `json:"difficulty,omitempty"`    // Difficulty or personality of every bot
+	Stakes        string       `json:"stakes,omitempty"`        // micro, low, mid or high
+	Ante          *int         `json:"ante,omitempty"`          // Up to the big blind
+	Variant       string       `json:"variant,omitempty"`       // One of game.VariantNames
+	Limit         string       `json:"limit,omitempty"`         // no-limit or pot-limit, ignored for pot-limit variants
+	Chips         types.Amount `json:"chips,omitempty"`         // Starting stack, within the stakes' buy-in range
+	Bankroll      *bool        `json:"bankroll,omitempty"`      // Buy in from the bankroll when it covers the minimum
+	Speed         string       `json:"speed,omitempty"`         // instant, fast, default or slow
+	Mode          string       `json:"mode,omitempty"`          // cash or tournament
+	Rebuy         *bool        `json:"rebuy,omitempty"`         // Allow re-buys and add-ons in a cash game
+	HandsPerLevel int          `json:"handsPerLevel,omitempty"` // Hands per blind level in a tournament
+	Seed          int64        `json:"seed,omitempty"`          // Seeds the shuffles and bot decisions, 0 from the clock
+	Table         []Bot        `json:"table,omitempty"`         // Each bot in seat order, instead of Bots alike
+}
+
+// Bot is one bot of a table. Settings it leaves out are the game's.
+type Bot struct {
+	Name       string       `json:"name,omitempty"`       // Bot N, for the Nth bot, when empty
+	Chips      types.Amount `json:"chips,omitempty"`      // Starting stack, any size; the game's when zero
+	Difficulty string       `json:"difficulty,omitempty"` // Difficulty or personality
+	Delay      Duration     `json:"delay,omitempty"`      // Time the bot takes to act, DefaultDelay when zero
+	Avatar     string       `json:"avatar,omitempty"`     // Shown next to the name instead of the personality's
+}
+
+// DefaultDelay is the time a bot takes to act unless told otherwise.
+const DefaultDelay = 500 * time.Millisecond
+
+// Duration is a time.Duration written in a config file as a string such as
+// "750ms" or "2s".
+type Duration time.Duration
+
+// UnmarshalJSON accepts a string in time.ParseDuration notation.
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("durations are written as strings such as \"2s\": %w", err)
+	}
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// Players returns the bots to seat: the table, or Bots bots named Bot 1,
+// Bot 2 and so on otherwise. Names, difficulties and delays left out are
+// filled in, difficulties with the game's; Chips is left zero for the
+// caller to fill in with the starting stack.
+func (g Game) Players() []Bot {
+	bots := g.Table
+	if len(bots) == 0 {
+		bots = make([]Bot, g.Bots)
+	}
+	filled := make([]Bot, len(bots))
+	for i, bot := range bots {
+		if bot.Name == "" {
+			bot.Name = fmt.Sprintf("Bot %d", i+1)
+		}
+		if bot.Difficulty == "" {
+			bot.Difficulty = g.Difficulty
+		}
+		if bot.Delay == 0 {
+			bot.Delay = Duration(DefaultDelay)
+		}
+		filled[i] = bot
+	}
+	return filled
+}
+
+// Blinds by stakes name.
+var stakes = map[string][2]int{"micro": {1, 2}, "low": {5, 10}, "mid": {25, 50}, "high": {100, 200}}
+
+// Speeds is the game speeds by name, in the order they are offered.
+var Speeds = []string{"instant", "fast", "default", "slow"}
+
+// StakesNames returns the stakes, smallest first.
+func StakesNames() []string {
+	names := make([]string, 0, len(stakes))
+	for name := range stakes {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return stakes[names[i]][1] < stakes[names[j]][1] })
+	return names
+}
+
+// Rules returns the default rules with the blinds of the named stakes.
+func Rules(name string) (game.GameConfig, bool) {
+	b, ok := stakes[name]
+	if !ok {
+		return game.GameConfig{}, false
+	}
+	config := game.DefaultGameConfig()
+	config.SmallBlind, config.BigBlind = b[0], b[1]
+	return config, true
+}
+
+// Load reads a config file, as YAML when it is named .yaml or .yml and as
+// JSON otherwise. Unknown fields are rejected so a misspelt setting is not
+// silently asked for again.
+func Load(path string) (Game, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Game{}, err
+	}
+	if ext := strings.ToLower(filepath.Ext(path)); ext == ".yaml" || ext == ".yml" {
+		if data, err = yamlToJSON(data); err != nil {
+			return Game{}, fmt.Errorf("setup: %s: %w", path, err)
+		}
+	}
+	var g Game
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&g); err != nil {
+		return Game{}, fmt.Errorf("setup: %s: %w", path, err)
+	}
+	if err := g.Validate(); err != nil {
+		return Game{}, fmt.Errorf("setup: %s: %w", path, err)
+	}
+	return g, nil
+}
+
+// yamlToJSON rewrites a YAML document as JSON, so YAML files are decoded, and
+// their mistakes caught, exactly as JSON ones are.
+func yamlToJSON(data []byte) ([]byte, error) {
+	var doc interface{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	if doc == nil {
+		doc = map[string]interface{}{} // An empty file sets nothing
+	}
+	return json.Marshal(doc)
+}
+
+// Validate checks the settings that are given. The starting stack is
+// checked against the stakes once both are known, see Fits.
+func (g Game) Validate() error {
+	switch {
+	case g.Bots < 0 || g.Bots > MaxBots:
+		return fmt.Errorf("bots must be 1 to %d, got %d", MaxBots, g.Bots)
+	case g.Difficulty != "" && !player.ValidDifficulty(g.Difficulty):
+		return fmt.Errorf("unknown difficulty %q, want easy, medium, hard or one of %s", g.Difficulty, strings.Join(player.PersonalityNames(), ", "))
+	case g.Stakes != "" && stakes[g.Stakes] == [2]int{}:
+		return fmt.Errorf("unknown stakes %q, want one of %s", g.Stakes, strings.Join(StakesNames(), ", "))
+	case g.Ante != nil && *g.Ante < 0:
+		return fmt.Errorf("ante must not be negative, got %d", *g.Ante)
+	case g.Limit != "" && g.Limit != "no-limit" && g.Limit != "pot-limit":
+		return fmt.Errorf("unknown limit %q, want no-limit or pot-limit", g.Limit)
+	case g.Chips < 0:
+		return fmt.Errorf("chips must not be negative, got %d", g.Chips)
+	case g.Speed != "" && !slices.Contains(Speeds, g.Speed):
+		return fmt.Errorf("unknown speed %q, want one of %s", g.Speed, strings.Join(Speeds, ", "))
+	case g.Mode != "" && g.Mode != "cash" && g.Mode != "tournament":
+		return fmt.Errorf("unknown mode %q, want cash or tournament", g.Mode)
+	case g.HandsPerLevel < 0 || g.HandsPerLevel > 100:
+		return fmt.Errorf("hands per level must be 1 to 100, got %d", g.HandsPerLevel)
+	}
+	if _, ok := game.LookupVariant(g.Variant); g.Variant != "" && !ok {
+		return fmt.Errorf("unknown variant %q, want one of %s", g.Variant, strings.Join(game.VariantNames(), ", "))
+	}
+	return g.validateTable()
+}
+
+// validateTable checks the humans' names and each bot of the table.
+func (g Game) validateTable() error {
+	switch {
+	case len(g.Humans) > MaxHumans:
+		return fmt.Errorf("%d humans are listed, at most %d can share the terminal", len(g.Humans), MaxHumans)
+	case slices.Contains(g.Humans, ""):
+		return fmt.Errorf("every human needs a name")
+	case len(g.Table) > MaxBots:
+		return fmt.Errorf("the table has %d bots, at most %d fit", len(g.Table), MaxBots)
+	case len(g.Table) > 0 && g.Bots != 0 && g.Bots != len(g.Table):
+		return fmt.Errorf("bots is %d but the table lists %d", g.Bots, len(g.Table))
+	}
+	names := map[string]bool{}
+	if len(g.Humans) == 0 {
+		names[HumanName] = true
+	}
+	for _, name := range g.Humans {
+		if names[name] {
+			return fmt.Errorf("two humans are named %q", name)
+		}
+		names[name] = true
+	}
+	for i, bot := range g.Players() {
+		switch {
+		case names[bot.Name]:
+			return fmt.Errorf("table bot %d: the name %q is taken", i+1, bot.Name)
+		case bot.Chips < 0:
+			return fmt.Errorf("table bot %d: chips must not be negative, got %d", i+1, bot.Chips)
+		case bot.Delay < 0:
+			return fmt.Errorf("table bot %d: delay must not be negative", i+1)
+		case bot.Difficulty != "" && !player.ValidDifficulty(bot.Difficulty):
+			return fmt.Errorf("table bot %d: unknown difficulty %q", i+1, bot.Difficulty)
+		}
+		if err := types.ValidateAvatar(bot.Avatar); err != nil {
+			return fmt.Errorf("table bot %d: %w", i+1, err)
+		}
+		names[bot.Name] = true
+	}
+	return nil
+}
+
+// Fits checks the settings that depend on the stakes: the ante, at most the
+// big blind, and the starting stack, within the buy-in range.
+func (g Game) Fits(rules game.GameConfig) error {
+	if g.Ante != nil && *g.Ante > rules.BigBlind {
+		return fmt.Errorf("ante must be at most the big blind of %d, got %d", rules.BigBlind, *g.Ante)
+	}
+	low, high := rules.BuyInRange()
+	if g.Chips != 0 && (int(g.Chips) < low || int(g.Chips) > high) {
+		return fmt.Errorf("chips must be %d to %d at these stakes, got %d", low, high, g.Chips)
+	}
+	return nil
+}
+
+// Override returns g with every setting that o gives replacing g's, as
+// flags override a config file.
+func (g Game) Override(o Game) Game {
+	if o.Humans != nil {
+		g.Humans = o.Humans
+	}
+	if o.Bots != 0 {
+		g.Bots = o.Bots
+	}
+	if o.Difficulty != "" {
+		g.Difficulty = o.Difficulty
+	}
+	if o.Stakes != "" {
+		g.Stakes = o.Stakes
+	}
+	if o.Ante != nil {
+		g.Ante = o.Ante
+	}
+	if o.Variant != "" {
+		g.Variant = o.Variant
+	}
+	if o.Limit != "" {
+		g.Limit = o.Limit
+	}
+	if o.Chips != 0 {
+		g.Chips = o.Chips
+	}
+	if o.Bankroll != nil {
+		g.Bankroll = o.Bankroll
+	}
+	if o.Speed != "" {
+		g.Speed = o.Speed
+	}
+	if o.Mode != "" {
+		g.Mode = o.Mode
+	}
+	if o.Rebuy != nil {
+		g.Rebuy = o.Rebuy
+	}
+	if o.HandsPerLevel != 0 {
+		g.HandsPerLevel = o.HandsPerLevel
+	}
+	if o.Seed != 0 {
+		g.Seed = o.Seed
+	}
+	if o.Table != nil {
+		g.Table = o.Table
+	}
+	return g
+}
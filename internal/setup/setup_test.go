@@ -0,0 +1,123 @@
+package setup
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// TestLoad checks a config file is read, flags override it and settings
+// that do not fit the stakes are caught.
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "game.json")
+	os.WriteFile(path, []byte(`{"bots": 2, "difficulty": "nit", "stakes": "low", "ante": 0, "chips": "1.5k", "rebuy": false}`), 0o644)
+
+	g, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if g.Bots != 2 || g.Difficulty != "nit" || g.Chips != 1500 || g.Ante == nil || g.Rebuy == nil || *g.Rebuy {
+		t.Errorf("Load() = %+v, want the file's settings", g)
+	}
+	g = g.Override(Game{Bots: 4, Speed: "fast"})
+	if g.Bots != 4 || g.Speed != "fast" || g.Difficulty != "nit" {
+		t.Errorf("Override() = %+v, want bots and speed replaced and the rest kept", g)
+	}
+
+	rules, _ := Rules("low")
+	if err := g.Fits(rules); err != nil {
+		t.Errorf("Fits(low) error: %v", err)
+	}
+	micro, _ := Rules("micro")
+	if err := g.Fits(micro); err == nil {
+		t.Errorf("Fits(micro) accepted a 1500 chip stack at 1/2")
+	}
+
+	for _, bad := range []string{`{"bot": 2}`, `{"bots": 9}`, `{"stakes": "huge"}`, `{"difficulty": "shark"}`, `{"speed": "warp"}`} {
+		os.WriteFile(path, []byte(bad), 0o644)
+		if _, err := Load(path); err == nil {
+			t.Errorf("Load(%s) succeeded, want an error", bad)
+		}
+	}
+}
+
+// TestTable checks that a table's bots keep their own stacks and styles and
+// take the game's settings for what they leave out.
+func TestTable(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "table.json")
+	os.WriteFile(path, []byte(`{"difficulty": "medium", "table": [
+		{"name": "Shark", "chips": "5k", "difficulty": "lag", "delay": "1s"},
+		{"chips": 300, "difficulty": "nit"},
+		{}]}`), 0o644)
+
+	g, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	want := []Bot{
+		{Name: "Shark", Chips: 5000, Difficulty: "lag", Delay: Duration(time.Second)},
+		{Name: "Bot 2", Chips: 300, Difficulty: "nit", Delay: Duration(DefaultDelay)},
+		{Name: "Bot 3", Difficulty: "medium", Delay: Duration(DefaultDelay)},
+	}
+	if got := g.Players(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Players() = %+v, want %+v", got, want)
+	}
+	if got := (Game{Bots: 2, Difficulty: "easy"}).Players(); len(got) != 2 || got[1].Name != "Bot 2" || got[1].Difficulty != "easy" {
+		t.Errorf("Players() without a table = %+v, want two easy bots", got)
+	}
+
+	for _, bad := range []string{
+		`{"table": [{"name": "Ann"}, {"name": "Ann"}]}`,
+		`{"table": [{"name": "Player 1"}]}`,
+		`{"table": [{"delay": 5}]}`,
+		`{"table": [{"difficulty": "shark"}]}`,
+		`{"bots": 3, "table": [{}]}`,
+	} {
+		os.WriteFile(path, []byte(bad), 0o644)
+		if _, err := Load(path); err == nil {
+			t.Errorf("Load(%s) succeeded, want an error", bad)
+		}
+	}
+}
+
+// TestLoadYAML checks that a .yaml file is read with the JSON keys and
+// checked as strictly.
+func TestLoadYAML(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "game.yaml")
+	os.WriteFile(path, []byte(`
+stakes: low
+ante: 0
+chips: 1.5k
+rebuy: false
+humans: [Ann, Bob]
+table:
+  - {name: Shark, chips: 5k, difficulty: lag, delay: 1s}
+  - chips: 300
+    difficulty: nit
+`), 0o644)
+
+	g, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if g.Stakes != "low" || g.Chips != 1500 || g.Ante == nil || g.Rebuy == nil || *g.Rebuy || !reflect.DeepEqual(g.Humans, []string{"Ann", "Bob"}) {
+		t.Errorf("Load() = %+v, want the file's settings", g)
+	}
+	want := []Bot{
+		{Name: "Shark", Chips: 5000, Difficulty: "lag", Delay: Duration(time.Second)},
+		{Name: "Bot 2", Chips: 300, Difficulty: "nit", Delay: Duration(DefaultDelay)},
+	}
+	if got := g.Players(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Players() = %+v, want %+v", got, want)
+	}
+
+	for _, bad := range []string{"bot: 2", "bots: 9", "stakes: [low]", "table: [{delay: 5}]", "stakes: low\n  bots: 2"} {
+		os.WriteFile(path, []byte(bad), 0o644)
+		if _, err := Load(path); err == nil {
+			t.Errorf("Load(%q) succeeded, want an error", bad)
+		}
+	}
+}
@@ -0,0 +1,174 @@
+package sim
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// InitialRating is the rating a configuration joins the ladder with.
+const InitialRating = 1500
+
+// ladderK is the most a rating moves in one game.
+const ladderK = 32
+
+// Ladder ranks bot configurations by Elo rating across arena runs. Every
+// game of a run counts as a match between each pair of configurations at
+// the table, won by the one that ended the game with more chips.
+type Ladder struct {
+	Ratings map[string]*Rating `json:"ratings"` // By configuration
+}
+
+// Rating is a configuration's standing on the ladder.
+type Rating struct {
+	Config     string    `json:"config"`
+	Rating     float64   `json:"rating"`
+	Games      int       `json:"games"` // Rated games played
+	Runs       int       `json:"runs"`  // Arena runs taken part in
+	LastPlayed time.Time `json:"lastPlayed"`
+}
+
+// LoadLadder reads the ladder at path, or returns an empty one if there is
+// none yet.
+func LoadLadder(path string) (*Ladder, error) {
+	l := &Ladder{Ratings: map[string]*Rating{}}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return l, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, l); err != nil {
+		return nil, fmt.Errorf("sim: reading ladder %s: %w", path, err)
+	}
+	if l.Ratings == nil {
+		l.Ratings = map[string]*Rating{}
+	}
+	return l, nil
+}
+
+// SaveLadder writes the ladder to path as indented JSON, replacing the file
+// atomically and creating its directory if needed.
+func SaveLadder(path string, l *Ladder) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// Record rates the games of an arena run and returns how much each
+// configuration's rating moved. Within a game every rating moves at once,
+// from the ratings the game started with, by up to ladderK shared out over
+// the configuration's opponents.
+func (l *Ladder) Record(r Report) map[string]float64 {
+	now := time.Now()
+	changes := map[string]float64{}
+	for _, res := range r.Results {
+		l.rating(res.Config).Runs++
+		l.rating(res.Config).LastPlayed = now
+		changes[res.Config] = 0
+	}
+	for _, nets := range r.GameNets {
+		configs := make([]string, 0, len(nets))
+		for config := range nets {
+			configs = append(configs, config)
+		}
+		if len(configs) < 2 {
+			continue
+		}
+		sort.Strings(configs)
+		k := ladderK / float64(len(configs)-1)
+		moves := make([]float64, len(configs))
+		for i, a := range configs {
+			for _, b := range configs {
+				if a == b {
+					continue
+				}
+				score := 0.5
+				switch {
+				case nets[a] > nets[b]:
+					score = 1
+				case nets[a] < nets[b]:
+					score = 0
+				}
+				expected := 1 / (1 + math.Pow(10, (l.rating(b).Rating-l.rating(a).Rating)/400))
+				moves[i] += k * (score - expected)
+			}
+		}
+		for i, config := range configs {
+			l.rating(config).Rating += moves[i]
+			l.rating(config).Games++
+			changes[config] += moves[i]
+		}
+	}
+	return changes
+}
+
+// rating returns a configuration's rating, adding it to the ladder at
+// InitialRating if it is new.
+func (l *Ladder) rating(config string) *Rating {
+	if l.Ratings == nil {
+		l.Ratings = map[string]*Rating{}
+	}
+	r, ok := l.Ratings[config]
+	if !ok {
+		r = &Rating{Config: config, Rating: InitialRating}
+		l.Ratings[config] = r
+	}
+	return r
+}
+
+// Standings returns the ratings, highest first.
+func (l *Ladder) Standings() []Rating {
+	standings := make([]Rating, 0, len(l.Ratings))
+	for _, r := range l.Ratings {
+		standings = append(standings, *r)
+	}
+	sort.Slice(standings, func(i, j int) bool {
+		a, b := standings[i], standings[j]
+		if a.Rating != b.Rating {
+			return a.Rating > b.Rating
+		}
+		return a.Config < b.Config
+	})
+	return standings
+}
+
+// LadderSummary formats the standings as a table. Changes, as returned by
+// Record, adds a column with each rating's move in the last run.
+func LadderSummary(l *Ladder, changes map[string]float64) string {
+	standings := l.Standings()
+	if len(standings) == 0 {
+		return "The ladder is empty. Play a run with: poker arena\n"
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- Arena ladder: %d bots ---\n", len(standings))
+	fmt.Fprintf(&b, "%4s %-24s %7s %6s %5s", "Rank", "Bot", "Rating", "Games", "Runs")
+	if changes != nil {
+		fmt.Fprintf(&b, " %7s", "Change")
+	}
+	b.WriteString("\n")
+	for i, r := range standings {
+		fmt.Fprintf(&b, "%4d %-24s %7.0f %6d %5d", i+1, r.Config, r.Rating, r.Games, r.Runs)
+		if change, ok := changes[r.Config]; ok {
+			fmt.Fprintf(&b, " %+7.1f", change)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
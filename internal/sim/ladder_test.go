@@ -0,0 +1,70 @@
+package sim
+
+import (
+	"math"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestLadderRecordsGames checks that each game moves the winner up and the
+// loser down by as much, and that the ladder is kept between runs.
+func TestLadderRecordsGames(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ladder", "arena.json")
+	l, err := LoadLadder(path)
+	if err != nil || len(l.Ratings) != 0 {
+		t.Fatalf("LoadLadder() of a missing file = %+v, %v, want an empty ladder", l, err)
+	}
+
+	report := Report{
+		Results:  []Result{{Config: "tag"}, {Config: "nit"}, {Config: "maniac"}},
+		GameNets: []map[string]int{{"tag": 150, "nit": 0, "maniac": -150}, {"tag": 40, "nit": 40, "maniac": -80}},
+	}
+	changes := l.Record(report)
+	standings := l.Standings()
+	if len(standings) != 3 || standings[0].Config != "tag" || standings[2].Config != "maniac" {
+		t.Fatalf("standings = %+v, want tag, nit, maniac", standings)
+	}
+	total := 0.0
+	for _, r := range standings {
+		total += r.Rating - InitialRating
+		if r.Games != 2 || r.Runs != 1 || math.Abs(changes[r.Config]-(r.Rating-InitialRating)) > 1e-9 {
+			t.Errorf("%s = %+v with a change of %.1f, want 2 games in 1 run", r.Config, r, changes[r.Config])
+		}
+	}
+	if math.Abs(total) > 1e-9 {
+		t.Errorf("ratings moved by %.2f in total, want 0", total)
+	}
+
+	if err := SaveLadder(path, l); err != nil {
+		t.Fatalf("SaveLadder() error: %v", err)
+	}
+	loaded, err := LoadLadder(path)
+	if err != nil {
+		t.Fatalf("LoadLadder() error: %v", err)
+	}
+	if got := loaded.Standings(); len(got) != 3 || got[0].Config != "tag" || got[0].Rating != standings[0].Rating || !got[0].LastPlayed.Equal(standings[0].LastPlayed) {
+		t.Errorf("loaded standings = %+v, want %+v", got, standings)
+	}
+	if summary := LadderSummary(loaded, nil); !strings.Contains(summary, "tag") || strings.Contains(summary, "Change") {
+		t.Errorf("LadderSummary() = %q, want the standings without changes", summary)
+	}
+}
+
+// TestLadderRatesSimulation checks that every game of a simulation is rated.
+func TestLadderRatesSimulation(t *testing.T) {
+	report, err := (&Simulation{Bots: []string{"easy", "tag"}, Chips: 100, Hands: 20, Games: 3, Seed: 2}).Run()
+	if err != nil {
+		t.Fatalf("Run() error: %v", err)
+	}
+	if len(report.GameNets) != 3 {
+		t.Fatalf("report has %d game results, want 3", len(report.GameNets))
+	}
+	l := &Ladder{}
+	l.Record(report)
+	for _, r := range l.Standings() {
+		if r.Games != 3 || r.Runs != 1 {
+			t.Errorf("%s = %+v, want 3 games in 1 run", r.Config, r)
+		}
+	}
+}
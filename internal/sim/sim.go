@@ -0,0 +1,275 @@
+// Package sim plays games between bots with no pauses and no one to prompt,
+// to compare bot configurations and to stress the engine with many hands.
+package sim
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"pokerclientv1/internal/player"
+	"pokerclientv1/pkg/game"
+	"pokerclientv1/pkg/gametest"
+	"pokerclientv1/pkg/types"
+)
+
+// Simulation seats one bot for each configuration in Bots and plays Games
+// games between them. Bots change seats from game to game so no
+// configuration keeps the best position.
+type Simulation struct {
+	Bots  []string // Difficulty or personality of each bot, e.g. "hard" or "tag", or script:FILE to play a decision file
+	Chips int      // Stack every bot starts each game with
+	Hands int      // Hands per game; zero plays until one bot has every chip
+	Games int      // Games to play, one when zero
+	Rules game.GameConfig
+	Seed  int64 // Seeds the shuffles and bot decisions; zero seeds from the clock
+
+	// Draw seats again at random within each game, every hand with
+	// SeatDrawHand or every orbit with SeatDrawOrbit, so no configuration
+	// sits to the left of another for long. Empty keeps the seats for the
+	// whole game.
+	SeatDraw string
+
+	// Check the chips after every action, panicking with a dump of the
+	// table if they do not add up
+	CheckInvariants bool
+
+	// Personalities for bots named in Bots beyond the presets, such as a
+	// preset with one parameter changed, by configuration
+	Personalities map[string]player.Personality
+}
+
+// ScriptPrefix starts a bot configuration that plays a decision file, see
+// gametest.Streets.
+const ScriptPrefix = "script:"
+
+// Seat draws for Simulation.SeatDraw.
+const (
+	SeatDrawHand  = "hand"
+	SeatDrawOrbit = "orbit"
+)
+
+// Result totals the games of one bot configuration over every seat that
+// played it.
+type Result struct {
+	Config    string
+	Seats     int // Bots that played the configuration, one per game per seat
+	GamesWon  int // Games finished with the most chips, shared on a tie
+	HandsWon  int // Hands in which the configuration took at least one pot
+	Hands     int // Hands its bots were dealt
+	Net       int // Chips won or lost
+	WinRate   float64
+	ChipEV    float64 // Chips won per hand dealt
+	HandShare float64 // Share of hands dealt in which a pot was won
+}
+
+// Report is the outcome of a simulation.
+type Report struct {
+	Games    int
+	Hands    int // Hands played at the table, across every game
+	Elapsed  time.Duration
+	Seed     int64    // Seed the simulation ran with, to repeat it
+	SeatDraw string   // How often seats were drawn, empty when never
+	Results  []Result // Sorted by chip EV, best first
+
+	// Chips each configuration won or lost in each game, in the order the
+	// games were played
+	GameNets []map[string]int
+}
+
+// Run plays the simulation. It fails if a hand ever creates or loses chips,
+// which would be a bug in the engine.
+func (s *Simulation) Run() (Report, error) {
+	if len(s.Bots) < 2 {
+		return Report{}, fmt.Errorf("sim: need at least two bots, got %d", len(s.Bots))
+	}
+	scripts := map[string]gametest.Streets{} // Decision files by configuration
+	for _, config := range s.Bots {
+		if path, ok := strings.CutPrefix(config, ScriptPrefix); ok {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return Report{}, fmt.Errorf("sim: %w", err)
+			}
+			if scripts[config], err = gametest.ParseStreets(data); err != nil {
+				return Report{}, fmt.Errorf("sim: %s: %w", path, err)
+			}
+			continue
+		}
+		if _, ok := s.Personalities[config]; !ok && !player.ValidDifficulty(config) {
+			return Report{}, fmt.Errorf("sim: unknown bot %q", config)
+		}
+	}
+	if s.SeatDraw != "" && s.SeatDraw != SeatDrawHand && s.SeatDraw != SeatDrawOrbit {
+		return Report{}, fmt.Errorf("sim: unknown seat draw %q, want %s or %s", s.SeatDraw, SeatDrawHand, SeatDrawOrbit)
+	}
+	games := max(s.Games, 1)
+	seed := s.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	rng := rand.New(rand.NewSource(seed))
+
+	started := time.Now()
+	report := Report{Games: games, Seed: seed, SeatDraw: s.SeatDraw}
+	totals := map[string]*Result{}
+	for _, config := range s.Bots {
+		if totals[config] == nil {
+			totals[config] = &Result{Config: config}
+		}
+	}
+	for i := 0; i < games; i++ {
+		hands, nets, err := s.play(i, rng, totals, scripts)
+		report.Hands += hands
+		report.GameNets = append(report.GameNets, nets)
+		if err != nil {
+			return report, err
+		}
+	}
+	report.Elapsed = time.Since(started)
+
+	for _, r := range totals {
+		if r.Hands > 0 {
+			r.ChipEV = float64(r.Net) / float64(r.Hands)
+			r.HandShare = float64(r.HandsWon) / float64(r.Hands)
+		}
+		if r.Seats > 0 {
+			r.WinRate = float64(r.GamesWon) / float64(r.Seats)
+		}
+		report.Results = append(report.Results, *r)
+	}
+	sort.Slice(report.Results, func(i, j int) bool {
+		a, b := report.Results[i], report.Results[j]
+		if a.ChipEV != b.ChipEV {
+			return a.ChipEV > b.ChipEV
+		}
+		return a.Config < b.Config
+	})
+	return report, nil
+}
+
+// play plays game number i, adding each bot's results to its configuration's
+// totals, and returns the number of hands played and what each
+// configuration won or lost in the game.
+func (s *Simulation) play(i int, rng *rand.Rand, totals map[string]*Result, scripts map[string]gametest.Streets) (int, map[string]int, error) {
+	n := len(s.Bots)
+	players := make([]types.Player, n)
+	configs := map[string]string{} // Bot ID to configuration
+	for seat := range players {
+		config := s.Bots[(seat+i)%n]
+		id := fmt.Sprintf("Bot %d", (seat+i)%n+1)
+		if streets, ok := scripts[config]; ok {
+			scripted := gametest.NewScriptedPlayer(id, s.Chips)
+			scripted.Streets = streets
+			players[seat] = scripted
+		} else {
+			bot := player.NewBotPlayer(id, s.Chips, config, 0)
+			if personality, ok := s.Personalities[config]; ok {
+				bot.AI.Personality = &personality
+			}
+			bot.AI.Rng = rand.New(rand.NewSource(rng.Int63()))
+			players[seat] = bot
+		}
+		configs[id] = config
+		totals[config].Seats++
+	}
+	total := s.Chips * n
+
+	wonHand := map[string]bool{}
+	var err error
+	cfg := game.Config{
+		Players: players,
+		Rules:   s.Rules,
+		Rng:     rand.New(rand.NewSource(rng.Int63())),
+	}
+	if s.CheckInvariants {
+		cfg.CheckInvariants = game.InvariantsPanic
+	}
+	engine := game.NewEngine(cfg)
+	g := engine.Game()
+	g.OnEvent(func(e game.Event) {
+		switch e.Type {
+		case game.EventHandStarted:
+			clear(wonHand)
+			for _, p := range g.Players {
+				if p.GetChips() > 0 {
+					totals[configs[p.GetID()]].Hands++
+				}
+			}
+		case game.EventPotAwarded:
+			wonHand[e.PlayerID] = true
+		case game.EventHandEnded:
+			for id := range wonHand {
+				totals[configs[id]].HandsWon++
+			}
+			if chips := chipsAtTable(g); chips != total && err == nil {
+				err = fmt.Errorf("sim: game %d hand %d ended with %d chips at the table, want %d", i+1, e.HandNumber, chips, total)
+			}
+		}
+	})
+	var seats *rand.Rand
+	if s.SeatDraw != "" {
+		seats = rand.New(rand.NewSource(rng.Int63()))
+	}
+	sinceDraw := 0
+	for (s.Hands == 0 || engine.HandNumber() < s.Hands) && err == nil {
+		if seats != nil && (s.SeatDraw == SeatDrawHand || sinceDraw >= len(g.Players)) {
+			g.DrawSeats(seats)
+			sinceDraw = 0
+		}
+		if !engine.PlayHand() {
+			break
+		}
+		sinceDraw++
+	}
+	if err != nil {
+		return engine.HandNumber(), nil, err
+	}
+
+	most := 0
+	for _, p := range players {
+		most = max(most, p.GetChips())
+	}
+	winners := []string{}
+	nets := map[string]int{}
+	for _, p := range players {
+		totals[configs[p.GetID()]].Net += p.GetChips() - s.Chips
+		nets[configs[p.GetID()]] += p.GetChips() - s.Chips
+		if p.GetChips() == most {
+			winners = append(winners, configs[p.GetID()])
+		}
+	}
+	if len(winners) == 1 {
+		totals[winners[0]].GamesWon++
+	}
+	return engine.HandNumber(), nets, nil
+}
+
+// chipsAtTable returns the chips in every stack and the pot. Bets are
+// counted in the pot as soon as they are made.
+func chipsAtTable(g *game.Game) int {
+	chips := g.Table.Pot
+	for _, p := range g.Players {
+		chips += p.GetChips()
+	}
+	return chips
+}
+
+// Summary formats a report as a table, one line per configuration.
+func Summary(r Report) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- Simulation: %d games, %d hands in %s ---\n", r.Games, r.Hands, r.Elapsed.Round(time.Millisecond))
+	fmt.Fprintf(&b, "Seed %d", r.Seed)
+	if r.SeatDraw != "" {
+		fmt.Fprintf(&b, ", seats drawn every %s", r.SeatDraw)
+	}
+	b.WriteString("\n")
+	fmt.Fprintf(&b, "%-16s %6s %8s %8s %10s %10s\n", "Bot", "Seats", "Won", "Pots", "Net", "Chips/hand")
+	for _, res := range r.Results {
+		fmt.Fprintf(&b, "%-16s %6d %7.1f%% %7.1f%% %+10d %+10.2f\n",
+			res.Config, res.Seats, 100*res.WinRate, 100*res.HandShare, res.Net, res.ChipEV)
+	}
+	return b.String()
+}
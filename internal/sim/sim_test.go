@@ -0,0 +1,103 @@
+package sim
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestSimulationConservesChips plays a few short games and checks that every
+// configuration is reported and no chips are made or lost.
+func TestSimulationConservesChips(t *testing.T) {
+	s := &Simulation{Bots: []string{"easy", "nit", "maniac"}, Chips: 200, Hands: 30, Games: 3, Seed: 1}
+	report, err := s.Run()
+	if err != nil {
+		t.Fatalf("Run() error: %v", err)
+	}
+	if len(report.Results) != 3 {
+		t.Fatalf("Run() returned %d results, want one per configuration", len(report.Results))
+	}
+	net, hands := 0, 0
+	for _, r := range report.Results {
+		if r.Seats != 3 {
+			t.Errorf("%s played %d seats, want 3", r.Config, r.Seats)
+		}
+		net += r.Net
+		hands += r.Hands
+	}
+	if net != 0 {
+		t.Errorf("net results add up to %d, want 0", net)
+	}
+	if hands == 0 || report.Hands == 0 {
+		t.Errorf("no hands were played: %+v", report)
+	}
+	if summary := Summary(report); !strings.Contains(summary, "maniac") {
+		t.Errorf("Summary() = %q, want a line for each configuration", summary)
+	}
+}
+
+// TestSimulationIsReproducible checks that a seed replays the same games.
+func TestSimulationIsReproducible(t *testing.T) {
+	run := func() Report {
+		s := &Simulation{Bots: []string{"easy", "lag"}, Chips: 100, Hands: 20, Games: 2, Seed: 7}
+		report, err := s.Run()
+		if err != nil {
+			t.Fatalf("Run() error: %v", err)
+		}
+		return report
+	}
+	first, second := run(), run()
+	if first.Hands != second.Hands || first.Results[0] != second.Results[0] {
+		t.Errorf("runs with the same seed differ: %+v and %+v", first.Results, second.Results)
+	}
+}
+
+// TestSimulationDrawsSeats checks that games with seat draws keep their chips
+// and the report says how seats were drawn.
+func TestSimulationDrawsSeats(t *testing.T) {
+	for _, draw := range []string{SeatDrawHand, SeatDrawOrbit} {
+		s := &Simulation{Bots: []string{"easy", "tag", "lag"}, Chips: 100, Hands: 20, Games: 2, Seed: 3, SeatDraw: draw}
+		report, err := s.Run()
+		if err != nil {
+			t.Fatalf("Run() drawing seats every %s error: %v", draw, err)
+		}
+		if report.SeatDraw != draw || report.Seed != 3 {
+			t.Errorf("report has seat draw %q and seed %d, want %q and 3", report.SeatDraw, report.Seed, draw)
+		}
+		if summary := Summary(report); !strings.Contains(summary, "seats drawn every "+draw) {
+			t.Errorf("Summary() = %q, want the seat draw noted", summary)
+		}
+	}
+}
+
+// TestSimulationRejectsUnknownBots checks the configurations are validated.
+func TestSimulationRejectsUnknownBots(t *testing.T) {
+	if _, err := (&Simulation{Bots: []string{"easy", "shark"}, Chips: 100}).Run(); err == nil {
+		t.Errorf("Run() with an unknown bot returned no error")
+	}
+	if _, err := (&Simulation{Bots: []string{"easy", "hard"}, Chips: 100, SeatDraw: "level"}).Run(); err == nil {
+		t.Errorf("Run() with an unknown seat draw returned no error")
+	}
+}
+
+// TestSimulationPlaysDecisionFiles seats a bot that plays a decision file,
+// calling every bet, against a built-in bot.
+func TestSimulationPlaysDecisionFiles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "station.json")
+	if err := os.WriteFile(path, []byte(`{"default": [{"if": "checked to", "action": "check"}, {"action": "call"}]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	s := &Simulation{Bots: []string{ScriptPrefix + path, "tag"}, Chips: 100, Hands: 20, Games: 2, Seed: 5}
+	report, err := s.Run()
+	if err != nil {
+		t.Fatalf("Run() error: %v", err)
+	}
+	if len(report.Results) != 2 || report.Hands == 0 {
+		t.Errorf("Run() = %+v, want results for both bots", report)
+	}
+
+	if _, err := (&Simulation{Bots: []string{ScriptPrefix + filepath.Join(t.TempDir(), "missing.json"), "tag"}, Chips: 100}).Run(); err == nil {
+		t.Errorf("Run() with a missing decision file returned no error")
+	}
+}
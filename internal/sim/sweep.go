@@ -0,0 +1,147 @@
+package sim
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"pokerclientv1/internal/player"
+)
+
+// SweepParams are the personality parameters a Sweep can vary, by the name
+// given on the command line.
+var SweepParams = map[string]func(p *player.Personality) *float64{
+	"bluffFreq":  func(p *player.Personality) *float64 { return &p.Bluff },
+	"vpip":       func(p *player.Personality) *float64 { return &p.VPIP },
+	"pfr":        func(p *player.Personality) *float64 { return &p.PFR },
+	"aggression": func(p *player.Personality) *float64 { return &p.Aggression },
+}
+
+// maxSweepValues bounds how many values one sweep plays.
+const maxSweepValues = 1000
+
+// Sweep varies one parameter of a bot personality over a range of values
+// and plays a simulation against the same opponents at each of them, with
+// the same seed so every value is dealt the same cards.
+type Sweep struct {
+	Simulation // Chips, hands, games, rules, seed and seat draw of every run; Bots is ignored
+
+	Base           string   // Personality preset the swept bot starts from, e.g. "tag"
+	Param          string   // Parameter varied, a key of SweepParams
+	From, To, Step float64  // Values played, From, From+Step and so on up to To
+	Opponents      []string // Configurations of the other bots, as in Simulation.Bots
+}
+
+// SweepPoint is how the swept bot did at one value of the parameter.
+type SweepPoint struct {
+	Value  float64
+	Result Result
+}
+
+// SweepReport is the outcome of a sweep.
+type SweepReport struct {
+	Base    string
+	Param   string
+	Seed    int64
+	Elapsed time.Duration
+	Points  []SweepPoint // In the order played, lowest value first
+	Best    SweepPoint   // The value with the best chip EV
+}
+
+// ParseSweepParam reads a parameter range written as name=from:to:step, such
+// as bluffFreq=0.1:0.5:0.05.
+func ParseSweepParam(spec string) (name string, from, to, step float64, err error) {
+	name, values, ok := strings.Cut(spec, "=")
+	bounds := strings.Split(values, ":")
+	if !ok || len(bounds) != 3 {
+		return "", 0, 0, 0, fmt.Errorf("sim: parameter range %q is not name=from:to:step", spec)
+	}
+	var parsed [3]float64
+	for i, b := range bounds {
+		if parsed[i], err = strconv.ParseFloat(strings.TrimSpace(b), 64); err != nil {
+			return "", 0, 0, 0, fmt.Errorf("sim: parameter range %q: %q is not a number", spec, b)
+		}
+	}
+	return strings.TrimSpace(name), parsed[0], parsed[1], parsed[2], nil
+}
+
+// Run plays the sweep, one simulation per value.
+func (s *Sweep) Run() (SweepReport, error) {
+	base, ok := player.LookupPersonality(s.Base)
+	if !ok {
+		return SweepReport{}, fmt.Errorf("sim: unknown personality %q, choose from %s", s.Base, strings.Join(player.PersonalityNames(), ", "))
+	}
+	field, ok := SweepParams[s.Param]
+	if !ok {
+		names := make([]string, 0, len(SweepParams))
+		for name := range SweepParams {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return SweepReport{}, fmt.Errorf("sim: unknown parameter %q, choose from %s", s.Param, strings.Join(names, ", "))
+	}
+	if s.Step <= 0 || s.To < s.From || s.From < 0 {
+		return SweepReport{}, fmt.Errorf("sim: sweep from %g to %g in steps of %g, want 0 <= from <= to and a positive step", s.From, s.To, s.Step)
+	}
+	values := int(math.Floor((s.To-s.From)/s.Step+1e-9)) + 1
+	if values > maxSweepValues {
+		return SweepReport{}, fmt.Errorf("sim: sweep plays %d values, at most %d allowed", values, maxSweepValues)
+	}
+	if len(s.Opponents) == 0 {
+		return SweepReport{}, fmt.Errorf("sim: sweep needs at least one opponent")
+	}
+
+	report := SweepReport{Base: base.Name, Param: s.Param, Seed: s.Seed}
+	if report.Seed == 0 {
+		report.Seed = time.Now().UnixNano()
+	}
+	started := time.Now()
+	for i := 0; i < values; i++ {
+		value := s.From + float64(i)*s.Step
+		swept := base
+		*field(&swept) = value
+		config := fmt.Sprintf("%s %s=%g", base.Name, s.Param, value)
+
+		sim := s.Simulation
+		sim.Seed = report.Seed
+		sim.Bots = append([]string{config}, s.Opponents...)
+		sim.Personalities = map[string]player.Personality{config: swept}
+		run, err := sim.Run()
+		if err != nil {
+			return report, err
+		}
+		for _, r := range run.Results {
+			if r.Config == config {
+				report.Points = append(report.Points, SweepPoint{Value: value, Result: r})
+			}
+		}
+	}
+	report.Elapsed = time.Since(started)
+
+	report.Best = report.Points[0]
+	for _, p := range report.Points[1:] {
+		if p.Result.ChipEV > report.Best.Result.ChipEV {
+			report.Best = p
+		}
+	}
+	return report, nil
+}
+
+// SweepSummary formats a sweep report as a table, one line per value, and
+// names the best one.
+func SweepSummary(r SweepReport) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- Sweep: %s %s over %d values in %s ---\n", r.Base, r.Param, len(r.Points), r.Elapsed.Round(time.Millisecond))
+	fmt.Fprintf(&b, "Seed %d\n", r.Seed)
+	fmt.Fprintf(&b, "%-10s %6s %8s %8s %10s %10s\n", r.Param, "Seats", "Won", "Pots", "Net", "Chips/hand")
+	for _, p := range r.Points {
+		res := p.Result
+		fmt.Fprintf(&b, "%-10g %6d %7.1f%% %7.1f%% %+10d %+10.2f\n",
+			p.Value, res.Seats, 100*res.WinRate, 100*res.HandShare, res.Net, res.ChipEV)
+	}
+	fmt.Fprintf(&b, "Best: %s=%g at %+.2f chips per hand.\n", r.Param, r.Best.Value, r.Best.Result.ChipEV)
+	return b.String()
+}
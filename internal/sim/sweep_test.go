@@ -0,0 +1,67 @@
+package sim
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestSweepPlaysEachValue sweeps the bluff frequency of a preset and checks
+// a result comes back for every value, with the best one named.
+func TestSweepPlaysEachValue(t *testing.T) {
+	s := &Sweep{
+		Simulation: Simulation{Chips: 200, Hands: 20, Games: 2, Seed: 1},
+		Base:       "tag",
+		Param:      "bluffFreq",
+		From:       0, To: 0.5, Step: 0.25,
+		Opponents: []string{"nit", "easy"},
+	}
+	report, err := s.Run()
+	if err != nil {
+		t.Fatalf("Run() error: %v", err)
+	}
+	if len(report.Points) != 3 {
+		t.Fatalf("Run() played %d values, want 0, 0.25 and 0.5", len(report.Points))
+	}
+	for i, want := range []float64{0, 0.25, 0.5} {
+		p := report.Points[i]
+		if p.Value != want || p.Result.Seats != 2 || p.Result.Hands == 0 {
+			t.Errorf("point %d = %+v, want value %g played in 2 seats", i, p, want)
+		}
+		if p.Result.ChipEV > report.Best.Result.ChipEV {
+			t.Errorf("value %g did better than the best, %g", p.Value, report.Best.Value)
+		}
+	}
+	if report.Seed != 1 {
+		t.Errorf("report seed = %d, want 1", report.Seed)
+	}
+	if summary := SweepSummary(report); !strings.Contains(summary, "Best: bluffFreq=") {
+		t.Errorf("SweepSummary() = %q, want the best value named", summary)
+	}
+}
+
+// TestSweepValidates checks the parameter range is read and checked.
+func TestSweepValidates(t *testing.T) {
+	name, from, to, step, err := ParseSweepParam("bluffFreq=0.1:0.5:0.05")
+	if err != nil || name != "bluffFreq" || from != 0.1 || to != 0.5 || step != 0.05 {
+		t.Errorf("ParseSweepParam() = %s %g %g %g %v, want bluffFreq 0.1 0.5 0.05", name, from, to, step, err)
+	}
+	for _, spec := range []string{"bluffFreq", "bluffFreq=0.1:0.5", "bluffFreq=a:b:c"} {
+		if _, _, _, _, err := ParseSweepParam(spec); err == nil {
+			t.Errorf("ParseSweepParam(%q) returned no error", spec)
+		}
+	}
+
+	sweeps := []Sweep{
+		{Base: "shark", Param: "bluffFreq", To: 1, Step: 0.1, Opponents: []string{"nit"}},
+		{Base: "tag", Param: "luck", To: 1, Step: 0.1, Opponents: []string{"nit"}},
+		{Base: "tag", Param: "bluffFreq", From: 0.5, To: 0.1, Step: 0.1, Opponents: []string{"nit"}},
+		{Base: "tag", Param: "bluffFreq", To: 1, Step: 0, Opponents: []string{"nit"}},
+		{Base: "tag", Param: "bluffFreq", To: 1, Step: 0.1},
+	}
+	for _, s := range sweeps {
+		s.Chips = 100
+		if _, err := s.Run(); err == nil {
+			t.Errorf("Run() of %+v returned no error", s)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+// Package sound plays sound effects for what happens at the table: cards
+// being dealt, chips going in and a jingle when a pot is won. A terminal
+// can only ring its bell, so that is all Bell does; a GUI frontend attaches
+// real audio by implementing Sound.
+package sound
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"pokerclientv1/pkg/game"
+)
+
+// Sound plays the game's sound effects. It is called on the game goroutine,
+// so an implementation playing real audio should not wait for it to end.
+type Sound interface {
+	CardDealt() // Hole cards or a street were dealt
+	ChipsBet()  // Chips went in: a blind, a bet, a call or a raise
+	Win()       // A pot was won by a player who hears the game
+}
+
+// Modes lists the sound modes New accepts.
+var Modes = []string{"bell", "off"}
+
+// New returns the Sound for mode: "bell" rings the terminal bell on out and
+// "off" plays nothing.
+func New(mode string, out io.Writer) (Sound, error) {
+	switch mode {
+	case "bell":
+		return Bell{Out: out}, nil
+	case "off":
+		return Off{}, nil
+	}
+	return nil, fmt.Errorf("unknown sound mode %q, choose from %s", mode, strings.Join(Modes, ", "))
+}
+
+// Bell rings the terminal bell: once for cards and chips, three times for a
+// win.
+type Bell struct {
+	Out io.Writer
+}
+
+func (b Bell) CardDealt() { b.ring(1) }
+func (b Bell) ChipsBet()  { b.ring(1) }
+func (b Bell) Win()       { b.ring(3) }
+
+func (b Bell) ring(times int) {
+	fmt.Fprint(b.Out, strings.Repeat("\a", times))
+}
+
+// Off plays nothing.
+type Off struct{}
+
+func (Off) CardDealt() {}
+func (Off) ChipsBet()  {}
+func (Off) Win()       {}
+
+// Effects plays Sound for the game's events. Register HandleEvent with
+// Game.OnEvent.
+type Effects struct {
+	Sound   Sound
+	Players []string // Whose hole cards and pots are heard, usually the humans at the screen
+}
+
+// NewEffects plays sound for the game as heard by players.
+func NewEffects(sound Sound, players ...string) *Effects {
+	return &Effects{Sound: sound, Players: players}
+}
+
+// HandleEvent plays the sound, if any, that goes with e.
+func (fx *Effects) HandleEvent(e game.Event) {
+	switch e.Type {
+	case game.EventHoleCardsDealt:
+		if fx.hears(e.PlayerID) {
+			fx.Sound.CardDealt()
+		}
+	case game.EventStreetDealt:
+		fx.Sound.CardDealt()
+	case game.EventBlindPosted:
+		fx.Sound.ChipsBet()
+	case game.EventPlayerAction:
+		if e.Amount > 0 {
+			fx.Sound.ChipsBet()
+		}
+	case game.EventPotAwarded:
+		if fx.hears(e.PlayerID) {
+			fx.Sound.Win()
+		}
+	}
+}
+
+func (fx *Effects) hears(playerID string) bool {
+	return slices.Contains(fx.Players, playerID)
+}
@@ -0,0 +1,55 @@
+package sound
+
+import (
+	"bytes"
+	"testing"
+
+	"pokerclientv1/pkg/gametest"
+)
+
+// counter counts the sounds it is asked to play.
+type counter struct {
+	cards, chips, wins int
+}
+
+func (c *counter) CardDealt() { c.cards++ }
+func (c *counter) ChipsBet()  { c.chips++ }
+func (c *counter) Win()       { c.wins++ }
+
+// TestEffects: BTN raises, the human in the big blind calls and wins the
+// hand checked down.
+func TestEffects(t *testing.T) {
+	btn := gametest.NewScriptedPlayer("BTN", 100, gametest.RaiseTo(6), gametest.Check(), gametest.Check(), gametest.Check())
+	you := gametest.NewScriptedPlayer("You", 100, gametest.Call(), gametest.Check(), gametest.Check(), gametest.Check())
+	deck := gametest.Deck(t,
+		[][]string{{"7c", "2d"}, {"Ks", "Kh"}},
+		[]string{"3c", "8d", "9s", "Jh", "Qc"})
+	h := gametest.New(t, deck, btn, you)
+	heard := &counter{}
+	h.Engine.OnEvent(NewEffects(heard, "You").HandleEvent)
+	h.PlayHand()
+
+	// Your hole cards and three streets; two blinds, a raise and a call
+	if *heard != (counter{cards: 4, chips: 4, wins: 1}) {
+		t.Errorf("heard %+v, want 4 deals, 4 bets and 1 win", *heard)
+	}
+}
+
+func TestNew(t *testing.T) {
+	var out bytes.Buffer
+	bell, err := New("bell", &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bell.CardDealt()
+	bell.Win()
+	if out.String() != "\a\a\a\a" {
+		t.Errorf("bell wrote %q, want four bells", out.String())
+	}
+	if _, err := New("off", nil); err != nil {
+		t.Errorf("New(off): %v", err)
+	}
+	if _, err := New("trumpet", nil); err == nil {
+		t.Error("New(trumpet) should fail")
+	}
+}
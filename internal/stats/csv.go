@@ -0,0 +1,151 @@
+package stats
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+
+	"pokerclientv1/internal/store"
+	"pokerclientv1/pkg/game"
+)
+
+// CSVSchemaVersion identifies the layout of CSVColumns. Columns are only
+// ever appended; renaming, removing or reordering one bumps the version so
+// spreadsheets and tracker import templates built on it keep working.
+const CSVSchemaVersion = 1
+
+// CSVColumn is one column of the stats CSV written by WriteCSV.
+type CSVColumn struct {
+	Name  string // Header, named as the popular trackers name the stat
+	Doc   string // What the column holds
+	value func(SessionStats) string
+}
+
+// CSVColumns is the stable schema of the stats CSV, in column order.
+// Percentages are plain numbers with one decimal and are empty when the spot
+// never came up, so spreadsheets average them correctly.
+var CSVColumns = []CSVColumn{
+	{"Player", "player ID", func(s SessionStats) string { return s.Stats.PlayerID }},
+	{"Session", "session ID the hands were recorded under", func(s SessionStats) string { return s.Session }},
+	{"Hands", "hands dealt in", func(s SessionStats) string { return strconv.Itoa(s.Stats.HandsDealt) }},
+	{"Net Won", "chips won minus chips put in", func(s SessionStats) string { return strconv.Itoa(s.Stats.NetChips) }},
+	{"bb/100", "net big blinds per 100 hands, at the big blind of each hand", func(s SessionStats) string {
+		return decimal(100*s.NetBB, float64(s.Stats.HandsDealt), 2)
+	}},
+	{"VPIP", "% of hands with money put in voluntarily pre-flop", func(s SessionStats) string {
+		return ratio(s.Stats.VPIPHands, s.Stats.HandsDealt)
+	}},
+	{"PFR", "% of hands raised pre-flop", func(s SessionStats) string { return ratio(s.Stats.PFRHands, s.Stats.HandsDealt) }},
+	{"3Bet", "% of single pre-flop raises re-raised", func(s SessionStats) string {
+		return ratio(s.Stats.ThreeBetHands, s.Stats.ThreeBetChance)
+	}},
+	{"Cold Call", "% of first pre-flop decisions facing a raise that called", func(s SessionStats) string {
+		return ratio(s.Stats.ColdCalls, s.Stats.ColdCallChance)
+	}},
+	{"Squeeze", "% of raise-and-caller spots re-raised", func(s SessionStats) string {
+		return ratio(s.Stats.Squeezes, s.Stats.SqueezeChance)
+	}},
+	{"Fold to F CBet", "% of flop continuation bets folded to", func(s SessionStats) string {
+		return ratio(s.Stats.FoldsToCBet, s.Stats.FoldToCBetChance)
+	}},
+	{"Check-Raise", "% of check-then-face-a-bet spots check-raised", func(s SessionStats) string {
+		return ratio(s.Stats.CheckRaises, s.Stats.CheckRaiseChance)
+	}},
+	{"AF", "bets and raises per call after the deal", func(s SessionStats) string {
+		return decimal(float64(s.Stats.Bets), float64(s.Stats.Calls), 2)
+	}},
+	{"W$SD", "% of showdowns won", func(s SessionStats) string { return ratio(s.Stats.ShowdownsWon, s.Stats.Showdowns) }},
+	{"Showdowns", "hands that went to showdown", func(s SessionStats) string { return strconv.Itoa(s.Stats.Showdowns) }},
+}
+
+// SessionStats is one player's stat line over one session.
+type SessionStats struct {
+	Session string
+	Stats   store.PlayerStats
+	NetBB   float64 // Net result in big blinds, at the stakes of each hand
+}
+
+// BySession computes every player's stats separately for each session, in
+// the order the sessions appear and the players were first dealt in.
+func BySession(hands []store.HandRecord) []SessionStats {
+	trackers := map[string]*Tracker{}
+	netBB := map[string]map[string]float64{}
+	sessions := []string{}
+	for _, hand := range hands {
+		tracker, ok := trackers[hand.SessionID]
+		if !ok {
+			tracker = NewTracker()
+			trackers[hand.SessionID] = tracker
+			netBB[hand.SessionID] = map[string]float64{}
+			sessions = append(sessions, hand.SessionID)
+		}
+		before := map[string]int{}
+		for _, st := range tracker.Stats() {
+			before[st.PlayerID] = st.NetChips
+		}
+		bigBlind := game.DefaultBigBlind
+		for _, e := range hand.Events {
+			if e.Type == game.EventBlindPosted && e.Action == "big blind" && e.Amount > 0 {
+				bigBlind = e.Amount
+			}
+			tracker.HandleEvent(e)
+		}
+		for _, st := range tracker.Stats() {
+			netBB[hand.SessionID][st.PlayerID] += float64(st.NetChips-before[st.PlayerID]) / float64(bigBlind)
+		}
+	}
+
+	rows := []SessionStats{}
+	for _, session := range sessions {
+		for _, st := range trackers[session].Stats() {
+			rows = append(rows, SessionStats{Session: session, Stats: st, NetBB: netBB[session][st.PlayerID]})
+		}
+	}
+	return rows
+}
+
+// WriteCSV writes a header of CSVColumns and one row per player per session.
+func WriteCSV(w io.Writer, rows []SessionStats) error {
+	out := csv.NewWriter(w)
+	record := make([]string, len(CSVColumns))
+	for i, col := range CSVColumns {
+		record[i] = col.Name
+	}
+	if err := out.Write(record); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		for i, col := range CSVColumns {
+			record[i] = col.value(row)
+		}
+		if err := out.Write(record); err != nil {
+			return err
+		}
+	}
+	out.Flush()
+	return out.Error()
+}
+
+// ratio formats n out of chances as a percentage with one decimal, or ""
+// when the spot never came up.
+func ratio(n, chances int) string {
+	return decimal(100*float64(n), float64(chances), 1)
+}
+
+// decimal formats n/d with the given precision, or "" when d is zero.
+func decimal(n, d float64, precision int) string {
+	if d == 0 {
+		return ""
+	}
+	return strconv.FormatFloat(n/d, 'f', precision, 64)
+}
+
+// CSVSchema describes every column of the stats CSV, one per line.
+func CSVSchema() string {
+	s := fmt.Sprintf("Stats CSV schema version %d\n", CSVSchemaVersion)
+	for _, col := range CSVColumns {
+		s += fmt.Sprintf("%-15s %s\n", col.Name, col.Doc)
+	}
+	return s
+}
@@ -0,0 +1,63 @@
+package stats
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strings"
+	"testing"
+
+	"pokerclientv1/internal/store"
+	"pokerclientv1/pkg/gametest"
+)
+
+// TestCSVColumnsStable guards the version 1 layout that import templates
+// rely on; a change here needs a new CSVSchemaVersion.
+func TestCSVColumnsStable(t *testing.T) {
+	want := "Player,Session,Hands,Net Won,bb/100,VPIP,PFR,3Bet,Cold Call,Squeeze,Fold to F CBet,Check-Raise,AF,W$SD,Showdowns"
+	names := make([]string, len(CSVColumns))
+	for i, col := range CSVColumns {
+		names[i] = col.Name
+	}
+	if got := strings.Join(names, ","); CSVSchemaVersion == 1 && got != want {
+		t.Errorf("columns = %s, want %s", got, want)
+	}
+}
+
+// TestWriteCSV exports the check-raise hand recorded in two sessions, one
+// row per player per session.
+func TestWriteCSV(t *testing.T) {
+	btn := gametest.NewScriptedPlayer("BTN", 100, gametest.RaiseTo(6), gametest.RaiseTo(6), gametest.Fold())
+	sb := gametest.NewScriptedPlayer("SB", 100, gametest.Fold())
+	bb := gametest.NewScriptedPlayer("BB", 100, gametest.Call(), gametest.Check(), gametest.RaiseTo(18))
+	deck := gametest.Deck(t,
+		[][]string{{"As", "Kd"}, {"7c", "2d"}, {"8h", "8s"}},
+		[]string{"8c", "Qd", "3s", "4h", "5c"})
+	h := gametest.New(t, deck, btn, sb, bb)
+	h.PlayHand()
+	hands := []store.HandRecord{
+		{SessionID: "s1", HandNumber: 1, Events: h.Events},
+		{SessionID: "s2", HandNumber: 1, Events: h.Events},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, BySession(hands)); err != nil {
+		t.Fatalf("WriteCSV() error: %v", err)
+	}
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("reading the CSV back: %v", err)
+	}
+	if len(records) != 7 {
+		t.Fatalf("CSV has %d records, want a header and 3 players in each of 2 sessions", len(records))
+	}
+	var row []string
+	for _, r := range records[1:] {
+		if r[0] == "BB" && r[1] == "s2" {
+			row = r
+		}
+	}
+	want := []string{"BB", "s2", "1", "13", "650.00", "100.0", "0.0", "0.0", "100.0", "", "0.0", "100.0", "", "", "0"}
+	if strings.Join(row, ",") != strings.Join(want, ",") {
+		t.Errorf("BB's s2 row = %q, want %q", row, want)
+	}
+}
@@ -0,0 +1,401 @@
+// Package stats derives per-player statistics from game events.
+package stats
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+
+	"pokerclientv1/pkg/game"
+	"pokerclientv1/pkg/types"
+)
+
+// DefaultEVIterations is how many run-outs an EVTracker deals per estimate.
+const DefaultEVIterations = 500
+
+// bigDecisionShare is the smallest call, as a share of the pot before it,
+// that is recorded as a big decision.
+const bigDecisionShare = 0.5
+
+// HandEV is one player's result in one hand next to its expected value.
+// For hands where the betting ended with a player all-in before the river,
+// EV is the player's share of the pot averaged over every run-out of the
+// remaining board, minus what they put in. Otherwise EV equals Result.
+type HandEV struct {
+	HandNumber int     `json:"hand"`
+	PlayerID   string  `json:"player"`
+	AllIn      bool    `json:"allIn"`            // The hand was decided all-in with cards to come
+	Equity     float64 `json:"equity,omitempty"` // Expected share of the pot when AllIn
+	EV         float64 `json:"ev"`
+	Result     int     `json:"result"` // Chips won minus chips put in
+}
+
+// Decision is a large call and what it was worth against the cards the
+// opponents actually held, assuming the hand is checked down from there.
+type Decision struct {
+	HandNumber int     `json:"hand"`
+	PlayerID   string  `json:"player"`
+	Round      string  `json:"round"`
+	Call       int     `json:"call"`
+	Pot        int     `json:"pot"` // Pot before the call
+	Equity     float64 `json:"equity"`
+	EV         float64 `json:"ev"` // Expected chips won by calling instead of folding
+}
+
+// PlayerEV totals a player's EV and results over a session.
+type PlayerEV struct {
+	PlayerID string
+	Hands    int
+	AllIns   int
+	EV       float64
+	Result   int
+}
+
+// Luck is how many chips the player won above (or below) expectation.
+func (p PlayerEV) Luck() float64 { return float64(p.Result) - p.EV }
+
+// EVTracker follows game events and records each player's EV per hand and at
+// big decisions. Register HandleEvent with Game.OnEvent or Engine.OnEvent.
+type EVTracker struct {
+	Iterations int        // Run-outs per estimate, DefaultEVIterations when zero
+	Rng        *rand.Rand // Seeded from the clock when nil
+	Hands      []HandEV
+	Decisions  []Decision
+
+	hand    int
+	order   []string
+	stacks  map[string]int
+	contrib map[string]int
+	won     map[string]int
+	holes   map[string][]types.Card
+	folded  map[string]bool
+	board   []types.Card
+	allIn   []types.Card // Board when the betting ended all-in, nil until then
+	decided bool         // The betting has ended all-in
+}
+
+// NewEVTracker creates a tracker with the default number of iterations.
+func NewEVTracker() *EVTracker {
+	return &EVTracker{Iterations: DefaultEVIterations}
+}
+
+// HandleEvent updates the tracker with one game event.
+func (t *EVTracker) HandleEvent(e game.Event) {
+	switch e.Type {
+	case game.EventHandStarted:
+		t.hand = e.HandNumber
+		t.order = nil
+		t.stacks = make(map[string]int)
+		t.contrib = make(map[string]int)
+		t.won = make(map[string]int)
+		t.holes = make(map[string][]types.Card)
+		t.folded = make(map[string]bool)
+		t.board = nil
+		t.allIn = nil
+		t.decided = false
+	case game.EventPlayerSeated:
+		t.order = append(t.order, e.PlayerID)
+		t.stacks[e.PlayerID] = e.Amount
+	case game.EventBlindPosted:
+		t.contrib[e.PlayerID] += e.Amount
+	case game.EventHoleCardsDealt:
+		t.holes[e.PlayerID] = append([]types.Card(nil), e.Cards...)
+	case game.EventStreetDealt:
+		t.board = append(t.board, e.Cards...)
+	case game.EventPlayerAction:
+		t.playerAction(e)
+	case game.EventBetReturned:
+		t.contrib[e.PlayerID] -= e.Amount
+	case game.EventPotAwarded:
+		t.won[e.PlayerID] += e.Amount
+	case game.EventHandEnded:
+		t.finishHand()
+	}
+}
+
+func (t *EVTracker) playerAction(e game.Event) {
+	if t.contrib == nil {
+		return // Joined mid-hand
+	}
+	if strings.HasPrefix(e.Action, "fold") {
+		t.folded[e.PlayerID] = true
+		return
+	}
+	if strings.HasPrefix(e.Action, "call") && e.Amount > 0 && !t.decided {
+		pot := t.pot()
+		if float64(e.Amount) >= bigDecisionShare*float64(pot) {
+			t.recordDecision(e, pot)
+		}
+	}
+	t.contrib[e.PlayerID] += e.Amount
+	if !t.decided && t.bettingClosedAllIn() {
+		t.decided = true
+		t.allIn = append([]types.Card{}, t.board...)
+	}
+}
+
+// recordDecision values a call of e.Amount into pot against the hands still
+// in play.
+func (t *EVTracker) recordDecision(e game.Event, pot int) {
+	contrib := make(map[string]int, len(t.contrib))
+	for id, c := range t.contrib {
+		contrib[id] = c
+	}
+	contrib[e.PlayerID] += e.Amount
+	shares := t.expected(t.board, contrib)
+	win := shares[e.PlayerID]
+	t.Decisions = append(t.Decisions, Decision{
+		HandNumber: t.hand,
+		PlayerID:   e.PlayerID,
+		Round:      e.Round,
+		Call:       e.Amount,
+		Pot:        pot,
+		Equity:     win / float64(pot+e.Amount),
+		EV:         win - float64(e.Amount),
+	})
+}
+
+// bettingClosedAllIn reports whether no more betting can happen because at
+// most one player in the hand has chips left and has matched every bet.
+func (t *EVTracker) bettingClosedAllIn() bool {
+	inHand, withChips, maxContrib := 0, []string{}, 0
+	anyAllIn := false
+	for _, id := range t.order {
+		if t.folded[id] || len(t.holes[id]) == 0 {
+			continue
+		}
+		inHand++
+		if t.contrib[id] > maxContrib {
+			maxContrib = t.contrib[id]
+		}
+		if t.contrib[id] >= t.stacks[id] {
+			anyAllIn = true
+		} else {
+			withChips = append(withChips, id)
+		}
+	}
+	if inHand < 2 || !anyAllIn || len(withChips) > 1 {
+		return false
+	}
+	return len(withChips) == 0 || t.contrib[withChips[0]] >= maxContrib
+}
+
+// finishHand records every dealt player's EV and result for the hand.
+func (t *EVTracker) finishHand() {
+	if t.contrib == nil {
+		return
+	}
+	var shares map[string]float64
+	allIn := t.decided && len(t.allIn) < 5
+	if allIn {
+		shares = t.expected(t.allIn, t.contrib)
+	}
+	for _, id := range t.order {
+		if len(t.holes[id]) == 0 {
+			continue
+		}
+		result := t.won[id] - t.contrib[id]
+		h := HandEV{HandNumber: t.hand, PlayerID: id, AllIn: allIn, EV: float64(result), Result: result}
+		if allIn {
+			h.EV = shares[id] - float64(t.contrib[id])
+			h.Equity = shares[id] / float64(t.pot())
+		}
+		t.Hands = append(t.Hands, h)
+	}
+	t.contrib = nil
+}
+
+// pot returns the chips put in so far this hand.
+func (t *EVTracker) pot() int {
+	pot := 0
+	for _, c := range t.contrib {
+		pot += c
+	}
+	return pot
+}
+
+// expected deals the rest of the board from the given one and returns the
+// average chips each player in the hand wins, splitting the pot into side
+// pots by contribution.
+func (t *EVTracker) expected(board []types.Card, contrib map[string]int) map[string]float64 {
+	iterations := t.Iterations
+	if iterations <= 0 {
+		iterations = DefaultEVIterations
+	}
+	if t.Rng == nil {
+		t.Rng = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+
+	// Folded hands are dead cards, they are not dealt again
+	contenders := []string{}
+	known := append([]types.Card{}, board...)
+	for _, id := range t.order {
+		known = append(known, t.holes[id]...)
+		if !t.folded[id] && len(t.holes[id]) > 0 {
+			contenders = append(contenders, id)
+		}
+	}
+	stub := unseen(known)
+	missing := 5 - len(board)
+	if missing <= 0 {
+		iterations = 1
+	}
+
+	// Side pots: each layer holds what players put in up to a contribution
+	// level, and only contenders who reached that level can win it
+	levels := []int{}
+	for _, id := range contenders {
+		levels = append(levels, contrib[id])
+	}
+	sort.Ints(levels)
+
+	shares := make(map[string]float64, len(contenders))
+	cards := make([]types.Card, 0, 7)
+	values := make(map[string]uint32, len(contenders))
+	for i := 0; i < iterations; i++ {
+		for j := 0; j < missing; j++ {
+			k := j + t.Rng.Intn(len(stub)-j)
+			stub[j], stub[k] = stub[k], stub[j]
+		}
+		for _, id := range contenders {
+			cards = append(append(append(cards[:0], t.holes[id]...), board...), stub[:missing]...)
+			values[id] = game.HandStrength(cards)
+		}
+		prev := 0
+		for li, level := range levels {
+			if level == prev {
+				continue
+			}
+			layer := 0
+			for _, c := range contrib {
+				layer += min(c, level) - min(c, prev)
+			}
+			if li == len(levels)-1 {
+				// Dead money above the last contender's level goes to this pot too
+				for _, c := range contrib {
+					if c > level {
+						layer += c - level
+					}
+				}
+			}
+			winners := []string{}
+			for _, id := range contenders {
+				if contrib[id] < level {
+					continue
+				}
+				if len(winners) == 0 {
+					winners = []string{id}
+					continue
+				}
+				switch {
+				case values[id] > values[winners[0]]:
+					winners = []string{id}
+				case values[id] == values[winners[0]]:
+					winners = append(winners, id)
+				}
+			}
+			for _, id := range winners {
+				shares[id] += float64(layer) / float64(len(winners)) / float64(iterations)
+			}
+			prev = level
+		}
+	}
+	return shares
+}
+
+// unseen returns the cards of a full deck that are not in known.
+func unseen(known []types.Card) []types.Card {
+	used := make(map[types.Card]bool, len(known))
+	for _, c := range known {
+		used[c] = true
+	}
+	stub := make([]types.Card, 0, 52)
+	for suit := types.Spade; suit <= types.Club; suit++ {
+		for rank := types.Two; rank <= types.Ace; rank++ {
+			if c := (types.Card{Suit: suit, Rank: rank}); !used[c] {
+				stub = append(stub, c)
+			}
+		}
+	}
+	return stub
+}
+
+// Totals sums each player's hands, in the order players were first seen.
+func (t *EVTracker) Totals() []PlayerEV {
+	index := make(map[string]int)
+	totals := []PlayerEV{}
+	for _, h := range t.Hands {
+		i, ok := index[h.PlayerID]
+		if !ok {
+			i = len(totals)
+			index[h.PlayerID] = i
+			totals = append(totals, PlayerEV{PlayerID: h.PlayerID})
+		}
+		totals[i].Hands++
+		totals[i].EV += h.EV
+		totals[i].Result += h.Result
+		if h.AllIn {
+			totals[i].AllIns++
+		}
+	}
+	return totals
+}
+
+// LuckiestAndUnluckiest returns the players who ran furthest above and below
+// their EV. ok is false when there are no totals.
+func LuckiestAndUnluckiest(totals []PlayerEV) (luckiest, unluckiest PlayerEV, ok bool) {
+	if len(totals) == 0 {
+		return PlayerEV{}, PlayerEV{}, false
+	}
+	luckiest, unluckiest = totals[0], totals[0]
+	for _, p := range totals[1:] {
+		if p.Luck() > luckiest.Luck() {
+			luckiest = p
+		}
+		if p.Luck() < unluckiest.Luck() {
+			unluckiest = p
+		}
+	}
+	return luckiest, unluckiest, true
+}
+
+// EVChart draws each player's EV and actual result as text bars, followed by
+// the luckiest and unluckiest players of the session.
+func EVChart(totals []PlayerEV) string {
+	const width = 20
+	scale := 0.0
+	for _, p := range totals {
+		scale = max(scale, abs(p.EV), abs(float64(p.Result)))
+	}
+	bar := func(v float64) string {
+		n := 0
+		if scale > 0 {
+			n = int(abs(v)/scale*width + 0.5)
+		}
+		if v < 0 {
+			return fmt.Sprintf("%*s|", width, strings.Repeat("-", n)) + strings.Repeat(" ", width)
+		}
+		return fmt.Sprintf("%*s|", width, "") + fmt.Sprintf("%-*s", width, strings.Repeat("+", n))
+	}
+
+	var b strings.Builder
+	b.WriteString("--- EV vs Results ---\n")
+	for _, p := range totals {
+		fmt.Fprintf(&b, "%-12s EV     %s %+8.1f\n", p.PlayerID, bar(p.EV), p.EV)
+		fmt.Fprintf(&b, "%-12s Result %s %+6d\n", "", bar(float64(p.Result)), p.Result)
+	}
+	if luckiest, unluckiest, ok := LuckiestAndUnluckiest(totals); ok && len(totals) > 1 {
+		fmt.Fprintf(&b, "Luckiest:   %s (%+.0f chips vs EV)\n", luckiest.PlayerID, luckiest.Luck())
+		fmt.Fprintf(&b, "Unluckiest: %s (%+.0f chips vs EV)\n", unluckiest.PlayerID, unluckiest.Luck())
+	}
+	return b.String()
+}
+
+func abs(v float64) float64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,66 @@
+package stats
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"pokerclientv1/pkg/gametest"
+)
+
+// TestEVTrackerAllInPreflop: aces get all-in against kings and lose to a king
+// on the flop. The aces were about an 82% favourite for the 200 chip pot.
+func TestEVTrackerAllInPreflop(t *testing.T) {
+	aces := gametest.NewScriptedPlayer("Aces", 100, gametest.AllIn())
+	kings := gametest.NewScriptedPlayer("Kings", 100, gametest.Call())
+	deck := gametest.Deck(t,
+		[][]string{{"As", "Ah"}, {"Ks", "Kh"}},
+		[]string{"Kd", "7c", "2s", "9h", "3d"})
+	h := gametest.New(t, deck, aces, kings)
+	tracker := &EVTracker{Iterations: 2000, Rng: rand.New(rand.NewSource(1))}
+	h.Engine.OnEvent(tracker.HandleEvent)
+	h.PlayHand()
+
+	totals := tracker.Totals()
+	if len(totals) != 2 {
+		t.Fatalf("Totals() = %+v, want both players", totals)
+	}
+	for _, p := range totals {
+		if p.AllIns != 1 {
+			t.Errorf("%s: %d all-in hands, want 1", p.PlayerID, p.AllIns)
+		}
+	}
+	if got := totals[0]; got.Result != -100 || got.EV < 50 || got.EV > 80 {
+		t.Errorf("aces: result %d EV %.1f, want -100 and about +64", got.Result, got.EV)
+	}
+	if got := totals[1]; got.Result != 100 || got.EV > -50 || got.EV < -80 {
+		t.Errorf("kings: result %d EV %.1f, want +100 and about -64", got.Result, got.EV)
+	}
+	luckiest, unluckiest, _ := LuckiestAndUnluckiest(totals)
+	if luckiest.PlayerID != "Kings" || unluckiest.PlayerID != "Aces" {
+		t.Errorf("luckiest %s, unluckiest %s", luckiest.PlayerID, unluckiest.PlayerID)
+	}
+	if len(tracker.Decisions) != 1 || tracker.Decisions[0].PlayerID != "Kings" || tracker.Decisions[0].EV > 0 {
+		t.Errorf("Decisions = %+v, want the kings' losing call", tracker.Decisions)
+	}
+	if chart := EVChart(totals); !strings.Contains(chart, "Luckiest:   Kings") {
+		t.Errorf("EVChart() is missing the luckiest player:\n%s", chart)
+	}
+}
+
+// TestEVTrackerWithoutAllIn: a hand that ends without an all-in counts its
+// result as its EV.
+func TestEVTrackerWithoutAllIn(t *testing.T) {
+	btn := gametest.NewScriptedPlayer("BTN", 100, gametest.RaiseTo(10))
+	bb := gametest.NewScriptedPlayer("BB", 100, gametest.Fold())
+	h := gametest.New(t, nil, btn, bb)
+	tracker := NewEVTracker()
+	h.Engine.OnEvent(tracker.HandleEvent)
+	h.PlayHand()
+
+	for _, hand := range tracker.Hands {
+		if hand.AllIn || hand.EV != float64(hand.Result) {
+			t.Errorf("%+v: want EV equal to the result", hand)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package stats
+
+import (
+	"fmt"
+	"time"
+
+	"pokerclientv1/internal/store"
+	"pokerclientv1/pkg/game"
+	"pokerclientv1/pkg/types"
+)
+
+// PlayerHistory is everything observed about one player in a hand history:
+// their stat line and every decision they made, for analysis outside the game.
+type PlayerHistory struct {
+	PlayerID string            `json:"player"`
+	Sessions []string          `json:"sessions"`
+	Stats    store.PlayerStats `json:"stats"`
+	Hands    []HandHistory     `json:"hands"`
+}
+
+// HandHistory is one hand the player was dealt into.
+type HandHistory struct {
+	Session string          `json:"session"`
+	Hand    int             `json:"hand"`
+	Played  time.Time       `json:"played"`
+	Shown   []types.Card    `json:"shown,omitempty"` // Hole cards, only when shown at showdown
+	Board   []types.Card    `json:"board,omitempty"`
+	Actions []ActionHistory `json:"actions"`
+	Result  int             `json:"result"` // Chips won minus chips put in
+}
+
+// ActionHistory is one of the player's decisions.
+type ActionHistory struct {
+	Round   string        `json:"round"`
+	Action  string        `json:"action"`
+	Amount  int           `json:"amount,omitempty"`  // Chips added to the pot
+	Pot     int           `json:"pot"`               // Pot before the action
+	Time    time.Time     `json:"time"`              // When the action was taken
+	Elapsed time.Duration `json:"elapsed,omitempty"` // Time taken to decide, in nanoseconds
+}
+
+// Export collects a player's history from recorded hands of any number of
+// sessions. It fails if the player was never dealt in.
+func Export(hands []store.HandRecord, playerID string) (PlayerHistory, error) {
+	history := PlayerHistory{PlayerID: playerID, Sessions: []string{}, Hands: []HandHistory{}}
+	tracker := NewTracker()
+	seenSession := map[string]bool{}
+	for _, hand := range hands {
+		record := HandHistory{Session: hand.SessionID, Hand: hand.HandNumber, Played: hand.Played, Actions: []ActionHistory{}}
+		dealt, showed := false, false
+		var hole []types.Card
+		pot := 0
+		for _, e := range hand.Events {
+			tracker.HandleEvent(e)
+			switch e.Type {
+			case game.EventHoleCardsDealt:
+				if e.PlayerID == playerID {
+					dealt = true
+					hole = e.Cards
+				}
+			case game.EventStreetDealt:
+				record.Board = append(record.Board, e.Cards...)
+			case game.EventBlindPosted:
+				pot += e.Amount
+				if e.PlayerID == playerID {
+					record.Result -= e.Amount
+				}
+			case game.EventPlayerAction:
+				if e.PlayerID == playerID {
+					record.Actions = append(record.Actions, ActionHistory{Round: e.Round, Action: e.Action, Amount: e.Amount, Pot: pot, Time: e.Time, Elapsed: e.Elapsed})
+					record.Result -= e.Amount
+				}
+				pot += e.Amount
+			case game.EventBetReturned:
+				pot -= e.Amount
+				if e.PlayerID == playerID {
+					record.Result += e.Amount
+				}
+			case game.EventShowdown, game.EventHandMucked:
+				showed = showed || e.PlayerID == playerID
+			case game.EventPotAwarded:
+				if e.PlayerID == playerID {
+					record.Result += e.Amount
+				}
+			}
+		}
+		if !dealt {
+			continue
+		}
+		if showed {
+			record.Shown = hole
+		}
+		if !seenSession[hand.SessionID] {
+			seenSession[hand.SessionID] = true
+			history.Sessions = append(history.Sessions, hand.SessionID)
+		}
+		history.Hands = append(history.Hands, record)
+	}
+
+	st, ok := tracker.Player(playerID)
+	if !ok || len(history.Hands) == 0 {
+		return PlayerHistory{}, fmt.Errorf("stats: %s was not dealt into any recorded hand", playerID)
+	}
+	history.Stats = st
+	return history, nil
+}
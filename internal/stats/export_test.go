@@ -0,0 +1,44 @@
+package stats
+
+import (
+	"testing"
+
+	"pokerclientv1/internal/store"
+	"pokerclientv1/pkg/gametest"
+)
+
+// TestExport builds BB's history from the check-raise hand recorded in two
+// sessions.
+func TestExport(t *testing.T) {
+	btn := gametest.NewScriptedPlayer("BTN", 100, gametest.RaiseTo(6), gametest.RaiseTo(6), gametest.Fold())
+	sb := gametest.NewScriptedPlayer("SB", 100, gametest.Fold())
+	bb := gametest.NewScriptedPlayer("BB", 100, gametest.Call(), gametest.Check(), gametest.RaiseTo(18))
+	deck := gametest.Deck(t,
+		[][]string{{"As", "Kd"}, {"7c", "2d"}, {"8h", "8s"}},
+		[]string{"8c", "Qd", "3s", "4h", "5c"})
+	h := gametest.New(t, deck, btn, sb, bb)
+	h.PlayHand()
+	hands := []store.HandRecord{
+		{SessionID: "s1", HandNumber: 1, Events: h.Events},
+		{SessionID: "s2", HandNumber: 1, Events: h.Events},
+	}
+
+	history, err := Export(hands, "BB")
+	if err != nil {
+		t.Fatalf("Export() error: %v", err)
+	}
+	if len(history.Sessions) != 2 || history.Stats.HandsDealt != 2 || history.Stats.CheckRaises != 2 {
+		t.Errorf("Export() sessions %v stats %+v, want two check-raising hands", history.Sessions, history.Stats)
+	}
+	first := history.Hands[0]
+	if len(first.Actions) != 3 || first.Actions[2].Action != "raises to 18" || first.Actions[2].Pot != 19 {
+		t.Errorf("first hand actions = %+v, want call, check and a raise to 18 into 19", first.Actions)
+	}
+	if first.Result != 13 || first.Shown != nil {
+		t.Errorf("first hand result %d shown %v, want +13 without a showdown", first.Result, first.Shown)
+	}
+
+	if _, err := Export(hands, "Nobody"); err == nil {
+		t.Errorf("Export(Nobody) succeeded, want an error")
+	}
+}
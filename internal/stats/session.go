@@ -0,0 +1,199 @@
+package stats
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"pokerclientv1/pkg/game"
+	"pokerclientv1/pkg/types"
+)
+
+// HandResult is one hand of a session from one player's seat.
+type HandResult struct {
+	HandNumber int          `json:"hand"`
+	Cards      []types.Card `json:"cards"`
+	Equity     float64      `json:"equity"`  // Chance of winning when dealt, against the other players' hands at random
+	Outcome    string       `json:"outcome"` // How the hand ended for the player, e.g. "won at showdown" or "folded on the flop"
+	Result     int          `json:"result"`  // Chips won minus chips put in
+	Chips      int          `json:"chips"`   // Stack after the hand
+}
+
+// SessionReport follows one player through a session, hand by hand, for a
+// report of their starting equity, results and chip count over time.
+// Register HandleEvent with Game.OnEvent or Engine.OnEvent.
+type SessionReport struct {
+	PlayerID   string
+	Iterations int        // Deals per equity estimate, DefaultEVIterations when zero
+	Rng        *rand.Rand // Seeded from the clock when nil
+	Start      int        // Stack before the first hand the player was dealt into
+	Hands      []HandResult
+
+	hand      *HandResult // Hand in progress, nil when the player is not in one
+	opponents int
+	stack     int
+	put       int // Chips put in this hand
+	won       int
+	folded    string // Round the player folded in, "" while still in
+	showdown  bool
+}
+
+// NewSessionReport creates a report of playerID's session.
+func NewSessionReport(playerID string) *SessionReport {
+	return &SessionReport{PlayerID: playerID, Iterations: DefaultEVIterations}
+}
+
+// HandleEvent follows the player through one game event.
+func (s *SessionReport) HandleEvent(e game.Event) {
+	switch e.Type {
+	case game.EventHandStarted:
+		s.hand, s.opponents = nil, 0
+		s.put, s.won, s.folded, s.showdown = 0, 0, "", false
+	case game.EventPlayerSeated:
+		if e.PlayerID != s.PlayerID {
+			s.opponents++
+			return
+		}
+		s.stack = e.Amount
+		s.hand = &HandResult{HandNumber: e.HandNumber}
+		if len(s.Hands) == 0 {
+			s.Start = e.Amount
+		}
+	case game.EventHandAbandoned:
+		s.hand = nil
+	}
+	if s.hand == nil || e.PlayerID != s.PlayerID && e.Type != game.EventHandEnded {
+		return
+	}
+	switch e.Type {
+	case game.EventHoleCardsDealt:
+		s.hand.Cards = append([]types.Card(nil), e.Cards...)
+	case game.EventBlindPosted:
+		s.put += e.Amount
+	case game.EventPlayerAction:
+		s.put += e.Amount
+		if strings.HasPrefix(e.Action, "fold") {
+			s.folded = e.Round
+		}
+	case game.EventBetReturned:
+		s.put -= e.Amount
+	case game.EventShowdown, game.EventHandMucked:
+		s.showdown = true
+	case game.EventPotAwarded:
+		s.won += e.Amount
+	case game.EventHandEnded:
+		s.finishHand()
+	}
+}
+
+// finishHand records the hand the player was dealt into.
+func (s *SessionReport) finishHand() {
+	h := s.hand
+	s.hand = nil
+	if len(h.Cards) == 0 {
+		return
+	}
+	if s.Rng == nil {
+		s.Rng = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	iterations := s.Iterations
+	if iterations <= 0 {
+		iterations = DefaultEVIterations
+	}
+	h.Equity = game.Equity(h.Cards, nil, s.opponents, iterations, s.Rng)
+	h.Result = s.won - s.put
+	h.Chips = s.stack + h.Result
+	switch {
+	case s.folded == "Pre-flop":
+		h.Outcome = "folded pre-flop"
+	case s.folded != "":
+		h.Outcome = "folded on the " + strings.ToLower(s.folded)
+	case s.won > 0 && s.showdown:
+		h.Outcome = "won at showdown"
+	case s.won > 0:
+		h.Outcome = "won"
+	case s.showdown:
+		h.Outcome = "lost at showdown"
+	default:
+		h.Outcome = "lost"
+	}
+	s.Hands = append(s.Hands, *h)
+}
+
+// Report lists every hand with its starting equity, outcome and result and
+// draws the player's chip count over the session, or returns "" when the
+// player was dealt no hands.
+func (s *SessionReport) Report() string {
+	if len(s.Hands) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- Session report: %s ---\n", s.PlayerID)
+	fmt.Fprintf(&b, "%-6s %-7s %-16s %-18s %7s %7s\n", "Hand", "Cards", "Equity", "Outcome", "Result", "Chips")
+	for _, h := range s.Hands {
+		cards := make([]string, len(h.Cards))
+		for i, c := range h.Cards {
+			cards[i] = c.String()
+		}
+		bar := strings.Repeat("#", int(h.Equity*10+0.5))
+		fmt.Fprintf(&b, "#%-5d %-7s %3.0f%% %-11s %-18s %+7d %7d\n",
+			h.HandNumber, strings.Join(cards, " "), h.Equity*100, bar, h.Outcome, h.Result, h.Chips)
+	}
+	chips := []int{s.Start}
+	for _, h := range s.Hands {
+		chips = append(chips, h.Chips)
+	}
+	fmt.Fprintf(&b, "\nChips after each hand, from %d to %d:\n", s.Start, chips[len(chips)-1])
+	b.WriteString(ChipGraph(chips, 60, 8))
+	return b.String()
+}
+
+// ChipGraph draws values as a text graph at most width columns wide and
+// height rows high, with the highest and lowest values marked on the left.
+// When there are more values than columns each column shows the last value
+// of its share of them.
+func ChipGraph(values []int, width, height int) string {
+	if len(values) == 0 || width < 1 || height < 1 {
+		return ""
+	}
+	columns := values
+	if len(values) > width {
+		columns = make([]int, width)
+		for i := range columns {
+			columns[i] = values[(i+1)*len(values)/width-1]
+		}
+	}
+	low, high := columns[0], columns[0]
+	for _, v := range columns {
+		low, high = min(low, v), max(high, v)
+	}
+	grid := make([][]byte, height)
+	for row := range grid {
+		grid[row] = []byte(strings.Repeat(" ", len(columns)))
+	}
+	for col, v := range columns {
+		row := 0
+		if high > low {
+			row = int(float64(v-low)/float64(high-low)*float64(height-1) + 0.5)
+		}
+		grid[height-1-row][col] = '*'
+	}
+	label := len(fmt.Sprint(high))
+	if n := len(fmt.Sprint(low)); n > label {
+		label = n
+	}
+	var b strings.Builder
+	for row, line := range grid {
+		mark := ""
+		switch row {
+		case 0:
+			mark = fmt.Sprint(high)
+		case height - 1:
+			mark = fmt.Sprint(low)
+		}
+		fmt.Fprintf(&b, "%*s |%s\n", label, mark, strings.TrimRight(string(line), " "))
+	}
+	fmt.Fprintf(&b, "%*s +%s\n", label, "", strings.Repeat("-", len(columns)))
+	return b.String()
+}
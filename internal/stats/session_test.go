@@ -0,0 +1,58 @@
+package stats
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"pokerclientv1/pkg/gametest"
+)
+
+// TestSessionReport: the human's aces hold against kings all-in, then the
+// human folds the next hand at another table.
+func TestSessionReport(t *testing.T) {
+	you := gametest.NewScriptedPlayer("You", 100, gametest.AllIn())
+	kings := gametest.NewScriptedPlayer("Kings", 100, gametest.Call())
+	deck := gametest.Deck(t,
+		[][]string{{"As", "Ah"}, {"Ks", "Kh"}},
+		[]string{"2d", "7c", "2s", "9h", "3d"})
+	h := gametest.New(t, deck, you, kings)
+	report := &SessionReport{PlayerID: "You", Iterations: 2000, Rng: rand.New(rand.NewSource(1))}
+	h.Engine.OnEvent(report.HandleEvent)
+	h.PlayHand()
+	you = gametest.NewScriptedPlayer("You", 200, gametest.Fold())
+	h = gametest.New(t, nil, you, gametest.NewScriptedPlayer("BB", 100))
+	h.Engine.OnEvent(report.HandleEvent)
+	h.PlayHand()
+
+	if len(report.Hands) != 2 {
+		t.Fatalf("Hands = %+v, want both hands", report.Hands)
+	}
+	if got := report.Hands[0]; got.Outcome != "won at showdown" || got.Result != 100 || got.Chips != 200 || got.Equity < 0.8 || got.Equity > 0.9 {
+		t.Errorf("first hand = %+v, want aces winning 100 with about 85%% equity", got)
+	}
+	if got := report.Hands[1]; got.Outcome != "folded pre-flop" || got.Chips != 200+got.Result {
+		t.Errorf("second hand = %+v, want a fold pre-flop", got)
+	}
+	out := report.Report()
+	for _, want := range []string{"Session report: You", "A♠ A♥", "won at showdown", "from 100 to"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Report() is missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestChipGraph(t *testing.T) {
+	got := ChipGraph([]int{100, 150, 50, 200}, 60, 3)
+	want := "" +
+		"200 |   *\n" +
+		"    |**\n" +
+		" 50 |  *\n" +
+		"    +----\n"
+	if got != want {
+		t.Errorf("ChipGraph() =\n%s\nwant\n%s", got, want)
+	}
+	if got := ChipGraph([]int{1, 2, 3, 4, 5, 6}, 3, 2); !strings.HasSuffix(got, "+---\n") {
+		t.Errorf("ChipGraph() of 6 values in 3 columns =\n%s", got)
+	}
+}
@@ -0,0 +1,99 @@
+package stats
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"pokerclientv1/pkg/game"
+)
+
+// TimedDecision is one player action and how long it took.
+type TimedDecision struct {
+	PlayerID string
+	Hand     int
+	Round    string
+	Action   string
+	Elapsed  time.Duration
+}
+
+// Timing records how long hands and decisions take, from the Elapsed field
+// of game events. Register HandleEvent with Game.OnEvent or
+// Engine.OnEvent, or feed it recorded hands.
+type Timing struct {
+	hands     []time.Duration // Length of each finished hand, in order
+	handNums  []int
+	decisions map[string][]TimedDecision
+	order     []string // Players in the order they first acted
+}
+
+// NewTiming creates an empty timing tracker.
+func NewTiming() *Timing {
+	return &Timing{decisions: make(map[string][]TimedDecision)}
+}
+
+// HandleEvent records the length of a finished hand or a player's decision.
+// Events recorded before timing was added carry no durations and are skipped.
+func (t *Timing) HandleEvent(e game.Event) {
+	if e.Elapsed <= 0 {
+		return
+	}
+	switch e.Type {
+	case game.EventHandEnded:
+		t.hands = append(t.hands, e.Elapsed)
+		t.handNums = append(t.handNums, e.HandNumber)
+	case game.EventPlayerAction:
+		if _, ok := t.decisions[e.PlayerID]; !ok {
+			t.order = append(t.order, e.PlayerID)
+		}
+		t.decisions[e.PlayerID] = append(t.decisions[e.PlayerID],
+			TimedDecision{PlayerID: e.PlayerID, Hand: e.HandNumber, Round: e.Round, Action: e.Action, Elapsed: e.Elapsed})
+	}
+}
+
+// Hands returns the length of every finished hand, in the order played.
+func (t *Timing) Hands() []time.Duration {
+	return append([]time.Duration(nil), t.hands...)
+}
+
+// Slowest returns up to n of a player's decisions, longest first.
+func (t *Timing) Slowest(playerID string, n int) []TimedDecision {
+	decisions := append([]TimedDecision(nil), t.decisions[playerID]...)
+	sort.SliceStable(decisions, func(i, j int) bool { return decisions[i].Elapsed > decisions[j].Elapsed })
+	if len(decisions) > n {
+		decisions = decisions[:n]
+	}
+	return decisions
+}
+
+// Report summarizes hand lengths and lists each player's n slowest
+// decisions, or returns "" when nothing was timed.
+func (t *Timing) Report(n int) string {
+	if len(t.hands) == 0 && len(t.order) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	fmt.Fprintln(&b, "--- Timing ---")
+	if len(t.hands) > 0 {
+		var total time.Duration
+		longest := 0
+		for i, d := range t.hands {
+			total += d
+			if d > t.hands[longest] {
+				longest = i
+			}
+		}
+		fmt.Fprintf(&b, "%d hands in %s, %s on average, longest %s (hand #%d)\n",
+			len(t.hands), total.Round(time.Second), (total / time.Duration(len(t.hands))).Round(100*time.Millisecond),
+			t.hands[longest].Round(100*time.Millisecond), t.handNums[longest])
+	}
+	for _, id := range t.order {
+		parts := []string{}
+		for _, d := range t.Slowest(id, n) {
+			parts = append(parts, fmt.Sprintf("%s (hand #%d %s, %s)", d.Elapsed.Round(100*time.Millisecond), d.Hand, d.Round, d.Action))
+		}
+		fmt.Fprintf(&b, "Slowest for %s: %s\n", id, strings.Join(parts, ", "))
+	}
+	return b.String()
+}
@@ -0,0 +1,46 @@
+package stats
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"pokerclientv1/pkg/game"
+)
+
+// TestTimingReport checks hand lengths and the slowest decisions per player.
+func TestTimingReport(t *testing.T) {
+	timing := NewTiming()
+	for _, e := range []game.Event{
+		{Type: game.EventPlayerAction, HandNumber: 1, PlayerID: "Alice", Round: "Pre-flop", Action: "calls", Elapsed: 2 * time.Second},
+		{Type: game.EventPlayerAction, HandNumber: 1, PlayerID: "Bob", Round: "Pre-flop", Action: "checks", Elapsed: 100 * time.Millisecond},
+		{Type: game.EventPlayerAction, HandNumber: 1, PlayerID: "Alice", Round: "River", Action: "raises to 40", Elapsed: 12 * time.Second},
+		{Type: game.EventHandEnded, HandNumber: 1, Elapsed: 20 * time.Second},
+		{Type: game.EventPlayerAction, HandNumber: 2, PlayerID: "Alice", Round: "Flop", Action: "folds", Elapsed: 5 * time.Second},
+		{Type: game.EventHandEnded, HandNumber: 2, Elapsed: 10 * time.Second},
+		{Type: game.EventHandEnded, HandNumber: 3}, // Recorded without timing
+	} {
+		timing.HandleEvent(e)
+	}
+
+	if hands := timing.Hands(); len(hands) != 2 || hands[0] != 20*time.Second {
+		t.Errorf("Hands() = %v, want [20s 10s]", hands)
+	}
+	slowest := timing.Slowest("Alice", 2)
+	if len(slowest) != 2 || slowest[0].Action != "raises to 40" || slowest[1].Action != "folds" {
+		t.Errorf("Slowest(Alice, 2) = %+v, want the river raise then the flop fold", slowest)
+	}
+	report := timing.Report(2)
+	for _, want := range []string{
+		"2 hands in 30s, 15s on average, longest 20s (hand #1)",
+		"Slowest for Alice: 12s (hand #1 River, raises to 40), 5s (hand #2 Flop, folds)",
+		"Slowest for Bob: 100ms (hand #1 Pre-flop, checks)",
+	} {
+		if !strings.Contains(report, want) {
+			t.Errorf("Report() is missing %q:\n%s", want, report)
+		}
+	}
+	if got := NewTiming().Report(3); got != "" {
+		t.Errorf("Report() with nothing timed = %q, want empty", got)
+	}
+}
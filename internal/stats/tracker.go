@@ -0,0 +1,289 @@
+package stats
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"pokerclientv1/internal/store"
+	"pokerclientv1/pkg/game"
+)
+
+// Tracker accumulates the store.PlayerStats counters of every player from
+// game events: VPIP, PFR and 3-bets, and the situational cold-call, squeeze,
+// fold to continuation bet and check-raise counts that make reads possible.
+// Register HandleEvent with Game.OnEvent or Engine.OnEvent.
+type Tracker struct {
+	stats map[string]*store.PlayerStats
+	order []string   // Players in the order they were first dealt in
+	hand  *handState // Hand in progress, nil between hands
+}
+
+// handState is what the tracker remembers about the hand in progress.
+type handState struct {
+	round   string
+	dealt   []string
+	contrib map[string]int
+	won     map[string]int
+	showed  map[string]bool
+
+	// Pre-flop
+	acted        map[string]bool // Made a pre-flop decision
+	voluntary    map[string]bool // Called or raised pre-flop
+	raised       map[string]bool // Raised pre-flop
+	threeBetSpot map[string]bool // Faced a single raise
+	raises       int             // Pre-flop raises so far
+	callers      int             // Calls of the last pre-flop raise
+	aggressor    string          // Last pre-flop raiser
+
+	// Street after the flop in progress
+	bet         bool            // Someone has bet this street
+	cbet        bool            // The pre-flop raiser bet the flop and nobody has raised since
+	facedCBet   map[string]bool // Responded to the continuation bet
+	checked     map[string]bool // Checked before anyone bet this street
+	facedAfterX map[string]bool // Faced a bet after checking this street
+}
+
+// NewTracker creates an empty tracker.
+func NewTracker() *Tracker {
+	return &Tracker{stats: make(map[string]*store.PlayerStats)}
+}
+
+// Stats returns the counters of every player seen, in the order they were
+// first dealt in.
+func (t *Tracker) Stats() []store.PlayerStats {
+	all := make([]store.PlayerStats, 0, len(t.order))
+	for _, id := range t.order {
+		all = append(all, *t.stats[id])
+	}
+	return all
+}
+
+// Player returns one player's counters and whether the player was seen.
+func (t *Tracker) Player(playerID string) (store.PlayerStats, bool) {
+	st, ok := t.stats[playerID]
+	if !ok {
+		return store.PlayerStats{}, false
+	}
+	return *st, true
+}
+
+func (t *Tracker) player(id string) *store.PlayerStats {
+	st, ok := t.stats[id]
+	if !ok {
+		st = &store.PlayerStats{PlayerID: id}
+		t.stats[id] = st
+		t.order = append(t.order, id)
+	}
+	return st
+}
+
+// HandleEvent updates the tracker with one game event.
+func (t *Tracker) HandleEvent(e game.Event) {
+	if e.Type == game.EventHandStarted {
+		t.hand = &handState{
+			round:        e.Round,
+			contrib:      map[string]int{},
+			won:          map[string]int{},
+			showed:       map[string]bool{},
+			acted:        map[string]bool{},
+			voluntary:    map[string]bool{},
+			raised:       map[string]bool{},
+			threeBetSpot: map[string]bool{},
+		}
+		return
+	}
+	h := t.hand
+	if h == nil {
+		return // Joined mid-hand
+	}
+	switch e.Type {
+	case game.EventHoleCardsDealt:
+		h.dealt = append(h.dealt, e.PlayerID)
+		t.player(e.PlayerID).HandsDealt++
+	case game.EventBlindPosted:
+		h.contrib[e.PlayerID] += e.Amount
+	case game.EventStreetDealt:
+		h.round = e.Round
+		h.bet, h.cbet = false, false
+		h.facedCBet = map[string]bool{}
+		h.checked = map[string]bool{}
+		h.facedAfterX = map[string]bool{}
+	case game.EventPlayerAction:
+		h.contrib[e.PlayerID] += e.Amount
+		if h.checked == nil {
+			t.preflopAction(e)
+		} else {
+			t.postflopAction(e)
+		}
+	case game.EventBetReturned:
+		h.contrib[e.PlayerID] -= e.Amount
+	case game.EventShowdown, game.EventHandMucked:
+		h.showed[e.PlayerID] = true
+	case game.EventPotAwarded:
+		h.won[e.PlayerID] += e.Amount
+	case game.EventHandEnded:
+		t.finishHand()
+	}
+}
+
+// actionKind reduces an action as logged by the game to fold, check, call or raise.
+func actionKind(action string) string {
+	switch {
+	case strings.HasPrefix(action, "fold"):
+		return "fold"
+	case strings.HasPrefix(action, "check"):
+		return "check"
+	case strings.HasPrefix(action, "call"):
+		return "call"
+	case strings.HasPrefix(action, "raise"):
+		return "raise"
+	}
+	return action
+}
+
+func (t *Tracker) preflopAction(e game.Event) {
+	h, st, kind := t.hand, t.player(e.PlayerID), actionKind(e.Action)
+	if !h.acted[e.PlayerID] && h.raises > 0 {
+		// A cold call puts money in for the first time facing a raise; a
+		// squeeze re-raises a raise that already has callers
+		st.ColdCallChance++
+		if kind == "call" {
+			st.ColdCalls++
+		}
+		if h.callers > 0 {
+			st.SqueezeChance++
+			if kind == "raise" {
+				st.Squeezes++
+			}
+		}
+	}
+	if h.raises == 1 && h.aggressor != e.PlayerID && !h.threeBetSpot[e.PlayerID] {
+		h.threeBetSpot[e.PlayerID] = true
+		st.ThreeBetChance++
+		if kind == "raise" {
+			st.ThreeBetHands++
+		}
+	}
+	h.acted[e.PlayerID] = true
+
+	switch kind {
+	case "call":
+		h.voluntary[e.PlayerID] = true
+		if h.raises > 0 {
+			h.callers++
+		}
+	case "raise":
+		h.voluntary[e.PlayerID] = true
+		h.raised[e.PlayerID] = true
+		h.raises++
+		h.callers = 0
+		h.aggressor = e.PlayerID
+	}
+}
+
+func (t *Tracker) postflopAction(e game.Event) {
+	h, st, kind := t.hand, t.player(e.PlayerID), actionKind(e.Action)
+	if h.bet {
+		if h.checked[e.PlayerID] && !h.facedAfterX[e.PlayerID] {
+			h.facedAfterX[e.PlayerID] = true
+			st.CheckRaiseChance++
+			if kind == "raise" {
+				st.CheckRaises++
+			}
+		}
+		if h.cbet && e.PlayerID != h.aggressor && !h.facedCBet[e.PlayerID] {
+			h.facedCBet[e.PlayerID] = true
+			st.FoldToCBetChance++
+			if kind == "fold" {
+				st.FoldsToCBet++
+			}
+		}
+	}
+
+	switch kind {
+	case "check":
+		if !h.bet {
+			h.checked[e.PlayerID] = true
+		}
+	case "call":
+		st.Calls++
+	case "raise":
+		st.Bets++
+		h.cbet = !h.bet && h.round == "Flop" && e.PlayerID == h.aggressor
+		h.bet = true
+	}
+}
+
+// finishHand adds the hand's per-player flags and results to the counters.
+func (t *Tracker) finishHand() {
+	h := t.hand
+	t.hand = nil
+	for _, id := range h.dealt {
+		st := t.player(id)
+		if h.voluntary[id] {
+			st.VPIPHands++
+		}
+		if h.raised[id] {
+			st.PFRHands++
+		}
+		if h.showed[id] {
+			st.Showdowns++
+			if h.won[id] > 0 {
+				st.ShowdownsWon++
+			}
+		}
+		st.NetChips += h.won[id] - h.contrib[id]
+	}
+}
+
+// percent formats n out of chances as a whole percentage, or "-" when the
+// spot never came up.
+func percent(n, chances int) string {
+	if chances == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%d", int(math.Round(100*float64(n)/float64(chances))))
+}
+
+// aggression formats the aggression factor, bets and raises per call after
+// the flop, or "-" before the player has called.
+func aggression(bets, calls int) string {
+	if calls == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f", float64(bets)/float64(calls))
+}
+
+// HUD returns a one-line summary of a player's stats to show next to their
+// seat, or "" before they were dealt a hand.
+func (t *Tracker) HUD(playerID string) string {
+	st, ok := t.stats[playerID]
+	if !ok || st.HandsDealt == 0 {
+		return ""
+	}
+	return fmt.Sprintf("VPIP %s PFR %s 3B %s CC %s SQZ %s FCB %s XR %s (%d hands)",
+		percent(st.VPIPHands, st.HandsDealt), percent(st.PFRHands, st.HandsDealt),
+		percent(st.ThreeBetHands, st.ThreeBetChance), percent(st.ColdCalls, st.ColdCallChance),
+		percent(st.Squeezes, st.SqueezeChance), percent(st.FoldsToCBet, st.FoldToCBetChance),
+		percent(st.CheckRaises, st.CheckRaiseChance), st.HandsDealt)
+}
+
+// Report renders an analysis table of the given players' stats, one row per
+// player, with percentages, the aggression factor and net chips.
+func Report(all []store.PlayerStats) string {
+	var b strings.Builder
+	fmt.Fprintln(&b, "--- Player Stats ---")
+	fmt.Fprintf(&b, "%-16s %6s %5s %5s %5s %5s %5s %5s %5s %5s %6s %8s\n",
+		"Player", "Hands", "VPIP", "PFR", "3Bet", "Cold", "Sqz", "FCBet", "XR", "AF", "W$SD", "Net")
+	for _, st := range all {
+		fmt.Fprintf(&b, "%-16s %6d %5s %5s %5s %5s %5s %5s %5s %5s %6s %+8d\n",
+			st.PlayerID, st.HandsDealt,
+			percent(st.VPIPHands, st.HandsDealt), percent(st.PFRHands, st.HandsDealt),
+			percent(st.ThreeBetHands, st.ThreeBetChance), percent(st.ColdCalls, st.ColdCallChance),
+			percent(st.Squeezes, st.SqueezeChance), percent(st.FoldsToCBet, st.FoldToCBetChance),
+			percent(st.CheckRaises, st.CheckRaiseChance), aggression(st.Bets, st.Calls),
+			percent(st.ShowdownsWon, st.Showdowns), st.NetChips)
+	}
+	return b.String()
+}
@@ -0,0 +1,86 @@
+package stats
+
+import (
+	"strings"
+	"testing"
+
+	"pokerclientv1/internal/store"
+	"pokerclientv1/pkg/gametest"
+)
+
+// TestTrackerSqueezeAndCBet: UTG opens, BTN cold-calls, SB squeezes, BB folds
+// and UTG calls. SB bets the flop and UTG folds to the continuation bet.
+func TestTrackerSqueezeAndCBet(t *testing.T) {
+	btn := gametest.NewScriptedPlayer("BTN", 100, gametest.Call(), gametest.Fold())
+	sb := gametest.NewScriptedPlayer("SB", 100, gametest.RaiseTo(20), gametest.RaiseTo(20))
+	bb := gametest.NewScriptedPlayer("BB", 100, gametest.Fold())
+	utg := gametest.NewScriptedPlayer("UTG", 100, gametest.RaiseTo(6), gametest.Call(), gametest.Fold())
+	deck := gametest.Deck(t,
+		[][]string{{"9c", "9d"}, {"Ah", "Qh"}, {"7c", "2d"}, {"Ks", "Js"}},
+		[]string{"Qc", "8d", "3s", "4h", "5c"})
+	h := gametest.New(t, deck, btn, sb, bb, utg)
+	tracker := NewTracker()
+	h.Engine.OnEvent(tracker.HandleEvent)
+	h.PlayHand()
+	h.AssertScriptsFinished()
+
+	want := map[string]store.PlayerStats{
+		"UTG": {PlayerID: "UTG", HandsDealt: 1, VPIPHands: 1, PFRHands: 1,
+			FoldToCBetChance: 1, FoldsToCBet: 1, NetChips: -20},
+		"BTN": {PlayerID: "BTN", HandsDealt: 1, VPIPHands: 1, ThreeBetChance: 1,
+			ColdCallChance: 1, ColdCalls: 1, NetChips: -6},
+		"SB": {PlayerID: "SB", HandsDealt: 1, VPIPHands: 1, PFRHands: 1, ThreeBetChance: 1, ThreeBetHands: 1,
+			ColdCallChance: 1, SqueezeChance: 1, Squeezes: 1, Bets: 1, NetChips: 28},
+		"BB": {PlayerID: "BB", HandsDealt: 1, ColdCallChance: 1, NetChips: -2},
+	}
+	for id, w := range want {
+		if got, _ := tracker.Player(id); got != w {
+			t.Errorf("%s stats = %+v\nwant %+v", id, got, w)
+		}
+	}
+}
+
+// TestTrackerCheckRaise: BB calls an open, checks the flop and raises the
+// continuation bet.
+func TestTrackerCheckRaise(t *testing.T) {
+	btn := gametest.NewScriptedPlayer("BTN", 100, gametest.RaiseTo(6), gametest.RaiseTo(6), gametest.Fold())
+	sb := gametest.NewScriptedPlayer("SB", 100, gametest.Fold())
+	bb := gametest.NewScriptedPlayer("BB", 100, gametest.Call(), gametest.Check(), gametest.RaiseTo(18))
+	deck := gametest.Deck(t,
+		[][]string{{"As", "Kd"}, {"7c", "2d"}, {"8h", "8s"}},
+		[]string{"8c", "Qd", "3s", "4h", "5c"})
+	h := gametest.New(t, deck, btn, sb, bb)
+	tracker := NewTracker()
+	h.Engine.OnEvent(tracker.HandleEvent)
+	h.PlayHand()
+	h.AssertScriptsFinished()
+
+	got, _ := tracker.Player("BB")
+	if got.CheckRaiseChance != 1 || got.CheckRaises != 1 || got.FoldToCBetChance != 1 || got.FoldsToCBet != 0 {
+		t.Errorf("BB stats = %+v, want one check-raise of a c-bet", got)
+	}
+	if got.ColdCallChance != 1 || got.ColdCalls != 1 || got.ThreeBetChance != 1 {
+		t.Errorf("BB pre-flop stats = %+v, want a cold call facing a single raise", got)
+	}
+	if hud := tracker.HUD("BB"); hud != "VPIP 100 PFR 0 3B 0 CC 100 SQZ - FCB 0 XR 100 (1 hands)" {
+		t.Errorf("HUD(BB) = %q", hud)
+	}
+	if report := Report(tracker.Stats()); !strings.Contains(report, "BTN") || !strings.Contains(report, "+13") {
+		t.Errorf("Report() is missing BB's +13 net:\n%s", report)
+	}
+}
+
+// TestReportAggression checks the aggression factor column.
+func TestReportAggression(t *testing.T) {
+	report := Report([]store.PlayerStats{
+		{PlayerID: "Caller", HandsDealt: 10, Bets: 3, Calls: 2},
+		{PlayerID: "Passive", HandsDealt: 10},
+	})
+	lines := strings.Split(report, "\n")
+	if !strings.Contains(lines[1], "AF") || !strings.Contains(lines[2], " 1.5 ") {
+		t.Errorf("Report() aggression factor of 3 bets to 2 calls is not 1.5:\n%s", report)
+	}
+	if fields := strings.Fields(lines[3]); fields[9] != "-" {
+		t.Errorf("Report() aggression factor without calls = %q, want -:\n%s", fields[9], report)
+	}
+}
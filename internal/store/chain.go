@@ -0,0 +1,78 @@
+package store
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"pokerclientv1/pkg/game"
+)
+
+// ChainError reports the first hand of an audit chain that does not verify.
+type ChainError struct {
+	Index      int // Position of the hand in the checked slice
+	SessionID  string
+	HandNumber int
+	Reason     string
+}
+
+func (e *ChainError) Error() string {
+	return fmt.Sprintf("store: hand %d of session %s (record %d) %s", e.HandNumber, e.SessionID, e.Index, e.Reason)
+}
+
+// HashHand returns the SHA-256 of a hand's contents and PrevHash as hex. The
+// Hash field itself is not part of the input, so a stored hand can be rehashed
+// to check it.
+func HashHand(hand HandRecord) (string, error) {
+	content := struct {
+		PrevHash   string       `json:"prevHash"`
+		SessionID  string       `json:"sessionId"`
+		HandNumber int          `json:"handNumber"`
+		Played     string       `json:"played"`
+		Events     []game.Event `json:"events"`
+	}{hand.PrevHash, hand.SessionID, hand.HandNumber, hand.Played.UTC().Format(time.RFC3339Nano), hand.Events}
+	data, err := json.Marshal(content)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// VerifyChain checks that every hand hashes to its stored Hash and points at
+// the hand before it. It returns a *ChainError for the first hand that was
+// edited, removed from or inserted into the chain.
+func VerifyChain(hands []HandRecord) error {
+	prev := ""
+	for i, hand := range hands {
+		fail := func(reason string) error {
+			return &ChainError{Index: i, SessionID: hand.SessionID, HandNumber: hand.HandNumber, Reason: reason}
+		}
+		if hand.Hash == "" {
+			return fail("has no hash")
+		}
+		if i > 0 && hand.PrevHash != prev {
+			return fail("does not follow the previous hand")
+		}
+		hash, err := HashHand(hand)
+		if err != nil {
+			return err
+		}
+		if hash != hand.Hash {
+			return fail("was modified after it was recorded")
+		}
+		prev = hand.Hash
+	}
+	return nil
+}
+
+// LastHash returns the hash at the end of a chain, to continue it in a new
+// recorder, or "" for an empty history.
+func LastHash(hands []HandRecord) string {
+	if len(hands) == 0 {
+		return ""
+	}
+	return hands[len(hands)-1].Hash
+}
@@ -0,0 +1,82 @@
+package store
+
+import (
+	"errors"
+	"testing"
+
+	"pokerclientv1/pkg/game"
+)
+
+// recordChain saves three chained hands to a JSON store and reads them back.
+func recordChain(t *testing.T) []HandRecord {
+	t.Helper()
+	s, err := OpenJSON(t.TempDir())
+	if err != nil {
+		t.Fatalf("OpenJSON() error: %v", err)
+	}
+	r := NewChainedHandRecorder(s, "table-1", "")
+	for hand := 1; hand <= 3; hand++ {
+		r.HandleEvent(game.Event{Type: game.EventHandStarted, HandNumber: hand, Amount: 2})
+		r.HandleEvent(game.Event{Type: game.EventPotAwarded, HandNumber: hand, PlayerID: "P1", Action: "pot", Amount: 10 * hand})
+		r.HandleEvent(game.Event{Type: game.EventHandEnded, HandNumber: hand})
+	}
+	if r.Err != nil {
+		t.Fatalf("HandRecorder error: %v", r.Err)
+	}
+	hands, err := s.Hands("table-1")
+	if err != nil {
+		t.Fatalf("Hands() error: %v", err)
+	}
+	if len(hands) != 3 {
+		t.Fatalf("Hands() returned %d hands, want 3", len(hands))
+	}
+	if LastHash(hands) != r.LastHash {
+		t.Errorf("LastHash() = %s, want the recorder's %s", LastHash(hands), r.LastHash)
+	}
+	return hands
+}
+
+// TestVerifyChainAcceptsUntouchedHistory checks a history read back from disk.
+func TestVerifyChainAcceptsUntouchedHistory(t *testing.T) {
+	hands := recordChain(t)
+	if err := VerifyChain(hands); err != nil {
+		t.Errorf("VerifyChain() = %v, want nil", err)
+	}
+	if hands[1].PrevHash != hands[0].Hash {
+		t.Errorf("hand 2 PrevHash = %s, want hand 1 hash %s", hands[1].PrevHash, hands[0].Hash)
+	}
+}
+
+// TestVerifyChainDetectsTampering checks edits, deletions and rehashed edits.
+func TestVerifyChainDetectsTampering(t *testing.T) {
+	tests := []struct {
+		name    string
+		tamper  func([]HandRecord) []HandRecord
+		wantIdx int
+	}{
+		{"edited amount", func(h []HandRecord) []HandRecord {
+			h[1].Events[1].Amount = 1000
+			return h
+		}, 1},
+		{"removed hand", func(h []HandRecord) []HandRecord {
+			return append(h[:1], h[2:]...)
+		}, 1},
+		{"edited and rehashed", func(h []HandRecord) []HandRecord {
+			h[0].Events[1].PlayerID = "P2"
+			h[0].Hash, _ = HashHand(h[0])
+			return h
+		}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifyChain(tt.tamper(recordChain(t)))
+			var chainErr *ChainError
+			if !errors.As(err, &chainErr) {
+				t.Fatalf("VerifyChain() = %v, want a *ChainError", err)
+			}
+			if chainErr.Index != tt.wantIdx {
+				t.Errorf("VerifyChain() failed at record %d, want %d", chainErr.Index, tt.wantIdx)
+			}
+		})
+	}
+}
@@ -0,0 +1,221 @@
+package store
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"pokerclientv1/pkg/types"
+)
+
+// Retention limits how much history is kept on disk. The live hand history
+// is rotated once it reaches MaxFileSize: it is renamed with the time it was
+// closed, gzipped when Compress is set, and a new file is started. Rotated
+// files and saved session logs are deleted once there are more than MaxFiles
+// of them or they are older than MaxAgeDays. Zero limits keep everything.
+type Retention struct {
+	MaxFileSize int64 `json:"maxFileSize"` // Bytes
+	MaxFiles    int   `json:"maxFiles"`
+	MaxAgeDays  int   `json:"maxAgeDays"`
+	Compress    bool  `json:"compress"`
+}
+
+// DefaultRetention rotates the hand history every 8 MiB and compresses what
+// it rotates, keeping all of it.
+var DefaultRetention = Retention{MaxFileSize: 8 << 20, Compress: true}
+
+// Settings are the preferences read from config.json in DefaultDir.
+type Settings struct {
+	History Retention      `json:"history"`
+	Keys    types.Bindings `json:"keys"` // Extra keys for commands at the action prompt
+}
+
+// LoadSettings reads config.json from dir. Settings the file leaves out, or
+// all of them when there is no file, keep their defaults.
+func LoadSettings(dir string) (Settings, error) {
+	settings := Settings{History: DefaultRetention}
+	data, err := os.ReadFile(filepath.Join(dir, "config.json"))
+	if errors.Is(err, os.ErrNotExist) {
+		return settings, nil
+	}
+	if err != nil {
+		return settings, err
+	}
+	if err := json.Unmarshal(data, &settings); err != nil {
+		return Settings{History: DefaultRetention}, fmt.Errorf("store: config.json: %w", err)
+	}
+	if err := settings.Keys.Validate(); err != nil {
+		return Settings{History: DefaultRetention}, fmt.Errorf("store: config.json: %w", err)
+	}
+	return settings, nil
+}
+
+// OpenHistory opens a history file for reading, decompressing it if it was
+// gzipped, whatever its name.
+func OpenHistory(path string) (io.ReadCloser, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	r := bufio.NewReader(f)
+	if magic, _ := r.Peek(2); !bytes.Equal(magic, []byte{0x1f, 0x8b}) {
+		return struct {
+			io.Reader
+			io.Closer
+		}{r, f}, nil
+	}
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return struct {
+		io.Reader
+		io.Closer
+	}{gz, closers{gz, f}}, nil
+}
+
+// closers closes each of its closers in turn, returning the first error.
+type closers []io.Closer
+
+func (cs closers) Close() error {
+	var first error
+	for _, c := range cs {
+		if err := c.Close(); err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
+
+// HistoryFiles returns the files making up the hand history at path, oldest
+// first: the files rotated from it, then path itself if it exists.
+func HistoryFiles(path string) ([]string, error) {
+	files, err := filepath.Glob(rotatedPattern(path))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files) // Rotated names sort by the time they were closed
+	if _, err := os.Stat(path); err == nil {
+		files = append(files, path)
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	return files, nil
+}
+
+// ReadHistory reads the hands of the history at path, including the files
+// rotated from it, compressed or not. See ReadHands for sessionID.
+func ReadHistory(path, sessionID string) ([]HandRecord, error) {
+	files, err := HistoryFiles(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("open %s: %w", path, os.ErrNotExist)
+	}
+	hands := []HandRecord{}
+	for _, file := range files {
+		f, err := OpenHistory(file)
+		if err != nil {
+			return nil, err
+		}
+		read, err := ReadHands(f, sessionID)
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+		hands = append(hands, read...)
+	}
+	return hands, nil
+}
+
+// rotatedPattern is the glob matching the files rotated from path:
+// hands.jsonl is rotated to hands-<time>.jsonl, or hands-<time>.jsonl.gz.
+func rotatedPattern(path string) string {
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + "-*" + ext + "*"
+}
+
+// Rotate closes the history at path if it has reached MaxFileSize, then
+// prunes the rotated files. It does nothing without a MaxFileSize.
+func (r Retention) Rotate(path string) error {
+	if r.MaxFileSize <= 0 {
+		return nil
+	}
+	info, err := os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil || info.Size() < r.MaxFileSize {
+		return err
+	}
+	ext := filepath.Ext(path)
+	rotated := strings.TrimSuffix(path, ext) + "-" + time.Now().Format("20060102-150405.000000000") + ext
+	if err := os.Rename(path, rotated); err != nil {
+		return err
+	}
+	if r.Compress {
+		if err := compressFile(rotated); err != nil {
+			return err
+		}
+	}
+	return r.Prune(rotatedPattern(path))
+}
+
+// Prune deletes the files matching a glob that the retention limits no
+// longer keep. Names must sort by age, as rotated files and session logs do.
+func (r Retention) Prune(pattern string) error {
+	files, err := filepath.Glob(pattern)
+	if err != nil {
+		return err
+	}
+	sort.Strings(files)
+	var errs []error
+	for i, file := range files {
+		expired := false
+		if r.MaxAgeDays > 0 {
+			info, err := os.Stat(file)
+			expired = err == nil && time.Since(info.ModTime()) > time.Duration(r.MaxAgeDays)*24*time.Hour
+		}
+		if expired || r.MaxFiles > 0 && i < len(files)-r.MaxFiles {
+			errs = append(errs, os.Remove(file))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// compressFile replaces a file with a gzipped copy named path.gz.
+func compressFile(path string) error {
+	in, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(path+".gz", os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	gz := gzip.NewWriter(out)
+	_, err = io.Copy(gz, in)
+	if cerr := gz.Close(); err == nil {
+		err = cerr
+	}
+	if cerr := out.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(path + ".gz")
+		return err
+	}
+	return os.Remove(path)
+}
@@ -0,0 +1,101 @@
+package store
+
+import (
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestJSONStoreRotatesHistory checks that the hand history is rotated and
+// compressed as it grows, that old files are pruned, and that the hands
+// kept are still read back in order.
+func TestJSONStoreRotatesHistory(t *testing.T) {
+	dir := t.TempDir()
+	s, err := OpenJSON(dir)
+	if err != nil {
+		t.Fatalf("OpenJSON() returned an unexpected error: %v", err)
+	}
+	s.Retention = Retention{MaxFileSize: 1, MaxFiles: 2, Compress: true}
+	for i := 1; i <= 4; i++ {
+		if err := s.SaveHand(HandRecord{SessionID: "s1", HandNumber: i}); err != nil {
+			t.Fatalf("SaveHand() returned an unexpected error: %v", err)
+		}
+	}
+
+	files, err := HistoryFiles(filepath.Join(dir, "hands.jsonl"))
+	if err != nil {
+		t.Fatalf("HistoryFiles() returned an unexpected error: %v", err)
+	}
+	if len(files) != 2 {
+		t.Fatalf("HistoryFiles() = %v, want the 2 rotated files kept", files)
+	}
+	for _, file := range files {
+		if !strings.HasSuffix(file, ".jsonl.gz") {
+			t.Errorf("rotated file %s was not compressed", file)
+		}
+	}
+	hands, err := s.Hands("s1")
+	if err != nil {
+		t.Fatalf("Hands() returned an unexpected error: %v", err)
+	}
+	if len(hands) != 2 || hands[0].HandNumber != 3 || hands[1].HandNumber != 4 {
+		t.Errorf("Hands() = %+v, want hands 3 and 4", hands)
+	}
+}
+
+// TestOpenHistoryDetectsGzip checks that a gzipped history is read whatever
+// it is called.
+func TestOpenHistoryDetectsGzip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hands.jsonl")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gz := gzip.NewWriter(f)
+	gz.Write([]byte(`{"sessionId":"s1","handNumber":7}` + "\n"))
+	gz.Close()
+	f.Close()
+
+	hands, err := ReadHistory(path, "")
+	if err != nil {
+		t.Fatalf("ReadHistory() returned an unexpected error: %v", err)
+	}
+	if len(hands) != 1 || hands[0].HandNumber != 7 {
+		t.Errorf("ReadHistory() = %+v, want hand 7", hands)
+	}
+}
+
+// TestLoadSettings checks that the config file overrides only the settings
+// it names.
+func TestLoadSettings(t *testing.T) {
+	dir := t.TempDir()
+	settings, err := LoadSettings(dir)
+	if err != nil || settings.History != DefaultRetention {
+		t.Fatalf("LoadSettings() without a file = %+v, %v, want the defaults", settings, err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "config.json"), []byte(`{"history":{"maxFiles":5}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	settings, err = LoadSettings(dir)
+	want := DefaultRetention
+	want.MaxFiles = 5
+	if err != nil || settings.History != want {
+		t.Errorf("LoadSettings() = %+v, %v, want %+v", settings, err, want)
+	}
+}
+
+// TestLoadSettingsRejectsKeyConflicts checks that a key bound to two
+// commands is reported and the defaults are used.
+func TestLoadSettingsRejectsKeyConflicts(t *testing.T) {
+	dir := t.TempDir()
+	config := `{"history":{"maxFiles":5},"keys":{"fold":["f"],"call":["f"]}}`
+	if err := os.WriteFile(filepath.Join(dir, "config.json"), []byte(config), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	settings, err := LoadSettings(dir)
+	if err == nil || settings.Keys != nil || settings.History != DefaultRetention {
+		t.Errorf("LoadSettings() = %+v, %v, want an error and the defaults", settings, err)
+	}
+}
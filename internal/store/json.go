@@ -0,0 +1,178 @@
+package store
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// JSONStore keeps data in flat files inside a directory: hands.jsonl holds one
+// hand per line and stats.json and bankrolls.json hold maps keyed by ID.
+type JSONStore struct {
+	// Limits on the hand history, applied as hands are saved. The zero
+	// value lets hands.jsonl grow without bound.
+	Retention Retention
+
+	mu  sync.Mutex
+	dir string
+}
+
+// OpenJSON creates the directory if needed and returns a store using it.
+func OpenJSON(dir string) (*JSONStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &JSONStore{dir: dir}, nil
+}
+
+func (s *JSONStore) SaveHand(hand HandRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data, err := json.Marshal(hand)
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(s.dir, "hands.jsonl")
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(append(data, '\n'))
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+	return s.Retention.Rotate(path)
+}
+
+func (s *JSONStore) Hands(sessionID string) ([]HandRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	hands, err := ReadHistory(filepath.Join(s.dir, "hands.jsonl"), sessionID)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	return hands, err
+}
+
+// ReadHands decodes a hand history written one JSON record per line, keeping
+// the hands of sessionID or every hand when sessionID is empty.
+func ReadHands(r io.Reader, sessionID string) ([]HandRecord, error) {
+	hands := []HandRecord{}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var hand HandRecord
+		if err := json.Unmarshal(scanner.Bytes(), &hand); err != nil {
+			return nil, err
+		}
+		if sessionID == "" || hand.SessionID == sessionID {
+			hands = append(hands, hand)
+		}
+	}
+	return hands, scanner.Err()
+}
+
+func (s *JSONStore) SaveStats(stats PlayerStats) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	all := map[string]PlayerStats{}
+	if err := s.readMap("stats.json", &all); err != nil {
+		return err
+	}
+	all[stats.PlayerID] = stats
+	return s.writeMap("stats.json", all)
+}
+
+func (s *JSONStore) LoadStats(playerID string) (PlayerStats, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	all := map[string]PlayerStats{}
+	if err := s.readMap("stats.json", &all); err != nil {
+		return PlayerStats{}, err
+	}
+	stats, ok := all[playerID]
+	if !ok {
+		return PlayerStats{}, ErrNotFound
+	}
+	return stats, nil
+}
+
+func (s *JSONStore) AllStats() ([]PlayerStats, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	all := map[string]PlayerStats{}
+	if err := s.readMap("stats.json", &all); err != nil {
+		return nil, err
+	}
+	result := make([]PlayerStats, 0, len(all))
+	for _, stats := range all {
+		result = append(result, stats)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].PlayerID < result[j].PlayerID })
+	return result, nil
+}
+
+func (s *JSONStore) SaveBankroll(bankroll Bankroll) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	all := map[string]Bankroll{}
+	if err := s.readMap("bankrolls.json", &all); err != nil {
+		return err
+	}
+	all[bankroll.Profile] = bankroll
+	return s.writeMap("bankrolls.json", all)
+}
+
+func (s *JSONStore) LoadBankroll(profile string) (Bankroll, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	all := map[string]Bankroll{}
+	if err := s.readMap("bankrolls.json", &all); err != nil {
+		return Bankroll{}, err
+	}
+	bankroll, ok := all[profile]
+	if !ok {
+		return Bankroll{}, ErrNotFound
+	}
+	return bankroll, nil
+}
+
+func (s *JSONStore) Close() error { return nil }
+
+// readMap loads a JSON file into v, leaving v untouched if the file does not exist.
+func (s *JSONStore) readMap(name string, v interface{}) error {
+	data, err := os.ReadFile(filepath.Join(s.dir, name))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// writeMap saves v as indented JSON, replacing the file atomically.
+func (s *JSONStore) writeMap(name string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(s.dir, name)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
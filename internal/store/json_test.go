@@ -0,0 +1,82 @@
+package store
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"pokerclientv1/pkg/game"
+)
+
+// TestJSONStoreRoundTrip checks that hands, stats and bankrolls survive reopening the store.
+func TestJSONStoreRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	s, err := Open(Config{Backend: "json", Path: dir})
+	if err != nil {
+		t.Fatalf("Open() returned an unexpected error: %v", err)
+	}
+
+	hand := HandRecord{SessionID: "s1", HandNumber: 1, Played: time.Now(), Events: []game.Event{
+		{Type: game.EventPotAwarded, PlayerID: "Bot 1", Amount: 40},
+	}}
+	if err := s.SaveHand(hand); err != nil {
+		t.Fatalf("SaveHand() returned an unexpected error: %v", err)
+	}
+	if err := s.SaveHand(HandRecord{SessionID: "s2", HandNumber: 1}); err != nil {
+		t.Fatalf("SaveHand() returned an unexpected error: %v", err)
+	}
+	if err := s.SaveStats(PlayerStats{PlayerID: "Bot 1", HandsDealt: 10, VPIPHands: 3}); err != nil {
+		t.Fatalf("SaveStats() returned an unexpected error: %v", err)
+	}
+	if err := s.SaveBankroll(Bankroll{Profile: "me", Avatar: "🦁", Chips: 1500}); err != nil {
+		t.Fatalf("SaveBankroll() returned an unexpected error: %v", err)
+	}
+
+	reopened, err := OpenJSON(dir)
+	if err != nil {
+		t.Fatalf("OpenJSON() returned an unexpected error: %v", err)
+	}
+	hands, err := reopened.Hands("s1")
+	if err != nil || len(hands) != 1 || len(hands[0].Events) != 1 || hands[0].Events[0].Amount != 40 {
+		t.Errorf("Hands(s1) = %+v, %v, want the one saved hand", hands, err)
+	}
+	if all, _ := reopened.Hands(""); len(all) != 2 {
+		t.Errorf("Hands(\"\") returned %d hands, want 2", len(all))
+	}
+	stats, err := reopened.LoadStats("Bot 1")
+	if err != nil || stats.VPIPHands != 3 {
+		t.Errorf("LoadStats() = %+v, %v, want VPIPHands 3", stats, err)
+	}
+	if _, err := reopened.LoadStats("nobody"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("LoadStats(nobody) error = %v, want ErrNotFound", err)
+	}
+	bankroll, err := reopened.LoadBankroll("me")
+	if err != nil || bankroll.Chips != 1500 || bankroll.Avatar != "🦁" {
+		t.Errorf("LoadBankroll() = %+v, %v, want 1500 chips and the avatar", bankroll, err)
+	}
+}
+
+// TestOpenUnknownBackend checks that misconfigured backends are rejected.
+func TestOpenUnknownBackend(t *testing.T) {
+	if _, err := Open(Config{Backend: "postgres"}); err == nil {
+		t.Errorf("Open(postgres) = nil error, want unknown backend error")
+	}
+}
+
+// TestProfileRoundTrip checks that a profile survives saving and that a
+// missing profile is reported as not found.
+func TestProfileRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "profile.json")
+	if _, err := LoadProfile(path); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("LoadProfile() of a missing file error = %v, want ErrNotFound", err)
+	}
+	want := Bankroll{Profile: "Player 1", Chips: 9400, LifetimeHands: 120, NetResult: -600}
+	if err := SaveProfile(path, want); err != nil {
+		t.Fatalf("SaveProfile() returned an unexpected error: %v", err)
+	}
+	got, err := LoadProfile(path)
+	if err != nil || got != want {
+		t.Errorf("LoadProfile() = %+v, %v, want %+v", got, err, want)
+	}
+}
@@ -0,0 +1,55 @@
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+// DefaultBankroll is the chip balance a new profile starts with.
+const DefaultBankroll = 10000
+
+// ProfilePath returns ~/.pokerclient/profile.json, where the human player's
+// bankroll and career totals are kept between games.
+func ProfilePath() (string, error) {
+	dir, err := DefaultDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "profile.json"), nil
+}
+
+// LoadProfile reads the profile at path, or returns ErrNotFound if there is
+// none yet.
+func LoadProfile(path string) (Bankroll, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return Bankroll{}, ErrNotFound
+	}
+	if err != nil {
+		return Bankroll{}, err
+	}
+	var profile Bankroll
+	if err := json.Unmarshal(data, &profile); err != nil {
+		return Bankroll{}, err
+	}
+	return profile, nil
+}
+
+// SaveProfile writes the profile to path as indented JSON, replacing the file
+// atomically and creating its directory if needed.
+func SaveProfile(path string, profile Bankroll) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(profile, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
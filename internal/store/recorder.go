@@ -0,0 +1,64 @@
+package store
+
+import (
+	"time"
+
+	"pokerclientv1/pkg/game"
+)
+
+// HandRecorder collects game events into hand records and saves each hand to
+// a Store when it ends. Register HandleEvent with Game.OnEvent.
+type HandRecorder struct {
+	Store     Store
+	SessionID string
+	Err       error // First error returned by the store, if any
+	Chained   bool  // Link hands in a tamper-evident hash chain, see HashHand
+	LastHash  string
+	current   *HandRecord
+}
+
+// NewHandRecorder creates a recorder that saves hands under sessionID.
+func NewHandRecorder(s Store, sessionID string) *HandRecorder {
+	return &HandRecorder{Store: s, SessionID: sessionID}
+}
+
+// NewChainedHandRecorder creates a recorder that hash-chains every saved hand
+// to the one before it. lastHash continues an existing chain, see LastHash.
+func NewChainedHandRecorder(s Store, sessionID, lastHash string) *HandRecorder {
+	return &HandRecorder{Store: s, SessionID: sessionID, Chained: true, LastHash: lastHash}
+}
+
+// HandleEvent adds an event to the current hand and saves the hand once it ends.
+func (r *HandRecorder) HandleEvent(e game.Event) {
+	switch e.Type {
+	case game.EventHandStarted:
+		r.current = &HandRecord{SessionID: r.SessionID, HandNumber: e.HandNumber, Played: time.Now()}
+	case game.EventGameOver:
+		return
+	case game.EventActionOn:
+		return // Prompts add nothing to the action that follows them
+	case game.EventHandAbandoned:
+		r.current = nil // Only finished hands are kept
+		return
+	}
+	if r.current == nil {
+		return // Events between hands, such as eliminations, are not part of a hand
+	}
+	r.current.Events = append(r.current.Events, e)
+
+	if e.Type == game.EventHandEnded {
+		if r.Chained {
+			r.current.PrevHash = r.LastHash
+			hash, err := HashHand(*r.current)
+			if err != nil && r.Err == nil {
+				r.Err = err
+			}
+			r.current.Hash = hash
+			r.LastHash = hash
+		}
+		if err := r.Store.SaveHand(*r.current); err != nil && r.Err == nil {
+			r.Err = err
+		}
+		r.current = nil
+	}
+}
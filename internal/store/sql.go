@@ -0,0 +1,225 @@
+package store
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// sqliteDriver is the database/sql driver name used by the sqlite backend.
+const sqliteDriver = "sqlite"
+
+// SQLStore keeps data in a SQL database through database/sql. The schema is
+// written for SQLite.
+type SQLStore struct {
+	db *sql.DB
+}
+
+const sqlSchema = `
+CREATE TABLE IF NOT EXISTS hands (
+	session_id  TEXT NOT NULL,
+	hand_number INTEGER NOT NULL,
+	played      TIMESTAMP NOT NULL,
+	events      TEXT NOT NULL,
+	prev_hash   TEXT NOT NULL DEFAULT '',
+	hash        TEXT NOT NULL DEFAULT '',
+	PRIMARY KEY (session_id, hand_number)
+);
+CREATE TABLE IF NOT EXISTS player_stats (
+	player_id           TEXT PRIMARY KEY,
+	hands_dealt         INTEGER NOT NULL,
+	vpip_hands          INTEGER NOT NULL,
+	pfr_hands           INTEGER NOT NULL,
+	three_bet_chance    INTEGER NOT NULL,
+	three_bet_hands     INTEGER NOT NULL,
+	bets                INTEGER NOT NULL,
+	calls               INTEGER NOT NULL,
+	showdowns           INTEGER NOT NULL,
+	showdowns_won       INTEGER NOT NULL,
+	net_chips           INTEGER NOT NULL,
+	cold_call_chance    INTEGER NOT NULL DEFAULT 0,
+	cold_calls          INTEGER NOT NULL DEFAULT 0,
+	squeeze_chance      INTEGER NOT NULL DEFAULT 0,
+	squeezes            INTEGER NOT NULL DEFAULT 0,
+	fold_to_cbet_chance INTEGER NOT NULL DEFAULT 0,
+	folds_to_cbet       INTEGER NOT NULL DEFAULT 0,
+	check_raise_chance  INTEGER NOT NULL DEFAULT 0,
+	check_raises        INTEGER NOT NULL DEFAULT 0
+);
+CREATE TABLE IF NOT EXISTS bankrolls (
+	profile        TEXT PRIMARY KEY,
+	avatar         TEXT NOT NULL DEFAULT '',
+	chips          INTEGER NOT NULL,
+	lifetime_hands INTEGER NOT NULL,
+	net_result     INTEGER NOT NULL,
+	updated        TIMESTAMP NOT NULL
+);`
+
+// sqlMigrations bring databases created by older versions up to sqlSchema.
+// Each adds one column; SQLite reports a duplicate column when it is there.
+var sqlMigrations = []string{
+	`ALTER TABLE bankrolls ADD COLUMN avatar TEXT NOT NULL DEFAULT ''`,
+	`ALTER TABLE player_stats ADD COLUMN cold_call_chance INTEGER NOT NULL DEFAULT 0`,
+	`ALTER TABLE player_stats ADD COLUMN cold_calls INTEGER NOT NULL DEFAULT 0`,
+	`ALTER TABLE player_stats ADD COLUMN squeeze_chance INTEGER NOT NULL DEFAULT 0`,
+	`ALTER TABLE player_stats ADD COLUMN squeezes INTEGER NOT NULL DEFAULT 0`,
+	`ALTER TABLE player_stats ADD COLUMN fold_to_cbet_chance INTEGER NOT NULL DEFAULT 0`,
+	`ALTER TABLE player_stats ADD COLUMN folds_to_cbet INTEGER NOT NULL DEFAULT 0`,
+	`ALTER TABLE player_stats ADD COLUMN check_raise_chance INTEGER NOT NULL DEFAULT 0`,
+	`ALTER TABLE player_stats ADD COLUMN check_raises INTEGER NOT NULL DEFAULT 0`,
+}
+
+// OpenSQL opens a database with the given driver and creates the schema if needed.
+func OpenSQL(driver, dsn string) (*SQLStore, error) {
+	if !driverRegistered(driver) {
+		return nil, fmt.Errorf("store: database driver %q is not compiled in", driver)
+	}
+	db, err := sql.Open(driver, dsn)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := db.Exec(sqlSchema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("store: creating schema: %w", err)
+	}
+	for _, m := range sqlMigrations {
+		if _, err := db.Exec(m); err != nil && !strings.Contains(err.Error(), "duplicate column") {
+			db.Close()
+			return nil, fmt.Errorf("store: migrating schema: %w", err)
+		}
+	}
+	return &SQLStore{db: db}, nil
+}
+
+func (s *SQLStore) SaveHand(hand HandRecord) error {
+	events, err := json.Marshal(hand.Events)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(`INSERT INTO hands (session_id, hand_number, played, events, prev_hash, hash) VALUES (?, ?, ?, ?, ?, ?)`,
+		hand.SessionID, hand.HandNumber, hand.Played.UTC(), string(events), hand.PrevHash, hand.Hash)
+	return err
+}
+
+func (s *SQLStore) Hands(sessionID string) ([]HandRecord, error) {
+	query := `SELECT session_id, hand_number, played, events, prev_hash, hash FROM hands`
+	args := []interface{}{}
+	if sessionID != "" {
+		query += ` WHERE session_id = ?`
+		args = append(args, sessionID)
+	}
+	rows, err := s.db.Query(query+` ORDER BY played, hand_number`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	hands := []HandRecord{}
+	for rows.Next() {
+		var hand HandRecord
+		var events string
+		if err := rows.Scan(&hand.SessionID, &hand.HandNumber, &hand.Played, &events, &hand.PrevHash, &hand.Hash); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(events), &hand.Events); err != nil {
+			return nil, err
+		}
+		hands = append(hands, hand)
+	}
+	return hands, rows.Err()
+}
+
+func (s *SQLStore) SaveStats(st PlayerStats) error {
+	_, err := s.db.Exec(`INSERT INTO player_stats (`+statsColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(player_id) DO UPDATE SET hands_dealt = excluded.hands_dealt, vpip_hands = excluded.vpip_hands,
+		pfr_hands = excluded.pfr_hands, three_bet_chance = excluded.three_bet_chance, three_bet_hands = excluded.three_bet_hands,
+		bets = excluded.bets, calls = excluded.calls, showdowns = excluded.showdowns, showdowns_won = excluded.showdowns_won,
+		net_chips = excluded.net_chips, cold_call_chance = excluded.cold_call_chance, cold_calls = excluded.cold_calls,
+		squeeze_chance = excluded.squeeze_chance, squeezes = excluded.squeezes,
+		fold_to_cbet_chance = excluded.fold_to_cbet_chance, folds_to_cbet = excluded.folds_to_cbet,
+		check_raise_chance = excluded.check_raise_chance, check_raises = excluded.check_raises`,
+		st.PlayerID, st.HandsDealt, st.VPIPHands, st.PFRHands, st.ThreeBetChance, st.ThreeBetHands,
+		st.Bets, st.Calls, st.Showdowns, st.ShowdownsWon, st.NetChips,
+		st.ColdCallChance, st.ColdCalls, st.SqueezeChance, st.Squeezes,
+		st.FoldToCBetChance, st.FoldsToCBet, st.CheckRaiseChance, st.CheckRaises)
+	return err
+}
+
+const statsColumns = `player_id, hands_dealt, vpip_hands, pfr_hands, three_bet_chance, three_bet_hands,
+	bets, calls, showdowns, showdowns_won, net_chips, cold_call_chance, cold_calls, squeeze_chance, squeezes,
+	fold_to_cbet_chance, folds_to_cbet, check_raise_chance, check_raises`
+
+func (s *SQLStore) LoadStats(playerID string) (PlayerStats, error) {
+	row := s.db.QueryRow(`SELECT `+statsColumns+` FROM player_stats WHERE player_id = ?`, playerID)
+	st, err := scanStats(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return PlayerStats{}, ErrNotFound
+	}
+	return st, err
+}
+
+func (s *SQLStore) AllStats() ([]PlayerStats, error) {
+	rows, err := s.db.Query(`SELECT ` + statsColumns + ` FROM player_stats ORDER BY player_id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	all := []PlayerStats{}
+	for rows.Next() {
+		st, err := scanStats(rows)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, st)
+	}
+	return all, rows.Err()
+}
+
+// scanStats reads one player_stats row selected with statsColumns.
+func scanStats(row interface{ Scan(...interface{}) error }) (PlayerStats, error) {
+	var st PlayerStats
+	err := row.Scan(&st.PlayerID, &st.HandsDealt, &st.VPIPHands, &st.PFRHands, &st.ThreeBetChance,
+		&st.ThreeBetHands, &st.Bets, &st.Calls, &st.Showdowns, &st.ShowdownsWon, &st.NetChips,
+		&st.ColdCallChance, &st.ColdCalls, &st.SqueezeChance, &st.Squeezes,
+		&st.FoldToCBetChance, &st.FoldsToCBet, &st.CheckRaiseChance, &st.CheckRaises)
+	return st, err
+}
+
+func (s *SQLStore) SaveBankroll(b Bankroll) error {
+	if b.Updated.IsZero() {
+		b.Updated = time.Now()
+	}
+	_, err := s.db.Exec(`INSERT INTO bankrolls (profile, avatar, chips, lifetime_hands, net_result, updated) VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT(profile) DO UPDATE SET avatar = excluded.avatar, chips = excluded.chips, lifetime_hands = excluded.lifetime_hands,
+		net_result = excluded.net_result, updated = excluded.updated`,
+		b.Profile, b.Avatar, b.Chips, b.LifetimeHands, b.NetResult, b.Updated.UTC())
+	return err
+}
+
+func (s *SQLStore) LoadBankroll(profile string) (Bankroll, error) {
+	var b Bankroll
+	err := s.db.QueryRow(`SELECT profile, avatar, chips, lifetime_hands, net_result, updated FROM bankrolls WHERE profile = ?`, profile).
+		Scan(&b.Profile, &b.Avatar, &b.Chips, &b.LifetimeHands, &b.NetResult, &b.Updated)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Bankroll{}, ErrNotFound
+	}
+	return b, err
+}
+
+func (s *SQLStore) Close() error {
+	return s.db.Close()
+}
+
+// driverRegistered reports whether a database/sql driver has been linked in.
+func driverRegistered(name string) bool {
+	for _, d := range sql.Drivers() {
+		if d == name {
+			return true
+		}
+	}
+	return false
+}
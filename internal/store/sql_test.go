@@ -0,0 +1,139 @@
+package store
+
+import (
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"pokerclientv1/pkg/game"
+)
+
+// TestSQLStoreRoundTrip checks that hands, stats and bankrolls survive
+// reopening the database, and that saving stats and bankrolls again
+// replaces the stored row.
+func TestSQLStoreRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "poker.db")
+	s, err := Open(Config{Backend: "sqlite", Path: path})
+	if err != nil {
+		t.Fatalf("Open() returned an unexpected error: %v", err)
+	}
+
+	played := time.Date(2024, 5, 1, 20, 0, 0, 0, time.UTC)
+	hand := HandRecord{SessionID: "s1", HandNumber: 1, Played: played, PrevHash: "p", Hash: "h", Events: []game.Event{
+		{Type: game.EventPotAwarded, PlayerID: "Bot 1", Amount: 40},
+	}}
+	if err := s.SaveHand(hand); err != nil {
+		t.Fatalf("SaveHand() returned an unexpected error: %v", err)
+	}
+	if err := s.SaveHand(hand); err == nil {
+		t.Errorf("SaveHand() of the same hand twice = nil, want an error")
+	}
+	if err := s.SaveHand(HandRecord{SessionID: "s2", HandNumber: 1, Played: played.Add(time.Minute)}); err != nil {
+		t.Fatalf("SaveHand() returned an unexpected error: %v", err)
+	}
+	if err := s.SaveStats(PlayerStats{PlayerID: "Bot 1", HandsDealt: 10, VPIPHands: 3}); err != nil {
+		t.Fatalf("SaveStats() returned an unexpected error: %v", err)
+	}
+	if err := s.SaveStats(PlayerStats{PlayerID: "Bot 1", HandsDealt: 12, VPIPHands: 4, CheckRaises: 1}); err != nil {
+		t.Fatalf("SaveStats() again returned an unexpected error: %v", err)
+	}
+	if err := s.SaveBankroll(Bankroll{Profile: "me", Avatar: "🦁", Chips: 1500}); err != nil {
+		t.Fatalf("SaveBankroll() returned an unexpected error: %v", err)
+	}
+	if err := s.SaveBankroll(Bankroll{Profile: "me", Avatar: "🦁", Chips: 1200, LifetimeHands: 30}); err != nil {
+		t.Fatalf("SaveBankroll() again returned an unexpected error: %v", err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close() returned an unexpected error: %v", err)
+	}
+
+	reopened, err := OpenSQL(sqliteDriver, path)
+	if err != nil {
+		t.Fatalf("OpenSQL() returned an unexpected error: %v", err)
+	}
+	defer reopened.Close()
+	hands, err := reopened.Hands("s1")
+	if err != nil || len(hands) != 1 || len(hands[0].Events) != 1 || hands[0].Events[0].Amount != 40 {
+		t.Fatalf("Hands(s1) = %+v, %v, want the one saved hand", hands, err)
+	}
+	if got := hands[0]; !got.Played.Equal(played) || got.PrevHash != "p" || got.Hash != "h" {
+		t.Errorf("Hands(s1)[0] = %+v, want it played at %v with its hashes", got, played)
+	}
+	if all, _ := reopened.Hands(""); len(all) != 2 || all[0].SessionID != "s1" {
+		t.Errorf("Hands(\"\") = %+v, want both hands in the order played", all)
+	}
+	stats, err := reopened.AllStats()
+	if err != nil || len(stats) != 1 || stats[0].HandsDealt != 12 || stats[0].CheckRaises != 1 {
+		t.Errorf("AllStats() = %+v, %v, want Bot 1's second save only", stats, err)
+	}
+	if _, err := reopened.LoadStats("nobody"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("LoadStats(nobody) error = %v, want ErrNotFound", err)
+	}
+	bankroll, err := reopened.LoadBankroll("me")
+	if err != nil || bankroll.Chips != 1200 || bankroll.LifetimeHands != 30 || bankroll.Avatar != "🦁" {
+		t.Errorf("LoadBankroll() = %+v, %v, want the second save", bankroll, err)
+	}
+	if _, err := reopened.LoadBankroll("nobody"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("LoadBankroll(nobody) error = %v, want ErrNotFound", err)
+	}
+}
+
+// TestSQLStoreMigratesOldSchema opens a database made before the avatar and
+// the later stat columns were added and checks that they are added and used.
+func TestSQLStoreMigratesOldSchema(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "old.db")
+	db, err := sql.Open(sqliteDriver, path)
+	if err != nil {
+		t.Fatalf("sql.Open() returned an unexpected error: %v", err)
+	}
+	_, err = db.Exec(`
+CREATE TABLE player_stats (
+	player_id        TEXT PRIMARY KEY,
+	hands_dealt      INTEGER NOT NULL,
+	vpip_hands       INTEGER NOT NULL,
+	pfr_hands        INTEGER NOT NULL,
+	three_bet_chance INTEGER NOT NULL,
+	three_bet_hands  INTEGER NOT NULL,
+	bets             INTEGER NOT NULL,
+	calls            INTEGER NOT NULL,
+	showdowns        INTEGER NOT NULL,
+	showdowns_won    INTEGER NOT NULL,
+	net_chips        INTEGER NOT NULL
+);
+CREATE TABLE bankrolls (
+	profile        TEXT PRIMARY KEY,
+	chips          INTEGER NOT NULL,
+	lifetime_hands INTEGER NOT NULL,
+	net_result     INTEGER NOT NULL,
+	updated        TIMESTAMP NOT NULL
+);
+INSERT INTO player_stats VALUES ('Bot 1', 5, 2, 1, 0, 0, 3, 1, 0, 0, -20);`)
+	db.Close()
+	if err != nil {
+		t.Fatalf("creating the old schema: %v", err)
+	}
+
+	s, err := OpenSQL(sqliteDriver, path)
+	if err != nil {
+		t.Fatalf("OpenSQL() of an old database returned an unexpected error: %v", err)
+	}
+	defer s.Close()
+	old, err := s.LoadStats("Bot 1")
+	if err != nil || old.HandsDealt != 5 || old.NetChips != -20 || old.ColdCalls != 0 {
+		t.Errorf("LoadStats() of an old row = %+v, %v, want it kept with the new counters at 0", old, err)
+	}
+	if err := s.SaveStats(PlayerStats{PlayerID: "Bot 1", HandsDealt: 6, SqueezeChance: 2, Squeezes: 1}); err != nil {
+		t.Fatalf("SaveStats() returned an unexpected error: %v", err)
+	}
+	if st, _ := s.LoadStats("Bot 1"); st.Squeezes != 1 || st.SqueezeChance != 2 {
+		t.Errorf("LoadStats() after saving = %+v, want the squeeze counters saved", st)
+	}
+	if err := s.SaveBankroll(Bankroll{Profile: "me", Avatar: "🦁", Chips: 100}); err != nil {
+		t.Fatalf("SaveBankroll() returned an unexpected error: %v", err)
+	}
+	if b, _ := s.LoadBankroll("me"); b.Avatar != "🦁" {
+		t.Errorf("LoadBankroll() = %+v, want the avatar kept in the new column", b)
+	}
+}
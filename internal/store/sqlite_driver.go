@@ -0,0 +1,4 @@
+package store
+
+// The SQLite driver is pure Go, so the sqlite backend builds without cgo.
+import _ "modernc.org/sqlite"
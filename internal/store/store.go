@@ -0,0 +1,133 @@
+// Package store persists player statistics, hand histories and bankrolls.
+// Features that keep data between sessions go through the Store interface so
+// the backend can be chosen in configuration.
+package store
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"pokerclientv1/pkg/game"
+	"pokerclientv1/pkg/types"
+)
+
+// ErrNotFound is returned when a requested record does not exist.
+var ErrNotFound = errors.New("store: record not found")
+
+// HandRecord is a completed hand as a sequence of game events.
+type HandRecord struct {
+	SessionID  string       `json:"sessionId"`
+	HandNumber int          `json:"handNumber"`
+	Played     time.Time    `json:"played"`
+	Events     []game.Event `json:"events"`
+	PrevHash   string       `json:"prevHash,omitempty"` // Hash of the previous hand in an audit chain
+	Hash       string       `json:"hash,omitempty"`     // Hash of this hand and PrevHash, see HashHand
+}
+
+// PlayerStats holds the raw counters behind a player's statistics. Ratios such
+// as VPIP are derived from them when displayed.
+type PlayerStats struct {
+	PlayerID       string `json:"playerId"`
+	HandsDealt     int    `json:"handsDealt"`
+	VPIPHands      int    `json:"vpipHands"`      // Hands where money was put in voluntarily pre-flop
+	PFRHands       int    `json:"pfrHands"`       // Hands raised pre-flop
+	ThreeBetChance int    `json:"threeBetChance"` // Hands that faced a single pre-flop raise
+	ThreeBetHands  int    `json:"threeBetHands"`  // Hands re-raised when facing a single raise
+	Bets           int    `json:"bets"`           // Bets and raises after the deal
+	Calls          int    `json:"calls"`          // Calls after the deal
+	Showdowns      int    `json:"showdowns"`
+	ShowdownsWon   int    `json:"showdownsWon"`
+	NetChips       int    `json:"netChips"`
+
+	// Situational counters: each chance counts the spots where the stat could apply
+	ColdCallChance   int `json:"coldCallChance"`   // First pre-flop decisions facing a raise
+	ColdCalls        int `json:"coldCalls"`        // Raises called without money in voluntarily
+	SqueezeChance    int `json:"squeezeChance"`    // First pre-flop decisions facing a raise and a caller
+	Squeezes         int `json:"squeezes"`         // Re-raises in that spot
+	FoldToCBetChance int `json:"foldToCBetChance"` // Flop bets faced from the pre-flop raiser
+	FoldsToCBet      int `json:"foldsToCBet"`
+	CheckRaiseChance int `json:"checkRaiseChance"` // Streets where the player checked and then faced a bet
+	CheckRaises      int `json:"checkRaises"`
+}
+
+// Bankroll is a persistent profile's chip balance and career totals.
+type Bankroll struct {
+	Profile       string    `json:"profile"`
+	Avatar        string    `json:"avatar,omitempty"` // Emoji or short glyph shown next to the profile's name
+	Chips         int       `json:"chips"`
+	LifetimeHands int       `json:"lifetimeHands"`
+	NetResult     int       `json:"netResult"`
+	Updated       time.Time `json:"updated"`
+
+	// The player's standing instructions for their turns; only the profile
+	// file keeps them, see SaveProfile
+	Preferences types.Preferences `json:"preferences"`
+}
+
+// Store is implemented by every persistence backend.
+type Store interface {
+	// SaveHand appends a completed hand to the history.
+	SaveHand(hand HandRecord) error
+	// Hands returns the recorded hands of a session, or of all sessions when sessionID is empty.
+	Hands(sessionID string) ([]HandRecord, error)
+
+	// SaveStats replaces the stored counters for a player.
+	SaveStats(stats PlayerStats) error
+	// LoadStats returns a player's counters, or ErrNotFound.
+	LoadStats(playerID string) (PlayerStats, error)
+	// AllStats returns the counters of every known player.
+	AllStats() ([]PlayerStats, error)
+
+	// SaveBankroll replaces the stored bankroll for a profile.
+	SaveBankroll(bankroll Bankroll) error
+	// LoadBankroll returns a profile's bankroll, or ErrNotFound.
+	LoadBankroll(profile string) (Bankroll, error)
+
+	Close() error
+}
+
+// Config selects and locates a backend.
+type Config struct {
+	Backend string // "json" (default) or "sqlite"
+	Path    string // Directory for json, database file for sqlite; defaults under DefaultDir
+}
+
+// Open creates the configured backend.
+func Open(cfg Config) (Store, error) {
+	switch cfg.Backend {
+	case "", "json":
+		path := cfg.Path
+		if path == "" {
+			dir, err := DefaultDir()
+			if err != nil {
+				return nil, err
+			}
+			path = filepath.Join(dir, "data")
+		}
+		return OpenJSON(path)
+	case "sqlite":
+		path := cfg.Path
+		if path == "" {
+			dir, err := DefaultDir()
+			if err != nil {
+				return nil, err
+			}
+			path = filepath.Join(dir, "poker.db")
+		}
+		return OpenSQL(sqliteDriver, path)
+	default:
+		return nil, fmt.Errorf("store: unknown backend %q", cfg.Backend)
+	}
+}
+
+// DefaultDir returns ~/.pokerclient, where local data is kept by default.
+func DefaultDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".pokerclient"), nil
+}
@@ -0,0 +1,100 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"pokerclientv1/pkg/game"
+	"pokerclientv1/pkg/types"
+	"strings"
+	"time"
+)
+
+// CompactUI implements types.GameUI with one line per game state and per
+// action and no screen clearing, for playing as many hands as possible.
+type CompactUI struct {
+	Out   io.Writer // Where output is written, os.Stdout by default
+	Theme *Theme    // ANSI colors, nil for plain text
+
+	// Only Viewer's hole cards are shown on a shared screen, as in ConsoleUI
+	SharedScreen bool
+	Viewer       string
+}
+
+// NewCompactUI creates a compact UI using the default theme.
+func NewCompactUI() *CompactUI {
+	return &CompactUI{Out: os.Stdout, Theme: Themes[DefaultTheme]}
+}
+
+// DisplayGameState prints the stage, pot, board and every seat on one line,
+// e.g. "Flop 120 [10♦ J♣ 2♠] | >Player 1 480/20 [A♠ K♥] | Bot 1 0/35 all-in".
+// Each seat shows chips behind and the current bet. Once there are side pots
+// their amounts follow the pot. In a game the line starts with the hand
+// number, the blinds and the session's length, e.g. "#12 10/20 0:14:32".
+func (ui *CompactUI) DisplayGameState(table *types.Table, players []types.Player, pot int, stage string) {
+	board := make([]string, len(table.CommunityCards))
+	for i, card := range table.CommunityCards {
+		board[i] = ui.Theme.card(card)
+	}
+	parts := []string{fmt.Sprintf("%s %d [%s]", table.Round, pot, strings.Join(board, " "))}
+	if len(table.Pots) > 0 {
+		pots := make([]string, len(table.Pots))
+		for i, p := range table.Pots {
+			pots[i] = fmt.Sprintf("%s %d", p.Name, p.Amount)
+		}
+		parts[0] += " (" + strings.Join(pots, ", ") + ")"
+	}
+	if table.HandNumber > 0 {
+		hand := fmt.Sprintf("#%d %d/%d", table.HandNumber, table.SmallBlind, table.BigBlind)
+		if !table.SessionStart.IsZero() {
+			hand += " " + clock(time.Since(table.SessionStart))
+		}
+		parts = append([]string{hand}, parts...)
+	}
+	for _, p := range players {
+		seat := fmt.Sprintf("%s %d/%d", withPosition(types.DisplayName(p), table.Positions[p.GetID()]), p.GetChips(), p.GetCurrentBet())
+		if p.GetID() == table.Acting {
+			seat = ">" + seat
+		}
+		shown, atShowdown := table.Shown[p.GetID()]
+		switch {
+		case p.IsFolded():
+			seat += " fold"
+		case atShowdown && !shown:
+			seat += " muck"
+		case p.IsAllIn() && !shown:
+			seat += " all-in"
+		case shown || p.IsHuman() && p.GetHand() != nil && (!ui.SharedScreen || p.GetID() == ui.Viewer):
+			hole := make([]string, len(p.GetHand().Cards))
+			for i, card := range p.GetHand().Cards {
+				hole[i] = ui.Theme.card(card)
+			}
+			seat += fmt.Sprintf(" [%s]", strings.Join(hole, " "))
+		}
+		if p.GetID() == table.Acting {
+			seat = ui.Theme.acting(seat)
+		}
+		parts = append(parts, seat)
+	}
+	fmt.Fprintln(ui.Out, strings.Join(parts, " | "))
+}
+
+// HandleEvent shows what players say at the table on a line of its own,
+// as ConsoleUI.HandleEvent does.
+func (ui *CompactUI) HandleEvent(e game.Event) {
+	if e.Type == game.EventChat {
+		fmt.Fprintf(ui.Out, "  %s: %q\n", e.PlayerID, e.Action)
+	}
+}
+
+// ClearScreen does nothing, so earlier hands stay on screen.
+func (ui *CompactUI) ClearScreen() {}
+
+// LogAction prints a player's action on one short line.
+func (ui *CompactUI) LogAction(playerID string, action string, amount int) {
+	if amount > 0 {
+		fmt.Fprintf(ui.Out, "  %s %s (%d)\n", playerID, action, amount)
+	} else {
+		fmt.Fprintf(ui.Out, "  %s %s\n", playerID, action)
+	}
+}
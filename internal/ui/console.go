@@ -2,74 +2,231 @@ package ui
 
 import (
 	"fmt"
-	"pokerclientv1/internal/types"
+	"io"
+	"os"
+	"pokerclientv1/pkg/game"
+	"pokerclientv1/pkg/types"
 	"strings"
+	"time"
 )
 
 // ConsoleUI implements types.GameUI for console-based display
-type ConsoleUI struct{}
+type ConsoleUI struct {
+	Out   io.Writer                    // Where output is written, os.Stdout by default
+	Theme *Theme                       // ANSI colors, nil for plain text
+	HUD   func(playerID string) string // Optional stats shown after each opponent's seat
 
-// NewConsoleUI creates a new console UI instance
+	// Set when several humans share the screen, see player.HotSeat: only
+	// Viewer's hole cards are shown then, and no one's while it is empty.
+	SharedScreen bool
+	Viewer       string
+
+	avatars map[string]string // Avatars of the players last displayed, for the action log
+}
+
+// NewConsoleUI creates a new console UI instance using the default theme
 func NewConsoleUI() *ConsoleUI {
-	return &ConsoleUI{}
+	return &ConsoleUI{Out: os.Stdout, Theme: Themes[DefaultTheme]}
+}
+
+// cards renders cards in brackets, e.g. "[ A♠ K♥ ]".
+func (ui *ConsoleUI) cards(cards []types.Card) string {
+	if len(cards) == 0 {
+		return "[ ]"
+	}
+	parts := make([]string, len(cards))
+	for i, card := range cards {
+		parts[i] = ui.Theme.card(card)
+	}
+	return fmt.Sprintf("[ %s ]", strings.Join(parts, " "))
 }
 
 // DisplayGameState prints the current state of the game to the console.
 func (ui *ConsoleUI) DisplayGameState(table *types.Table, players []types.Player, pot int, stage string) {
-	fmt.Println("\n==================================================")
-	fmt.Printf("--- %s --- Pot: %d ---\n", stage, pot)
+	fmt.Fprintln(ui.Out, "\n==================================================")
+	if header := header(table); header != "" {
+		fmt.Fprintln(ui.Out, header)
+	}
+	fmt.Fprintf(ui.Out, "--- %s --- Pot: %d ---\n", stage, pot)
 
 	// Display Community Cards
-	if len(table.CommunityCards) > 0 {
-		cardsStr := []string{}
-		for _, card := range table.CommunityCards {
-			cardsStr = append(cardsStr, card.String())
+	fmt.Fprintf(ui.Out, "Community Cards: %s\n", ui.cards(table.CommunityCards))
+	if len(table.Pots) > 0 {
+		pots := make([]string, len(table.Pots))
+		for i, p := range table.Pots {
+			pots[i] = fmt.Sprintf("%s %d (%s)", p.Name, p.Amount, strings.Join(p.Eligible, ", "))
 		}
-		fmt.Printf("Community Cards: [ %s ]\n", strings.Join(cardsStr, " "))
-	} else {
-		fmt.Println("Community Cards: [ ]")
+		fmt.Fprintf(ui.Out, "Pots: %s\n", strings.Join(pots, " | "))
 	}
 
-	fmt.Println("--- Players ---")
+	fmt.Fprintln(ui.Out, "--- Players ---")
 	for _, p := range players {
+		if a, ok := p.(types.AvatarHolder); ok && a.GetAvatar() != "" {
+			if ui.avatars == nil {
+				ui.avatars = map[string]string{}
+			}
+			ui.avatars[p.GetID()] = a.GetAvatar()
+		}
+		shown, atShowdown := table.Shown[p.GetID()]
 		status := ""
-		if p.IsFolded() {
+		if atShowdown && !shown {
+			status = " (Mucked)"
+		} else if p.IsFolded() {
 			status = " (Folded)"
-		} else if p.GetChips() == 0 && p.GetCurrentBet() > 0 {
+		} else if p.IsAllIn() {
 			status = " (All-In)"
+			if pots := table.PotsOf(p.GetID()); len(pots) > 0 {
+				status = fmt.Sprintf(" (All-In, plays for the %s)", strings.Join(pots, " and "))
+			}
 		}
 		// Don't show bot hands
 		handStr := "[ ? ? ]" // Default hidden hand
 		// Note: We can't type assert here anymore since we're using the interface
 		// We'll need to add a method to the Player interface to check if it's human
 		// handStr = p.GetHand().String() // Keep original logic for human
-		if !p.IsHuman() {
-			handStr = "[ ###### ]" // Hide bot hand
+		if shown {
+			handStr = ui.cards(p.GetHand().Cards) // Shown at showdown
+		} else if !p.IsHuman() || (ui.SharedScreen && p.GetID() != ui.Viewer) {
+			handStr = "[ ###### ]" // Hide bot hands and other humans' on a shared screen
 		} else {
 			handStr = p.GetHand().String() // Show human hand
+			if p.GetHand() != nil {
+				handStr = ui.cards(p.GetHand().Cards)
+			}
 		}
 
-		fmt.Printf("- %s: Chips: %d | Bet: %d | Hand: %s%s\n",
-			p.GetID(),
+		marker := "-"
+		if p.GetID() == table.Acting {
+			marker = ">" // Their turn
+		}
+		line := fmt.Sprintf("%s %s: Chips: %d | Bet: %d | Hand: %s%s",
+			marker,
+			withPosition(types.DisplayName(p), table.Positions[p.GetID()]),
 			p.GetChips(),
 			p.GetCurrentBet(),
 			handStr,
 			status)
+		if ui.HUD != nil && !p.IsHuman() {
+			if hud := ui.HUD(p.GetID()); hud != "" {
+				line += " | " + hud
+			}
+		}
+		if p.GetID() == table.Acting {
+			if !table.Deadline.IsZero() {
+				line += fmt.Sprintf(" | %ds to act", max(int(time.Until(table.Deadline).Round(time.Second).Seconds()), 0))
+			}
+			line = ui.Theme.acting(line)
+		}
+		fmt.Fprintln(ui.Out, line)
+	}
+}
+
+// HandleEvent shows what players say at the table; register it with
+// Game.OnEvent. The UI is told of everything else through GameUI.
+func (ui *ConsoleUI) HandleEvent(e game.Event) {
+	if e.Type != game.EventChat {
+		return
+	}
+	name := e.PlayerID
+	if avatar := ui.avatars[name]; avatar != "" {
+		name = avatar + " " + name
+	}
+	fmt.Fprintf(ui.Out, "[chat] %s: %s\n", name, e.Action)
+}
+
+// header describes the hand, its stakes and how long the session has
+// lasted, e.g. "Hand #12 | Blinds 10/20, ante 2 (level 3) | 0:14:32", or
+// returns "" for a table that is not part of a game.
+func header(table *types.Table) string {
+	if table.HandNumber == 0 {
+		return ""
+	}
+	blinds := fmt.Sprintf("Blinds %d/%d", table.SmallBlind, table.BigBlind)
+	if table.Ante > 0 {
+		blinds += fmt.Sprintf(", ante %d", table.Ante)
 	}
+	if table.BlindLevel > 0 {
+		blinds += fmt.Sprintf(" (level %d)", table.BlindLevel)
+	}
+	parts := []string{fmt.Sprintf("Hand #%d", table.HandNumber), blinds}
+	if !table.SessionStart.IsZero() {
+		parts = append(parts, clock(time.Since(table.SessionStart)))
+	}
+	return strings.Join(parts, " | ")
+}
+
+// clock shows a duration as hours, minutes and seconds, e.g. "1:02:03".
+func clock(d time.Duration) string {
+	s := int(d.Seconds())
+	return fmt.Sprintf("%d:%02d:%02d", s/3600, s/60%60, s%60)
+}
+
+// withPosition adds a player's position at the table to their name, when
+// the game reported one.
+func withPosition(name, position string) string {
+	if position == "" {
+		return name
+	}
+	return name + " (" + position + ")"
 }
 
 // ClearScreen prints a number of newlines to simulate clearing the console.
 func (ui *ConsoleUI) ClearScreen() {
 	// Simple way to clear - print many newlines. A more robust solution
 	// might use platform-specific commands, but this is portable.
-	fmt.Print(strings.Repeat("\n", 50))
+	fmt.Fprint(ui.Out, strings.Repeat("\n", 50))
 }
 
 // LogAction prints a message describing a player's action.
 func (ui *ConsoleUI) LogAction(playerID string, action string, amount int) {
+	if avatar := ui.avatars[playerID]; avatar != "" {
+		playerID = avatar + " " + playerID
+	}
 	if amount > 0 {
-		fmt.Printf(">> %s %s (%d)\n", playerID, action, amount)
+		fmt.Fprintf(ui.Out, ">> %s %s (%d)\n", playerID, action, amount)
 	} else {
-		fmt.Printf(">> %s %s\n", playerID, action)
+		fmt.Fprintf(ui.Out, ">> %s %s\n", playerID, action)
+	}
+}
+
+// TryDisplayGameState is DisplayGameState, reporting an error writing to Out.
+// With it and the other Try methods ConsoleUI is a types.FallibleUI, so a
+// game whose output goes away carries on with its fallback.
+func (ui *ConsoleUI) TryDisplayGameState(table *types.Table, players []types.Player, pot int, stage string) error {
+	return ui.try(func() { ui.DisplayGameState(table, players, pot, stage) })
+}
+
+// TryLogAction is LogAction, reporting an error writing to Out.
+func (ui *ConsoleUI) TryLogAction(playerID string, action string, amount int) error {
+	return ui.try(func() { ui.LogAction(playerID, action, amount) })
+}
+
+// TryClearScreen is ClearScreen, reporting an error writing to Out.
+func (ui *ConsoleUI) TryClearScreen() error {
+	return ui.try(ui.ClearScreen)
+}
+
+// try draws with Out wrapped so the first write error can be returned.
+func (ui *ConsoleUI) try(draw func()) error {
+	out := ui.Out
+	w := &errWriter{w: out}
+	ui.Out = w
+	defer func() { ui.Out = out }()
+	draw()
+	return w.err
+}
+
+// errWriter remembers the first error writing to w and skips later writes.
+type errWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (e *errWriter) Write(p []byte) (int, error) {
+	if e.err != nil {
+		return 0, e.err
 	}
+	n, err := e.w.Write(p)
+	e.err = err
+	return n, err
 }
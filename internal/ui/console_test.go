@@ -0,0 +1,205 @@
+package ui
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"pokerclientv1/pkg/gametest"
+	"pokerclientv1/pkg/types"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files in testdata")
+
+// assertGolden compares rendered output with testdata/<name>.golden.
+// Run `go test ./internal/ui -update` to accept intentional display changes.
+func assertGolden(t *testing.T, name string, got []byte) {
+	t.Helper()
+	path := filepath.Join("testdata", name+".golden")
+	if *update {
+		if err := os.MkdirAll("testdata", 0o755); err != nil {
+			t.Fatalf("creating testdata: %v", err)
+		}
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatalf("writing %s: %v", path, err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading %s: %v (run with -update to create it)", path, err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("%s output does not match %s\n--- got ---\n%s\n--- want ---\n%s", name, path, got, want)
+	}
+}
+
+// mustCards parses short card notation for test fixtures.
+func mustCards(t *testing.T, notations ...string) []types.Card {
+	t.Helper()
+	cards := []types.Card{}
+	for _, n := range notations {
+		card, err := types.ParseCard(n)
+		if err != nil {
+			t.Fatalf("parsing card: %v", err)
+		}
+		cards = append(cards, card)
+	}
+	return cards
+}
+
+// canonicalPlayers returns a human and two bots with fixed hands and bets.
+func canonicalPlayers(t *testing.T) []*gametest.ScriptedPlayer {
+	human := gametest.NewScriptedPlayer("Player 1", 480)
+	human.Human = true
+	human.Hand.Cards = mustCards(t, "As", "Kh")
+	human.CurrentBet = 20
+	bot1 := gametest.NewScriptedPlayer("Bot 1", 0)
+	bot1.Hand.Cards = mustCards(t, "9c", "9d")
+	bot1.CurrentBet = 35
+	bot2 := gametest.NewScriptedPlayer("Bot 2", 965)
+	bot2.Hand.Cards = mustCards(t, "7s", "2h")
+	bot2.Folded = true
+	return []*gametest.ScriptedPlayer{human, bot1, bot2}
+}
+
+// TestConsoleUIGolden renders canonical game states and compares them with golden files.
+func TestConsoleUIGolden(t *testing.T) {
+	tests := []struct {
+		name   string
+		render func(ui *ConsoleUI)
+	}{
+		{"preflop_empty_board", func(ui *ConsoleUI) {
+			players := canonicalPlayers(t)
+			table := &types.Table{Round: "Pre-flop", Positions: map[string]string{"Player 1": "BTN", "Bot 1": "SB", "Bot 2": "BB"}}
+			ui.DisplayGameState(table, []types.Player{players[0], players[1], players[2]}, 55, "Pre-flop Betting")
+		}},
+		{"flop_folded_and_all_in", func(ui *ConsoleUI) {
+			players := canonicalPlayers(t)
+			table := &types.Table{Round: "Flop", CommunityCards: mustCards(t, "Td", "Jc", "2s")}
+			ui.DisplayGameState(table, []types.Player{players[0], players[1], players[2]}, 120, "Flop Betting")
+		}},
+		{"side_pots", func(ui *ConsoleUI) {
+			players := canonicalPlayers(t)
+			players[0].CurrentBet, players[1].CurrentBet = 0, 0 // Bot 1 went all-in on an earlier street
+			players[2].Folded = false
+			table := &types.Table{Round: "Turn", CommunityCards: mustCards(t, "Td", "Jc", "2s", "Qh"), Pots: []types.TablePot{
+				{Name: "main pot", Amount: 105, Eligible: []string{"Player 1", "Bot 1", "Bot 2"}},
+				{Name: "side pot 1", Amount: 60, Eligible: []string{"Player 1", "Bot 2"}},
+			}}
+			ui.DisplayGameState(table, []types.Player{players[0], players[1], players[2]}, 165, "Turn Betting")
+		}},
+		{"river_full_board", func(ui *ConsoleUI) {
+			players := canonicalPlayers(t)
+			table := &types.Table{Round: "River", CommunityCards: mustCards(t, "Td", "Jc", "2s", "Qh", "3d")}
+			ui.DisplayGameState(table, []types.Player{players[0], players[1]}, 300, "River Betting")
+		}},
+		{"header", func(ui *ConsoleUI) {
+			players := canonicalPlayers(t)
+			table := &types.Table{Round: "Pre-flop", HandNumber: 12, SmallBlind: 10, BigBlind: 20, Ante: 2, BlindLevel: 3,
+				SessionStart: time.Now().Add(-(time.Hour + 2*time.Minute + 3*time.Second))}
+			ui.DisplayGameState(table, []types.Player{players[0], players[1], players[2]}, 55, "Pre-flop Betting")
+		}},
+		{"avatars", func(ui *ConsoleUI) {
+			players := canonicalPlayers(t)
+			players[0].Avatar = "🦁"
+			players[1].Avatar = "🦊"
+			table := &types.Table{Round: "Pre-flop"}
+			ui.DisplayGameState(table, []types.Player{players[0], players[1], players[2]}, 55, "Pre-flop Betting")
+			ui.LogAction("Bot 1", "raises to 20", 18)
+			ui.LogAction("Bot 2", "folds", 0)
+		}},
+		{"hud", func(ui *ConsoleUI) {
+			players := canonicalPlayers(t)
+			ui.HUD = func(id string) string {
+				if id == "Bot 1" {
+					return "VPIP 25 PFR 20 3B 8 CC 5 SQZ - FCB 50 XR 10 (40 hands)"
+				}
+				return ""
+			}
+			table := &types.Table{Round: "Pre-flop"}
+			ui.DisplayGameState(table, []types.Player{players[0], players[1], players[2]}, 55, "Pre-flop Betting")
+		}},
+		{"shared_screen", func(ui *ConsoleUI) {
+			players := canonicalPlayers(t)
+			players[2].ID, players[2].Human, players[2].Folded = "Player 2", true, false
+			ui.SharedScreen = true
+			table := &types.Table{Round: "Pre-flop"}
+			ui.DisplayGameState(table, []types.Player{players[0], players[1], players[2]}, 55, "Before the device is passed")
+			ui.Viewer = "Player 2"
+			ui.DisplayGameState(table, []types.Player{players[0], players[1], players[2]}, 55, "Player 2 has the device")
+		}},
+		{"action_log", func(ui *ConsoleUI) {
+			ui.LogAction("Bot 1", "posts small blind", 1)
+			ui.LogAction("Player 1", "raises to 20", 18)
+			ui.LogAction("Bot 2", "folds", 0)
+			ui.LogAction("Bot 1", "checks", 0)
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			tt.render(&ConsoleUI{Out: &buf})
+			assertGolden(t, tt.name, buf.Bytes())
+		})
+	}
+}
+
+// TestConsoleUIThemes renders the flop with the human to act in each theme.
+func TestConsoleUIThemes(t *testing.T) {
+	for _, name := range ThemeNames() {
+		t.Run(name, func(t *testing.T) {
+			players := canonicalPlayers(t)
+			table := &types.Table{Round: "Flop", CommunityCards: mustCards(t, "Td", "Jc", "2s"), Acting: "Player 1"}
+			var buf bytes.Buffer
+			theme, _ := LookupTheme(name)
+			ui := &ConsoleUI{Out: &buf, Theme: theme}
+			ui.DisplayGameState(table, []types.Player{players[0], players[1], players[2]}, 120, "Flop Betting")
+			assertGolden(t, "theme_"+name, buf.Bytes())
+		})
+	}
+}
+
+// TestCompactUIGolden renders a hand in progress with the compact UI.
+func TestCompactUIGolden(t *testing.T) {
+	players := canonicalPlayers(t)
+	players[1].Avatar = "🦊"
+	table := &types.Table{Round: "Flop", CommunityCards: mustCards(t, "Td", "Jc", "2s"), Acting: "Player 1"}
+	var buf bytes.Buffer
+	ui := &CompactUI{Out: &buf}
+	ui.DisplayGameState(table, []types.Player{players[0], players[1], players[2]}, 120, "Flop Betting")
+	ui.LogAction("Player 1", "raises to 60", 40)
+	ui.LogAction("Bot 2", "folds", 0)
+	ui.ClearScreen()
+	table.Round, table.CommunityCards = "Pre-flop", nil
+	table.Positions = map[string]string{"Player 1": "BTN/SB", "Bot 1": "BB"}
+	ui.DisplayGameState(table, []types.Player{players[0], players[1]}, 3, "Pre-flop Betting")
+	assertGolden(t, "compact", buf.Bytes())
+}
+
+// failingWriter fails every write, like a closed pipe.
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) { return 0, os.ErrClosed }
+
+// TestConsoleUITryReportsWriteErrors checks that the Try methods report a
+// failing Out and leave Out as it was.
+func TestConsoleUITryReportsWriteErrors(t *testing.T) {
+	ui := &ConsoleUI{Out: failingWriter{}}
+	if err := ui.TryLogAction("Player 1", "checks", 0); err == nil {
+		t.Errorf("TryLogAction() on a failing writer returned no error")
+	}
+	if _, ok := ui.Out.(failingWriter); !ok {
+		t.Errorf("Out = %T after TryLogAction(), want it restored", ui.Out)
+	}
+
+	var buf bytes.Buffer
+	ui.Out = &buf
+	if err := ui.TryLogAction("Player 1", "checks", 0); err != nil || buf.String() != ">> Player 1 checks\n" {
+		t.Errorf("TryLogAction() = %v writing %q, want no error writing the action", err, buf.String())
+	}
+}
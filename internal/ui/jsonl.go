@@ -0,0 +1,91 @@
+package ui
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"pokerclientv1/pkg/game"
+	"pokerclientv1/pkg/types"
+)
+
+// JSONLinesUI implements types.GameUI for other programs rather than people:
+// it writes one JSON object per line for every table state, action and game
+// event, so a game can be piped into analysis tools or drawn by a separate
+// GUI process. Register HandleEvent with Game.OnEvent to include events.
+//
+// Each line has a type ("state", "action" or "event"), the time it was
+// written, and one of the fields below. States are the table as Viewer may
+// see it, with every other player's hole cards left out.
+type JSONLinesUI struct {
+	Out    io.Writer // Where lines are written, os.Stdout by default
+	Viewer string    // Player whose hole cards are shown, none when empty
+
+	mu sync.Mutex // Held while a line is written, so lines never interleave
+}
+
+// JSONLine is one line written by JSONLinesUI.
+type JSONLine struct {
+	Type   string           `json:"type"`
+	Time   time.Time        `json:"time"`
+	State  *game.TableState `json:"state,omitempty"`
+	Action *JSONAction      `json:"action,omitempty"`
+	Event  *game.Event      `json:"event,omitempty"`
+}
+
+// JSONAction is an action logged by the game.
+type JSONAction struct {
+	Player string `json:"player"`
+	Action string `json:"action"`
+	Amount int    `json:"amount,omitempty"`
+}
+
+// NewJSONLinesUI creates a JSON lines UI writing to out.
+func NewJSONLinesUI(out io.Writer) *JSONLinesUI {
+	return &JSONLinesUI{Out: out}
+}
+
+// DisplayGameState writes a "state" line.
+func (ui *JSONLinesUI) DisplayGameState(table *types.Table, players []types.Player, pot int, stage string) {
+	ui.TryDisplayGameState(table, players, pot, stage)
+}
+
+// LogAction writes an "action" line.
+func (ui *JSONLinesUI) LogAction(playerID string, action string, amount int) {
+	ui.TryLogAction(playerID, action, amount)
+}
+
+// ClearScreen does nothing; there is no screen.
+func (ui *JSONLinesUI) ClearScreen() {}
+
+// HandleEvent writes an "event" line. It can be registered with Game.OnEvent.
+func (ui *JSONLinesUI) HandleEvent(e game.Event) {
+	ui.write(JSONLine{Type: "event", Event: &e})
+}
+
+// TryDisplayGameState is DisplayGameState, reporting an error writing to Out.
+func (ui *JSONLinesUI) TryDisplayGameState(table *types.Table, players []types.Player, pot int, stage string) error {
+	return ui.write(JSONLine{Type: "state", State: game.TableStateFor(ui.Viewer, table, players, pot, stage)})
+}
+
+// TryLogAction is LogAction, reporting an error writing to Out.
+func (ui *JSONLinesUI) TryLogAction(playerID string, action string, amount int) error {
+	return ui.write(JSONLine{Type: "action", Action: &JSONAction{Player: playerID, Action: action, Amount: amount}})
+}
+
+// TryClearScreen does nothing and never fails.
+func (ui *JSONLinesUI) TryClearScreen() error { return nil }
+
+// write stamps a line with the time and writes it to Out.
+func (ui *JSONLinesUI) write(line JSONLine) error {
+	ui.mu.Lock()
+	defer ui.mu.Unlock()
+	out := ui.Out
+	if out == nil {
+		out = os.Stdout
+	}
+	line.Time = time.Now()
+	return json.NewEncoder(out).Encode(line)
+}
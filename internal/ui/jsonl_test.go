@@ -0,0 +1,50 @@
+package ui
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"pokerclientv1/pkg/game"
+	"pokerclientv1/pkg/types"
+)
+
+// TestJSONLinesUI checks that states, actions and events are written one
+// JSON object per line, with only the viewer's hole cards.
+func TestJSONLinesUI(t *testing.T) {
+	players := canonicalPlayers(t)
+	table := &types.Table{Round: "Flop", CommunityCards: mustCards(t, "Td", "Jc", "2s"), Acting: "Bot 1"}
+	var buf bytes.Buffer
+	ui := NewJSONLinesUI(&buf)
+	ui.Viewer = "Player 1"
+	ui.DisplayGameState(table, []types.Player{players[0], players[1], players[2]}, 120, "Flop Betting")
+	ui.LogAction("Bot 1", "calls", 20)
+	ui.HandleEvent(game.Event{Type: game.EventPotAwarded, PlayerID: "Bot 1", Amount: 160})
+
+	var lines []JSONLine
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		var line JSONLine
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			t.Fatalf("line %q is not JSON: %v", scanner.Text(), err)
+		}
+		lines = append(lines, line)
+	}
+	if len(lines) != 3 || lines[0].Type != "state" || lines[1].Type != "action" || lines[2].Type != "event" {
+		t.Fatalf("got lines %+v, want a state, an action and an event", lines)
+	}
+	state := lines[0].State
+	if state.Pot != 120 || state.Acting != "Bot 1" || len(state.Players) != 3 {
+		t.Errorf("state = %+v, want the flop with 120 in the pot and Bot 1 to act", state)
+	}
+	if len(state.Players[0].Cards) != 2 || len(state.Players[1].Cards) != 0 {
+		t.Errorf("state shows cards %v and %v, want only the viewer's", state.Players[0].Cards, state.Players[1].Cards)
+	}
+	if a := lines[1].Action; a.Player != "Bot 1" || a.Action != "calls" || a.Amount != 20 {
+		t.Errorf("action = %+v, want Bot 1 calls 20", a)
+	}
+	if e := lines[2].Event; e.Type != game.EventPotAwarded || e.Amount != 160 {
+		t.Errorf("event = %+v, want the pot awarded", e)
+	}
+}
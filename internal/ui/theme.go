@@ -0,0 +1,93 @@
+package ui
+
+import (
+	"sort"
+	"strings"
+
+	"pokerclientv1/pkg/types"
+)
+
+// ANSI escape sequences used by the themes.
+const (
+	ansiReset       = "\033[0m"
+	ansiBold        = "\033[1m"
+	ansiRed         = "\033[31m"
+	ansiGreen       = "\033[32m"
+	ansiYellow      = "\033[33m"
+	ansiBlue        = "\033[34m"
+	ansiBrightRed   = "\033[91m"
+	ansiBrightWhite = "\033[97m"
+	ansiReverse     = "\033[7m"
+)
+
+// Theme is a set of ANSI colors for the console. Empty strings leave the
+// terminal's default color in place.
+type Theme struct {
+	Name   string
+	Suits  map[types.Suit]string // Color of each suit's cards
+	Acting string                // Highlight for the player whose turn it is
+	Reset  string                // Sequence that ends any color
+}
+
+// Themes are the built-in color themes, keyed by name.
+var Themes = map[string]*Theme{
+	"classic": {
+		Name:   "classic",
+		Suits:  map[types.Suit]string{types.Heart: ansiRed, types.Diamond: ansiRed},
+		Acting: ansiBold + ansiYellow,
+		Reset:  ansiReset,
+	},
+	"dark": {
+		Name: "dark",
+		Suits: map[types.Suit]string{
+			types.Heart: ansiBrightRed, types.Diamond: ansiBrightRed,
+			types.Spade: ansiBrightWhite, types.Club: ansiBrightWhite,
+		},
+		Acting: ansiReverse,
+		Reset:  ansiReset,
+	},
+	"four-color": {
+		Name: "four-color",
+		Suits: map[types.Suit]string{
+			types.Heart: ansiRed, types.Diamond: ansiBlue, types.Club: ansiGreen,
+		},
+		Acting: ansiBold + ansiYellow,
+		Reset:  ansiReset,
+	},
+}
+
+// DefaultTheme is the theme NewConsoleUI starts with.
+const DefaultTheme = "classic"
+
+// LookupTheme finds a theme by name, ignoring case.
+func LookupTheme(name string) (*Theme, bool) {
+	theme, ok := Themes[strings.ToLower(name)]
+	return theme, ok
+}
+
+// ThemeNames lists the built-in themes in alphabetical order.
+func ThemeNames() []string {
+	names := make([]string, 0, len(Themes))
+	for name := range Themes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// card renders a card in its suit's color. A nil theme renders plain text.
+func (t *Theme) card(c types.Card) string {
+	if t == nil || t.Suits[c.Suit] == "" {
+		return c.String()
+	}
+	return t.Suits[c.Suit] + c.String() + t.Reset
+}
+
+// acting highlights the line of the player whose turn it is.
+func (t *Theme) acting(s string) string {
+	if t == nil || t.Acting == "" {
+		return s
+	}
+	// Cards end their color with Reset, so the highlight is restored after each
+	return t.Acting + strings.ReplaceAll(s, t.Reset, t.Reset+t.Acting) + t.Reset
+}
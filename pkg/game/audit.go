@@ -0,0 +1,90 @@
+package game
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"time"
+
+	"pokerclientv1/pkg/types"
+)
+
+// DealRecord is one hand in the deal audit log: enough to repeat the shuffle
+// and check every card that was dealt. It is kept apart from the hand
+// history, which players see.
+type DealRecord struct {
+	Time       time.Time    `json:"time"`
+	HandNumber int          `json:"hand"`
+	Seed       int64        `json:"seed"`              // Seed of the shuffle, 0 for a stacked deck
+	Stacked    bool         `json:"stacked,omitempty"` // The deck was preset instead of shuffled
+	Deck       []types.Card `json:"deck"`              // Deck order after the shuffle, top card first
+	Dealt      []types.Card `json:"dealt"`             // Cards dealt, burns included, in order
+	Burned     []types.Card `json:"burned,omitempty"`  // The burn cards among them, in order
+}
+
+// newDealRecord captures a deck right after it was shuffled. Dealt is filled
+// in when the hand is over.
+func newDealRecord(hand int, d *Deck) DealRecord {
+	return DealRecord{Time: time.Now(), HandNumber: hand, Seed: d.seed, Stacked: d.stacked, Deck: d.Remaining()}
+}
+
+// Verify repeats the shuffle from the seed and checks that it gives the
+// recorded deck, and that the dealt cards came off the top of it in order.
+func (r DealRecord) Verify() error {
+	if !r.Stacked {
+		deck := NewDeck()
+		shuffleCards(deck.cards, r.Seed)
+		if len(deck.cards) != len(r.Deck) {
+			return fmt.Errorf("hand %d: recorded deck has %d cards, want %d", r.HandNumber, len(r.Deck), len(deck.cards))
+		}
+		for i, card := range r.Deck {
+			if want := deck.cards[i]; card != want {
+				return fmt.Errorf("hand %d: card %d of the deck is %s, seed %d shuffles %s there", r.HandNumber, i+1, card, r.Seed, want)
+			}
+		}
+	}
+	if len(r.Dealt) > len(r.Deck) {
+		return fmt.Errorf("hand %d: %d cards dealt from a %d card deck", r.HandNumber, len(r.Dealt), len(r.Deck))
+	}
+	for i, card := range r.Dealt {
+		if card != r.Deck[i] {
+			return fmt.Errorf("hand %d: card %d dealt was %s, the deck had %s next", r.HandNumber, i+1, card, r.Deck[i])
+		}
+	}
+	// Burn cards are dealt too, so they must appear among the dealt cards in order
+	dealt := r.Dealt
+	for _, card := range r.Burned {
+		i := slices.Index(dealt, card)
+		if i < 0 {
+			return fmt.Errorf("hand %d: burned %s, which was not dealt after the burn before it", r.HandNumber, card)
+		}
+		dealt = dealt[i+1:]
+	}
+	return nil
+}
+
+// ReadDealLog reads a deal audit log written by Game.DealLog.
+func ReadDealLog(r io.Reader) ([]DealRecord, error) {
+	records := []DealRecord{}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var rec DealRecord
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			return nil, fmt.Errorf("deal log line %d: %w", len(records)+1, err)
+		}
+		records = append(records, rec)
+	}
+	return records, scanner.Err()
+}
+
+// writeDealRecord appends the hand's record to DealLog.
+func (g *Game) writeDealRecord(rec DealRecord) {
+	rec.Dealt = g.Deck.Dealt()
+	rec.Burned = g.Deck.Burned()
+	if err := json.NewEncoder(g.DealLog).Encode(rec); err != nil {
+		fmt.Fprintf(g.Out, "Warning: could not write the deal audit log: %v\n", err)
+	}
+}
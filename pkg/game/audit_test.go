@@ -0,0 +1,59 @@
+package game_test
+
+import (
+	"bytes"
+	"testing"
+
+	"pokerclientv1/pkg/game"
+	"pokerclientv1/pkg/gametest"
+	"pokerclientv1/pkg/types"
+)
+
+// TestDealLogVerifies plays shuffled hands with a deal audit log and checks
+// every record against the shuffle, then that a changed card is caught.
+func TestDealLogVerifies(t *testing.T) {
+	var log bytes.Buffer
+	players := []types.Player{
+		gametest.NewScriptedPlayer("A", 100, gametest.Call(), gametest.Check(), gametest.Check(), gametest.Check()),
+		gametest.NewScriptedPlayer("B", 100, gametest.Check(), gametest.Check(), gametest.Check(), gametest.Check(), gametest.Fold()),
+	}
+	engine := game.NewEngine(game.Config{Players: players, DealLog: &log})
+	engine.PlayHand()
+	engine.PlayHand()
+
+	records, err := game.ReadDealLog(&log)
+	if err != nil {
+		t.Fatalf("ReadDealLog() error: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("got %d deal records, want 2", len(records))
+	}
+	first := records[0]
+	if first.HandNumber != 1 || first.Seed == 0 || len(first.Deck) != 52 {
+		t.Errorf("first record = hand %d seed %d with %d cards", first.HandNumber, first.Seed, len(first.Deck))
+	}
+	// Four hole cards, then three burns and five board cards
+	if len(first.Dealt) != 12 {
+		t.Errorf("first hand dealt %d cards, want 12", len(first.Dealt))
+	}
+	if len(first.Burned) != 3 || first.Burned[0] != first.Dealt[4] || first.Burned[2] != first.Dealt[10] {
+		t.Errorf("first hand burned %v, want the 5th, 9th and 11th cards dealt", first.Burned)
+	}
+	for _, rec := range records {
+		if err := rec.Verify(); err != nil {
+			t.Errorf("Verify() error: %v", err)
+		}
+	}
+
+	burned := first.Burned[0]
+	first.Burned[0] = first.Dealt[11]
+	if err := first.Verify(); err == nil {
+		t.Errorf("Verify() accepted a burn card that was dealt to the board")
+	}
+	first.Burned[0] = burned
+
+	first.Dealt[3], first.Dealt[4] = first.Dealt[4], first.Dealt[3]
+	if err := first.Verify(); err == nil {
+		t.Errorf("Verify() accepted cards dealt out of order")
+	}
+}
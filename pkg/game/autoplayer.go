@@ -0,0 +1,35 @@
+package game
+
+import (
+	"pokerclientv1/pkg/types"
+)
+
+// AutoPlayer is a types.Player for programs embedding the engine. It keeps
+// its own chips, cards and bets, so a bot is written as a single Decide
+// function instead of a whole Player implementation. See types.StrategyPlayer
+// for one whose decisions are given a context and may fail.
+type AutoPlayer struct {
+	types.BasePlayer
+
+	// Chooses each action as Player.TakeTurn does, given the table, the
+	// chips needed to call and the minimum raise. The player checks or
+	// calls when it is nil.
+	Decide func(table *types.Table, toCall, minRaise int) (action string, amount int)
+}
+
+// NewAutoPlayer creates a player who acts as decide says, or checks and
+// calls every bet when decide is nil.
+func NewAutoPlayer(id string, chips int, decide func(table *types.Table, toCall, minRaise int) (string, int)) *AutoPlayer {
+	return &AutoPlayer{BasePlayer: types.NewBasePlayer(id, chips), Decide: decide}
+}
+
+func (p *AutoPlayer) IsHuman() bool { return false }
+
+// TakeTurn asks Decide for the action, checking or calling without it.
+func (p *AutoPlayer) TakeTurn(table *types.Table, currentBet int, minRaise int) (action string, amount int) {
+	toCall := currentBet - p.CurrentBet
+	if p.Decide != nil {
+		return p.Decide(table, toCall, minRaise)
+	}
+	return p.Legal(table, currentBet, minRaise).Fit("call", 0)
+}
@@ -0,0 +1,130 @@
+package game
+
+import (
+	"fmt"
+	"slices"
+
+	"pokerclientv1/pkg/types"
+)
+
+// balance runs between hands when the lobby has MaxSeats. It breaks the
+// smallest table whenever the players left fit at one table fewer, then
+// moves players from the largest tables to the smallest until no two differ
+// by more than one. Tables playing a hand are waited for.
+func (l *Lobby) balance() {
+	l.balanceMu.Lock()
+	defer l.balanceMu.Unlock()
+	l.mu.Lock()
+	tables := append([]*lobbyTable(nil), l.tables...)
+	l.mu.Unlock()
+	locked := slices.Clone(tables)
+	for _, t := range locked {
+		t.mu.Lock()
+	}
+	defer func() {
+		for _, t := range locked {
+			t.mu.Unlock()
+		}
+	}()
+
+	multiTable := len(tables) > 1
+	for len(tables) > 1 {
+		total := 0
+		for _, t := range tables {
+			total += t.inPlay()
+		}
+		if total <= (len(tables)-1)*l.MaxSeats {
+			smallest := l.smallest(tables)
+			tables = slices.DeleteFunc(tables, func(t *lobbyTable) bool { return t == smallest })
+			l.breakTable(smallest, tables)
+			continue
+		}
+		largest, smallest := l.largest(tables), l.smallest(tables)
+		if largest.inPlay()-smallest.inPlay() <= 1 {
+			break
+		}
+		l.move(largest, smallest, largest.nextToMove())
+	}
+
+	if multiTable && len(tables) == 1 && !l.final {
+		l.final = true
+		final := tables[0].game
+		fmt.Fprintf(final.Out, "\n*** Final table: %d players at table %s ***\n", tables[0].inPlay(), final.TableID)
+		final.emit(Event{Type: EventFinalTable, Amount: tables[0].inPlay()})
+	}
+}
+
+// inPlay returns how many players at the table still have chips.
+func (t *lobbyTable) inPlay() int {
+	return len(t.game.getPlayersWithChips())
+}
+
+// nextToMove picks the player to move off a table that is too large: the
+// one due to post the big blind next, so no one skips or pays it twice.
+func (t *lobbyTable) nextToMove() types.Player {
+	g := t.game
+	if bb := g.nextBigBlind(); bb >= 0 {
+		return g.Players[bb]
+	}
+	n := len(g.Players)
+	bb := (g.DealerPos + 2) % n
+	if len(g.getPlayersWithChips()) == 2 {
+		bb = (g.DealerPos + 1) % n
+	}
+	for i := 0; i < n; i++ {
+		if p := g.Players[(bb+i)%n]; p.GetChips() > 0 {
+			return p
+		}
+	}
+	return g.Players[bb]
+}
+
+// smallest returns the table with the fewest players in play, the last
+// added among equals so the first tables are the ones kept.
+func (l *Lobby) smallest(tables []*lobbyTable) *lobbyTable {
+	best := tables[0]
+	for _, t := range tables[1:] {
+		if t.inPlay() <= best.inPlay() {
+			best = t
+		}
+	}
+	return best
+}
+
+// largest returns the table with the most players in play, the first
+// added among equals.
+func (l *Lobby) largest(tables []*lobbyTable) *lobbyTable {
+	best := tables[0]
+	for _, t := range tables[1:] {
+		if t.inPlay() > best.inPlay() {
+			best = t
+		}
+	}
+	return best
+}
+
+// breakTable closes a table, seating each of its players at whichever of
+// the other tables is smallest at the time.
+func (l *Lobby) breakTable(t *lobbyTable, others []*lobbyTable) {
+	for _, p := range t.game.getPlayersWithChips() {
+		l.move(t, l.smallest(others), p)
+	}
+	t.broken = true
+	fmt.Fprintf(t.game.Out, "\n*** Table %s is broken ***\n", t.game.TableID)
+	t.game.emit(Event{Type: EventTableBroken})
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.tables = slices.DeleteFunc(l.tables, func(lt *lobbyTable) bool { return lt == t })
+}
+
+// move seats a player from one table at another and announces it. The
+// caller holds both tables' locks.
+func (l *Lobby) move(from, to *lobbyTable, p types.Player) {
+	if _, ok := from.game.Unseat(p.GetID()); !ok {
+		return
+	}
+	to.game.Seat(p)
+	fmt.Fprintf(from.game.Out, ">> %s moves to table %s\n", p.GetID(), to.game.TableID)
+	from.game.emit(Event{Type: EventPlayerMoved, PlayerID: p.GetID(), Action: to.game.TableID})
+}
@@ -0,0 +1,68 @@
+package game
+
+import (
+	"strconv"
+	"strings"
+)
+
+// BetSize works out the round total of a raise sized relative to the pot or
+// the bet, as players say it at the table:
+//
+//   - "pot" raises the size of the pot after calling, "half" (or "half-pot")
+//     half of it, and a fraction such as "2/3" or "3/4 pot" that share of it
+//   - a multiple such as "3x" or "2.5x" raises to that many times the bet,
+//     or the minimum raise when nothing has been bet yet
+//
+// pot is every chip in the middle, bets of this round included, and legal
+// the player's options, whose MinRaise and MaxRaise the total is kept
+// between. ok is false when size is none of the above or raising is not
+// allowed.
+func BetSize(size string, pot int, legal LegalActions) (total int, ok bool) {
+	size = strings.ToLower(strings.NewReplacer(" ", "", "-", "").Replace(size))
+	if size == "" || !legal.Can("raise") {
+		return 0, false
+	}
+	currentBet := legal.Bet + legal.ToCall
+	potRaise := func(share float64) int {
+		return currentBet + int(share*float64(pot+legal.ToCall)+0.5)
+	}
+	switch share, isShare := potShare(size); {
+	case isShare:
+		total = potRaise(share)
+	case strings.HasSuffix(size, "x"):
+		times, err := strconv.ParseFloat(strings.TrimSuffix(size, "x"), 64)
+		if err != nil || times <= 0 {
+			return 0, false
+		}
+		base := currentBet
+		if base == 0 {
+			base = legal.MinRaise
+		}
+		total = int(times*float64(base) + 0.5)
+	default:
+		return 0, false
+	}
+	return min(max(total, legal.MinRaise), legal.MaxRaise), true
+}
+
+// potShare returns the share of the pot a size such as "pot", "half" or
+// "2/3pot" names.
+func potShare(size string) (float64, bool) {
+	size = strings.TrimSuffix(size, "pot")
+	switch size {
+	case "":
+		return 1, true
+	case "half":
+		return 0.5, true
+	}
+	num, den, found := strings.Cut(size, "/")
+	if !found {
+		return 0, false
+	}
+	n, err1 := strconv.Atoi(num)
+	d, err2 := strconv.Atoi(den)
+	if err1 != nil || err2 != nil || n <= 0 || d <= 0 {
+		return 0, false
+	}
+	return float64(n) / float64(d), true
+}
@@ -0,0 +1,43 @@
+package game
+
+import (
+	"testing"
+
+	"pokerclientv1/pkg/types"
+)
+
+func TestBetSize(t *testing.T) {
+	// Facing a bet of 10 into a pot of 30 (20 before the bet), 200 behind
+	facing := types.NewLegalActions("P1", 10, 0, 200, 10)
+	// First to act on the flop with 40 in the pot, minimum bet 2
+	opening := types.NewLegalActions("P1", 0, 0, 200, 2)
+	tests := []struct {
+		size  string
+		pot   int
+		legal LegalActions
+		want  int
+		ok    bool
+	}{
+		{"pot", 30, facing, 50, true},
+		{"half", 30, facing, 30, true},
+		{"half-pot", 30, facing, 30, true},
+		{"3/4 pot", 30, facing, 40, true},
+		{"3x", 30, facing, 30, true},
+		{"2.5x", 30, facing, 25, true},
+		{"pot", 40, opening, 40, true},
+		{"1/2", 40, opening, 20, true},
+		{"3x", 40, opening, 6, true},
+		{"1/10", 30, facing, 20, true},   // Below the minimum raise
+		{"pot", 1000, facing, 200, true}, // Above the stack
+		{"big", 30, facing, 0, false},
+		{"0x", 30, facing, 0, false},
+		{"", 30, facing, 0, false},
+		{"pot", 30, facing.WithoutRaise(), 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := BetSize(tt.size, tt.pot, tt.legal)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("BetSize(%q, %d) = %d, %v, want %d, %v", tt.size, tt.pot, got, ok, tt.want, tt.ok)
+		}
+	}
+}
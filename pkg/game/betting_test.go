@@ -0,0 +1,131 @@
+package game_test
+
+import (
+	"slices"
+	"testing"
+
+	"pokerclientv1/pkg/game"
+	"pokerclientv1/pkg/gametest"
+	"pokerclientv1/pkg/types"
+)
+
+// TestMinRaiseFollowsLastRaise: BTN opens to 10 (a raise of 8) and SB re-raises
+// to 30 (a raise of 20), so BB must be offered a minimum raise of 20.
+func TestMinRaiseFollowsLastRaise(t *testing.T) {
+	btn := gametest.NewScriptedPlayer("BTN", 100, gametest.RaiseTo(10), gametest.Fold())
+	sb := gametest.NewScriptedPlayer("SB", 100, gametest.RaiseTo(30))
+	bb := gametest.NewScriptedPlayer("BB", 100, gametest.Fold())
+
+	h := gametest.New(t, nil, btn, sb, bb)
+	h.PlayHand()
+
+	h.AssertScriptsFinished()
+	if len(bb.MinRaises) != 1 || bb.MinRaises[0] != 20 {
+		t.Errorf("BB was offered min raises %v, want [20]", bb.MinRaises)
+	}
+	if len(btn.MinRaises) != 2 || btn.MinRaises[0] != 2 || btn.MinRaises[1] != 20 {
+		t.Errorf("BTN was offered min raises %v, want [2 20]", btn.MinRaises)
+	}
+	h.AssertStacks(gametest.Stacks{"BTN": 90, "SB": 112, "BB": 98})
+}
+
+// TestUndersizedRaiseIsMinRaise: BTN raises to 3 when the minimum is 4, so
+// the raise is made to 4 instead of folding BTN, and BB calls 2 more.
+func TestUndersizedRaiseIsMinRaise(t *testing.T) {
+	btn := gametest.NewScriptedPlayer("BTN", 100, gametest.RaiseTo(3), gametest.Check(), gametest.Check(), gametest.Check())
+	sb := gametest.NewScriptedPlayer("SB", 100, gametest.Fold())
+	bb := gametest.NewScriptedPlayer("BB", 100, gametest.Call(), gametest.Check(), gametest.Check(), gametest.Check())
+	deck := gametest.Deck(t,
+		[][]string{{"As", "Ah"}, {"8h", "3s"}, {"7c", "2d"}},
+		[]string{"Kd", "Qc", "9s", "5h", "4d"})
+
+	h := gametest.New(t, deck, btn, sb, bb)
+	h.PlayHand()
+
+	h.AssertScriptsFinished()
+	// BTN's aces win the 9 chip pot
+	h.AssertStacks(gametest.Stacks{"BTN": 105, "SB": 99, "BB": 96})
+}
+
+// TestBrokeHumanLeavesHotSeat: H1 busts with another human still playing, so
+// H1 leaves like a bot would and the next hand ends when B folds to H2.
+func TestBrokeHumanLeavesHotSeat(t *testing.T) {
+	h1 := gametest.NewScriptedPlayer("H1", 10, gametest.AllIn())
+	h2 := gametest.NewScriptedPlayer("H2", 100, gametest.Call())
+	b := gametest.NewScriptedPlayer("B", 100, gametest.Fold(), gametest.Fold())
+	h1.Human, h2.Human = true, true
+	deck := gametest.Deck(t,
+		[][]string{{"7c", "2d"}, {"As", "Ah"}, {"8h", "3s"}},
+		[]string{"Kd", "Qc", "9s", "5h", "4d"})
+
+	h := gametest.New(t, deck, h1, h2, b)
+	h.PlayHand()
+	h.AssertStacks(gametest.Stacks{"H1": 0, "H2": 112, "B": 98})
+
+	h.PlayHand()
+	h.AssertScriptsFinished()
+	for _, p := range h.Engine.Players() {
+		if p.GetID() == "H1" {
+			t.Errorf("H1 is still seated with %d chips after busting", p.GetChips())
+		}
+	}
+	for _, e := range h.Events {
+		if e.Type == game.EventShowdown || e.Type == game.EventStreetDealt {
+			t.Fatalf("hand 2 reached %s, want it to end when B folds", e.Type)
+		}
+	}
+	// B folds the small blind to H2's big blind
+	h.AssertStacks(gametest.Stacks{"H2": 113, "B": 97})
+}
+
+// TestShortAllInDoesNotReopenAction: BTN opens to 10 and SB calls. BB moves
+// all-in for 14, which is less than a full raise, so BTN may only call.
+func TestShortAllInDoesNotReopenAction(t *testing.T) {
+	btn := gametest.NewScriptedPlayer("BTN", 100, gametest.RaiseTo(10), gametest.RaiseTo(40), gametest.Fold())
+	sb := gametest.NewScriptedPlayer("SB", 100, gametest.Call(), gametest.Call(), gametest.Check())
+	bb := gametest.NewScriptedPlayer("BB", 14, gametest.AllIn())
+	deck := gametest.Deck(t,
+		[][]string{{"7c", "2d"}, {"8h", "3s"}, {"As", "Ah"}},
+		[]string{"Kd", "Qc", "9s", "5h", "4d"})
+
+	h := gametest.New(t, deck, btn, sb, bb)
+	h.PlayHand()
+
+	h.AssertScriptsFinished()
+	// BTN's re-raise is turned into a call of 14; BB's aces win the 42 chip pot
+	h.AssertStacks(gametest.Stacks{"BTN": 86, "SB": 86, "BB": 42})
+}
+
+// TestAllInShownInSidePots: Short moves all-in for 20 pre-flop and the other
+// two keep betting on the flop, so the table shows a main pot Short can win
+// and a side pot only BTN and BB can.
+func TestAllInShownInSidePots(t *testing.T) {
+	btn := gametest.NewScriptedPlayer("BTN", 200, gametest.Call(), gametest.Call(), gametest.RaiseTo(40), gametest.Check(), gametest.Check())
+	short := gametest.NewScriptedPlayer("Short", 20, gametest.AllIn())
+	bb := gametest.NewScriptedPlayer("BB", 200, gametest.Call(), gametest.Check(), gametest.Call(), gametest.Check(), gametest.Check())
+	deck := gametest.Deck(t,
+		[][]string{{"7c", "2d"}, {"As", "Ah"}, {"8h", "3s"}},
+		[]string{"Kd", "Qc", "9s", "5h", "4d"})
+
+	h := gametest.New(t, deck, btn, short, bb)
+	h.PlayHand()
+	h.AssertScriptsFinished()
+
+	var shown []types.TablePot
+	for i, stage := range h.UI.Stages {
+		if stage == "Flop Betting" && len(h.UI.Pots[i]) > 0 {
+			shown = h.UI.Pots[i]
+		}
+	}
+	if len(shown) != 2 {
+		t.Fatalf("pots shown on the flop = %+v, want a main pot and a side pot", shown)
+	}
+	if main := shown[0]; main.Name != "main pot" || main.Amount != 60 || !slices.Equal(main.Eligible, []string{"BTN", "Short", "BB"}) {
+		t.Errorf("main pot = %+v, want 60 chips for BTN, Short and BB", main)
+	}
+	if side := shown[1]; side.Name != "side pot 1" || side.Amount != 80 || !slices.Equal(side.Eligible, []string{"BTN", "BB"}) {
+		t.Errorf("side pot = %+v, want 80 chips for BTN and BB", side)
+	}
+	// Short's aces win the main pot; BTN and BB split the side pot with the board
+	h.AssertStacks(gametest.Stacks{"Short": 60})
+}
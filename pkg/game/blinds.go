@@ -0,0 +1,81 @@
+package game
+
+import "time"
+
+// BlindLevel is one step of a blind structure. A level lasts for a number of
+// hands, or for a duration of play when Hands is zero.
+type BlindLevel struct {
+	SmallBlind int
+	BigBlind   int
+	Ante       int           // Paid by every player before each hand, 0 for none
+	Hands      int           // Hands played at this level
+	Duration   time.Duration // Time spent at this level when Hands is 0
+}
+
+// BlindSchedule raises the blinds as a game goes on. The last level is kept
+// once all levels have been played.
+type BlindSchedule struct {
+	Levels       []BlindLevel
+	level        int
+	handsAtLevel int
+	levelStarted time.Time
+	now          func() time.Time // Clock, replaceable in tests
+}
+
+// NewBlindSchedule creates a schedule that starts at the first level.
+func NewBlindSchedule(levels []BlindLevel) *BlindSchedule {
+	return &BlindSchedule{Levels: levels, now: time.Now}
+}
+
+// DoublingSchedule builds a schedule starting at the given blinds that doubles
+// them every handsPerLevel hands, with antes of a tenth of the big blind from
+// the fourth level on.
+func DoublingSchedule(smallBlind, bigBlind, handsPerLevel, numLevels int) *BlindSchedule {
+	levels := make([]BlindLevel, numLevels)
+	for i := range levels {
+		level := BlindLevel{SmallBlind: smallBlind, BigBlind: bigBlind, Hands: handsPerLevel}
+		if i >= 3 {
+			level.Ante = bigBlind / 10
+		}
+		levels[i] = level
+		smallBlind *= 2
+		bigBlind *= 2
+	}
+	return NewBlindSchedule(levels)
+}
+
+// NextHand is called before every hand. It returns the level the hand is
+// played at and whether the blinds went up since the previous hand.
+func (s *BlindSchedule) NextHand() (BlindLevel, bool) {
+	if s.levelStarted.IsZero() {
+		s.levelStarted = s.now()
+	}
+	raised := false
+	if s.level < len(s.Levels)-1 && s.levelFinished() {
+		s.level++
+		s.handsAtLevel = 0
+		s.levelStarted = s.now()
+		raised = true
+	}
+	s.handsAtLevel++
+	return s.Current(), raised
+}
+
+// Current returns the blind level currently in play.
+func (s *BlindSchedule) Current() BlindLevel {
+	return s.Levels[s.level]
+}
+
+// LevelNumber returns the current level, starting at 1.
+func (s *BlindSchedule) LevelNumber() int {
+	return s.level + 1
+}
+
+// levelFinished reports whether the current level has run its course.
+func (s *BlindSchedule) levelFinished() bool {
+	current := s.Current()
+	if current.Hands > 0 {
+		return s.handsAtLevel >= current.Hands
+	}
+	return current.Duration > 0 && s.now().Sub(s.levelStarted) >= current.Duration
+}
@@ -0,0 +1,48 @@
+package game
+
+import (
+	"strings"
+	"unicode"
+)
+
+// MaxChatLength is the most characters a chat message keeps; the rest is cut.
+const MaxChatLength = 200
+
+// Chat has playerID say text at the table: it is sent to the event handlers
+// as an EventChat, which UIs show and networked tables relay to every
+// client. Text is cleaned first, see CleanChat, and nothing is said when
+// none is left. Like the rest of the game, Chat is called on the game
+// goroutine, as from TakeTurn; an event handler may call it too, and the
+// chat follows the event being handled.
+func (g *Game) Chat(playerID, text string) {
+	text = CleanChat(text)
+	if text == "" {
+		return
+	}
+	e := Event{Type: EventChat, PlayerID: playerID, Action: text}
+	if g.emitting {
+		g.said = append(g.said, e)
+		return
+	}
+	g.emit(e)
+}
+
+// CleanChat makes text fit to show on anyone's screen: control characters,
+// such as terminal escape codes, are dropped, runs of spaces are joined and
+// the message is cut at MaxChatLength characters.
+func CleanChat(text string) string {
+	text = strings.Map(func(r rune) rune {
+		switch {
+		case unicode.IsSpace(r):
+			return ' '
+		case !unicode.IsPrint(r):
+			return -1
+		}
+		return r
+	}, text)
+	text = strings.Join(strings.Fields(text), " ")
+	if runes := []rune(text); len(runes) > MaxChatLength {
+		text = strings.TrimSpace(string(runes[:MaxChatLength]))
+	}
+	return text
+}
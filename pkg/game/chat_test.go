@@ -0,0 +1,59 @@
+package game_test
+
+import (
+	"strings"
+	"testing"
+
+	"pokerclientv1/pkg/game"
+	"pokerclientv1/pkg/gametest"
+)
+
+func TestCleanChat(t *testing.T) {
+	tests := []struct {
+		text, want string
+	}{
+		{"gl hf", "gl hf"},
+		{"  nice\thand \n ", "nice hand"},
+		{"\x1b[2Jgotcha", "[2Jgotcha"},
+		{"\x07\x1b", ""},
+		{strings.Repeat("x", game.MaxChatLength+10), strings.Repeat("x", game.MaxChatLength)},
+	}
+	for _, tt := range tests {
+		if got := game.CleanChat(tt.text); got != tt.want {
+			t.Errorf("CleanChat(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+// TestChatFromHandler checks that chat said while an event is handled is
+// sent once every handler has seen that event.
+func TestChatFromHandler(t *testing.T) {
+	btn := gametest.NewScriptedPlayer("BTN", 100, gametest.Fold())
+	bb := gametest.NewScriptedPlayer("BB", 100)
+	h := gametest.New(t, nil, btn, bb)
+	g := h.Engine.Game()
+	g.OnEvent(func(e game.Event) {
+		if e.Type == game.EventPotAwarded {
+			g.Chat(e.PlayerID, "  ty ")
+			g.Chat(e.PlayerID, "\x07")
+		}
+	})
+	h.PlayHand()
+
+	for i, e := range h.Events {
+		if e.Type != game.EventPotAwarded {
+			continue
+		}
+		if i+1 == len(h.Events) || h.Events[i+1].Type != game.EventChat {
+			t.Fatalf("no chat right after the pot was awarded, events: %v", h.Events)
+		}
+		if chat := h.Events[i+1]; chat.PlayerID != "BB" || chat.Action != "ty" {
+			t.Errorf("chat = %s %q, want BB %q", chat.PlayerID, chat.Action, "ty")
+		}
+		if i+2 < len(h.Events) && h.Events[i+2].Type == game.EventChat {
+			t.Errorf("empty chat was sent: %+v", h.Events[i+2])
+		}
+		return
+	}
+	t.Fatal("no pot was awarded")
+}
@@ -0,0 +1,134 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+)
+
+// GameConfig holds the stakes and betting rules of a game.
+type GameConfig struct {
+	SmallBlind int
+	BigBlind   int  // Also the minimum raise
+	Ante       int  // Paid by every player each hand, 0 for none
+	MaxRaise   int  // Most a single raise may add on top of the current bet, 0 for no cap
+	PotLimit   bool // Cap raises at the size of the pot after calling
+
+	Variant string // Registered variant to deal, see RegisterVariant; empty for Texas Hold'em
+	Reveal  string // Registered reveal policy at showdown, see RegisterRevealPolicy; empty shows every hand
+
+	// Buy-in limits in big blinds, so they follow the stakes; 0 for no limit.
+	// They apply to joins as well as to re-buys and add-ons.
+	MinBuyInBB int
+	MaxBuyInBB int
+
+	Rebuy RebuyPolicy // Cash game re-buys and add-ons between hands, none by default
+}
+
+// RebuyPolicy lets players buy more chips between hands of a cash game
+// instead of leaving the table when they go broke.
+type RebuyPolicy struct {
+	UpTo  int  // Stack a re-buy or add-on brings a player up to, 0 disables them
+	Limit int  // Re-buys allowed per player, 0 for no limit; add-ons are not counted
+	AddOn bool // Players with chips left may top up to UpTo between hands
+	Bots  bool // Players who cannot be asked, such as bots, re-buy UpTo whenever they go broke
+}
+
+// DefaultGameConfig returns 1/2 no-limit with no ante and buy-ins of 20 to
+// 500 big blinds.
+func DefaultGameConfig() GameConfig {
+	return GameConfig{
+		SmallBlind: DefaultSmallBlind,
+		BigBlind:   DefaultBigBlind,
+		MinBuyInBB: 20,
+		MaxBuyInBB: 500,
+	}
+}
+
+// Validate checks that the configuration describes a playable game.
+func (c GameConfig) Validate() error {
+	if c.SmallBlind <= 0 || c.BigBlind <= 0 {
+		return fmt.Errorf("blinds must be positive, got %d/%d", c.SmallBlind, c.BigBlind)
+	}
+	if c.SmallBlind > c.BigBlind {
+		return fmt.Errorf("small blind %d is larger than big blind %d", c.SmallBlind, c.BigBlind)
+	}
+	if c.Ante < 0 || c.MinBuyInBB < 0 || c.MaxBuyInBB < 0 || c.MaxRaise < 0 {
+		return fmt.Errorf("ante, buy-in limits and maximum raise cannot be negative")
+	}
+	if c.MaxBuyInBB > 0 && c.MaxBuyInBB < c.MinBuyInBB {
+		return fmt.Errorf("maximum buy-in of %d big blinds is below the minimum of %d", c.MaxBuyInBB, c.MinBuyInBB)
+	}
+	if c.MaxRaise > 0 && c.MaxRaise < c.BigBlind {
+		return fmt.Errorf("maximum raise %d is below the big blind %d", c.MaxRaise, c.BigBlind)
+	}
+	if c.Rebuy.UpTo < 0 || c.Rebuy.Limit < 0 {
+		return fmt.Errorf("re-buy amount and limit cannot be negative")
+	}
+	if c.Rebuy.UpTo > 0 {
+		if err := c.CheckBuyIn(c.Rebuy.UpTo); err != nil {
+			return fmt.Errorf("re-buys: %w", err)
+		}
+	}
+	if _, ok := LookupVariant(c.Variant); !ok {
+		return fmt.Errorf("unknown variant %q, registered: %s", c.Variant, strings.Join(VariantNames(), ", "))
+	}
+	if _, ok := LookupRevealPolicy(c.Reveal); !ok {
+		return fmt.Errorf("unknown reveal policy %q, registered: %s", c.Reveal, strings.Join(RevealPolicyNames(), ", "))
+	}
+	return nil
+}
+
+// BuyInRange returns the smallest and largest stack a player may buy in
+// for at the config's big blind, with 0 for no maximum.
+func (c GameConfig) BuyInRange() (lo, hi int) {
+	return c.MinBuyInBB * c.BigBlind, c.MaxBuyInBB * c.BigBlind
+}
+
+// CheckBuyIn reports whether a starting stack is within the table's buy-in range.
+func (c GameConfig) CheckBuyIn(chips int) error {
+	lo, hi := c.BuyInRange()
+	if chips < lo {
+		return fmt.Errorf("buy-in of %d is below the table minimum of %d", chips, lo)
+	}
+	if hi > 0 && chips > hi {
+		return fmt.Errorf("buy-in of %d is above the table maximum of %d", chips, hi)
+	}
+	return nil
+}
+
+// String describes the stakes, e.g. "5/10 pot-limit, ante 1" or
+// "5/10 pot-limit omaha".
+func (c GameConfig) String() string {
+	s := fmt.Sprintf("%d/%d", c.SmallBlind, c.BigBlind)
+	if v, ok := LookupVariant(c.Variant); c.PotLimit || ok && v.PotLimit() {
+		s += " pot-limit"
+	} else {
+		s += " no-limit"
+	}
+	if c.Variant != "" && c.Variant != DefaultVariant {
+		s += " " + c.Variant
+	}
+	if c.Ante > 0 {
+		s += fmt.Sprintf(", ante %d", c.Ante)
+	}
+	if c.MaxRaise > 0 {
+		s += fmt.Sprintf(", raises capped at %d", c.MaxRaise)
+	}
+	if c.Rebuy.UpTo > 0 {
+		s += fmt.Sprintf(", re-buys to %d", c.Rebuy.UpTo)
+	}
+	return s
+}
+
+// BuyInString describes the buy-in range, e.g. "buy-in 40-1000 (20-500 bb)",
+// or "" when there are no limits.
+func (c GameConfig) BuyInString() string {
+	lo, hi := c.BuyInRange()
+	switch {
+	case hi > 0:
+		return fmt.Sprintf("buy-in %d-%d (%d-%d bb)", lo, hi, c.MinBuyInBB, c.MaxBuyInBB)
+	case lo > 0:
+		return fmt.Sprintf("buy-in at least %d (%d bb)", lo, c.MinBuyInBB)
+	}
+	return ""
+}
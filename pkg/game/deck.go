@@ -0,0 +1,203 @@
+package game
+
+import (
+	"errors"
+	"math/rand"
+	"slices"
+	"time"
+
+	"pokerclientv1/pkg/types"
+)
+
+// Deck represents a deck of playing cards. Cards are kept top card first and
+// dealt by moving a cursor down the deck, so the cards dealt so far stay in
+// place ahead of it.
+type Deck struct {
+	cards   []types.Card
+	next    int          // Index of the top card still in the deck
+	burned  []types.Card // Cards burned so far, in order
+	stacked bool         // Stacked decks keep their order and ignore Shuffle
+	seed    int64        // Seed of the last shuffle
+	rng     *rand.Rand   // Source of shuffle seeds, the clock when nil
+}
+
+// NewDeck creates and returns a new deck of 52 cards
+func NewDeck() *Deck {
+	deck := &Deck{
+		cards: make([]types.Card, 0, 52),
+	}
+
+	// Create all combinations of suits and ranks
+	for suit := types.Spade; suit <= types.Club; suit++ {
+		for rank := types.Two; rank <= types.Ace; rank++ {
+			deck.cards = append(deck.cards, types.Card{
+				Suit: suit,
+				Rank: rank,
+			})
+		}
+	}
+
+	return deck
+}
+
+// NewDeckWithRand creates a deck whose shuffle seeds are drawn from rng, so
+// a game dealt from one seeded source can be replayed exactly. A nil rng
+// behaves like NewDeck.
+func NewDeckWithRand(rng *rand.Rand) *Deck {
+	deck := NewDeck()
+	deck.rng = rng
+	return deck
+}
+
+// NewStackedDeck creates a deck that deals the given cards in order and is
+// never shuffled. It is meant for tests and scripted scenarios.
+func NewStackedDeck(cards []types.Card) *Deck {
+	return &Deck{
+		cards:   append([]types.Card(nil), cards...),
+		stacked: true,
+	}
+}
+
+// NewPresetDeck creates a stacked deck that deals the given hole cards, listed
+// per seat in seat order, and up to five board cards (flop, turn, river).
+// Seats without cards, burns and missing board cards get the remaining cards,
+// shuffled with rng or in a fixed order when rng is nil. Cards must not repeat.
+func NewPresetDeck(holes [][]types.Card, board []types.Card, rng *rand.Rand) *Deck {
+	used := make(map[types.Card]bool)
+	for _, hole := range holes {
+		for _, card := range hole {
+			used[card] = true
+		}
+	}
+	for _, card := range board {
+		used[card] = true
+	}
+
+	filler := []types.Card{}
+	for suit := types.Spade; suit <= types.Club; suit++ {
+		for rank := types.Two; rank <= types.Ace; rank++ {
+			if card := (types.Card{Suit: suit, Rank: rank}); !used[card] {
+				filler = append(filler, card)
+			}
+		}
+	}
+	if rng != nil {
+		rng.Shuffle(len(filler), func(i, j int) { filler[i], filler[j] = filler[j], filler[i] })
+	}
+	nextFiller := func() types.Card {
+		card := filler[0]
+		filler = filler[1:]
+		return card
+	}
+	boardCard := func(i int) types.Card {
+		if i < len(board) {
+			return board[i]
+		}
+		return nextFiller()
+	}
+
+	// Deal order: one card to each seat twice, then burn+flop, burn+turn, burn+river
+	order := []types.Card{}
+	for round := 0; round < 2; round++ {
+		for _, hole := range holes {
+			if round < len(hole) {
+				order = append(order, hole[round])
+			} else {
+				order = append(order, nextFiller())
+			}
+		}
+	}
+	order = append(order, nextFiller(), boardCard(0), boardCard(1), boardCard(2))
+	order = append(order, nextFiller(), boardCard(3))
+	order = append(order, nextFiller(), boardCard(4))
+	order = append(order, filler...)
+	return NewStackedDeck(order)
+}
+
+// Shuffle randomizes the order of the cards left in the deck
+func (d *Deck) Shuffle() {
+	if d.stacked {
+		return
+	}
+	if d.rng != nil {
+		d.seed = d.rng.Int63()
+	} else {
+		d.seed = time.Now().UnixNano()
+	}
+	shuffleCards(d.cards[d.next:], d.seed)
+}
+
+// shuffleCards is the shuffle behind Deck.Shuffle, kept separate so a deal
+// audit can repeat it from the seed. The cards end up top card first.
+func shuffleCards(cards []types.Card, seed int64) {
+	r := rand.New(rand.NewSource(seed))
+	r.Shuffle(len(cards), func(i, j int) {
+		cards[i], cards[j] = cards[j], cards[i]
+	})
+	// Decks used to deal from the end of the slice; reversing keeps the
+	// order every seed deals in, so older deal logs still verify
+	slices.Reverse(cards)
+}
+
+// Seed returns the seed of the last shuffle, or 0 if the deck was never shuffled.
+func (d *Deck) Seed() int64 {
+	return d.seed
+}
+
+// Dealt returns the cards dealt so far in the order they left the deck,
+// burn cards included.
+func (d *Deck) Dealt() []types.Card {
+	return slices.Clone(d.cards[:d.next])
+}
+
+// Burned returns the cards burned so far, in order.
+func (d *Deck) Burned() []types.Card {
+	return slices.Clone(d.burned)
+}
+
+// Remaining returns the cards still in the deck, top card first.
+func (d *Deck) Remaining() []types.Card {
+	return slices.Clone(d.cards[d.next:])
+}
+
+// Deal removes and returns the top card from the deck
+func (d *Deck) Deal() (types.Card, error) {
+	if d.next == len(d.cards) {
+		return types.Card{}, errors.New("no cards left in deck")
+	}
+
+	card := d.cards[d.next]
+	d.next++
+	return card, nil
+}
+
+// Burn deals the top card face down, out of play, and records it.
+func (d *Deck) Burn() (types.Card, error) {
+	card, err := d.Deal()
+	if err != nil {
+		return types.Card{}, err
+	}
+	d.burned = append(d.burned, card)
+	return card, nil
+}
+
+// DealMultiple deals multiple cards from the deck
+func (d *Deck) DealMultiple(numCards int) ([]types.Card, error) {
+	if d.CardsLeft() < numCards {
+		return nil, errors.New("not enough cards left in deck")
+	}
+
+	cards := slices.Clone(d.cards[d.next : d.next+numCards])
+	d.next += numCards
+	return cards, nil
+}
+
+// CardsLeft returns the number of cards remaining in the deck
+func (d *Deck) CardsLeft() int {
+	return len(d.cards) - d.next
+}
+
+// Reset resets the deck to a full 52-card deck, keeping its source of seeds
+func (d *Deck) Reset() {
+	*d = *NewDeckWithRand(d.rng)
+}
@@ -1,7 +1,9 @@
 package game
 
 import (
-	"pokerclientv1/internal/types"
+	"math/rand"
+	"pokerclientv1/pkg/types"
+	"slices"
 	"testing"
 )
 
@@ -62,23 +64,44 @@ func TestShuffle(t *testing.T) {
 	}
 }
 
+// TestNewDeckWithRand checks that decks drawing seeds from equally seeded
+// sources shuffle identically and record the seed for the deal audit.
+func TestNewDeckWithRand(t *testing.T) {
+	deck1 := NewDeckWithRand(rand.New(rand.NewSource(42)))
+	deck2 := NewDeckWithRand(rand.New(rand.NewSource(42)))
+	for hand := 0; hand < 3; hand++ {
+		deck1.Shuffle()
+		deck2.Shuffle()
+		if deck1.Seed() != deck2.Seed() {
+			t.Fatalf("hand %d: seeds %d and %d differ", hand, deck1.Seed(), deck2.Seed())
+		}
+		for i := range deck1.cards {
+			if deck1.cards[i] != deck2.cards[i] {
+				t.Fatalf("hand %d: card %d is %s in one deck and %s in the other", hand, i, deck1.cards[i], deck2.cards[i])
+			}
+		}
+		deck1.Reset()
+		deck2.Reset()
+	}
+}
+
 // TestDeal checks dealing a single card.
 func TestDeal(t *testing.T) {
 	deck := NewDeck()
-	initialSize := len(deck.cards)
+	initialSize := deck.CardsLeft()
 
 	card, err := deck.Deal()
 	if err != nil {
 		t.Fatalf("Deal() returned an unexpected error: %v", err)
 	}
 
-	if len(deck.cards) != initialSize-1 {
-		t.Errorf("Deal() did not reduce deck size correctly. Got %d, want %d", len(deck.cards), initialSize-1)
+	if deck.CardsLeft() != initialSize-1 {
+		t.Errorf("Deal() did not reduce deck size correctly. Got %d, want %d", deck.CardsLeft(), initialSize-1)
 	}
 
 	// Check if the dealt card is no longer in the deck
 	found := false
-	for _, remainingCard := range deck.cards {
+	for _, remainingCard := range deck.Remaining() {
 		if remainingCard == card {
 			found = true
 			break
@@ -89,7 +112,7 @@ func TestDeal(t *testing.T) {
 	}
 
 	// Test dealing from an empty deck
-	deck.cards = []types.Card{} // Empty the deck
+	deck.next = len(deck.cards) // Empty the deck
 	_, err = deck.Deal()
 	if err == nil {
 		t.Errorf("Deal() from empty deck did not return an error")
@@ -99,7 +122,7 @@ func TestDeal(t *testing.T) {
 // TestDealMultiple checks dealing multiple cards.
 func TestDealMultiple(t *testing.T) {
 	deck := NewDeck()
-	initialSize := len(deck.cards)
+	initialSize := deck.CardsLeft()
 	numToDeal := 5
 
 	cards, err := deck.DealMultiple(numToDeal)
@@ -111,8 +134,12 @@ func TestDealMultiple(t *testing.T) {
 		t.Errorf("DealMultiple(%d) dealt %d cards, want %d", numToDeal, len(cards), numToDeal)
 	}
 
-	if len(deck.cards) != initialSize-numToDeal {
-		t.Errorf("DealMultiple(%d) did not reduce deck size correctly. Got %d, want %d", numToDeal, len(deck.cards), initialSize-numToDeal)
+	if deck.CardsLeft() != initialSize-numToDeal {
+		t.Errorf("DealMultiple(%d) did not reduce deck size correctly. Got %d, want %d", numToDeal, deck.CardsLeft(), initialSize-numToDeal)
+	}
+
+	if dealt := deck.Dealt(); !slices.Equal(dealt, cards) {
+		t.Errorf("Dealt() = %v after DealMultiple, want %v", dealt, cards)
 	}
 
 	// Test dealing more cards than available
@@ -123,3 +150,32 @@ func TestDealMultiple(t *testing.T) {
 		t.Errorf("DealMultiple(%d) with insufficient cards did not return an error", numToDeal)
 	}
 }
+
+// TestBurn checks that burn cards are dealt in turn, kept apart from the
+// cards in play and not dealt again.
+func TestBurn(t *testing.T) {
+	stack, err := types.ParseCards("As Ks Qs Js Ts 9s")
+	if err != nil {
+		t.Fatalf("ParseCards() error: %v", err)
+	}
+	deck := NewStackedDeck(stack)
+	hole, _ := deck.DealMultiple(2)
+	burn, err := deck.Burn()
+	if err != nil {
+		t.Fatalf("Burn() error: %v", err)
+	}
+	flop, _ := deck.DealMultiple(2)
+
+	if burn.String() != "Q♠" {
+		t.Errorf("Burn() = %s, want the card after the hole cards", burn)
+	}
+	if got := deck.Burned(); len(got) != 1 || got[0] != burn {
+		t.Errorf("Burned() = %v, want [%s]", got, burn)
+	}
+	if got := deck.Dealt(); !slices.Equal(got, append(append(hole, burn), flop...)) {
+		t.Errorf("Dealt() = %v, want the hole cards, the burn and the flop in order", got)
+	}
+	if got := deck.Remaining(); len(got) != 1 || got[0].String() != "9♠" || deck.CardsLeft() != 1 {
+		t.Errorf("Remaining() = %v with CardsLeft() %d, want [9♠] and 1", got, deck.CardsLeft())
+	}
+}
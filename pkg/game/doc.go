@@ -0,0 +1,46 @@
+// Package game contains the Texas Hold'em engine: dealing, betting rounds,
+// side pots and hand evaluation.
+//
+// Programs that want to run a game without the console client should use
+// Engine, which needs only a list of players implementing types.Player.
+// AutoPlayer implements it for bots written as a single decision function,
+// and types.StrategyPlayer for a types.Decider, which the engine asks with
+// the hand's context and the turn clock's deadline:
+//
+//	engine := game.NewEngine(game.Config{
+//		Players: players,
+//		OnEvent: func(e game.Event) {
+//			if e.Type == game.EventPotAwarded {
+//				fmt.Printf("%s wins %d\n", e.PlayerID, e.Amount)
+//			}
+//		},
+//	})
+//	for engine.PlayHand() {
+//	}
+//
+// Several games can run side by side in one process. A Lobby plays its
+// tables concurrently and moves players between them between hands:
+//
+//	lobby := game.NewLobby()
+//	lobby.AddTable("1", game.NewEngine(game.Config{Players: first}).Game())
+//	lobby.AddTable("2", game.NewEngine(game.Config{Players: second}).Game())
+//	lobby.Run(ctx)
+//
+// With Lobby.MaxSeats set, as for a multi-table tournament, the lobby keeps
+// the tables balanced as players bust and breaks them down to a final table,
+// announcing each move with a player_moved event.
+//
+// Texas Hold'em is dealt unless GameConfig.Variant names another variant.
+// Pot-limit Omaha is built in, and other packages can add their own with
+// RegisterVariant, typically from an init function:
+//
+//	func init() {
+//		game.RegisterVariant("pineapple", pineapple{})
+//	}
+//
+// Which hands are shown at showdown is decided the same way, by the
+// RevealPolicy named in GameConfig.Reveal; RegisterRevealPolicy adds more.
+//
+// A GameUI can be supplied to render table state, otherwise nothing is
+// displayed and the game is observed through events only.
+package game
@@ -0,0 +1,109 @@
+package game
+
+import (
+	"io"
+	"math/rand"
+	"time"
+
+	"pokerclientv1/pkg/types"
+)
+
+// Config describes a game to be driven through an Engine.
+type Config struct {
+	Players   []types.Player // Seated players in table order, at least two
+	Rules     GameConfig     // Stakes and betting rules, DefaultGameConfig when left empty
+	UI        types.GameUI   // Optional, defaults to a UI that displays nothing
+	Output    io.Writer      // Optional destination for the engine's text log, discarded when nil
+	GameSpeed time.Duration  // Delay between steps, zero runs without pauses
+	OnEvent   EventHandler   // Optional callback for every game event
+	Deck      func() *Deck   // Optional deck source for each hand, e.g. NewStackedDeck in tests
+	DealLog   io.Writer      // Optional deal audit log, see DealRecord
+	Rng       *rand.Rand     // Optional source of shuffle seeds, set for reproducible games
+	TableID   string         // Optional name of the table, reported with every event
+	UITimeout time.Duration  // Longest a UI call may take before the game drops the UI for a text log on Output
+
+	CheckInvariants InvariantMode // Optional debug check of the chips after every action
+}
+
+// Engine runs a poker game on behalf of another program. It wraps Game with
+// defaults suited to embedding: no console output and no pauses unless asked for.
+type Engine struct {
+	game *Game
+}
+
+// NewEngine creates an engine for the configured players.
+func NewEngine(cfg Config) *Engine {
+	ui := cfg.UI
+	if ui == nil {
+		ui = nopUI{}
+	}
+	rules := cfg.Rules
+	if rules == (GameConfig{}) {
+		rules = DefaultGameConfig()
+	}
+	g := NewGame(cfg.Players, ui, cfg.GameSpeed, rules)
+	g.Out = cfg.Output
+	if g.Out == nil {
+		g.Out = io.Discard
+	}
+	g.DeckFactory = cfg.Deck
+	g.DealLog = cfg.DealLog
+	g.Rng = cfg.Rng
+	g.TableID = cfg.TableID
+	g.UITimeout = cfg.UITimeout
+	g.CheckInvariants = cfg.CheckInvariants
+	if cfg.OnEvent != nil {
+		g.OnEvent(cfg.OnEvent)
+	}
+	return &Engine{game: g}
+}
+
+// PlayHand plays one complete hand. Returns false if the game is over and no
+// further hands can be played.
+func (e *Engine) PlayHand() bool {
+	return e.game.PlayNextHand()
+}
+
+// Run plays hands until the game is over.
+func (e *Engine) Run() {
+	for e.PlayHand() {
+	}
+	e.game.emit(Event{Type: EventGameOver})
+}
+
+// OnEvent registers an additional event callback.
+func (e *Engine) OnEvent(handler EventHandler) {
+	e.game.OnEvent(handler)
+}
+
+// Players returns the players still seated at the table.
+func (e *Engine) Players() []types.Player {
+	return e.game.Players
+}
+
+// HandNumber returns the number of hands started so far.
+func (e *Engine) HandNumber() int {
+	return e.game.HandNumber
+}
+
+// IsOver reports whether the game has ended.
+func (e *Engine) IsOver() bool {
+	return e.game.IsOver()
+}
+
+// LegalActions returns the actions open to a player, see Game.LegalActions.
+func (e *Engine) LegalActions(playerID string) (LegalActions, error) {
+	return e.game.LegalActions(playerID)
+}
+
+// Game gives access to the underlying game state.
+func (e *Engine) Game() *Game {
+	return e.game
+}
+
+// nopUI is the default GameUI for engines created without one.
+type nopUI struct{}
+
+func (nopUI) DisplayGameState(table *types.Table, players []types.Player, pot int, stage string) {}
+func (nopUI) LogAction(playerID string, action string, amount int)                               {}
+func (nopUI) ClearScreen()                                                                       {}
@@ -0,0 +1,156 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"pokerclientv1/pkg/types"
+	"testing"
+	"time"
+)
+
+// TestEnginePlayHand checks that an engine plays a hand silently and reports events.
+func TestEnginePlayHand(t *testing.T) {
+	mockP1 := NewMockPlayer("P1", 100, false)
+	mockP2 := NewMockPlayer("P2", 100, false)
+	// P1 is the small blind heads-up and acts first; with no queued actions it folds
+
+	events := []EventType{}
+	engine := NewEngine(Config{
+		Players: []types.Player{mockP1, mockP2},
+		OnEvent: func(e Event) { events = append(events, e.Type) },
+	})
+
+	if !engine.PlayHand() {
+		t.Fatalf("PlayHand() = false, want true for the first hand")
+	}
+	if engine.HandNumber() != 1 {
+		t.Errorf("HandNumber() = %d, want 1", engine.HandNumber())
+	}
+	if mockP1.GetChips()+mockP2.GetChips() != 200 {
+		t.Errorf("PlayHand() chips not conserved, got %d total, want 200", mockP1.GetChips()+mockP2.GetChips())
+	}
+	if mockP2.GetChips() != 100+DefaultSmallBlind {
+		t.Errorf("PlayHand() P2 chips = %d, want %d after P1 folds the small blind", mockP2.GetChips(), 100+DefaultSmallBlind)
+	}
+
+	want := []EventType{EventHandStarted, EventPlayerSeated, EventPlayerSeated, EventBlindPosted, EventBlindPosted, EventHoleCardsDealt, EventHoleCardsDealt, EventActionOn, EventPlayerAction, EventBetReturned, EventPotAwarded, EventHandEnded}
+	if len(events) != len(want) {
+		t.Fatalf("PlayHand() emitted %v, want %v", events, want)
+	}
+	for i := range want {
+		if events[i] != want[i] {
+			t.Errorf("PlayHand() event %d = %s, want %s", i, events[i], want[i])
+		}
+	}
+}
+
+// TestEngineSeededDeals checks that engines with equally seeded sources deal
+// the same cards hand after hand.
+func TestEngineSeededDeals(t *testing.T) {
+	deal := func() []string {
+		holes := []string{}
+		engine := NewEngine(Config{
+			Players: []types.Player{NewMockPlayer("P1", 100, false), NewMockPlayer("P2", 100, false)},
+			Rng:     rand.New(rand.NewSource(7)),
+			OnEvent: func(e Event) {
+				if e.Type == EventHoleCardsDealt {
+					holes = append(holes, fmt.Sprint(e.Cards))
+				}
+			},
+		})
+		for i := 0; i < 3; i++ {
+			engine.PlayHand()
+		}
+		return holes
+	}
+	first, second := deal(), deal()
+	if len(first) == 0 || fmt.Sprint(first) != fmt.Sprint(second) {
+		t.Errorf("seeded engines dealt %v and %v, want the same cards", first, second)
+	}
+}
+
+// TestEngineEventsAreTimed checks that every event is timestamped and the
+// end of a hand reports its length.
+func TestEngineEventsAreTimed(t *testing.T) {
+	start := time.Now()
+	var ended Event
+	engine := NewEngine(Config{
+		Players: []types.Player{NewMockPlayer("P1", 100, false), NewMockPlayer("P2", 100, false)},
+		OnEvent: func(e Event) {
+			if e.Time.Before(start) || e.Time.After(time.Now()) {
+				t.Errorf("%s event time %v is outside the hand", e.Type, e.Time)
+			}
+			if e.Type == EventHandEnded {
+				ended = e
+			}
+		},
+	})
+	engine.PlayHand()
+	if ended.Elapsed <= 0 || ended.Elapsed > time.Since(start) {
+		t.Errorf("hand_ended Elapsed = %v, want the length of the hand", ended.Elapsed)
+	}
+}
+
+// TestEngineConservesChips plays hands between players who bet at random,
+// often more than they have, and checks that every hand accounts for all
+// of its chips.
+func TestEngineConservesChips(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	wild := func(table *types.Table, toCall, minRaise int) (string, int) {
+		switch rng.Intn(4) {
+		case 0:
+			return "fold", 0
+		case 1:
+			return "call", toCall + rng.Intn(3)*50 // Sometimes more than the stack
+		case 2:
+			return "raise", toCall + minRaise*(1+rng.Intn(40))
+		}
+		return "check", 0
+	}
+	players := []types.Player{}
+	for i, chips := range []int{40, 100, 250, 15} {
+		players = append(players, NewAutoPlayer(fmt.Sprintf("P%d", i+1), chips, wild))
+	}
+	engine := NewEngine(Config{Players: players, Rng: rand.New(rand.NewSource(3))})
+	for hand := 0; hand < 200 && engine.PlayHand(); hand++ {
+		if err := engine.Game().ChipError(); err != nil {
+			t.Fatalf("ChipError() = %v", err)
+		}
+		total := 0
+		for _, p := range engine.Players() {
+			total += p.GetChips()
+		}
+		if total != 405 {
+			t.Fatalf("hand %d: %d chips at the table, want 405", engine.HandNumber(), total)
+		}
+	}
+}
+
+// TestEngineAsksDeciders checks that the engine asks a player with a
+// strategy to Decide, within the turn clock's deadline, and that one whose
+// strategy fails folds to a bet.
+func TestEngineAsksDeciders(t *testing.T) {
+	var views []types.TurnView
+	var deadline time.Time
+	decider := types.NewStrategyPlayer("P1", 100, types.DecideFunc(func(ctx context.Context, view types.TurnView) (types.Action, error) {
+		views = append(views, view)
+		deadline, _ = ctx.Deadline()
+		return types.Action{}, errors.New("no idea")
+	}))
+	caller := NewMockPlayer("P2", 100, false)
+	engine := NewEngine(Config{Players: []types.Player{decider, caller}})
+	engine.Game().TurnClock = time.Minute
+
+	engine.PlayHand()
+	if len(views) != 1 || views[0].ToCall != DefaultSmallBlind || views[0].Chips != 100-DefaultSmallBlind {
+		t.Fatalf("strategy saw %+v, want one turn owing the rest of the big blind", views)
+	}
+	if deadline.IsZero() {
+		t.Error("strategy was given no deadline, want the turn clock's")
+	}
+	if !decider.IsFolded() || caller.GetChips() != 100+DefaultSmallBlind {
+		t.Errorf("P1 folded %v and P2 has %d chips, want P1 to fold the small blind", decider.IsFolded(), caller.GetChips())
+	}
+}
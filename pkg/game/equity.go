@@ -0,0 +1,226 @@
+package game
+
+import (
+	"math/rand"
+	"sort"
+	"sync"
+
+	"pokerclientv1/pkg/types"
+)
+
+// Equity estimates the share of the pot that hole cards win against a number
+// of opponents holding random hands, given the community cards dealt so far.
+// It runs iterations Monte Carlo deals of the unknown cards; ties count as a
+// fraction of a win. The result is between 0 and 1.
+func Equity(hole, board []types.Card, opponents, iterations int, rng *rand.Rand) float64 {
+	if opponents < 1 || iterations < 1 {
+		return 1
+	}
+
+	stub := unknownCards(hole, board)
+	missing := 5 - len(board)
+	needed := missing + 2*opponents
+	if needed > len(stub) {
+		return 0
+	}
+
+	ours := make([]types.Card, 0, 7)
+	theirs := make([]types.Card, 0, 7)
+	total := 0.0
+	for i := 0; i < iterations; i++ {
+		// Partial Fisher-Yates: the first needed cards of stub become the deal
+		for j := 0; j < needed; j++ {
+			k := j + rng.Intn(len(stub)-j)
+			stub[j], stub[k] = stub[k], stub[j]
+		}
+		runout := stub[:missing]
+
+		ours = append(append(append(ours[:0], hole...), board...), runout...)
+		best := HandStrength(ours)
+		tied := 1
+		lost := false
+		for o := 0; o < opponents && !lost; o++ {
+			opp := stub[missing+2*o : missing+2*o+2]
+			theirs = append(append(append(theirs[:0], opp...), board...), runout...)
+			switch opponent := HandStrength(theirs); {
+			case opponent > best:
+				lost = true
+			case opponent == best:
+				tied++
+			}
+		}
+		if !lost {
+			total += 1 / float64(tied)
+		}
+	}
+	return total / float64(iterations)
+}
+
+// PotOdds returns the share of the final pot a call would be: the equity a
+// hand needs for the call to break even. It is 0 when there is nothing to call.
+func PotOdds(pot, toCall int) float64 {
+	if toCall <= 0 {
+		return 0
+	}
+	return float64(toCall) / float64(pot+toCall)
+}
+
+// FoldEquityNeeded returns how often a bet of bet chips into pot must make the
+// opponent fold to break even if it never wins when called.
+func FoldEquityNeeded(pot, bet int) float64 {
+	if bet <= 0 {
+		return 0
+	}
+	return float64(bet) / float64(pot+bet)
+}
+
+// CallingRange models how wide an opponent continues against a bet: the
+// minimum defense frequency pot/(pot+bet), so bigger bets are called by
+// stronger hands only.
+func CallingRange(pot, bet int) float64 {
+	if bet <= 0 {
+		return 1
+	}
+	return float64(pot) / float64(pot+bet)
+}
+
+// EquityWhenCalled estimates the heads-up equity of hole cards against one
+// opponent who only calls with the best share of starting hands, ranked by
+// ChenScore. Deals that give the opponent a hand outside the range are
+// redrawn, so the estimate is a little optimistic for very narrow ranges.
+func EquityWhenCalled(hole, board []types.Card, share float64, iterations int, rng *rand.Rand) float64 {
+	if iterations < 1 {
+		return 1
+	}
+	stub := unknownCards(hole, board)
+	missing := 5 - len(board)
+	needed := missing + 2
+	if needed > len(stub) {
+		return 0
+	}
+	threshold := rangeThreshold(share)
+
+	ours := make([]types.Card, 0, 7)
+	theirs := make([]types.Card, 0, 7)
+	total := 0.0
+	for i := 0; i < iterations; i++ {
+		for try := 0; try < 50; try++ {
+			for j := 0; j < needed; j++ {
+				k := j + rng.Intn(len(stub)-j)
+				stub[j], stub[k] = stub[k], stub[j]
+			}
+			if ChenScore(stub[missing], stub[missing+1]) >= threshold {
+				break
+			}
+		}
+		runout := stub[:missing]
+		ours = append(append(append(ours[:0], hole...), board...), runout...)
+		theirs = append(append(append(theirs[:0], stub[missing:needed]...), board...), runout...)
+		switch hero, villain := HandStrength(ours), HandStrength(theirs); {
+		case hero > villain:
+			total++
+		case hero == villain:
+			total += 0.5
+		}
+	}
+	return total / float64(iterations)
+}
+
+// ChenScore rates a starting hand with Bill Chen's formula: higher is
+// stronger, from -1.5 for seven-deuce offsuit up to 20 for aces.
+func ChenScore(a, b types.Card) float64 {
+	hi, lo := a.Rank, b.Rank
+	if lo > hi {
+		hi, lo = lo, hi
+	}
+	score := chenCardPoints(hi)
+	if hi == lo {
+		score *= 2
+		if score < 5 {
+			score = 5
+		}
+		return score
+	}
+	if a.Suit == b.Suit {
+		score += 2
+	}
+	switch gap := int(hi-lo) - 1; {
+	case gap == 1:
+		score--
+	case gap == 2:
+		score -= 2
+	case gap == 3:
+		score -= 4
+	case gap >= 4:
+		score -= 5
+	}
+	if int(hi-lo) <= 2 && hi < types.Queen {
+		score++ // Connectors and one-gappers below queens can make straights both ways
+	}
+	return score
+}
+
+// chenCardPoints values the highest card of a hand for ChenScore.
+func chenCardPoints(r types.Rank) float64 {
+	switch r {
+	case types.Ace:
+		return 10
+	case types.King:
+		return 8
+	case types.Queen:
+		return 7
+	case types.Jack:
+		return 6
+	}
+	return float64(r) / 2
+}
+
+// chenScores holds the score of every two-card combination, best first.
+var (
+	chenScores     []float64
+	chenScoresOnce sync.Once
+)
+
+// rangeThreshold returns the lowest ChenScore among the best share of
+// starting hands.
+func rangeThreshold(share float64) float64 {
+	chenScoresOnce.Do(func() {
+		deck := unknownCards(nil, nil)
+		for i := range deck {
+			for j := i + 1; j < len(deck); j++ {
+				chenScores = append(chenScores, ChenScore(deck[i], deck[j]))
+			}
+		}
+		sort.Sort(sort.Reverse(sort.Float64Slice(chenScores)))
+	})
+	i := int(share*float64(len(chenScores))+0.5) - 1
+	if i < 0 {
+		i = 0
+	}
+	if i >= len(chenScores) {
+		i = len(chenScores) - 1
+	}
+	return chenScores[i]
+}
+
+// unknownCards returns the cards of a full deck that are not among the
+// hole cards or on the board.
+func unknownCards(hole, board []types.Card) []types.Card {
+	known := make(map[types.Card]bool, len(hole)+len(board))
+	for _, c := range hole {
+		known[c] = true
+	}
+	for _, c := range board {
+		known[c] = true
+	}
+	stub := make([]types.Card, 0, 52)
+	for suit := types.Spade; suit <= types.Club; suit++ {
+		for rank := types.Two; rank <= types.Ace; rank++ {
+			c := types.Card{Suit: suit, Rank: rank}
+			if !known[c] {
+				stub = append(stub, c)
+			}
+		}
+	}
+	return stub
+}
@@ -0,0 +1,121 @@
+package game
+
+import (
+	"math/rand"
+	"testing"
+
+	"pokerclientv1/pkg/types"
+)
+
+func parseCards(t *testing.T, codes ...string) []types.Card {
+	t.Helper()
+	out := make([]types.Card, len(codes))
+	for i, code := range codes {
+		c, err := types.ParseCard(code)
+		if err != nil {
+			t.Fatalf("ParseCard(%q) error: %v", code, err)
+		}
+		out[i] = c
+	}
+	return out
+}
+
+// TestEquityKnownMatchups checks estimates against well-known equities.
+func TestEquityKnownMatchups(t *testing.T) {
+	tests := []struct {
+		name      string
+		hole      []string
+		board     []string
+		opponents int
+		min, max  float64
+	}{
+		{"aces heads-up pre-flop", []string{"As", "Ah"}, nil, 1, 0.82, 0.88},
+		{"seven-deuce heads-up pre-flop", []string{"7c", "2d"}, nil, 1, 0.30, 0.38},
+		{"aces against four players", []string{"As", "Ah"}, nil, 4, 0.50, 0.62},
+		{"nut flush on the river", []string{"Ah", "Kh"}, []string{"2h", "7h", "9h", "Jc", "3s"}, 2, 0.999, 1},
+		{"board plays on the river", []string{"2c", "3d"}, []string{"As", "Ks", "Qs", "Js", "Ts"}, 1, 0.5, 0.5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rng := rand.New(rand.NewSource(1))
+			got := Equity(parseCards(t, tt.hole...), parseCards(t, tt.board...), tt.opponents, 2000, rng)
+			if got < tt.min || got > tt.max {
+				t.Errorf("Equity() = %.3f, want between %.3f and %.3f", got, tt.min, tt.max)
+			}
+		})
+	}
+}
+
+// TestPotOdds checks the break-even equity of a call.
+func TestPotOdds(t *testing.T) {
+	if got := PotOdds(100, 50); got < 0.333 || got > 0.334 {
+		t.Errorf("PotOdds(100, 50) = %.3f, want 0.333", got)
+	}
+	if got := PotOdds(100, 0); got != 0 {
+		t.Errorf("PotOdds(100, 0) = %.3f, want 0", got)
+	}
+}
+
+// TestEquityWhenCalled checks that a narrow calling range hurts a strong hand
+// more than calls from any two cards do.
+func TestEquityWhenCalled(t *testing.T) {
+	hole := parseCards(t, "Ks", "Jd")
+	wide := EquityWhenCalled(hole, nil, 1, 2000, rand.New(rand.NewSource(1)))
+	narrow := EquityWhenCalled(hole, nil, 0.05, 2000, rand.New(rand.NewSource(1)))
+	if wide < 0.55 || wide > 0.66 {
+		t.Errorf("KJo against any two cards = %.3f, want about 0.60", wide)
+	}
+	if narrow > 0.35 {
+		t.Errorf("KJo against the top 5%% = %.3f, want under 0.35", narrow)
+	}
+}
+
+// TestChenScore checks the formula at both ends and for suited connectors.
+func TestChenScore(t *testing.T) {
+	tests := []struct {
+		hand []string
+		want float64
+	}{
+		{[]string{"As", "Ad"}, 20},
+		{[]string{"2c", "2d"}, 5},
+		{[]string{"Ah", "Kh"}, 12},
+		{[]string{"Ts", "9s"}, 8},
+		{[]string{"7c", "2d"}, -1.5},
+	}
+	for _, tt := range tests {
+		cards := parseCards(t, tt.hand...)
+		if got := ChenScore(cards[0], cards[1]); got != tt.want {
+			t.Errorf("ChenScore(%v) = %v, want %v", tt.hand, got, tt.want)
+		}
+	}
+}
+
+// TestFoldEquityNeeded checks the break-even fold rate of a bluff.
+func TestFoldEquityNeeded(t *testing.T) {
+	if got := FoldEquityNeeded(100, 100); got != 0.5 {
+		t.Errorf("FoldEquityNeeded(100, 100) = %.3f, want 0.5", got)
+	}
+	if got := CallingRange(100, 50); got < 0.666 || got > 0.667 {
+		t.Errorf("CallingRange(100, 50) = %.3f, want 0.667", got)
+	}
+}
+
+// BenchmarkEquityPreflop measures one pre-flop estimate as a medium bot runs it.
+func BenchmarkEquityPreflop(b *testing.B) {
+	hole := []types.Card{{Suit: types.Spade, Rank: types.Ace}, {Suit: types.Heart, Rank: types.King}}
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < b.N; i++ {
+		Equity(hole, nil, 2, 500, rng)
+	}
+}
+
+// BenchmarkEquityFlop measures a hard bot's rollout against three opponents on
+// the flop.
+func BenchmarkEquityFlop(b *testing.B) {
+	rng := rand.New(rand.NewSource(1))
+	hole := []types.Card{{Rank: types.Ace, Suit: types.Spade}, {Rank: types.King, Suit: types.Spade}}
+	board := []types.Card{{Rank: types.Two, Suit: types.Spade}, {Rank: types.Nine, Suit: types.Heart}, {Rank: types.King, Suit: types.Club}}
+	for i := 0; i < b.N; i++ {
+		Equity(hole, board, 3, 1000, rng)
+	}
+}
@@ -0,0 +1,227 @@
+package game
+
+import (
+	"sort"
+
+	"pokerclientv1/pkg/types"
+)
+
+// HandRank is the category of a five-card poker hand, from weakest to strongest.
+type HandRank int
+
+const (
+	HighCard HandRank = iota
+	OnePair
+	TwoPair
+	ThreeOfAKind
+	Straight
+	Flush
+	FullHouse
+	FourOfAKind
+	StraightFlush
+)
+
+func (r HandRank) String() string {
+	return [...]string{
+		"High Card", "One Pair", "Two Pair", "Three of a Kind", "Straight",
+		"Flush", "Full House", "Four of a Kind", "Straight Flush",
+	}[r]
+}
+
+// HandValue is the result of evaluating a hand. Two values can be compared
+// with CompareHands to find the stronger hand.
+type HandValue struct {
+	Rank     HandRank
+	Kickers  []types.Rank // Tie-break ranks in order of importance
+	BestFive []types.Card // The five cards that make the hand
+}
+
+// EvaluateHand returns the best five-card hand that can be made from the given
+// cards (hole cards plus community cards, 5 to 7 cards in total).
+// HandStrength is much faster when only the order of hands matters.
+func EvaluateHand(cards []types.Card) HandValue {
+	if len(cards) <= 5 {
+		return evaluateFive(cards)
+	}
+
+	var best HandValue
+	found := false
+	combo := make([]types.Card, 5)
+	// Try every 5-card combination and keep the strongest
+	var choose func(start, depth int)
+	choose = func(start, depth int) {
+		if depth == 5 {
+			value := evaluateFive(combo)
+			if !found || CompareHands(value, best) > 0 {
+				best = value
+				found = true
+			}
+			return
+		}
+		for i := start; i <= len(cards)-(5-depth); i++ {
+			combo[depth] = cards[i]
+			choose(i+1, depth+1)
+		}
+	}
+	choose(0, 0)
+	return best
+}
+
+// CompareHands returns 1 if a beats b, -1 if b beats a and 0 for a tie.
+func CompareHands(a, b HandValue) int {
+	if a.Rank != b.Rank {
+		if a.Rank > b.Rank {
+			return 1
+		}
+		return -1
+	}
+	for i := 0; i < len(a.Kickers) && i < len(b.Kickers); i++ {
+		if a.Kickers[i] != b.Kickers[i] {
+			if a.Kickers[i] > b.Kickers[i] {
+				return 1
+			}
+			return -1
+		}
+	}
+	return 0
+}
+
+// evaluateFive scores a hand of at most five cards.
+func evaluateFive(cards []types.Card) HandValue {
+	hand := make([]types.Card, len(cards))
+	copy(hand, cards)
+	sort.Slice(hand, func(i, j int) bool { return hand[i].Rank > hand[j].Rank })
+
+	// Group ranks by count, highest count first, then highest rank
+	counts := make(map[types.Rank]int)
+	for _, c := range hand {
+		counts[c.Rank]++
+	}
+	groups := make([]types.Rank, 0, len(counts))
+	for rank := range counts {
+		groups = append(groups, rank)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if counts[groups[i]] != counts[groups[j]] {
+			return counts[groups[i]] > counts[groups[j]]
+		}
+		return groups[i] > groups[j]
+	})
+
+	isFlush := len(hand) == 5
+	for _, c := range hand {
+		if c.Suit != hand[0].Suit {
+			isFlush = false
+			break
+		}
+	}
+
+	straightHigh, isStraight := straightHighCard(groups, len(hand))
+
+	value := HandValue{Kickers: groups, BestFive: hand}
+	switch {
+	case isStraight && isFlush:
+		value.Rank = StraightFlush
+		value.Kickers = []types.Rank{straightHigh}
+	case counts[groups[0]] == 4:
+		value.Rank = FourOfAKind
+	case counts[groups[0]] == 3 && len(groups) > 1 && counts[groups[1]] == 2:
+		value.Rank = FullHouse
+	case isFlush:
+		value.Rank = Flush
+	case isStraight:
+		value.Rank = Straight
+		value.Kickers = []types.Rank{straightHigh}
+	case counts[groups[0]] == 3:
+		value.Rank = ThreeOfAKind
+	case counts[groups[0]] == 2 && len(groups) > 1 && counts[groups[1]] == 2:
+		value.Rank = TwoPair
+	case counts[groups[0]] == 2:
+		value.Rank = OnePair
+	default:
+		value.Rank = HighCard
+	}
+	return value
+}
+
+// straightHighCard checks five distinct ranks (sorted high to low) for a straight.
+// The wheel (A-2-3-4-5) counts as a five-high straight.
+func straightHighCard(ranks []types.Rank, numCards int) (types.Rank, bool) {
+	if numCards != 5 || len(ranks) != 5 {
+		return 0, false
+	}
+	sorted := make([]types.Rank, len(ranks))
+	copy(sorted, ranks)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] > sorted[j] })
+
+	if sorted[0]-sorted[4] == 4 {
+		return sorted[0], true
+	}
+	if sorted[0] == types.Ace && sorted[1] == types.Five && sorted[4] == types.Two {
+		return types.Five, true
+	}
+	return 0, false
+}
+
+// rankNames are the spoken names of the ranks, indexed by rank.
+var rankNames = [...]string{
+	types.Two: "Two", types.Three: "Three", types.Four: "Four", types.Five: "Five",
+	types.Six: "Six", types.Seven: "Seven", types.Eight: "Eight", types.Nine: "Nine",
+	types.Ten: "Ten", types.Jack: "Jack", types.Queen: "Queen", types.King: "King", types.Ace: "Ace",
+}
+
+// rankName names a rank, e.g. "King", or in the plural "Kings" or "Sixes".
+func rankName(r types.Rank, plural bool) string {
+	if r < types.Two || r > types.Ace {
+		return r.String()
+	}
+	name := rankNames[r]
+	switch {
+	case !plural:
+		return name
+	case r == types.Six:
+		return name + "es"
+	}
+	return name + "s"
+}
+
+// Describe names the hand the way a dealer reads it out, e.g. "Two Pair,
+// Kings and Nines, Queen kicker" or "Full House, Sevens full of Twos".
+func (v HandValue) Describe() string {
+	k := func(i int) types.Rank { // Tie-break rank i, or 0 when there is none
+		if i < len(v.Kickers) {
+			return v.Kickers[i]
+		}
+		return 0
+	}
+	kicker := func(i int) string {
+		if k(i) == 0 {
+			return ""
+		}
+		return ", " + rankName(k(i), false) + " kicker"
+	}
+	switch v.Rank {
+	case HighCard:
+		return "High Card, " + rankName(k(0), false) + kicker(1)
+	case OnePair:
+		return "One Pair, " + rankName(k(0), true) + kicker(1)
+	case TwoPair:
+		return "Two Pair, " + rankName(k(0), true) + " and " + rankName(k(1), true) + kicker(2)
+	case ThreeOfAKind:
+		return "Three of a Kind, " + rankName(k(0), true) + kicker(1)
+	case Straight:
+		return "Straight, " + rankName(k(0), false) + " high"
+	case Flush:
+		return "Flush, " + rankName(k(0), false) + " high"
+	case FullHouse:
+		return "Full House, " + rankName(k(0), true) + " full of " + rankName(k(1), true)
+	case FourOfAKind:
+		return "Four of a Kind, " + rankName(k(0), true) + kicker(1)
+	case StraightFlush:
+		if k(0) == types.Ace {
+			return "Royal Flush"
+		}
+		return "Straight Flush, " + rankName(k(0), false) + " high"
+	}
+	return v.Rank.String()
+}
@@ -0,0 +1,109 @@
+package game
+
+import (
+	"pokerclientv1/pkg/types"
+	"strings"
+	"testing"
+)
+
+// cards builds a card slice from rank/suit pairs for test readability.
+func cards(pairs ...interface{}) []types.Card {
+	result := []types.Card{}
+	for i := 0; i+1 < len(pairs); i += 2 {
+		result = append(result, types.Card{Rank: pairs[i].(types.Rank), Suit: pairs[i+1].(types.Suit)})
+	}
+	return result
+}
+
+// TestEvaluateHandRanks checks that each hand category is recognised from seven cards.
+func TestEvaluateHandRanks(t *testing.T) {
+	tests := []struct {
+		name  string
+		cards []types.Card
+		want  HandRank
+	}{
+		{"high card", cards(types.Ace, types.Spade, types.Ten, types.Heart, types.Eight, types.Club, types.Six, types.Diamond, types.Four, types.Spade, types.Three, types.Heart, types.Two, types.Club), HighCard},
+		{"one pair", cards(types.Ace, types.Spade, types.Ace, types.Heart, types.Eight, types.Club, types.Six, types.Diamond, types.Four, types.Spade, types.Three, types.Heart, types.Two, types.Club), OnePair},
+		{"two pair", cards(types.Ace, types.Spade, types.Ace, types.Heart, types.Eight, types.Club, types.Eight, types.Diamond, types.Four, types.Spade, types.Three, types.Heart, types.Ten, types.Club), TwoPair},
+		{"three of a kind", cards(types.Nine, types.Spade, types.Nine, types.Heart, types.Nine, types.Club, types.Six, types.Diamond, types.Four, types.Spade, types.Three, types.Heart, types.Queen, types.Club), ThreeOfAKind},
+		{"straight", cards(types.Five, types.Spade, types.Six, types.Heart, types.Seven, types.Club, types.Eight, types.Diamond, types.Nine, types.Spade, types.King, types.Heart, types.Two, types.Club), Straight},
+		{"wheel straight", cards(types.Ace, types.Spade, types.Two, types.Heart, types.Three, types.Club, types.Four, types.Diamond, types.Five, types.Spade, types.King, types.Heart, types.King, types.Club), Straight},
+		{"flush", cards(types.Ace, types.Heart, types.Ten, types.Heart, types.Eight, types.Heart, types.Six, types.Heart, types.Four, types.Heart, types.Three, types.Spade, types.Two, types.Club), Flush},
+		{"full house", cards(types.King, types.Spade, types.King, types.Heart, types.King, types.Club, types.Six, types.Diamond, types.Six, types.Spade, types.Three, types.Heart, types.Two, types.Club), FullHouse},
+		{"four of a kind", cards(types.Jack, types.Spade, types.Jack, types.Heart, types.Jack, types.Club, types.Jack, types.Diamond, types.Four, types.Spade, types.Three, types.Heart, types.Two, types.Club), FourOfAKind},
+		{"straight flush", cards(types.Nine, types.Club, types.Ten, types.Club, types.Jack, types.Club, types.Queen, types.Club, types.King, types.Club, types.Three, types.Heart, types.Two, types.Spade), StraightFlush},
+	}
+	for _, tt := range tests {
+		got := EvaluateHand(tt.cards)
+		if got.Rank != tt.want {
+			t.Errorf("EvaluateHand(%s) = %s, want %s", tt.name, got.Rank, tt.want)
+		}
+		if len(got.BestFive) != 5 {
+			t.Errorf("EvaluateHand(%s) best hand has %d cards, want 5", tt.name, len(got.BestFive))
+		}
+	}
+}
+
+// TestCompareHands checks kicker and straight tie-breaks.
+func TestCompareHands(t *testing.T) {
+	board := cards(types.King, types.Spade, types.Nine, types.Heart, types.Seven, types.Club, types.Four, types.Diamond, types.Two, types.Spade)
+
+	aceKicker := EvaluateHand(append(cards(types.King, types.Heart, types.Ace, types.Club), board...))
+	queenKicker := EvaluateHand(append(cards(types.King, types.Club, types.Queen, types.Diamond), board...))
+	if CompareHands(aceKicker, queenKicker) != 1 {
+		t.Errorf("CompareHands() pair of kings with ace kicker should beat queen kicker")
+	}
+
+	wheel := EvaluateHand(cards(types.Ace, types.Spade, types.Two, types.Heart, types.Three, types.Club, types.Four, types.Diamond, types.Five, types.Spade))
+	sixHigh := EvaluateHand(cards(types.Six, types.Spade, types.Two, types.Heart, types.Three, types.Club, types.Four, types.Diamond, types.Five, types.Spade))
+	if CompareHands(wheel, sixHigh) != -1 {
+		t.Errorf("CompareHands() wheel should lose to a six-high straight")
+	}
+
+	same := EvaluateHand(append(cards(types.Ace, types.Heart, types.Three, types.Club), board...))
+	sameOtherSuits := EvaluateHand(append(cards(types.Ace, types.Diamond, types.Three, types.Spade), board...))
+	if CompareHands(same, sameOtherSuits) != 0 {
+		t.Errorf("CompareHands() identical ranks in different suits should tie")
+	}
+}
+
+// TestDescribeHand checks the spoken names of made hands.
+func TestDescribeHand(t *testing.T) {
+	tests := []struct {
+		hand string
+		want string
+	}{
+		{"Ks Kh 9c 9d Qs 3h 2c", "Two Pair, Kings and Nines, Queen kicker"},
+		{"6s 6h Ac 9d Qs 3h 2c", "One Pair, Sixes, Ace kicker"},
+		{"As Th 8c 6d 4s 3h 2c", "High Card, Ace, Ten kicker"},
+		{"7s 7h 7c 2d 2s 3h 4c", "Full House, Sevens full of Twos"},
+		{"As 2h 3c 4d 5s Kh Kc", "Straight, Five high"},
+		{"Ah Th 8h 6h 4h 3s 2c", "Flush, Ace high"},
+		{"Tc Jc Qc Kc Ac 3h 2s", "Royal Flush"},
+	}
+	for _, tt := range tests {
+		var hand []types.Card
+		for _, code := range strings.Fields(tt.hand) {
+			c, err := types.ParseCard(code)
+			if err != nil {
+				t.Fatalf("ParseCard(%q) error: %v", code, err)
+			}
+			hand = append(hand, c)
+		}
+		if got := EvaluateHand(hand).Describe(); got != tt.want {
+			t.Errorf("Describe(%s) = %q, want %q", tt.hand, got, tt.want)
+		}
+	}
+}
+
+// TestShowdownLine checks that the cards making the hand are split between
+// the player's own and the board's.
+func TestShowdownLine(t *testing.T) {
+	hole := cards(types.King, types.Spade, types.Two, types.Heart)
+	board := cards(types.King, types.Heart, types.Nine, types.Club, types.Nine, types.Diamond, types.Queen, types.Spade, types.Three, types.Club)
+	got := showdownLine("Alice", EvaluateHand(append(hole, board...)), hole)
+	want := "Alice has Two Pair, Kings and Nines, Queen kicker: K♠ with K♥ 9♣ 9♦ Q♠ from the board"
+	if got != want {
+		t.Errorf("showdownLine() = %q, want %q", got, want)
+	}
+}
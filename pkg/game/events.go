@@ -0,0 +1,97 @@
+package game
+
+import (
+	"time"
+
+	"pokerclientv1/pkg/types"
+)
+
+// EventType identifies what happened in the game.
+type EventType string
+
+const (
+	EventHandStarted      EventType = "hand_started"      // A new hand begins, Amount is the number of players
+	EventPlayerSeated     EventType = "player_seated"     // Player is dealt in with a stack of Amount chips at Position, Action is a bot's difficulty
+	EventBlindPosted      EventType = "blind_posted"      // A player posted a blind of Amount chips
+	EventHoleCardsDealt   EventType = "hole_cards_dealt"  // Cards holds the player's private cards
+	EventStreetDealt      EventType = "street_dealt"      // Cards holds the new community cards for Round
+	EventActionOn         EventType = "action_on"         // Player is about to be asked to act, Amount is what they need to call
+	EventPlayerAction     EventType = "player_action"     // Action is what the player did, Amount the chips added, Elapsed the time taken to decide
+	EventBetReturned      EventType = "bet_returned"      // Amount chips of an uncalled bet went back to Player
+	EventShowdown         EventType = "showdown"          // Action names the player's hand, Cards holds the best five
+	EventHandMucked       EventType = "hand_mucked"       // Player went to showdown without showing their cards, see RevealPolicy
+	EventPotAwarded       EventType = "pot_awarded"       // Player won Amount chips
+	EventHandEnded        EventType = "hand_ended"        // The hand is over and chips have been paid out, Elapsed is its length
+	EventHandAbandoned    EventType = "hand_abandoned"    // The hand was stopped unfinished and every stack is back where it started; no hand_ended follows
+	EventPlayerEliminated EventType = "player_eliminated" // Player has no chips left and leaves the table
+	EventRebuy            EventType = "rebuy"             // Between hands, Player bought Amount chips; Action is "re-buy" or "add-on"
+	EventGameOver         EventType = "game_over"         // No more hands will be played
+	EventChat             EventType = "chat"              // Player said Action at the table, see Game.Chat
+
+	// Multi-table events, see Lobby.MaxSeats
+	EventPlayerMoved EventType = "player_moved" // Player left Table between hands to take a seat at table Action
+	EventTableBroken EventType = "table_broken" // Table closed and its players were moved to other tables
+	EventFinalTable  EventType = "final_table"  // The Amount players left are all seated at Table
+
+	// Experimental, see Tournament.CashOuts
+	EventCashedOut EventType = "cashed_out" // Between hands, Player left the tournament for Amount of the prize pool
+)
+
+// Event describes something that happened during a game. Fields that do not
+// apply to the event type are left empty. Table is the game's TableID, so
+// handlers shared by several tables can tell them apart.
+type Event struct {
+	Type       EventType    `json:"type"`
+	Table      string       `json:"table,omitempty"`
+	HandNumber int          `json:"hand"`
+	PlayerID   string       `json:"player,omitempty"`
+	Action     string       `json:"action,omitempty"`
+	Amount     int          `json:"amount,omitempty"`
+	Round      string       `json:"round,omitempty"`
+	Position   string       `json:"position,omitempty"` // Player's position at the table, see PositionNames
+	Cards      []types.Card `json:"cards,omitempty"`
+
+	Time    time.Time     `json:"time"`              // When the event happened
+	Elapsed time.Duration `json:"elapsed,omitempty"` // Duration for player_action and hand_ended, in nanoseconds
+}
+
+// EventHandler receives game events as they happen. Handlers run on the game
+// goroutine and should return quickly.
+type EventHandler func(Event)
+
+// OnEvent registers a handler that is called for every game event.
+func (g *Game) OnEvent(handler EventHandler) {
+	g.handlers = append(g.handlers, handler)
+}
+
+// emit sends an event to all registered handlers.
+func (g *Game) emit(e Event) {
+	if e.HandNumber == 0 {
+		e.HandNumber = g.HandNumber
+	}
+	if e.Table == "" {
+		e.Table = g.TableID
+	}
+	if e.Round == "" && g.Table != nil {
+		e.Round = g.Table.Round
+	}
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+	g.emitting = true
+	for _, handler := range g.handlers {
+		handler(e)
+	}
+	g.emitting = false
+	for len(g.said) > 0 { // Chat from the handlers, in the order it was said
+		said := g.said[0]
+		g.said = g.said[1:]
+		g.emit(said)
+	}
+}
+
+// logAction shows a player's action on the UI and reports it to event handlers.
+func (g *Game) logAction(p types.Player, action string, amount int) {
+	g.display().LogAction(p.GetID(), action, amount)
+	g.emit(Event{Type: EventPlayerAction, PlayerID: p.GetID(), Action: action, Amount: amount, Elapsed: g.decisionTime})
+}
@@ -0,0 +1,102 @@
+package game_test
+
+import (
+	"fmt"
+	"math/rand"
+
+	"pokerclientv1/pkg/game"
+	"pokerclientv1/pkg/types"
+)
+
+// Deal hole cards and a board from a deck. A deck drawing its shuffles from
+// a seeded source deals the same cards every run.
+func ExampleDeck() {
+	deck := game.NewDeckWithRand(rand.New(rand.NewSource(1)))
+	deck.Shuffle()
+	hole, _ := deck.DealMultiple(2)
+	board, _ := deck.DealMultiple(5)
+	fmt.Println("Hole cards:", hole)
+	fmt.Println("Board:", board)
+	fmt.Println("Left in the deck:", deck.CardsLeft())
+	// Output:
+	// Hole cards: [Q♣ 9♣]
+	// Board: [5♥ Q♦ 9♠ K♦ 6♥]
+	// Left in the deck: 45
+}
+
+// Evaluate two hands at showdown and find the winner.
+func ExampleCompareHands() {
+	board, _ := types.ParseCards("Ks 9h 9c 2d 7s")
+	alice, _ := types.ParseCards("Kh Qd")
+	bob, _ := types.ParseCards("Ad 9d")
+
+	a := game.EvaluateHand(append(alice, board...))
+	b := game.EvaluateHand(append(bob, board...))
+	fmt.Println("Alice:", a.Describe())
+	fmt.Println("Bob:", b.Describe())
+	switch game.CompareHands(a, b) {
+	case 1:
+		fmt.Println("Alice wins")
+	case -1:
+		fmt.Println("Bob wins")
+	default:
+		fmt.Println("Split pot")
+	}
+	// Output:
+	// Alice: Two Pair, Kings and Nines, Queen kicker
+	// Bob: Three of a Kind, Nines, Ace kicker
+	// Bob wins
+}
+
+// Run a game between bots until one of them has every chip. AutoPlayer
+// keeps each bot's chips and bets, so a bot only has to decide its actions.
+func ExampleEngine() {
+	caller := game.NewAutoPlayer("Caller", 100, nil) // Checks and calls everything
+	shover := game.NewAutoPlayer("Shover", 100, nil)
+	shover.Decide = func(table *types.Table, toCall, minRaise int) (string, int) {
+		// Move all in with a pair or an ace, otherwise give up the hand
+		hole := shover.Hand.Cards
+		if hole[0].Rank != hole[1].Rank && hole[0].Rank != types.Ace && hole[1].Rank != types.Ace {
+			if toCall == 0 {
+				return "check", 0
+			}
+			return "fold", 0
+		}
+		if shover.Chips <= toCall {
+			return "call", shover.Chips
+		}
+		return "raise", shover.Chips
+	}
+
+	engine := game.NewEngine(game.Config{
+		Players: []types.Player{caller, shover},
+		Rng:     rand.New(rand.NewSource(7)), // Seeded so the example deals the same every run
+		OnEvent: func(e game.Event) {
+			if e.Type == game.EventPotAwarded {
+				fmt.Printf("%s wins %d\n", e.PlayerID, e.Amount)
+			}
+		},
+	})
+	engine.Run()
+	fmt.Printf("Game over after %d hands\n", engine.HandNumber())
+	// Output:
+	// Shover wins 4
+	// Caller wins 2
+	// Shover wins 4
+	// Caller wins 2
+	// Caller wins 4
+	// Shover wins 200
+	// Game over after 6 hands
+}
+
+// Split a prize pool by each stack's chance of finishing in each place.
+func ExampleICM() {
+	equity := game.ICM([]int{5000, 3000, 2000}, []int{500, 300, 200})
+	for i, value := range equity {
+		fmt.Printf("Player %d: %.2f\n", i+1, value)
+	}
+	// Output:
+	// Player 1: 383.93
+	// Player 2: 327.50
+	// Player 3: 288.57
+}
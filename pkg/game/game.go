@@ -0,0 +1,1458 @@
+package game
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"pokerclientv1/pkg/types"
+	"slices"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	DefaultSmallBlind = 1
+	DefaultBigBlind   = 2
+)
+
+// Game manages the overall poker game state and flow.
+type Game struct {
+	Players         []types.Player
+	Deck            *Deck
+	DeckFactory     func() *Deck // Optional source of the deck for each hand, defaults to NewDeckWithRand(Rng)
+	Rng             *rand.Rand   // Optional source of shuffle seeds for reproducible games, the clock when nil
+	Table           *types.Table
+	PotManager      *PotManager // Per-player contributions used to build side pots
+	DealerPos       int
+	CurrentPlayer   int
+	SmallBlindPos   int // -1 when the small blind is dead
+	BigBlindPos     int
+	SmallBlind      int            // Current small blind amount
+	BigBlind        int            // Current big blind, also the minimum raise
+	Ante            int            // Current ante paid by every player, 0 for none
+	Schedule        *BlindSchedule // Optional blind structure raising the stakes over time
+	Config          GameConfig     // Stakes and betting rules the game was created with
+	HandNumber      int            // Number of the hand being played, starting at 1
+	UI              types.GameUI   // UI interface for display and logging
+	Out             io.Writer      // Destination for the engine's progress messages
+	DealLog         io.Writer      // Optional audit log of each hand's shuffle seed and dealt cards, see DealRecord
+	GameSpeed       time.Duration  // Delay between steps
+	TurnClock       time.Duration  // Time each player has to act before checking or folding, 0 for no clock
+	CheckInvariants InvariantMode  // Whether chips are checked after every action too, see InvariantMode
+	gameOver        bool           // Flag to signal game end
+	runningOut      bool           // Set once action is closed and the board is run out
+	saveRequested   bool           // Set when a player stops the game to save it
+	handStartChips  map[string]int // Stacks at the start of the hand in progress, nil between hands
+	handlers        []EventHandler
+	rebuys          map[string]int // Re-buys each player has made, for RebuyPolicy.Limit
+	handAbandoned   bool           // Set when the hand in progress was stopped before it finished
+	chipErr         error          // Why the chips of the last hand played did not add up, nil when they did
+	stopRequested   atomic.Bool    // Set by Stop to end the game after the current hand
+	emitting        bool           // Set while the event handlers are called
+	said            []Event        // Chat said by a handler, sent once the event being handled is done
+
+	// Betting round state, kept on the game so LegalActions can answer at any time
+	minRaise        int           // Size of the last full raise, at least the big blind
+	actedSinceRaise map[int]bool  // Seats that acted since the action was last reopened by a full raise
+	decisionTime    time.Duration // How long the player acting took to choose, reported with their action
+	aggressor       string        // Last player to bet or raise on the last street with betting, who shows first
+
+	TableID string // Optional name of the table, reported with every event; see Lobby
+
+	// Protection against a UI that fails or blocks, see UIError
+	UITimeout  time.Duration // Longest a UI call may take before the UI is dropped, 0 to wait as long as it takes
+	UIFallback types.GameUI  // Used once the UI is dropped, a plain text log on Out when nil
+	uiErr      error         // Why the UI was dropped, nil while it works
+
+	seats []Seat // Every seat at the table, see Seats
+
+	// Blinds of the last hand, which the next hand's blinds move on from so the
+	// big blind advances one seat at a time however many players bust
+	lastSmallBlind string // Empty when the small blind was dead
+	lastBigBlind   string // Empty before the first hand
+	lastBigBlindAt int    // Seat number of lastBigBlind, 0 before the first hand
+	deadButton     bool   // Set when the button is on the seat of a player who left
+
+	tournament bool               // Set by Tournament.Attach; players who sit down are dealt in at once
+	sitters    map[string]*sitter // Seated players who are not dealt in, see SitOut
+	posting    []*sitter          // Players dealt back in this hand who owe missed blinds
+
+	// Offer a player may take to leave between hands, set by Tournament.Attach;
+	// ok is false when nothing is offered
+	cashOutOffer func(p types.Player) (value, chipValue int, ok bool)
+}
+
+// NewGame initializes a new game with players, using the config for stakes and betting rules.
+func NewGame(players []types.Player, ui types.GameUI, gameSpeed time.Duration, config GameConfig) *Game {
+	return &Game{
+		Players:       players,
+		Deck:          NewDeck(),
+		Table:         &types.Table{},
+		PotManager:    NewPotManager(),
+		DealerPos:     0,
+		CurrentPlayer: 0,
+		SmallBlindPos: 0,
+		BigBlindPos:   0,
+		SmallBlind:    config.SmallBlind,
+		BigBlind:      config.BigBlind,
+		Ante:          config.Ante,
+		Config:        config,
+		UI:            ui,
+		Out:           os.Stdout,
+		GameSpeed:     gameSpeed, // Store game speed
+		gameOver:      false,
+	}
+}
+
+// Start runs the main game loop until the game is over. Cancelling ctx
+// abandons the hand in progress, giving every player back the chips they
+// started it with, while Stop lets it finish first. Either way the final chip
+// counts are shown.
+func (g *Game) Start(ctx context.Context) {
+	fmt.Fprintln(g.Out, "Starting Poker Game!")
+	for g.PlayNextHandContext(ctx) && !g.stopRequested.Load() {
+		g.waitWithLoader(ctx, g.GameSpeed*2) // Pause between hands
+	}
+	if g.saveRequested {
+		fmt.Fprintln(g.Out, "\n--- Game Saved ---")
+		return
+	}
+
+	if ctx.Err() != nil || g.stopRequested.Load() {
+		fmt.Fprintln(g.Out, "\n--- Game Stopped --- ")
+	} else {
+		fmt.Fprintln(g.Out, "\n--- Game Over --- ")
+	}
+	// Display final chip counts if players remain
+	if len(g.Players) > 0 {
+		fmt.Fprintln(g.Out, "Final Chip Counts:")
+		for _, p := range g.Players {
+			fmt.Fprintf(g.Out, "- %s: %d chips\n", p.GetID(), p.GetChips())
+		}
+	}
+	g.emit(Event{Type: EventGameOver})
+}
+
+// Stop asks the game to end once the hand in progress is over. It may be
+// called from any goroutine, such as a signal handler.
+func (g *Game) Stop() {
+	g.stopRequested.Store(true)
+}
+
+// PlayNextHand plays a single hand and prepares the table for the next one.
+// Returns false if the game is over, either before or during the hand.
+func (g *Game) PlayNextHand() bool {
+	return g.PlayNextHandContext(context.Background())
+}
+
+// PlayNextHandContext is PlayNextHand, abandoning the hand if ctx is
+// cancelled before it is over. Stacks go back to what they were when the
+// hand started and the game ends.
+func (g *Game) PlayNextHandContext(ctx context.Context) bool {
+	// Check for game end conditions before starting the hand
+	if ctx.Err() != nil {
+		return false
+	}
+	g.returnSitters()
+	if g.checkGameOver() {
+		return false
+	}
+
+	g.HandNumber++
+	g.applyBlindSchedule()
+	g.updateHeader()
+	fmt.Fprintf(g.Out, "\n--- Starting Hand %d ---\n", g.HandNumber)
+	g.playHand(ctx)
+	if g.chipErr = g.checkChips(); g.chipErr != nil {
+		g.invariantViolated("the end of the hand", g.chipErr)
+	}
+
+	// Check for game end immediately after the hand (e.g., if human folded and lost)
+	if g.gameOver {
+		return false
+	}
+
+	g.handStartChips = nil
+	g.recordBlinds()
+	g.offerRebuys()
+	g.removeBrokePlayers(ctx) // Remove players with 0 chips
+	g.offerCashOuts()
+
+	// Rotate dealer position for the next hand, only among remaining players.
+	// determineBlinds places the button itself once a hand has been played;
+	// this keeps DealerPos sensible for saves and callers between hands.
+	if len(g.Players) > 0 { // Avoid panic if all players are removed
+		g.DealerPos = (g.DealerPos + 1) % len(g.Players)
+	}
+	return true
+}
+
+// offerCashOuts asks each player who decides for themselves whether to take
+// the tournament's cash-out offer. Those who do leave the table; a human
+// cashing out ends the game, as if they had left.
+func (g *Game) offerCashOuts() {
+	if g.cashOutOffer == nil {
+		return
+	}
+	for _, p := range slices.Clone(g.Players) {
+		c, ok := p.(types.CashOuter)
+		if !ok || p.GetChips() == 0 {
+			continue
+		}
+		value, chipValue, ok := g.cashOutOffer(p)
+		if !ok || !c.CashOut(value, chipValue) {
+			continue
+		}
+		g.Unseat(p.GetID())
+		fmt.Fprintf(g.Out, "\n>> %s cashes out of the tournament with %d chips for %d.\n", p.GetID(), p.GetChips(), value)
+		g.emit(Event{Type: EventCashedOut, PlayerID: p.GetID(), Amount: value})
+		if p.IsHuman() {
+			g.gameOver = true
+		}
+	}
+}
+
+// applyBlindSchedule moves the blinds to the schedule's level for the next hand.
+func (g *Game) applyBlindSchedule() {
+	if g.Schedule == nil {
+		return
+	}
+	level, raised := g.Schedule.NextHand()
+	g.SmallBlind, g.BigBlind, g.Ante = level.SmallBlind, level.BigBlind, level.Ante
+	if raised {
+		fmt.Fprintf(g.Out, "\n*** Blinds are now %d/%d", g.SmallBlind, g.BigBlind)
+		if g.Ante > 0 {
+			fmt.Fprintf(g.Out, " with a %d ante", g.Ante)
+		}
+		fmt.Fprintf(g.Out, " (level %d) ***\n", g.Schedule.LevelNumber())
+	}
+}
+
+// updateHeader tells the UI which hand is being played, at what stakes and
+// since when, through the table it is shown.
+func (g *Game) updateHeader() {
+	g.Table.HandNumber = g.HandNumber
+	g.Table.SmallBlind, g.Table.BigBlind, g.Table.Ante = g.SmallBlind, g.BigBlind, g.Ante
+	g.Table.BlindLevel = 0
+	if g.Schedule != nil {
+		g.Table.BlindLevel = g.Schedule.LevelNumber()
+	}
+	if g.Table.SessionStart.IsZero() {
+		g.Table.SessionStart = time.Now()
+	}
+}
+
+// IsOver reports whether the game has ended.
+func (g *Game) IsOver() bool {
+	return g.gameOver
+}
+
+// getPlayersWithChips returns players who have chips > 0.
+func (g *Game) getPlayersWithChips() []types.Player {
+	active := []types.Player{}
+	for _, p := range g.Players {
+		if p.GetChips() > 0 {
+			active = append(active, p)
+		}
+	}
+	return active
+}
+
+// checkGameOver checks if the game should end.
+func (g *Game) checkGameOver() bool {
+	if g.gameOver { // Already flagged by player action
+		return true
+	}
+
+	playersWithChips := g.getPlayersWithChips()
+	if len(playersWithChips) <= 1 {
+		fmt.Fprintln(g.Out, "Only one player remains!")
+		g.gameOver = true
+		return true
+	}
+
+	// Check if human player exists and has chips
+	humanAlive := false
+	for _, p := range playersWithChips {
+		if p.IsHuman() {
+			humanAlive = true
+			break
+		}
+	}
+	if !humanAlive {
+		// Find the human player in the original list to check if they were ever in the game
+		humanWasPresent := false
+		for _, p := range g.Players {
+			if p.IsHuman() {
+				humanWasPresent = true
+				break
+			}
+		}
+		if humanWasPresent {
+			fmt.Fprintln(g.Out, "You are out of chips!")
+			g.gameOver = true
+			return true
+		}
+	}
+
+	return false
+}
+
+// offerRebuys lets broke players buy back in, and short stacks top up when
+// add-ons are allowed, as the config's re-buy policy permits.
+func (g *Game) offerRebuys() {
+	policy := g.Config.Rebuy
+	if policy.UpTo <= 0 {
+		return
+	}
+	if g.rebuys == nil {
+		g.rebuys = make(map[string]int)
+	}
+	rules := g.Config
+	rules.BigBlind = g.BigBlind // Limits follow the blinds as they go up
+	lo, hi := rules.BuyInRange()
+	for _, p := range g.Players {
+		chips := p.GetChips()
+		kind := "re-buy"
+		switch {
+		case chips == 0 && policy.Limit > 0 && g.rebuys[p.GetID()] >= policy.Limit:
+			continue
+		case chips > 0 && (!policy.AddOn || chips >= policy.UpTo):
+			continue
+		case chips > 0:
+			kind = "add-on"
+		}
+		most := policy.UpTo - chips
+		if hi > 0 {
+			most = min(most, hi-chips)
+		}
+		if most <= 0 || chips+most < lo {
+			continue
+		}
+		amount := 0
+		if r, ok := p.(types.Rebuyer); ok {
+			amount = min(r.Rebuy(most), most)
+		} else if policy.Bots && chips == 0 {
+			amount = most
+		}
+		if amount <= 0 {
+			continue
+		}
+		if err := rules.CheckBuyIn(chips + amount); kind == "re-buy" && err != nil {
+			fmt.Fprintf(g.Out, "\n>> %s cannot re-buy: %v.\n", p.GetID(), err)
+			continue
+		}
+		p.AddChips(amount)
+		if kind == "re-buy" {
+			g.rebuys[p.GetID()]++
+			fmt.Fprintf(g.Out, "\n>> %s re-buys for %d chips.\n", p.GetID(), amount)
+		} else {
+			fmt.Fprintf(g.Out, "\n>> %s adds on %d chips.\n", p.GetID(), amount)
+		}
+		g.emit(Event{Type: EventRebuy, PlayerID: p.GetID(), Action: kind, Amount: amount})
+	}
+}
+
+// Rebuys returns how many times a player has re-bought this game.
+func (g *Game) Rebuys(playerID string) int {
+	return g.rebuys[playerID]
+}
+
+// removeBrokePlayers removes players with zero chips from the game. A broke
+// human stays seated only when no other human has chips, for checkGameOver to
+// end the game on.
+func (g *Game) removeBrokePlayers(ctx context.Context) {
+	button := g.buttonSeat()
+	humanLeft := slices.ContainsFunc(g.Players, func(p types.Player) bool { return p.IsHuman() && p.GetChips() > 0 })
+	remainingPlayers := []types.Player{}
+	for _, p := range g.Players {
+		switch {
+		case p.GetChips() > 0:
+			remainingPlayers = append(remainingPlayers, p)
+		case p.IsHuman() && !humanLeft:
+			// The last human is broke, game over is handled in checkGameOver
+			// Keep human in the list for final display, but checkGameOver will stop the loop
+			remainingPlayers = append(remainingPlayers, p) // Keep human for final display
+		case p.IsHuman():
+			// Another human at the same screen plays on without them
+			fmt.Fprintf(g.Out, "\n>> %s is out of chips and leaves the table.\n", p.GetID())
+			g.emit(Event{Type: EventPlayerEliminated, PlayerID: p.GetID()})
+			g.waitWithLoader(ctx, g.GameSpeed)
+		default:
+			fmt.Fprintf(g.Out, "\n>> %s was kicked out due to being poor.\n", p.GetID())
+			g.emit(Event{Type: EventPlayerEliminated, PlayerID: p.GetID()})
+			g.waitWithLoader(ctx, g.GameSpeed)
+		}
+	}
+	g.Players = remainingPlayers
+	// Keep the button on its seat, or the next one taken if it is now empty
+	g.DealerPos = g.indexFromSeat(button)
+}
+
+// Seat adds a player to the table between hands, in the first empty seat or
+// a new one after the last. They are dealt in from the next hand, except
+// that a player joining a cash game under way waits for the big blind to
+// reach them; see SitIn.
+func (g *Game) Seat(p types.Player) {
+	if g.lastBigBlindAt != 0 && !g.tournament && g.Schedule == nil {
+		g.seatWaiting(p)
+		return
+	}
+	if g.takeSeat(p) <= g.DealerPos && len(g.Players) > 1 {
+		g.DealerPos++
+	}
+}
+
+// Unseat removes a player from the table between hands, emptying their seat
+// and keeping the button with the player due to deal next. It returns false
+// if the player is not seated.
+func (g *Game) Unseat(playerID string) (types.Player, bool) {
+	if s, ok := g.sitters[playerID]; ok {
+		delete(g.sitters, playerID)
+		g.syncSeats()
+		return s.player, true
+	}
+	for i, p := range g.Players {
+		if p.GetID() != playerID {
+			continue
+		}
+		button := g.buttonSeat()
+		g.Players = slices.Delete(g.Players, i, i+1)
+		g.DealerPos = g.indexFromSeat(button)
+		return p, true
+	}
+	return nil, false
+}
+
+// DrawSeats seats the players in a random order between hands and gives the
+// button to a random seat, as at the start of a game. The next hand's blinds
+// follow the new button instead of moving on from the last hand's.
+func (g *Game) DrawSeats(rng *rand.Rand) {
+	if len(g.Players) == 0 {
+		return
+	}
+	rng.Shuffle(len(g.Players), func(i, j int) { g.Players[i], g.Players[j] = g.Players[j], g.Players[i] })
+	g.DealerPos = rng.Intn(len(g.Players))
+	g.seats = nil
+	g.syncSeats()
+	g.lastBigBlind, g.lastSmallBlind, g.lastBigBlindAt = "", "", 0
+}
+
+// getActivePlayers returns players who haven't folded and have chips.
+func (g *Game) getActivePlayers() []types.Player {
+	active := []types.Player{}
+	for _, p := range g.Players {
+		// Consider players active if they haven't folded in the *current* hand
+		// and have chips. Folded status is reset each hand.
+		if !p.IsFolded() && p.GetChips() > 0 {
+			active = append(active, p)
+		}
+	}
+	return active
+}
+
+// countPlayersAbleToAct returns the number of players in the hand who are not all-in.
+func (g *Game) countPlayersAbleToAct() int {
+	count := 0
+	for _, p := range g.getPlayersInHand() {
+		if !p.IsAllIn() {
+			count++
+		}
+	}
+	return count
+}
+
+// getPlayersInHand returns players who haven't folded in the current hand.
+func (g *Game) getPlayersInHand() []types.Player {
+	active := []types.Player{}
+	for _, p := range g.Players {
+		if !p.IsFolded() {
+			active = append(active, p)
+		}
+	}
+	return active
+}
+
+// playHand executes a single hand of poker.
+func (g *Game) playHand(ctx context.Context) {
+	// 0. Clear screen at the start of the hand
+	g.display().ClearScreen()
+
+	// Reset gameOver flag potentially set by human player exiting previous hand's betting
+	// g.gameOver = false // Let checkGameOver handle this
+
+	// Check if enough players to play
+	if len(g.getPlayersWithChips()) < 2 {
+		g.gameOver = true
+		return
+	}
+
+	// 1. Reset table and player states for the new hand
+	g.resetForNewHand()
+	g.handStartChips = make(map[string]int, len(g.Players))
+	for _, p := range g.Players {
+		g.handStartChips[p.GetID()] = p.GetChips()
+	}
+	started := time.Now()
+	g.handAbandoned = false
+	g.emit(Event{Type: EventHandStarted, Amount: len(g.Players), Time: started})
+	defer func() {
+		if !g.handAbandoned {
+			g.emit(Event{Type: EventHandEnded, Elapsed: time.Since(started)})
+		}
+	}()
+
+	// 2. Shuffle the deck
+	g.Deck.Shuffle()
+	if g.DealLog != nil {
+		defer g.writeDealRecord(newDealRecord(g.HandNumber, g.Deck))
+	}
+
+	// 3. Determine blind positions
+	g.determineBlinds()
+	for _, p := range g.Players {
+		seated := Event{Type: EventPlayerSeated, PlayerID: p.GetID(), Amount: p.GetChips(), Position: g.Table.Positions[p.GetID()]}
+		if bot, ok := p.(difficultyReporter); ok {
+			seated.Action = bot.GetDifficulty()
+		}
+		g.emit(seated)
+	}
+
+	// 4. Post blinds
+	g.postBlinds()
+
+	// 5. Deal initial hands (2 cards each for Texas Hold'em)
+	variant := g.Variant()
+	g.dealHands(variant.HoleCards())
+	g.waitWithLoader(ctx, g.GameSpeed)
+
+	// 6. Pre-flop betting round
+	g.Table.Round = "Pre-flop"
+	if !g.playBettingStreet(ctx, "Pre-flop Betting", (g.BigBlindPos+1)%len(g.Players)) {
+		return // Hand ends early or player exited
+	}
+
+	// 7-9. Flop, Turn and River, each followed by a betting round
+	for _, street := range variant.Streets() {
+		g.dealCommunityCards(street.Name, street.Cards)
+		g.waitWithLoader(ctx, g.GameSpeed)
+		if !g.playBettingStreet(ctx, street.Name+" Betting", (g.DealerPos+1)%len(g.Players)) {
+			return // Hand ends early or player exited
+		}
+	}
+
+	// 10. Showdown
+	g.waitWithLoader(ctx, g.GameSpeed)
+	if ctx.Err() != nil {
+		g.abandonHand()
+		return
+	}
+	g.showdown()
+}
+
+// abandonHand stops the hand in progress without a winner: every player gets
+// back the chips they started it with and the game ends.
+func (g *Game) abandonHand() {
+	for _, p := range g.Players {
+		if start, ok := g.handStartChips[p.GetID()]; ok {
+			p.AddChips(start - p.GetChips())
+		}
+		p.ResetBet()
+	}
+	g.Table.Pot = 0
+	g.PotManager.Reset()
+	g.Table.Acting, g.Table.Deadline = "", time.Time{}
+	g.handAbandoned = true
+	g.gameOver = true
+	fmt.Fprintf(g.Out, "\nHand %d was stopped. Every player gets back the chips they started it with.\n", g.HandNumber)
+	g.emit(Event{Type: EventHandAbandoned})
+}
+
+// playBettingStreet displays the table and runs a betting round for the current street.
+// If action is already closed (everyone left is all-in), no one is prompted and the
+// hands are revealed so the board can be run out automatically.
+// Returns true if the hand should continue to the next street.
+func (g *Game) playBettingStreet(ctx context.Context, stage string, startPos int) bool {
+	if ctx.Err() != nil {
+		g.abandonHand()
+		return false
+	}
+	if g.actionClosed() {
+		g.returnUncalledBet() // An all-in blind can leave the other blind uncalled
+		g.revealAllInHands()
+		g.showTable(g.Table.Round + " (All-In)")
+		return true
+	}
+
+	g.Table.Acting = ""
+	if i := g.nextToAct(startPos); i >= 0 {
+		g.Table.Acting = g.Players[i].GetID()
+	}
+	g.showTable(stage)
+	if !g.runBettingRound(ctx, startPos) {
+		if !g.gameOver { // Player exit ends the game without awarding the pot
+			g.awardPotUncontested()
+		}
+		return false
+	}
+	return !g.gameOver
+}
+
+// showTable displays the table, with the side pots as they stand and who
+// can win each once someone is all-in for less than the others have bet.
+func (g *Game) showTable(stage string) {
+	g.Table.Pots = nil
+	if pots := g.PotManager.LivePots(g.Players); len(pots) > 1 {
+		for i, pot := range pots {
+			shown := types.TablePot{Name: potName(i), Amount: pot.Amount}
+			for _, p := range pot.Eligible {
+				shown.Eligible = append(shown.Eligible, p.GetID())
+			}
+			g.Table.Pots = append(g.Table.Pots, shown)
+		}
+	}
+	g.display().DisplayGameState(g.Table, g.Players, g.Table.Pot, stage)
+}
+
+// actionClosed reports whether betting is capped for the rest of the hand.
+// This is the case when no more than one player in the hand still has chips to bet
+// and that player has already matched the current bet.
+func (g *Game) actionClosed() bool {
+	if len(g.getPlayersInHand()) <= 1 {
+		return false // Hand is won uncontested, not run out
+	}
+	canAct := []types.Player{}
+	for _, p := range g.getPlayersInHand() {
+		if !p.IsAllIn() {
+			canAct = append(canAct, p)
+		}
+	}
+	switch len(canAct) {
+	case 0:
+		return true
+	case 1:
+		return canAct[0].GetCurrentBet() >= g.Table.CurrentBet
+	default:
+		return false
+	}
+}
+
+// revealAllInHands shows every remaining hand once action is closed, unless
+// the reveal policy keeps them down until showdown.
+func (g *Game) revealAllInHands() {
+	if g.runningOut {
+		return
+	}
+	g.runningOut = true
+	if !g.handsTabled() {
+		fmt.Fprintln(g.Out, "Action is closed. Running out the board:")
+		return
+	}
+	fmt.Fprintln(g.Out, "Action is closed. Revealing hands and running out the board:")
+	for _, p := range g.getPlayersInHand() {
+		fmt.Fprintf(g.Out, "- %s: %s\n", p.GetID(), p.GetHand())
+	}
+}
+
+// handsTabled reports whether the hands in play were turned face up when
+// action was closed, so they must be shown at showdown.
+func (g *Game) handsTabled() bool {
+	return g.runningOut && g.revealPolicy().TableAllIns()
+}
+
+// resetForNewHand prepares the game state for a new hand.
+func (g *Game) resetForNewHand() {
+	g.Deck = NewDeckWithRand(g.Rng) // Get a fresh deck
+	if g.DeckFactory != nil {
+		g.Deck = g.DeckFactory()
+	}
+	g.Table.ResetForNewHand()
+	g.Table.Pot = 0
+	g.PotManager.Reset()
+	g.PotManager.SetRound("Pre-flop") // Blinds and antes count as pre-flop chips
+	g.runningOut = false
+	g.aggressor = ""
+	for _, p := range g.Players {
+		p.ResetForNewHand()
+	}
+}
+
+// determineBlinds sets the button and blind positions for the hand. The
+// first hand's blinds follow DealerPos; after that the big blind moves one
+// seat to the left each hand, see nextBigBlind.
+func (g *Game) determineBlinds() {
+	numPlayers := len(g.Players)
+	g.deadButton = false
+	bb := g.nextBigBlind()
+	switch {
+	case bb < 0:
+		// First hand: the blinds sit to the left of the button
+		g.SmallBlindPos = (g.DealerPos + 1) % numPlayers
+		g.BigBlindPos = (g.DealerPos + 2) % numPlayers
+		// Handle heads-up case (2 players)
+		if numPlayers == 2 {
+			g.SmallBlindPos = g.DealerPos
+			g.BigBlindPos = (g.DealerPos + 1) % numPlayers
+		}
+	case numPlayers == 2:
+		// Heads-up the button posts the small blind
+		g.BigBlindPos = bb
+		g.DealerPos = 1 - bb
+		g.SmallBlindPos = g.DealerPos
+	default:
+		// Dead button rules: last hand's big blind posts the small blind and
+		// the button goes to last hand's small blind. Either may have left,
+		// leaving a dead small blind or a dead button rather than a player
+		// posting twice or skipping a blind.
+		g.BigBlindPos = bb
+		g.SmallBlindPos = g.seatOf(g.lastBigBlind)
+		button := -1
+		if g.lastSmallBlind != "" {
+			button = g.seatOf(g.lastSmallBlind)
+		}
+		if button < 0 {
+			// The player to the right of the blinds stands in for the button
+			// so the action still starts to the left of it
+			first := g.BigBlindPos
+			if g.SmallBlindPos >= 0 {
+				first = g.SmallBlindPos
+			}
+			button = (first - 1 + numPlayers) % numPlayers
+			g.deadButton = true
+		}
+		g.DealerPos = button
+	}
+
+	g.Table.Positions = g.positions()
+	dealer, smallBlind := g.Players[g.DealerPos].GetID(), "dead"
+	if g.deadButton {
+		dealer = "dead button"
+	}
+	if g.SmallBlindPos >= 0 {
+		smallBlind = g.Players[g.SmallBlindPos].GetID()
+	}
+	fmt.Fprintf(g.Out, "Dealer: %s | Small Blind: %s | Big Blind: %s\n",
+		dealer, smallBlind, g.Players[g.BigBlindPos].GetID())
+}
+
+// nextBigBlind returns the index of the player due to post the big blind:
+// the first player with chips in the seats to the left of last hand's big
+// blind, which stays put even if its player has left. It returns -1 before
+// the first hand.
+func (g *Game) nextBigBlind() int {
+	g.syncSeats()
+	if g.lastBigBlindAt == 0 {
+		return -1
+	}
+	for i := 1; i <= len(g.seats); i++ {
+		s := g.seats[(g.lastBigBlindAt-1+i)%len(g.seats)]
+		if s.Empty() {
+			continue
+		}
+		if index := g.seatOf(s.Player.GetID()); index >= 0 {
+			return index
+		}
+	}
+	return -1
+}
+
+// seatOf returns the index of a player who is seated with chips, or -1.
+func (g *Game) seatOf(playerID string) int {
+	return slices.IndexFunc(g.Players, func(p types.Player) bool {
+		return p.GetID() == playerID && p.GetChips() > 0
+	})
+}
+
+// takeTurn asks a player for their action. Players with a strategy decide
+// within the hand's context, given the turn clock's deadline; one whose
+// strategy fails checks when nothing is owed and folds otherwise. Others
+// are asked through TakeTurn.
+func (g *Game) takeTurn(ctx context.Context, p types.Player, legal types.LegalActions) (action string, amount int) {
+	decider, ok := p.(types.Decider)
+	if !ok {
+		return p.TakeTurn(g.Table, g.Table.CurrentBet, g.minRaise)
+	}
+	if !g.Table.Deadline.IsZero() {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithDeadline(ctx, g.Table.Deadline)
+		defer cancel()
+	}
+	view := types.TurnView{
+		Table:      g.Table,
+		CurrentBet: g.Table.CurrentBet,
+		ToCall:     legal.ToCall,
+		MinRaise:   g.minRaise,
+		Chips:      p.GetChips(),
+		Legal:      legal,
+	}
+	decision, err := decider.Decide(ctx, view)
+	if err != nil {
+		action, amount = legal.Fit("check", 0)
+		fmt.Fprintf(g.Out, "%s could not decide (%v) and %ss.\n", p.GetID(), err, action)
+		return action, amount
+	}
+	return decision.Type, decision.Amount
+}
+
+// recordBlinds remembers who posted the blinds this hand and the big blind's
+// seat, before busted players leave, for the next hand's determineBlinds.
+func (g *Game) recordBlinds() {
+	g.lastBigBlind = g.Players[g.BigBlindPos].GetID()
+	g.lastBigBlindAt = g.SeatNumber(g.lastBigBlind)
+	g.lastSmallBlind = ""
+	if g.SmallBlindPos >= 0 {
+		g.lastSmallBlind = g.Players[g.SmallBlindPos].GetID()
+	}
+}
+
+// postBlinds forces the blind players to make their bets.
+func (g *Game) postBlinds() {
+	bbPlayer := g.Players[g.BigBlindPos]
+
+	g.postAntes()
+
+	if g.SmallBlindPos >= 0 {
+		sbPlayer := g.Players[g.SmallBlindPos]
+		sbAmount := g.forceBet(sbPlayer, g.SmallBlind)
+		g.display().LogAction(sbPlayer.GetID(), "posts small blind", sbAmount)
+		g.emit(Event{Type: EventBlindPosted, PlayerID: sbPlayer.GetID(), Action: "small blind", Amount: sbAmount})
+	}
+
+	bbAmount := g.forceBet(bbPlayer, g.BigBlind)
+	g.display().LogAction(bbPlayer.GetID(), "posts big blind", bbAmount)
+	g.emit(Event{Type: EventBlindPosted, PlayerID: bbPlayer.GetID(), Action: "big blind", Amount: bbAmount})
+	g.postMissedBlinds()
+
+	g.Table.CurrentBet = g.BigBlind // Initial bet to match is the Big Blind
+	g.checkInvariants("the blinds")
+}
+
+// postAntes collects the ante from every player. Antes go into the pot but do
+// not count towards a player's bet for the round.
+func (g *Game) postAntes() {
+	if g.Ante <= 0 {
+		return
+	}
+	for _, p := range g.Players {
+		if p.GetChips() == 0 {
+			continue
+		}
+		amount := p.Bet(g.Ante) // All-in when short of the ante
+		g.addToPot(p, amount)
+		g.display().LogAction(p.GetID(), "posts ante", amount)
+		g.emit(Event{Type: EventBlindPosted, PlayerID: p.GetID(), Action: "ante", Amount: amount})
+	}
+}
+
+// forceBet makes a player bet a specific amount, handling all-in cases.
+func (g *Game) forceBet(p types.Player, amount int) int {
+	betAmount := g.bet(p, amount)
+	if betAmount < amount {
+		fmt.Fprintf(g.Out, "%s is all-in for the blind.\n", p.GetID())
+	}
+	return betAmount
+}
+
+// bet moves up to amount chips from a player's stack into the pot as part of
+// their bet for the round. It returns the chips moved, which are fewer than
+// amount when the player goes all-in.
+func (g *Game) bet(p types.Player, amount int) int {
+	amount = p.Bet(max(amount, 0))
+	p.SetCurrentBet(p.GetCurrentBet() + amount)
+	g.addToPot(p, amount)
+	return amount
+}
+
+// addToPot moves chips a player has bet into the pot.
+func (g *Game) addToPot(p types.Player, amount int) {
+	g.Table.Pot += amount
+	g.PotManager.Add(p, amount)
+}
+
+// returnUncalledBet gives back the part of the last bet or raise that nobody
+// called, so it is never left in a pot only its bettor can win.
+func (g *Game) returnUncalledBet() {
+	p, amount := g.PotManager.ReturnUncalled(g.Players)
+	if amount == 0 {
+		return
+	}
+	g.Table.Pot -= amount
+	p.AddChips(amount)
+	p.SetCurrentBet(p.GetCurrentBet() - amount)
+	g.Table.CurrentBet = p.GetCurrentBet()
+	fmt.Fprintf(g.Out, "Uncalled bet of %d returned to %s.\n", amount, p.GetID())
+	g.checkInvariants("returning the uncalled bet")
+	g.emit(Event{Type: EventBetReturned, PlayerID: p.GetID(), Amount: amount})
+}
+
+// dealHands deals the initial private cards to each player.
+func (g *Game) dealHands(numCards int) {
+	fmt.Fprintln(g.Out, "Dealing hands...")
+	for i := 0; i < numCards; i++ {
+		for _, p := range g.Players {
+			if p.GetChips() > 0 { // Only deal to players with chips
+				card, err := g.Deck.Deal()
+				if err != nil {
+					fmt.Fprintf(g.Out, "Error dealing card: %v\n", err)
+					return // Or handle error more gracefully
+				}
+				p.GetHand().AddCard(card)
+			}
+		}
+	}
+	for _, p := range g.Players {
+		if len(p.GetHand().Cards) > 0 {
+			g.emit(Event{Type: EventHoleCardsDealt, PlayerID: p.GetID(), Cards: p.GetHand().Cards})
+		}
+	}
+	// Show the human player their hand. With several humans, as at a shared
+	// screen, each is shown theirs on their turn instead
+	var humans []types.Player
+	for _, p := range g.Players {
+		if p.IsHuman() {
+			humans = append(humans, p)
+		}
+	}
+	if len(humans) == 1 {
+		fmt.Fprintf(g.Out, "Your hand (%s): %s\n", humans[0].GetID(), humans[0].GetHand())
+	}
+}
+
+// dealCommunityCards deals cards to the table (Flop, Turn, River).
+func (g *Game) dealCommunityCards(roundName string, numCards int) {
+	fmt.Fprintf(g.Out, "--- Dealing %s ---\n", roundName)
+	// Burn a card (optional, standard practice)
+	_, err := g.Deck.Burn()
+	if err != nil {
+		fmt.Fprintf(g.Out, "Error burning card: %v\n", err)
+		return
+	}
+
+	cards, err := g.Deck.DealMultiple(numCards)
+	if err != nil {
+		fmt.Fprintf(g.Out, "Error dealing %s cards: %v\n", roundName, err)
+		return
+	}
+	for _, card := range cards {
+		g.Table.AddCommunityCard(card)
+	}
+	g.Table.Round = roundName
+	g.PotManager.SetRound(roundName)
+	g.emit(Event{Type: EventStreetDealt, Cards: cards})
+	// Reset betting state for the new round
+	g.Table.CurrentBet = 0
+	for _, p := range g.Players {
+		p.ResetBet()
+	}
+}
+
+// runBettingRound manages the betting actions for a single round.
+// Returns true if the hand should continue, false if only one player remains or player exits.
+func (g *Game) runBettingRound(ctx context.Context, startPos int) bool {
+	numPlayers := len(g.Players)
+	lastRaiser := -1 // Index of the last player who raised
+	playersActed := 0
+	g.minRaise = g.BigBlind
+	g.actedSinceRaise = map[int]bool{}
+	numToAct := g.countPlayersAbleToAct() // All-in players never act again
+
+	if g.actionClosed() {
+		return len(g.getPlayersInHand()) > 1 // Nothing to bet on
+	}
+	g.aggressor = "" // The street's bettor shows first, not an earlier street's
+
+	// Determine the initial player to act
+	currentPlayerIndex := g.nextToAct(startPos)
+	if currentPlayerIndex < 0 {
+		return len(g.getPlayersInHand()) > 1
+	}
+
+	// The loop ends once everyone able to act has acted, or when the action
+	// returns to the last raiser.
+	for playersActed < numToAct {
+		if ctx.Err() != nil {
+			g.abandonHand()
+			return false
+		}
+		// Check if only one player is left in the hand (not just with chips)
+		if len(g.getPlayersInHand()) <= 1 {
+			return false // Hand ends, pot awarded uncontested later
+		}
+
+		currentPlayer := g.Players[currentPlayerIndex]
+
+		// Once the action is back to the last raiser everyone else has
+		// answered the raise, even if the raiser went all-in with it
+		if lastRaiser == currentPlayerIndex {
+			break // Betting round is over
+		}
+
+		// Skip folded and all-in players, they have no decisions left this
+		// hand and do not count towards numToAct
+		if currentPlayer.IsFolded() || currentPlayer.IsAllIn() {
+			currentPlayerIndex = (currentPlayerIndex + 1) % numPlayers
+			continue
+		}
+
+		// Get player action. The turn clock starts once a bot has thought, but
+		// the deadline is known and published as the action comes to them.
+		think, clock := g.thinkTime(currentPlayer), g.turnClock(currentPlayer)
+		g.Table.Acting = currentPlayer.GetID()
+		g.Table.Deadline = time.Time{}
+		if clock > 0 {
+			g.Table.Deadline = time.Now().Add(think + clock)
+		}
+		g.emit(Event{Type: EventActionOn, PlayerID: currentPlayer.GetID(), Amount: g.Table.CurrentBet - currentPlayer.GetCurrentBet()})
+		g.waitWithLoader(ctx, think)
+		g.Table.PlayersInHand = len(g.getPlayersInHand())
+		g.Table.Position = (currentPlayerIndex - g.DealerPos + numPlayers) % numPlayers
+		g.Table.Seats = numPlayers
+		asked := time.Now()
+		if clocked, ok := currentPlayer.(types.Clocked); ok && g.TurnClock > 0 {
+			clocked.SetTurnDeadline(g.Table.Deadline)
+		}
+		legal, _ := g.LegalActions(currentPlayer.GetID())
+		g.Table.Legal = &legal
+		action, amount := g.takeTurn(ctx, currentPlayer, legal)
+		g.Table.Legal = nil
+		g.decisionTime = time.Since(asked)
+		if ctx.Err() != nil {
+			g.abandonHand() // Stopped while the player was deciding
+			return false
+		}
+		if clock > 0 && g.decisionTime > clock {
+			action, amount = "fold", 0
+			if g.Table.CurrentBet == currentPlayer.GetCurrentBet() {
+				action = "check"
+			}
+			fmt.Fprintf(g.Out, "%s ran out of time and %ss.\n", currentPlayer.GetID(), action)
+		}
+
+		// A player who already acted and only faces an all-in raise below the
+		// minimum may call or fold, but the action is not reopened for them.
+		if action == "raise" && g.actedSinceRaise[currentPlayerIndex] {
+			fmt.Fprintf(g.Out, "%s cannot re-raise, the short all-in did not reopen the action. Treating as call.\n", currentPlayer.GetID())
+			action = "call"
+		}
+
+		// Check for player exit
+		if action == "exit" {
+			fmt.Fprintf(g.Out, "\n%s has chosen to leave the table.\n", currentPlayer.GetID())
+			g.gameOver = true
+			return false // Signal game end
+		}
+
+		// Saving stops the game; the hand in progress is dealt again on resume
+		if action == "save" {
+			fmt.Fprintf(g.Out, "\n%s is saving the game. This hand will be replayed when the game resumes.\n", currentPlayer.GetID())
+			g.saveRequested = true
+			g.gameOver = true
+			return false
+		}
+
+		// Process action
+		// A raise is fitted to the legal ones like bots' and remote players':
+		// one below the minimum becomes the minimum raise, and one that is
+		// not allowed calls
+		if action == "raise" {
+			action, amount = legal.Fit(action, currentPlayer.GetCurrentBet()+amount)
+		}
+		betAmount := 0
+		switch action {
+		case "fold":
+			currentPlayer.SetFolded(true)
+			g.logAction(currentPlayer, "folds", 0)
+		case "check":
+			if g.Table.CurrentBet > currentPlayer.GetCurrentBet() {
+				// This should be caught by TakeTurn, but double-check
+				fmt.Fprintf(g.Out, "Error: %s cannot check, current bet is %d\n", currentPlayer.GetID(), g.Table.CurrentBet)
+				// Force fold for now, or re-prompt human
+				currentPlayer.SetFolded(true)
+				g.logAction(currentPlayer, "folds (error)", 0)
+			} else {
+				g.logAction(currentPlayer, "checks", 0)
+			}
+		case "call":
+			betAmount = g.bet(currentPlayer, legal.ToCall) // A call is always what is owed, all-in when short
+			g.logAction(currentPlayer, "calls", betAmount)
+		case "raise":
+			betAmount = amount // Amount to ADD to the pot
+			if betAmount > currentPlayer.GetChips() {
+				betAmount = currentPlayer.GetChips() // All-in raise
+			}
+			// Cap the raise according to the table's betting rules
+			if maxRaise := g.maxRaise(currentPlayer); maxRaise > 0 && currentPlayer.GetCurrentBet()+betAmount-g.Table.CurrentBet > maxRaise {
+				betAmount = g.Table.CurrentBet + maxRaise - currentPlayer.GetCurrentBet()
+				fmt.Fprintf(g.Out, "%s's raise is capped at %d by the table rules.\n", currentPlayer.GetID(), maxRaise)
+			}
+
+			totalPlayerBet := currentPlayer.GetCurrentBet() + betAmount
+			actualRaiseAmount := totalPlayerBet - g.Table.CurrentBet
+
+			// Validate raise amount (minimum raise, etc.) - Should be partially done in TakeTurn
+			if totalPlayerBet <= g.Table.CurrentBet {
+				fmt.Fprintf(g.Out, "Error: %s raise amount %d is not greater than current bet %d. Treating as call.\n", currentPlayer.GetID(), totalPlayerBet, g.Table.CurrentBet)
+				// Treat as call
+				betAmount = g.bet(currentPlayer, g.Table.CurrentBet-currentPlayer.GetCurrentBet())
+				action = "call"
+				g.logAction(currentPlayer, "calls (invalid raise)", betAmount)
+
+			} else {
+				// Valid raise, or an all-in for less than a full one
+				g.bet(currentPlayer, betAmount)
+				g.aggressor = currentPlayer.GetID()
+				g.Table.CurrentBet = totalPlayerBet  // Update the high bet
+				lastRaiser = currentPlayerIndex      // This player is the new last raiser
+				playersActed = 0                     // Reset count since the bet changed
+				numToAct = g.countPlayersAbleToAct() // Re-evaluate number of players to act
+				if actualRaiseAmount >= g.minRaise {
+					// A full raise sets the new minimum and reopens the action for everyone
+					g.minRaise = actualRaiseAmount
+					g.actedSinceRaise = map[int]bool{}
+				}
+				g.logAction(currentPlayer, fmt.Sprintf("raises to %d", totalPlayerBet), betAmount)
+			}
+		}
+
+		// Check if player went all-in
+		if currentPlayer.GetChips() == 0 && action != "fold" {
+			fmt.Fprintf(g.Out, "%s is all-in!\n", currentPlayer.GetID())
+		}
+
+		g.checkInvariants(fmt.Sprintf("%s's %s of %d", currentPlayer.GetID(), action, betAmount))
+		g.actedSinceRaise[currentPlayerIndex] = true
+
+		// Only increment playersActed if the player wasn't skipped and didn't raise
+		if action != "raise" {
+			playersActed++
+		}
+
+		currentPlayerIndex = (currentPlayerIndex + 1) % numPlayers
+
+		// Update UI after each action, marking who acts next if anyone does
+		g.Table.Acting, g.Table.Deadline = "", time.Time{}
+		if next := g.nextToAct(currentPlayerIndex); next >= 0 && next != lastRaiser &&
+			playersActed < numToAct && !g.actionClosed() && len(g.getPlayersInHand()) > 1 {
+			g.Table.Acting = g.Players[next].GetID()
+		}
+		g.showTable(g.Table.Round + " Betting")
+		g.waitWithLoader(ctx, g.GameSpeed/4) // Short pause after each action
+
+		// Stop prompting once everyone else is all-in and the bet is matched
+		if g.actionClosed() {
+			break
+		}
+	}
+
+	// End of betting round cleanup
+	g.returnUncalledBet()
+	g.Table.Acting, g.Table.Deadline = "", time.Time{}
+	g.minRaise = g.BigBlind
+	g.actedSinceRaise = nil
+	g.waitWithLoader(ctx, g.GameSpeed/2) // Short pause after betting round
+	fmt.Fprintln(g.Out, "Betting round finished.")
+	fmt.Fprintf(g.Out, "Pot: %d\n", g.Table.Pot)
+	if pots := g.PotManager.Pots(g.Players); len(pots) > 1 {
+		for i, pot := range pots {
+			fmt.Fprintf(g.Out, "  %s: %d\n", potName(i), pot.Amount)
+		}
+	}
+	// Return true if more than one player is still in the hand
+	return len(g.getPlayersInHand()) > 1
+}
+
+// nextToAct returns the index of the first player from index from onwards who
+// can still act, or -1 if nobody can.
+func (g *Game) nextToAct(from int) int {
+	for i := 0; i < len(g.Players); i++ {
+		idx := (from + i) % len(g.Players)
+		if p := g.Players[idx]; !p.IsFolded() && !p.IsAllIn() {
+			return idx
+		}
+	}
+	return -1
+}
+
+// maxRaise returns the most a player may raise on top of the current bet under
+// the table's betting rules, or 0 if raises are not capped.
+func (g *Game) maxRaise(p types.Player) int {
+	limit := g.Config.MaxRaise
+	if g.Config.PotLimit || g.Variant().PotLimit() {
+		// Pot-limit: the raise can be as large as the pot after calling
+		potAfterCall := g.Table.Pot + g.Table.CurrentBet - p.GetCurrentBet()
+		if limit == 0 || potAfterCall < limit {
+			limit = potAfterCall
+		}
+	}
+	return limit
+}
+
+// showdown determines the winner(s) among the remaining players.
+func (g *Game) showdown() {
+	fmt.Fprintln(g.Out, "--- Showdown ---")
+	remainingPlayers := g.getPlayersInHand()
+
+	if len(remainingPlayers) == 0 {
+		fmt.Fprintln(g.Out, "No players left for showdown?") // Should not happen
+		return
+	}
+
+	if len(remainingPlayers) == 1 {
+		g.awardPotUncontested()
+		return
+	}
+
+	fmt.Fprintf(g.Out, "Community Cards: %v\n", g.Table.CommunityCards)
+
+	// Evaluate every remaining hand against the board and find the best
+	// eligible hands for the main pot and each side pot
+	values := make(map[types.Player]HandValue)
+	for _, p := range remainingPlayers {
+		values[p] = g.evaluatePlayerHand(p)
+	}
+	pots := g.PotManager.Pots(g.Players)
+	potWinners := make([][]types.Player, len(pots))
+	winner := make(map[types.Player]bool)
+	for i, pot := range pots {
+		potWinners[i] = bestHands(pot.Eligible, values)
+		for _, p := range potWinners[i] {
+			winner[p] = true
+		}
+	}
+
+	// Show the hands the reveal policy turns up, the last aggressor's first;
+	// the others are mucked
+	policy := g.revealPolicy()
+	tabled := g.handsTabled()
+	g.Table.Shown = make(map[string]bool, len(remainingPlayers))
+	for _, p := range g.showdownOrder(remainingPlayers) {
+		if !tabled && !policy.Reveal(p, winner[p]) {
+			g.Table.Shown[p.GetID()] = false
+			fmt.Fprintf(g.Out, "%s mucks\n", p.GetID())
+			g.emit(Event{Type: EventHandMucked, PlayerID: p.GetID()})
+			continue
+		}
+		g.Table.Shown[p.GetID()] = true
+		fmt.Fprintln(g.Out, showdownLine(p.GetID(), values[p], p.GetHand().Cards))
+		g.emit(Event{Type: EventShowdown, PlayerID: p.GetID(), Action: values[p].Rank.String(), Cards: values[p].BestFive})
+	}
+	g.showTable("Showdown")
+
+	// Award the main pot and each side pot to the best eligible hand,
+	// splitting it when several hands tie
+	for i, pot := range pots {
+		winners := potWinners[i]
+		switch len(winners) {
+		case 0:
+			continue
+		case 1:
+			g.awardPot(winners[0], pot.Amount, potName(i))
+		default:
+			g.splitPot(winners, pot.Amount, potName(i))
+		}
+	}
+	g.Table.Pot = 0
+}
+
+// showdownOrder returns the players in the order they show: the last
+// player to bet or raise first, or the first player left of the button when
+// the last street was checked through, then clockwise.
+func (g *Game) showdownOrder(players []types.Player) []types.Player {
+	start := (g.DealerPos + 1) % len(g.Players)
+	for i, p := range g.Players {
+		if p.GetID() == g.aggressor && !p.IsFolded() {
+			start = i
+		}
+	}
+	order := make([]types.Player, 0, len(players))
+	for i := range g.Players {
+		p := g.Players[(start+i)%len(g.Players)]
+		if slices.Contains(players, p) {
+			order = append(order, p)
+		}
+	}
+	return order
+}
+
+// showdownLine names a player's best hand and shows which of its cards are
+// the player's own and which come from the board.
+func showdownLine(id string, value HandValue, hole []types.Card) string {
+	// Cards making the hand come first, then the kickers
+	best := slices.Clone(value.BestFive)
+	order := func(c types.Card) int {
+		if i := slices.Index(value.Kickers, c.Rank); i >= 0 {
+			return i
+		}
+		return len(value.Kickers)
+	}
+	sort.SliceStable(best, func(i, j int) bool { return order(best[i]) < order(best[j]) })
+	var own, board []string
+	for _, c := range best {
+		if slices.Contains(hole, c) {
+			own = append(own, c.String())
+		} else {
+			board = append(board, c.String())
+		}
+	}
+	switch {
+	case len(own) == 0:
+		return fmt.Sprintf("%s has %s, playing the board: %s", id, value.Describe(), strings.Join(board, " "))
+	case len(board) == 0:
+		return fmt.Sprintf("%s has %s: %s", id, value.Describe(), strings.Join(own, " "))
+	}
+	return fmt.Sprintf("%s has %s: %s with %s from the board", id, value.Describe(), strings.Join(own, " "), strings.Join(board, " "))
+}
+
+// evaluatePlayerHand scores a player's hole cards together with the community cards.
+func (g *Game) evaluatePlayerHand(p types.Player) HandValue {
+	return g.Variant().Evaluate(p.GetHand().Cards, g.Table.CommunityCards)
+}
+
+// Variant returns the variant named by the config, Texas Hold'em when the
+// name is empty or not registered.
+func (g *Game) Variant() Variant {
+	if v, ok := LookupVariant(g.Config.Variant); ok {
+		return v
+	}
+	v, _ := LookupVariant(DefaultVariant)
+	return v
+}
+
+// bestHands returns the players with the strongest hand among the
+// candidates, more than one when hands tie, in the candidates' order.
+func bestHands(candidates []types.Player, values map[types.Player]HandValue) []types.Player {
+	var winners []types.Player
+	for _, p := range candidates {
+		switch {
+		case len(winners) == 0:
+			winners = []types.Player{p}
+		case CompareHands(values[p], values[winners[0]]) > 0:
+			winners = []types.Player{p}
+		case CompareHands(values[p], values[winners[0]]) == 0:
+			winners = append(winners, p)
+		}
+	}
+	return winners
+}
+
+// potName returns a display name for the pot at the given index.
+func potName(index int) string {
+	if index == 0 {
+		return "main pot"
+	}
+	return fmt.Sprintf("side pot %d", index)
+}
+
+// awardPot gives a single pot to its winner.
+func (g *Game) awardPot(winner types.Player, amount int, name string) {
+	fmt.Fprintf(g.Out, "%s wins the %s of %d chips!\n", winner.GetID(), name, amount)
+	winner.AddChips(amount)
+	g.emit(Event{Type: EventPotAwarded, PlayerID: winner.GetID(), Action: name, Amount: amount})
+}
+
+// splitPot shares a pot equally among tied winners. Chips that do not divide
+// evenly go to the first winner to the left of the dealer button.
+func (g *Game) splitPot(winners []types.Player, amount int, name string) {
+	ordered := append([]types.Player(nil), winners...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return g.seatsLeftOfDealer(ordered[i]) < g.seatsLeftOfDealer(ordered[j])
+	})
+	ids := make([]string, len(ordered))
+	for i, p := range ordered {
+		ids[i] = p.GetID()
+	}
+	fmt.Fprintf(g.Out, "%s split the %s of %d chips.\n", strings.Join(ids, " and "), name, amount)
+
+	share := amount / len(ordered)
+	for i, p := range ordered {
+		chips := share
+		if i == 0 {
+			chips += amount % len(ordered) // The odd chips
+		}
+		g.awardPot(p, chips, name)
+	}
+}
+
+// seatsLeftOfDealer counts the seats from the dealer button to the player,
+// 1 for the player directly to the left and len(Players) for the dealer.
+func (g *Game) seatsLeftOfDealer(p types.Player) int {
+	n := len(g.Players)
+	for i, seated := range g.Players {
+		if seated == p {
+			return (i-g.DealerPos+n-1)%n + 1
+		}
+	}
+	return n + 1
+}
+
+// awardPotUncontested gives the pot to the last remaining player.
+func (g *Game) awardPotUncontested() {
+	remaining := g.getPlayersInHand()
+	if len(remaining) == 1 {
+		g.returnUncalledBet()
+		winner := remaining[0]
+		fmt.Fprintf(g.Out, "%s wins the pot of %d chips uncontested!\n", winner.GetID(), g.Table.Pot)
+		winner.AddChips(g.Table.Pot)
+		g.emit(Event{Type: EventPotAwarded, PlayerID: winner.GetID(), Action: "pot", Amount: g.Table.Pot})
+		g.Table.Pot = 0
+	} else {
+		fmt.Fprintln(g.Out, "Error: Tried to award pot uncontested with multiple players remaining.")
+	}
+}
+
+// turnClock returns how long a player has to act: the turn clock and what is
+// left of their time bank, see types.TimeBanked, or 0 without a clock.
+func (g *Game) turnClock(p types.Player) time.Duration {
+	if g.TurnClock <= 0 {
+		return 0
+	}
+	if banked, ok := p.(types.TimeBanked); ok {
+		return g.TurnClock + banked.TimeBank()
+	}
+	return g.TurnClock
+}
+
+// thinkTime returns how long to wait for a player's simulated thinking before
+// they act. Nothing is waited for at instant game speed.
+func (g *Game) thinkTime(p types.Player) time.Duration {
+	if g.GameSpeed <= 0 {
+		return 0
+	}
+	thinker, ok := p.(types.Thinker)
+	if !ok {
+		return 0
+	}
+	think := thinker.ThinkTime()
+	if g.TurnClock > 0 {
+		think = min(think, g.TurnClock) // Bots keep to the clock too
+	}
+	return think
+}
+
+// waitWithLoader pauses execution for a duration and shows a simple loader.
+// It returns early, with the loader cleared, if ctx is cancelled.
+func (g *Game) waitWithLoader(ctx context.Context, duration time.Duration) {
+	if duration <= 0 {
+		return // No delay for instant speed
+	}
+	loaderChars := []string{".   ", "..  ", "... ", "...."}
+	startTime := time.Now()
+	charIndex := 0
+	tick := time.NewTicker(200 * time.Millisecond) // Update loader every 200ms
+	defer tick.Stop()
+	for time.Since(startTime) < duration && ctx.Err() == nil {
+		// Print loader character and carriage return to overwrite
+		fmt.Fprintf(g.Out, "\r%s", loaderChars[charIndex%len(loaderChars)])
+		charIndex++
+		select {
+		case <-tick.C:
+		case <-ctx.Done():
+		}
+	}
+	// Clear the loader line
+	fmt.Fprintf(g.Out, "\r%s\r", strings.Repeat(" ", len(loaderChars[0])))
+}
@@ -0,0 +1,70 @@
+package game_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"pokerclientv1/internal/player"
+	"pokerclientv1/pkg/game"
+	"pokerclientv1/pkg/types"
+)
+
+// newBotEngine seats bots at an instant-speed engine with no UI or text output.
+// The bots keep their normal turn delay, which instant speed must skip.
+func newBotEngine(numBots int, difficulty string) *game.Engine {
+	players := make([]types.Player, numBots)
+	for i := range players {
+		players[i] = player.NewBotPlayer(fmt.Sprintf("Bot %d", i+1), 1000, difficulty, 500*time.Millisecond)
+	}
+	return game.NewEngine(game.Config{Players: players})
+}
+
+// benchmarkBotHands plays bot-only hands, starting a fresh table whenever the
+// game ends, and reports hands per second.
+func benchmarkBotHands(b *testing.B, numBots int, difficulty string) {
+	engine := newBotEngine(numBots, difficulty)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if !engine.PlayHand() {
+			b.StopTimer()
+			engine = newBotEngine(numBots, difficulty)
+			b.StartTimer()
+		}
+	}
+	b.ReportMetric(float64(b.N)/b.Elapsed().Seconds(), "hands/s")
+}
+
+func BenchmarkPlayHandHeadsUp(b *testing.B) { benchmarkBotHands(b, 2, "medium") }
+func BenchmarkPlayHandSixMax(b *testing.B)  { benchmarkBotHands(b, 6, "medium") }
+func BenchmarkPlayHandSixMaxHard(b *testing.B) {
+	benchmarkBotHands(b, 6, "hard")
+}
+
+// BenchmarkPlayHandWithEvents measures the overhead of an event subscriber.
+func BenchmarkPlayHandWithEvents(b *testing.B) {
+	engine := newBotEngine(6, "medium")
+	events := 0
+	engine.OnEvent(func(e game.Event) { events++ })
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if !engine.PlayHand() {
+			b.StopTimer()
+			engine = newBotEngine(6, "medium")
+			engine.OnEvent(func(e game.Event) { events++ })
+			b.StartTimer()
+		}
+	}
+	b.ReportMetric(float64(events)/float64(b.N), "events/hand")
+}
+
+// TestInstantSpeedSkipsThinkTime checks that bots do not sleep at instant speed.
+func TestInstantSpeedSkipsThinkTime(t *testing.T) {
+	engine := newBotEngine(4, "easy")
+	start := time.Now()
+	for i := 0; i < 5 && engine.PlayHand(); i++ {
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("5 instant-speed hands took %v, bots should not wait for their turn delay", elapsed)
+	}
+}
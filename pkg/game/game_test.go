@@ -0,0 +1,748 @@
+package game
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"pokerclientv1/pkg/types"
+	"strings"
+	"testing"
+	"time"
+)
+
+// --- Mock Implementations ---
+
+// MockPlayer implements the types.Player interface for testing.
+type MockPlayer struct {
+	types.BasePlayer
+	IsHumanVal bool
+	// ActionQueue allows predefining actions for TakeTurn
+	ActionQueue []struct {
+		Action string
+		Amount int
+	}
+	TurnCount int
+}
+
+func NewMockPlayer(id string, chips int, isHuman bool) *MockPlayer {
+	return &MockPlayer{BasePlayer: types.NewBasePlayer(id, chips), IsHumanVal: isHuman}
+}
+
+func (mp *MockPlayer) IsHuman() bool { return mp.IsHumanVal }
+
+func (mp *MockPlayer) ResetForNewHand() {
+	mp.BasePlayer.ResetForNewHand()
+	mp.TurnCount = 0 // Reset turn count for action queue
+}
+
+// TakeTurn returns the next action from the queue.
+func (mp *MockPlayer) TakeTurn(table *types.Table, currentBet int, minRaise int) (action string, amount int) {
+	if mp.TurnCount >= len(mp.ActionQueue) {
+		// Default action if queue is empty (e.g., fold)
+		fmt.Printf("Warning: MockPlayer %s ran out of actions, defaulting to fold\n", mp.ID)
+		return "fold", 0
+	}
+	a := mp.ActionQueue[mp.TurnCount]
+	mp.TurnCount++
+	return a.Action, a.Amount
+}
+
+// MockUI implements the types.GameUI interface for testing.
+type MockUI struct {
+	DisplayedStates []string // Store descriptions of displayed states
+	LoggedActions   []string // Store logged actions
+	Cleared         bool     // Track if ClearScreen was called
+}
+
+func (mu *MockUI) DisplayGameState(table *types.Table, players []types.Player, pot int, stage string) {
+	mu.DisplayedStates = append(mu.DisplayedStates, fmt.Sprintf("Stage: %s, Pot: %d", stage, pot))
+}
+func (mu *MockUI) LogAction(playerID string, action string, amount int) {
+	if amount > 0 {
+		mu.LoggedActions = append(mu.LoggedActions, fmt.Sprintf("%s %s (%d)", playerID, action, amount))
+	} else {
+		mu.LoggedActions = append(mu.LoggedActions, fmt.Sprintf("%s %s", playerID, action))
+	}
+}
+func (mu *MockUI) ClearScreen() {
+	mu.Cleared = true
+}
+
+// --- Test Functions ---
+
+// TestNewGame checks basic game initialization.
+func TestNewGame(t *testing.T) {
+	mockP1 := NewMockPlayer("P1", 100, true)
+	mockP2 := NewMockPlayer("P2", 100, false)
+	mockUI := &MockUI{}
+	gameSpeed := 0 * time.Millisecond // Instant for tests
+
+	game := NewGame([]types.Player{mockP1, mockP2}, mockUI, gameSpeed, DefaultGameConfig())
+
+	if game == nil {
+		t.Fatal("NewGame() returned nil")
+	}
+	if len(game.Players) != 2 {
+		t.Errorf("NewGame() created game with %d players, want 2", len(game.Players))
+	}
+	if game.Table.Pot != 0 {
+		t.Errorf("NewGame() initial pot is %d, want 0", game.Table.Pot)
+	}
+	if game.Table == nil {
+		t.Errorf("NewGame() did not initialize Table")
+	}
+	if game.Deck == nil || game.Deck.CardsLeft() != 52 {
+		t.Errorf("NewGame() did not initialize Deck correctly")
+	}
+	if game.UI != mockUI {
+		t.Errorf("NewGame() did not set UI correctly")
+	}
+	if game.GameSpeed != gameSpeed {
+		t.Errorf("NewGame() did not set GameSpeed correctly")
+	}
+}
+
+// TestDetermineBlinds checks blind positions for 2 and 3 players.
+func TestDetermineBlinds(t *testing.T) {
+	mockP1 := NewMockPlayer("P1", 100, true)
+	mockP2 := NewMockPlayer("P2", 100, false)
+	mockP3 := NewMockPlayer("P3", 100, false)
+	mockUI := &MockUI{}
+	gameSpeed := 0 * time.Millisecond
+
+	// Test 2 players (Heads-up)
+	game2p := NewGame([]types.Player{mockP1, mockP2}, mockUI, gameSpeed, DefaultGameConfig())
+	game2p.DealerPos = 0
+	game2p.determineBlinds()
+	if game2p.SmallBlindPos != 0 || game2p.BigBlindPos != 1 {
+		t.Errorf("determineBlinds() 2p, Dealer 0: SB=%d BB=%d, want SB=0 BB=1", game2p.SmallBlindPos, game2p.BigBlindPos)
+	}
+	game2p.DealerPos = 1
+	game2p.determineBlinds()
+	if game2p.SmallBlindPos != 1 || game2p.BigBlindPos != 0 {
+		t.Errorf("determineBlinds() 2p, Dealer 1: SB=%d BB=%d, want SB=1 BB=0", game2p.SmallBlindPos, game2p.BigBlindPos)
+	}
+
+	// Test 3 players
+	game3p := NewGame([]types.Player{mockP1, mockP2, mockP3}, mockUI, gameSpeed, DefaultGameConfig())
+	game3p.DealerPos = 0
+	game3p.determineBlinds()
+	if game3p.SmallBlindPos != 1 || game3p.BigBlindPos != 2 {
+		t.Errorf("determineBlinds() 3p, Dealer 0: SB=%d BB=%d, want SB=1 BB=2", game3p.SmallBlindPos, game3p.BigBlindPos)
+	}
+	game3p.DealerPos = 1
+	game3p.determineBlinds()
+	if game3p.SmallBlindPos != 2 || game3p.BigBlindPos != 0 {
+		t.Errorf("determineBlinds() 3p, Dealer 1: SB=%d BB=%d, want SB=2 BB=0", game3p.SmallBlindPos, game3p.BigBlindPos)
+	}
+	game3p.DealerPos = 2
+	game3p.determineBlinds()
+	if game3p.SmallBlindPos != 0 || game3p.BigBlindPos != 1 {
+		t.Errorf("determineBlinds() 3p, Dealer 2: SB=%d BB=%d, want SB=0 BB=1", game3p.SmallBlindPos, game3p.BigBlindPos)
+	}
+}
+
+// TestDeadButton checks that the big blind moves one seat each hand as
+// players bust, leaving a dead small blind or dead button where needed.
+func TestDeadButton(t *testing.T) {
+	players := []types.Player{
+		NewMockPlayer("P1", 100, false), NewMockPlayer("P2", 100, false),
+		NewMockPlayer("P3", 100, false), NewMockPlayer("P4", 100, false),
+		NewMockPlayer("P5", 100, false),
+	}
+	g := NewGame(players, &MockUI{}, 0, DefaultGameConfig())
+	g.Out = io.Discard
+	name := func(pos int) string {
+		if pos < 0 {
+			return "dead"
+		}
+		return g.Players[pos].GetID()
+	}
+	// bust plays out a hand and removes the given players afterwards
+	bust := func(ids ...string) {
+		g.recordBlinds()
+		for _, id := range ids {
+			g.Players[g.seatOf(id)].Bet(100)
+		}
+		g.removeBrokePlayers(context.Background())
+		g.determineBlinds()
+	}
+
+	g.determineBlinds()
+	if name(g.DealerPos) != "P1" || name(g.SmallBlindPos) != "P2" || name(g.BigBlindPos) != "P3" {
+		t.Fatalf("first hand: button %s, blinds %s/%s, want P1, P2/P3", name(g.DealerPos), name(g.SmallBlindPos), name(g.BigBlindPos))
+	}
+
+	// The big blind busts: P4 posts it, the small blind is dead and P2 has the button
+	bust("P3")
+	if name(g.DealerPos) != "P2" || g.deadButton || name(g.SmallBlindPos) != "dead" || name(g.BigBlindPos) != "P4" {
+		t.Errorf("after P3 busts: button %s, blinds %s/%s, want P2, dead/P4", name(g.DealerPos), name(g.SmallBlindPos), name(g.BigBlindPos))
+	}
+
+	// The button busts: it stays dead on P2's seat while P4 and P5 post
+	bust("P2")
+	if !g.deadButton || name(g.SmallBlindPos) != "P4" || name(g.BigBlindPos) != "P5" {
+		t.Errorf("after P2 busts: dead button %v, blinds %s/%s, want true, P4/P5", g.deadButton, name(g.SmallBlindPos), name(g.BigBlindPos))
+	}
+	if name((g.DealerPos+1)%len(g.Players)) != "P4" {
+		t.Errorf("after P2 busts: action after the button starts with %s, want P4", name((g.DealerPos+1)%len(g.Players)))
+	}
+
+	// Heads-up the button posts the small blind and the big blind keeps moving
+	bust("P4")
+	if name(g.DealerPos) != "P5" || name(g.SmallBlindPos) != "P5" || name(g.BigBlindPos) != "P1" {
+		t.Errorf("heads-up: button %s, blinds %s/%s, want P5, P5/P1", name(g.DealerPos), name(g.SmallBlindPos), name(g.BigBlindPos))
+	}
+	bust()
+	if name(g.SmallBlindPos) != "P1" || name(g.BigBlindPos) != "P5" {
+		t.Errorf("heads-up next hand: blinds %s/%s, want P1/P5", name(g.SmallBlindPos), name(g.BigBlindPos))
+	}
+}
+
+// TestDrawSeats checks that a seat draw starts the blinds afresh from the new
+// button instead of moving on from the last hand's big blind.
+func TestDrawSeats(t *testing.T) {
+	players := []types.Player{
+		NewMockPlayer("P1", 100, false), NewMockPlayer("P2", 100, false),
+		NewMockPlayer("P3", 100, false), NewMockPlayer("P4", 100, false),
+	}
+	g := NewGame(players, &MockUI{}, 0, DefaultGameConfig())
+	g.Out = io.Discard
+	g.determineBlinds()
+	g.recordBlinds()
+
+	g.DrawSeats(rand.New(rand.NewSource(3)))
+	if len(g.Players) != 4 {
+		t.Fatalf("DrawSeats() left %d players, want 4", len(g.Players))
+	}
+	button := g.DealerPos
+	g.determineBlinds()
+	if g.DealerPos != button || g.SmallBlindPos != (button+1)%4 || g.BigBlindPos != (button+2)%4 {
+		t.Errorf("after DrawSeats: button %d, blinds %d/%d, want %d, %d/%d", g.DealerPos, g.SmallBlindPos, g.BigBlindPos, button, (button+1)%4, (button+2)%4)
+	}
+}
+
+// TestSeats checks that busting players empties their seats without moving
+// the button off its player, and that a new player fills the first empty seat.
+func TestSeats(t *testing.T) {
+	players := []types.Player{
+		NewMockPlayer("P1", 100, false), NewMockPlayer("P2", 0, false),
+		NewMockPlayer("P3", 100, false), NewMockPlayer("P4", 0, false),
+	}
+	g := NewGame(players, &MockUI{}, 0, DefaultGameConfig())
+	g.Out = io.Discard
+	g.DealerPos = 2
+	g.removeBrokePlayers(context.Background())
+
+	if got := g.Players[g.DealerPos].GetID(); got != "P3" {
+		t.Errorf("button after P2 and P4 bust = %s, want P3", got)
+	}
+	if got := g.SeatNumber("P3"); got != 3 {
+		t.Errorf("SeatNumber(P3) = %d, want 3", got)
+	}
+	seats := g.Seats()
+	if len(seats) != 4 || !seats[1].Empty() || !seats[3].Empty() {
+		t.Fatalf("Seats() = %+v, want seats 2 and 4 empty", seats)
+	}
+
+	g.Seat(NewMockPlayer("P5", 100, false))
+	if got := g.SeatNumber("P5"); got != 2 {
+		t.Errorf("SeatNumber(P5) = %d, want 2", got)
+	}
+	if got := g.Players[1].GetID(); got != "P5" {
+		t.Errorf("Players[1] = %s, want P5 between P1 and P3", got)
+	}
+	if got := g.Players[g.DealerPos].GetID(); got != "P3" {
+		t.Errorf("button after P5 sits down = %s, want P3", got)
+	}
+}
+
+// TestPostBlinds checks if blinds are posted correctly, including all-in.
+func TestPostBlinds(t *testing.T) {
+	mockUI := &MockUI{}
+	gameSpeed := 0 * time.Millisecond
+
+	// Scenario 1: Both players have enough chips
+	mockP1 := NewMockPlayer("P1", 100, true)
+	mockP2 := NewMockPlayer("P2", 100, false)
+	game := NewGame([]types.Player{mockP1, mockP2}, mockUI, gameSpeed, DefaultGameConfig())
+	game.DealerPos = 0
+	game.determineBlinds() // SB=P1, BB=P2
+	game.postBlinds()
+
+	if mockP1.GetChips() != 100-DefaultSmallBlind || mockP1.GetCurrentBet() != DefaultSmallBlind {
+		t.Errorf("PostBlinds() P1 chips/bet incorrect. Got %d/%d, want %d/%d", mockP1.GetChips(), mockP1.GetCurrentBet(), 100-DefaultSmallBlind, DefaultSmallBlind)
+	}
+	if mockP2.GetChips() != 100-DefaultBigBlind || mockP2.GetCurrentBet() != DefaultBigBlind {
+		t.Errorf("PostBlinds() P2 chips/bet incorrect. Got %d/%d, want %d/%d", mockP2.GetChips(), mockP2.GetCurrentBet(), 100-DefaultBigBlind, DefaultBigBlind)
+	}
+	if game.Table.Pot != DefaultSmallBlind+DefaultBigBlind {
+		t.Errorf("PostBlinds() pot incorrect. Got %d, want %d", game.Table.Pot, DefaultSmallBlind+DefaultBigBlind)
+	}
+	if game.Table.CurrentBet != DefaultBigBlind {
+		t.Errorf("PostBlinds() table current bet incorrect. Got %d, want %d", game.Table.CurrentBet, DefaultBigBlind)
+	}
+
+	// Scenario 2: Small blind goes all-in
+	mockP1 = NewMockPlayer("P1", DefaultSmallBlind-1, true)
+	mockP2 = NewMockPlayer("P2", 100, false)
+	game = NewGame([]types.Player{mockP1, mockP2}, mockUI, gameSpeed, DefaultGameConfig())
+	game.DealerPos = 0
+	game.determineBlinds() // SB=P1, BB=P2
+	game.postBlinds()
+
+	if mockP1.GetChips() != 0 || mockP1.GetCurrentBet() != DefaultSmallBlind-1 {
+		t.Errorf("PostBlinds() All-in SB chips/bet incorrect. Got %d/%d, want %d/%d", mockP1.GetChips(), mockP1.GetCurrentBet(), 0, DefaultSmallBlind-1)
+	}
+	if mockP2.GetChips() != 100-DefaultBigBlind || mockP2.GetCurrentBet() != DefaultBigBlind {
+		t.Errorf("PostBlinds() All-in SB, BB chips/bet incorrect. Got %d/%d, want %d/%d", mockP2.GetChips(), mockP2.GetCurrentBet(), 100-DefaultBigBlind, DefaultBigBlind)
+	}
+	if game.Table.Pot != (DefaultSmallBlind-1)+DefaultBigBlind {
+		t.Errorf("PostBlinds() All-in SB pot incorrect. Got %d, want %d", game.Table.Pot, (DefaultSmallBlind-1)+DefaultBigBlind)
+	}
+	if game.Table.CurrentBet != DefaultBigBlind {
+		t.Errorf("PostBlinds() All-in SB, table current bet incorrect. Got %d, want %d", game.Table.CurrentBet, DefaultBigBlind)
+	}
+
+	// Scenario 3: Big blind goes all-in
+	mockP1 = NewMockPlayer("P1", 100, true)
+	mockP2 = NewMockPlayer("P2", DefaultBigBlind-1, false)
+	game = NewGame([]types.Player{mockP1, mockP2}, mockUI, gameSpeed, DefaultGameConfig())
+	game.DealerPos = 0
+	game.determineBlinds() // SB=P1, BB=P2
+	game.postBlinds()
+
+	if mockP1.GetChips() != 100-DefaultSmallBlind || mockP1.GetCurrentBet() != DefaultSmallBlind {
+		t.Errorf("PostBlinds() All-in BB, SB chips/bet incorrect. Got %d/%d, want %d/%d", mockP1.GetChips(), mockP1.GetCurrentBet(), 100-DefaultSmallBlind, DefaultSmallBlind)
+	}
+	if mockP2.GetChips() != 0 || mockP2.GetCurrentBet() != DefaultBigBlind-1 {
+		t.Errorf("PostBlinds() All-in BB chips/bet incorrect. Got %d/%d, want %d/%d", mockP2.GetChips(), mockP2.GetCurrentBet(), 0, DefaultBigBlind-1)
+	}
+	if game.Table.Pot != DefaultSmallBlind+(DefaultBigBlind-1) {
+		t.Errorf("PostBlinds() All-in BB pot incorrect. Got %d, want %d", game.Table.Pot, DefaultSmallBlind+(DefaultBigBlind-1))
+	}
+	// Current bet should still be the attempted Big Blind value, even if player couldn't meet it
+	if game.Table.CurrentBet != DefaultBigBlind {
+		t.Errorf("PostBlinds() All-in BB, table current bet incorrect. Got %d, want %d", game.Table.CurrentBet, DefaultBigBlind)
+	}
+}
+
+// TestDealHands checks if the correct number of cards are dealt.
+func TestDealHands(t *testing.T) {
+	mockP1 := NewMockPlayer("P1", 100, true)
+	mockP2 := NewMockPlayer("P2", 100, false)
+	mockP3 := NewMockPlayer("P3", 0, false) // Player with 0 chips
+	mockUI := &MockUI{}
+	gameSpeed := 0 * time.Millisecond
+	game := NewGame([]types.Player{mockP1, mockP2, mockP3}, mockUI, gameSpeed, DefaultGameConfig())
+	initialDeckSize := game.Deck.CardsLeft()
+	numCardsToDeal := 2
+
+	game.dealHands(numCardsToDeal)
+
+	if len(mockP1.GetHand().Cards) != numCardsToDeal {
+		t.Errorf("dealHands() P1 got %d cards, want %d", len(mockP1.GetHand().Cards), numCardsToDeal)
+	}
+	if len(mockP2.GetHand().Cards) != numCardsToDeal {
+		t.Errorf("dealHands() P2 got %d cards, want %d", len(mockP2.GetHand().Cards), numCardsToDeal)
+	}
+	if len(mockP3.GetHand().Cards) != 0 {
+		t.Errorf("dealHands() P3 (0 chips) got %d cards, want 0", len(mockP3.GetHand().Cards))
+	}
+
+	expectedDeckSize := initialDeckSize - (numCardsToDeal * 2) // Only P1 and P2 get cards
+	if game.Deck.CardsLeft() != expectedDeckSize {
+		t.Errorf("dealHands() deck size is %d, want %d", game.Deck.CardsLeft(), expectedDeckSize)
+	}
+}
+
+// TestDealCommunityCards checks dealing community cards (flop, turn, river).
+func TestDealCommunityCards(t *testing.T) {
+	mockP1 := NewMockPlayer("P1", 100, true)
+	mockUI := &MockUI{}
+	gameSpeed := 0 * time.Millisecond
+	game := NewGame([]types.Player{mockP1}, mockUI, gameSpeed, DefaultGameConfig())
+	initialDeckSize := game.Deck.CardsLeft()
+
+	// Flop
+	game.dealCommunityCards("Flop", 3)
+	if len(game.Table.CommunityCards) != 3 {
+		t.Errorf("dealCommunityCards() Flop dealt %d cards, want 3", len(game.Table.CommunityCards))
+	}
+	if game.Deck.CardsLeft() != initialDeckSize-(3+1) { // +1 for burn card
+		t.Errorf("dealCommunityCards() Flop deck size is %d, want %d", game.Deck.CardsLeft(), initialDeckSize-4)
+	}
+	if game.Table.Round != "Flop" {
+		t.Errorf("dealCommunityCards() Flop did not set table round correctly")
+	}
+
+	// Turn
+	initialDeckSize = game.Deck.CardsLeft()
+	game.dealCommunityCards("Turn", 1)
+	if len(game.Table.CommunityCards) != 3+1 {
+		t.Errorf("dealCommunityCards() Turn dealt %d total cards, want 4", len(game.Table.CommunityCards))
+	}
+	if game.Deck.CardsLeft() != initialDeckSize-(1+1) { // +1 for burn card
+		t.Errorf("dealCommunityCards() Turn deck size is %d, want %d", game.Deck.CardsLeft(), initialDeckSize-2)
+	}
+	if game.Table.Round != "Turn" {
+		t.Errorf("dealCommunityCards() Turn did not set table round correctly")
+	}
+
+	// River
+	initialDeckSize = game.Deck.CardsLeft()
+	game.dealCommunityCards("River", 1)
+	if len(game.Table.CommunityCards) != 3+1+1 {
+		t.Errorf("dealCommunityCards() River dealt %d total cards, want 5", len(game.Table.CommunityCards))
+	}
+	if game.Deck.CardsLeft() != initialDeckSize-(1+1) { // +1 for burn card
+		t.Errorf("dealCommunityCards() River deck size is %d, want %d", game.Deck.CardsLeft(), initialDeckSize-2)
+	}
+	if game.Table.Round != "River" {
+		t.Errorf("dealCommunityCards() River did not set table round correctly")
+	}
+	if burned := game.Deck.Burned(); len(burned) != 3 {
+		t.Errorf("Burned() = %v after the river, want one burn per street", burned)
+	}
+}
+
+// TestActionClosed checks detection of capped betting when players are all-in.
+func TestActionClosed(t *testing.T) {
+	mockUI := &MockUI{}
+	gameSpeed := 0 * time.Millisecond
+
+	// Scenario 1: Everyone still has chips, action is open
+	mockP1 := NewMockPlayer("P1", 100, true)
+	mockP2 := NewMockPlayer("P2", 100, false)
+	game := NewGame([]types.Player{mockP1, mockP2}, mockUI, gameSpeed, DefaultGameConfig())
+	if game.actionClosed() {
+		t.Errorf("actionClosed() with two players holding chips = true, want false")
+	}
+
+	// Scenario 2: One player all-in, the other has matched the bet
+	mockP1.Chips = 0
+	mockP1.CurrentBet = 50
+	mockP2.CurrentBet = 50
+	game.Table.CurrentBet = 50
+	if !game.actionClosed() {
+		t.Errorf("actionClosed() with one all-in and bet matched = false, want true")
+	}
+
+	// Scenario 3: One player all-in, the other still has to call
+	mockP2.CurrentBet = 0
+	if game.actionClosed() {
+		t.Errorf("actionClosed() with a call pending = true, want false")
+	}
+
+	// Scenario 4: Everyone all-in
+	mockP2.Chips = 0
+	if !game.actionClosed() {
+		t.Errorf("actionClosed() with everyone all-in = false, want true")
+	}
+}
+
+// TestRunBettingRoundSkipsAllInPlayers checks that all-in players are never asked to act.
+func TestRunBettingRoundSkipsAllInPlayers(t *testing.T) {
+	mockP1 := NewMockPlayer("P1", 0, true) // All-in from a previous street
+	mockP2 := NewMockPlayer("P2", 100, false)
+	mockP3 := NewMockPlayer("P3", 100, false)
+	mockP2.ActionQueue = []struct {
+		Action string
+		Amount int
+	}{{"check", 0}}
+	mockP3.ActionQueue = []struct {
+		Action string
+		Amount int
+	}{{"check", 0}}
+	mockUI := &MockUI{}
+	game := NewGame([]types.Player{mockP1, mockP2, mockP3}, mockUI, 0, DefaultGameConfig())
+	game.Table.Round = "Flop"
+
+	if !game.runBettingRound(context.Background(), 0) {
+		t.Fatalf("runBettingRound() = false, want true with three players in the hand")
+	}
+	if mockP1.TurnCount != 0 {
+		t.Errorf("runBettingRound() asked all-in player to act %d times, want 0", mockP1.TurnCount)
+	}
+	if mockP2.TurnCount != 1 || mockP3.TurnCount != 1 {
+		t.Errorf("runBettingRound() turns P2=%d P3=%d, want 1 each", mockP2.TurnCount, mockP3.TurnCount)
+	}
+}
+
+// TestRunBettingRoundClosedAction checks that no one is prompted once action is closed.
+func TestRunBettingRoundClosedAction(t *testing.T) {
+	mockP1 := NewMockPlayer("P1", 0, true)
+	mockP2 := NewMockPlayer("P2", 0, false)
+	mockUI := &MockUI{}
+	game := NewGame([]types.Player{mockP1, mockP2}, mockUI, 0, DefaultGameConfig())
+	game.Table.Round = "Turn"
+
+	if !game.runBettingRound(context.Background(), 0) {
+		t.Errorf("runBettingRound() = false, want true when both players are all-in")
+	}
+	if mockP1.TurnCount != 0 || mockP2.TurnCount != 0 {
+		t.Errorf("runBettingRound() prompted all-in players (P1=%d, P2=%d), want none", mockP1.TurnCount, mockP2.TurnCount)
+	}
+}
+
+// TestRaiseCappedByTableRules checks pot-limit and fixed maximum raise caps.
+func TestRaiseCappedByTableRules(t *testing.T) {
+	tests := []struct {
+		name      string
+		config    GameConfig
+		wantTotal int // Raiser's total bet after the capped raise
+	}{
+		// Pot is 30 with a 10 bet to call, so pot-limit allows a raise of 40 on top of 10
+		{"pot-limit", GameConfig{SmallBlind: 1, BigBlind: 2, PotLimit: true}, 50},
+		{"max raise", GameConfig{SmallBlind: 1, BigBlind: 2, MaxRaise: 20}, 30},
+		{"no limit", GameConfig{SmallBlind: 1, BigBlind: 2}, 100},
+	}
+	for _, tt := range tests {
+		raiser := NewMockPlayer("Raiser", 100, false)
+		caller := NewMockPlayer("Caller", 100, false)
+		raiser.ActionQueue = []struct {
+			Action string
+			Amount int
+		}{{"raise", 100}}
+		caller.ActionQueue = []struct {
+			Action string
+			Amount int
+		}{{"fold", 0}}
+		ui := &MockUI{}
+		game := NewGame([]types.Player{raiser, caller}, ui, 0, tt.config)
+		game.Table.Round = "Flop"
+		game.Table.CurrentBet = 10
+		game.Table.Pot = 30
+		caller.CurrentBet = 10
+
+		game.runBettingRound(context.Background(), 0)
+
+		// The uncalled part of the raise is returned once the caller folds, so
+		// check the raise as it was logged
+		want := fmt.Sprintf("Raiser raises to %d", tt.wantTotal)
+		if len(ui.LoggedActions) == 0 || !strings.HasPrefix(ui.LoggedActions[0], want) {
+			t.Errorf("%s: logged %v, want %q", tt.name, ui.LoggedActions, want)
+		}
+	}
+}
+
+// TestGameConfigValidate checks rejection of unplayable stakes.
+func TestGameConfigValidate(t *testing.T) {
+	if err := DefaultGameConfig().Validate(); err != nil {
+		t.Errorf("DefaultGameConfig().Validate() = %v, want nil", err)
+	}
+	invalid := []GameConfig{
+		{SmallBlind: 0, BigBlind: 2},
+		{SmallBlind: 5, BigBlind: 2},
+		{SmallBlind: 1, BigBlind: 2, Ante: -1},
+		{SmallBlind: 1, BigBlind: 10, MaxRaise: 5},
+		{SmallBlind: 1, BigBlind: 2, MinBuyInBB: 20, Rebuy: RebuyPolicy{UpTo: 20}},
+		{SmallBlind: 1, BigBlind: 2, MinBuyInBB: 50, MaxBuyInBB: 40},
+		{SmallBlind: 1, BigBlind: 2, MaxBuyInBB: 100, Rebuy: RebuyPolicy{UpTo: 300}},
+	}
+	for _, c := range invalid {
+		if err := c.Validate(); err == nil {
+			t.Errorf("Validate(%+v) = nil, want an error", c)
+		}
+	}
+	if err := DefaultGameConfig().CheckBuyIn(10); err == nil {
+		t.Errorf("CheckBuyIn(10) = nil, want an error below the 40 chip minimum")
+	}
+	if err := DefaultGameConfig().CheckBuyIn(1001); err == nil {
+		t.Errorf("CheckBuyIn(1001) = nil, want an error above the 1000 chip maximum")
+	}
+	if err := DefaultGameConfig().CheckBuyIn(1000); err != nil {
+		t.Errorf("CheckBuyIn(1000) = %v, want nil at the maximum", err)
+	}
+}
+
+// rebuyingPlayer asks for a fixed number of chips whenever it is offered some.
+type rebuyingPlayer struct {
+	*MockPlayer
+	want   int
+	offers []int
+}
+
+func (p *rebuyingPlayer) Rebuy(max int) int {
+	p.offers = append(p.offers, max)
+	return p.want
+}
+
+// TestOfferRebuys checks re-buys for broke players, add-ons for short stacks
+// and the per-player re-buy limit.
+func TestOfferRebuys(t *testing.T) {
+	bot := NewMockPlayer("Bot", 0, false)
+	human := &rebuyingPlayer{MockPlayer: NewMockPlayer("Human", 0, true), want: 80}
+	short := &rebuyingPlayer{MockPlayer: NewMockPlayer("Short", 30, false), want: 500}
+	config := DefaultGameConfig()
+	config.Rebuy = RebuyPolicy{UpTo: 100, Limit: 1, AddOn: true, Bots: true}
+	g := NewGame([]types.Player{bot, human, short}, nopUI{}, 0, config)
+	g.Out = io.Discard
+	events := []Event{}
+	g.OnEvent(func(e Event) { events = append(events, e) })
+
+	g.offerRebuys()
+	if bot.Chips != 100 || human.Chips != 80 || short.Chips != 100 {
+		t.Errorf("stacks after re-buys = %d, %d, %d, want 100, 80, 100", bot.Chips, human.Chips, short.Chips)
+	}
+	if len(human.offers) != 1 || human.offers[0] != 100 || short.offers[0] != 70 {
+		t.Errorf("offers = %v and %v, want [100] and [70]", human.offers, short.offers)
+	}
+	if len(events) != 3 || events[2].Type != EventRebuy || events[2].Action != "add-on" || events[2].Amount != 70 {
+		t.Errorf("events = %+v, want two re-buys and an add-on of 70", events)
+	}
+
+	bot.Chips, human.Chips = 0, 0
+	g.offerRebuys()
+	if bot.Chips != 0 || human.Chips != 0 || g.Rebuys("Bot") != 1 {
+		t.Errorf("second re-buys gave %d and %d chips with %d re-buys used, want none past the limit of 1", bot.Chips, human.Chips, g.Rebuys("Bot"))
+	}
+}
+
+// TestOfferRebuysWithinBuyInRange checks that re-buys stay inside the table's
+// buy-in range at the current big blind.
+func TestOfferRebuysWithinBuyInRange(t *testing.T) {
+	bot := NewMockPlayer("Bot", 0, false)
+	human := &rebuyingPlayer{MockPlayer: NewMockPlayer("Human", 0, true), want: 10}
+	config := DefaultGameConfig()
+	config.MaxBuyInBB = 100
+	config.Rebuy = RebuyPolicy{UpTo: 200, Bots: true}
+	g := NewGame([]types.Player{bot, human}, nopUI{}, 0, config)
+	g.Out = io.Discard
+	g.BigBlind = 1 // Blinds went down, so the 200 chip re-buy is now too much
+
+	g.offerRebuys()
+	if bot.Chips != 100 {
+		t.Errorf("bot re-bought to %d, want the 100 chip maximum at a 1 chip big blind", bot.Chips)
+	}
+	if human.Chips != 0 || g.Rebuys("Human") != 0 {
+		t.Errorf("human re-bought %d chips below the 20 chip minimum, want it rejected", human.Chips)
+	}
+}
+
+// interruptingPlayer runs interrupt the first time it is asked to act, as a
+// Ctrl+C pressed while it decides would.
+type interruptingPlayer struct {
+	*MockPlayer
+	interrupt func()
+}
+
+func (p *interruptingPlayer) TakeTurn(table *types.Table, currentBet int, minRaise int) (string, int) {
+	if p.interrupt != nil {
+		p.interrupt()
+		p.interrupt = nil
+	}
+	return p.MockPlayer.TakeTurn(table, currentBet, minRaise)
+}
+
+// TestStartCancelledMidHand checks that cancelling the context abandons the
+// hand with every stack back where it started and ends the game.
+func TestStartCancelledMidHand(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	p1 := &interruptingPlayer{MockPlayer: NewMockPlayer("P1", 100, true), interrupt: cancel}
+	p1.ActionQueue = append(p1.ActionQueue, struct {
+		Action string
+		Amount int
+	}{"raise", 20})
+	p2 := NewMockPlayer("P2", 100, false)
+	g := NewGame([]types.Player{p1, p2}, &MockUI{}, 0, DefaultGameConfig())
+	g.Out = io.Discard
+	events := []EventType{}
+	g.OnEvent(func(e Event) { events = append(events, e.Type) })
+
+	g.Start(ctx)
+
+	if p1.Chips != 100 || p2.Chips != 100 {
+		t.Errorf("stacks after cancelling = %d, %d, want 100 each", p1.Chips, p2.Chips)
+	}
+	for _, e := range events {
+		if e == EventHandEnded || e == EventPlayerAction {
+			t.Errorf("events = %v, want the hand abandoned before any action", events)
+			break
+		}
+	}
+	if len(events) < 2 || events[len(events)-2] != EventHandAbandoned || events[len(events)-1] != EventGameOver {
+		t.Errorf("events = %v, want hand_abandoned then game_over last", events)
+	}
+}
+
+// TestStopFinishesHand checks that Stop lets the hand in progress finish.
+func TestStopFinishesHand(t *testing.T) {
+	p2 := NewMockPlayer("P2", 100, false)
+	g := NewGame(nil, &MockUI{}, 0, DefaultGameConfig())
+	p1 := &interruptingPlayer{MockPlayer: NewMockPlayer("P1", 100, true), interrupt: g.Stop}
+	g.Players = []types.Player{p1, p2}
+	g.Out = io.Discard
+	ended := 0
+	g.OnEvent(func(e Event) {
+		if e.Type == EventHandEnded {
+			ended++
+		}
+	})
+
+	g.Start(context.Background())
+
+	if ended != 1 || g.HandNumber != 1 {
+		t.Errorf("played %d hands with %d ended, want the one hand in progress finished", g.HandNumber, ended)
+	}
+	if p1.Chips+p2.Chips != 200 || p2.Chips == 100 {
+		t.Errorf("stacks = %d, %d, want the finished hand paid out", p1.Chips, p2.Chips)
+	}
+}
+
+// slowPlayer raises every time, but only after sleeping past the turn clock.
+type slowPlayer struct {
+	*MockPlayer
+	deadline time.Time
+}
+
+func (p *slowPlayer) SetTurnDeadline(deadline time.Time) { p.deadline = deadline }
+
+func (p *slowPlayer) TakeTurn(table *types.Table, currentBet int, minRaise int) (string, int) {
+	time.Sleep(20 * time.Millisecond)
+	return "raise", currentBet - p.CurrentBet + minRaise
+}
+
+// TestTurnClock checks that a player who answers after the clock runs out
+// checks or folds instead, and is told the deadline first.
+func TestTurnClock(t *testing.T) {
+	slow := &slowPlayer{MockPlayer: NewMockPlayer("Slow", 100, true)}
+	other := NewMockPlayer("Other", 100, false)
+	for i := 0; i < 4; i++ {
+		other.ActionQueue = append(other.ActionQueue, struct {
+			Action string
+			Amount int
+		}{"check", 0})
+	}
+	g := NewGame([]types.Player{slow, other}, &MockUI{}, 0, DefaultGameConfig())
+	g.Out = io.Discard
+	g.TurnClock = 5 * time.Millisecond
+	actions := []string{}
+	g.OnEvent(func(e Event) {
+		if e.Type == EventPlayerAction && e.PlayerID == "Slow" {
+			actions = append(actions, e.Action)
+		}
+	})
+
+	g.PlayNextHand()
+	if slow.deadline.IsZero() {
+		t.Errorf("SetTurnDeadline was not called")
+	}
+	if len(actions) == 0 || actions[0] != "folds" && actions[0] != "checks" {
+		t.Errorf("slow player's actions = %v, want a check or fold for running out of time", actions)
+	}
+	for _, a := range actions {
+		if strings.HasPrefix(a, "raise") {
+			t.Errorf("slow player's late %q was accepted", a)
+		}
+	}
+}
+
+// TODO: Add tests for runBettingRound (complex scenarios)
+// TODO: Add tests for awardPot, awardPotUncontested
+// TODO: Add tests for removeBrokePlayers
+// TODO: Add tests for checkGameOver
+// TODO: Add tests for playHand (integration)
+// TODO: Add tests for Start (integration)
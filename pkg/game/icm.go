@@ -0,0 +1,34 @@
+package game
+
+// ICM returns what each stack is worth in prize money under the Independent
+// Chip Model: a player finishes first with the share of the chips they hold,
+// and each place after that goes the same way among the players left. prizes
+// are the payouts from first place down. The work grows with the number of
+// stacks to the power of the number of prizes, so it suits the few paid
+// places of a sit-and-go or a final table.
+func ICM(stacks []int, prizes []int) []float64 {
+	equity := make([]float64, len(stacks))
+	total := 0
+	for _, s := range stacks {
+		total += s
+	}
+	placed := make([]bool, len(stacks))
+	var place func(n int, chance float64, chipsLeft int)
+	place = func(n int, chance float64, chipsLeft int) {
+		if n == len(prizes) || chipsLeft <= 0 {
+			return
+		}
+		for i, s := range stacks {
+			if placed[i] || s <= 0 {
+				continue
+			}
+			p := chance * float64(s) / float64(chipsLeft)
+			equity[i] += p * float64(prizes[n])
+			placed[i] = true
+			place(n+1, p, chipsLeft-s)
+			placed[i] = false
+		}
+	}
+	place(0, 1, total)
+	return equity
+}
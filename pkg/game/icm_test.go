@@ -0,0 +1,30 @@
+package game
+
+import (
+	"math"
+	"testing"
+)
+
+// TestICM checks the Independent Chip Model against values worked out by hand.
+func TestICM(t *testing.T) {
+	tests := []struct {
+		name   string
+		stacks []int
+		prizes []int
+		want   []float64
+	}{
+		{"heads-up", []int{75, 25}, []int{70, 30}, []float64{60, 40}},
+		{"equal stacks", []int{100, 100, 100}, []int{50, 30, 20}, []float64{100.0 / 3, 100.0 / 3, 100.0 / 3}},
+		{"three left, two paid", []int{600, 300, 300}, []int{840, 360}, []float64{540, 330, 330}},
+		{"busted stack", []int{100, 0, 100}, []int{50, 30, 20}, []float64{40, 0, 40}},
+	}
+	for _, tt := range tests {
+		got := ICM(tt.stacks, tt.prizes)
+		for i := range tt.want {
+			if math.Abs(got[i]-tt.want[i]) > 1e-9 {
+				t.Errorf("%s: ICM(%v, %v) = %v, want %v", tt.name, tt.stacks, tt.prizes, got, tt.want)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+)
+
+// InvariantMode says how closely a game watches its chips. However it is
+// set, the game checks after every hand that no chips were created or lost;
+// see Game.ChipError. The debug modes also check after every blind and
+// action, and dump the table when a check fails. It implements flag.Value
+// as a boolean flag, so --check-invariants alone panics and
+// --check-invariants=log logs.
+type InvariantMode string
+
+const (
+	InvariantsOff   InvariantMode = ""      // Check after each hand, reporting a violation on Out
+	InvariantsLog   InvariantMode = "log"   // Also check after each action, dumping the table to Out on a violation
+	InvariantsPanic InvariantMode = "panic" // Also check after each action, panicking with a dump of the table on a violation
+)
+
+func (m *InvariantMode) String() string {
+	if m == nil {
+		return ""
+	}
+	return string(*m)
+}
+
+// Set reads the mode from a flag: log, panic, or a boolean where true
+// means panic.
+func (m *InvariantMode) Set(s string) error {
+	switch strings.ToLower(s) {
+	case "", "false", "off":
+		*m = InvariantsOff
+	case "true", "panic":
+		*m = InvariantsPanic
+	case "log":
+		*m = InvariantsLog
+	default:
+		return fmt.Errorf("invariant check %q is not log or panic", s)
+	}
+	return nil
+}
+
+// IsBoolFlag lets the flag be given without a value.
+func (m *InvariantMode) IsBoolFlag() bool { return true }
+
+// checkChips reports an error if the chips in the players' stacks and the
+// pot no longer add up to the stacks the hand in progress started with, or
+// if the pot and the contributions building the side pots disagree while
+// there is a pot.
+func (g *Game) checkChips() error {
+	if g.handStartChips == nil {
+		return nil
+	}
+	want, got := 0, g.Table.Pot
+	for _, chips := range g.handStartChips {
+		want += chips
+	}
+	for _, p := range g.Players {
+		if p.GetChips() < 0 || p.GetCurrentBet() < 0 {
+			return fmt.Errorf("hand %d: %s has %d chips and a bet of %d", g.HandNumber, p.GetID(), p.GetChips(), p.GetCurrentBet())
+		}
+		got += p.GetChips()
+	}
+	if got != want {
+		return fmt.Errorf("hand %d: %d chips in stacks and the pot, the hand started with %d", g.HandNumber, got, want)
+	}
+	// Contributions are kept after the pot is paid out, until the next hand
+	if total := g.PotManager.Total(); g.Table.Pot > 0 && total != g.Table.Pot {
+		return fmt.Errorf("hand %d: the pot has %d chips, contributions to it add up to %d", g.HandNumber, g.Table.Pot, total)
+	}
+	return nil
+}
+
+// ChipError returns why the chips of the last hand played did not add up,
+// or nil if every chip was accounted for. The engine checks after each hand
+// that chips were neither created nor lost.
+func (g *Game) ChipError() error {
+	return g.chipErr
+}
+
+// checkInvariants checks the chips after a blind or an action when the game
+// runs in one of the debug modes. what says what was just done, for the dump.
+func (g *Game) checkInvariants(what string) {
+	if g.CheckInvariants == InvariantsOff {
+		return
+	}
+	if err := g.checkChips(); err != nil {
+		g.invariantViolated(what, err)
+	}
+}
+
+// invariantViolated reports a failed chip check as the game's mode says:
+// one line normally, or a dump of the table in the debug modes.
+func (g *Game) invariantViolated(what string, err error) {
+	switch g.CheckInvariants {
+	case InvariantsOff:
+		fmt.Fprintf(g.Out, "Error: %v\n", err)
+	case InvariantsLog:
+		fmt.Fprint(g.Out, g.chipDump(what, err))
+	default:
+		panic(g.chipDump(what, err))
+	}
+}
+
+// chipDump describes the chips at the table when a check fails.
+func (g *Game) chipDump(what string, err error) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Chip invariant violated after %s: %v\n", what, err)
+	fmt.Fprintf(&b, "  round %q, pot %d, contributions %d, current bet %d\n", g.Table.Round, g.Table.Pot, g.PotManager.Total(), g.Table.CurrentBet)
+	for _, p := range g.Players {
+		fmt.Fprintf(&b, "  %-12s started %6d  stack %6d  bet %6d  in pot %6d", p.GetID(), g.handStartChips[p.GetID()], p.GetChips(), p.GetCurrentBet(), g.PotManager.Contribution(p))
+		if p.IsFolded() {
+			b.WriteString("  folded")
+		} else if p.IsAllIn() {
+			b.WriteString("  all-in")
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
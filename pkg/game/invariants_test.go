@@ -0,0 +1,88 @@
+package game
+
+import (
+	"bytes"
+	"flag"
+	"strings"
+	"testing"
+
+	"pokerclientv1/pkg/types"
+)
+
+// skimmingPlayer takes one chip more from its stack than it puts in the pot
+// each time it bets.
+type skimmingPlayer struct {
+	*AutoPlayer
+}
+
+func (p skimmingPlayer) Bet(amount int) int {
+	bet := p.AutoPlayer.Bet(amount)
+	if bet > 0 && p.Chips > 0 {
+		p.Chips--
+	}
+	return bet
+}
+
+func newSkimmingGame(mode InvariantMode, out *bytes.Buffer) *Game {
+	players := []types.Player{skimmingPlayer{NewAutoPlayer("Skim", 100, nil)}, NewAutoPlayer("Honest", 100, nil)}
+	engine := NewEngine(Config{Players: players, Output: out, CheckInvariants: mode})
+	return engine.Game()
+}
+
+// TestChipErrorAfterHand checks that chips lost during a hand are reported
+// after it even when the debug checks are off.
+func TestChipErrorAfterHand(t *testing.T) {
+	var out bytes.Buffer
+	g := newSkimmingGame(InvariantsOff, &out)
+	g.PlayNextHand()
+	if g.ChipError() == nil {
+		t.Fatalf("ChipError() = nil after a player lost chips")
+	}
+	if !strings.Contains(out.String(), "Error: hand 1:") || strings.Contains(out.String(), "violated after") {
+		t.Errorf("output should have a one-line error and no dump:\n%s", out.String())
+	}
+}
+
+// TestInvariantsLogDumpsTable checks that the log mode catches the first
+// skimmed blind and carries on.
+func TestInvariantsLogDumpsTable(t *testing.T) {
+	var out bytes.Buffer
+	g := newSkimmingGame(InvariantsLog, &out)
+	if !g.PlayNextHand() {
+		t.Fatalf("PlayNextHand() = false, the hand should carry on after a violation")
+	}
+	got := out.String()
+	for _, want := range []string{"Chip invariant violated after the blinds", "Skim", "started    100", "Honest"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("dump is missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestInvariantsPanic(t *testing.T) {
+	var out bytes.Buffer
+	g := newSkimmingGame(InvariantsPanic, &out)
+	defer func() {
+		if r, _ := recover().(string); !strings.Contains(r, "Chip invariant violated after the blinds") {
+			t.Errorf("panicked with %q, want a dump of the table", r)
+		}
+	}()
+	g.PlayNextHand()
+	t.Errorf("PlayNextHand() did not panic")
+}
+
+func TestInvariantModeFlag(t *testing.T) {
+	for args, want := range map[string]InvariantMode{
+		"":                       InvariantsOff,
+		"-check-invariants":      InvariantsPanic,
+		"-check-invariants=log":  InvariantsLog,
+		"-check-invariants=true": InvariantsPanic,
+	} {
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		var mode InvariantMode
+		fs.Var(&mode, "check-invariants", "")
+		if err := fs.Parse(strings.Fields(args)); err != nil || mode != want {
+			t.Errorf("%q gives %q, %v, want %q", args, mode, err, want)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package game
+
+import (
+	"fmt"
+
+	"pokerclientv1/pkg/types"
+)
+
+// LegalActions describes what a player may do if it were their turn now.
+type LegalActions = types.LegalActions
+
+// LegalActions returns the actions open to the player with the given ID in
+// the current betting round. A raise is only offered when the player can put
+// in more than a call and the action has been reopened to them; a short
+// all-in may be smaller than MinRaise, so MinRaise never exceeds MaxRaise.
+// The player asked to act is given the same answer in Table.Legal.
+func (g *Game) LegalActions(playerID string) (LegalActions, error) {
+	seat := -1
+	for i, p := range g.Players {
+		if p.GetID() == playerID {
+			seat = i
+		}
+	}
+	if seat < 0 {
+		return LegalActions{}, fmt.Errorf("no player %q at the table", playerID)
+	}
+	p := g.Players[seat]
+	switch {
+	case p.IsFolded():
+		return LegalActions{}, fmt.Errorf("%s has folded", playerID)
+	case p.IsAllIn():
+		return LegalActions{}, fmt.Errorf("%s is all-in", playerID)
+	}
+
+	minRaise := max(g.minRaise, g.BigBlind)
+	legal := types.NewLegalActions(playerID, g.Table.CurrentBet, p.GetCurrentBet(), p.GetChips(), minRaise)
+	if g.actedSinceRaise[seat] {
+		return legal.WithoutRaise(), nil
+	}
+	if limit := g.maxRaise(p); limit > 0 && legal.Can("raise") && g.Table.CurrentBet+limit < legal.MaxRaise {
+		legal.MaxRaise = g.Table.CurrentBet + limit
+		legal.MinRaise = min(legal.MinRaise, legal.MaxRaise)
+	}
+	return legal, nil
+}
@@ -0,0 +1,59 @@
+package game
+
+import (
+	"reflect"
+	"testing"
+
+	"pokerclientv1/pkg/types"
+)
+
+// TestLegalActions checks the action set and raise totals mid-round, after
+// P1 raised to 10 over a big blind of 2.
+func TestLegalActions(t *testing.T) {
+	raiser := NewMockPlayer("P1", 90, false)
+	raiser.CurrentBet = 10
+	caller := NewMockPlayer("P2", 100, false)
+	caller.CurrentBet = 2
+	folded := NewMockPlayer("P3", 100, false)
+	folded.Folded = true
+	short := NewMockPlayer("P4", 5, false)
+	shortRaise := NewMockPlayer("P5", 12, false)
+
+	g := NewGame([]types.Player{raiser, caller, folded, short, shortRaise}, nopUI{}, 0, DefaultGameConfig())
+	g.Table.CurrentBet = 10
+	g.Table.Pot = 13
+	g.minRaise = 8
+	g.actedSinceRaise = map[int]bool{0: true}
+
+	tests := []struct {
+		id   string
+		want LegalActions
+	}{
+		{"P1", LegalActions{PlayerID: "P1", Actions: []string{"fold", "check"}, Bet: 10}},
+		{"P2", LegalActions{PlayerID: "P2", Actions: []string{"fold", "call", "raise"}, Bet: 2, ToCall: 8, MinRaise: 18, MaxRaise: 102}},
+		{"P4", LegalActions{PlayerID: "P4", Actions: []string{"fold", "call"}, ToCall: 5}},
+		{"P5", LegalActions{PlayerID: "P5", Actions: []string{"fold", "call", "raise"}, ToCall: 10, MinRaise: 12, MaxRaise: 12}},
+	}
+	for _, tt := range tests {
+		got, err := g.LegalActions(tt.id)
+		if err != nil {
+			t.Errorf("LegalActions(%s) error: %v", tt.id, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("LegalActions(%s) = %+v, want %+v", tt.id, got, tt.want)
+		}
+	}
+
+	for _, id := range []string{"P3", "nobody"} {
+		if _, err := g.LegalActions(id); err == nil {
+			t.Errorf("LegalActions(%s) succeeded, want an error", id)
+		}
+	}
+
+	// Pot-limit caps the raise at the pot after calling: 10 + (13 + 8)
+	g.Config.PotLimit = true
+	if got, _ := g.LegalActions("P2"); got.MaxRaise != 31 {
+		t.Errorf("pot-limit MaxRaise = %d, want 31", got.MaxRaise)
+	}
+}
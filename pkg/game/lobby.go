@@ -0,0 +1,242 @@
+package game
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"pokerclientv1/pkg/types"
+)
+
+// Lobby runs several tables at once in one process, such as the tables of a
+// multi-table tournament or the games hosted by one server. Each table is a
+// Game with its own deck, pot and blinds; the lobby holds the pool of players
+// seated across them and moves players from table to table between hands.
+//
+// Tables must not share a Rng, UI or event handler that is not safe to use
+// from several goroutines, since Run plays the tables concurrently.
+type Lobby struct {
+	// Seats at each table. When set, Run keeps the tables balanced as
+	// players bust and breaks tables until one final table is left. It
+	// should be at least 4 so no table is left with a single player.
+	MaxSeats int
+
+	mu        sync.Mutex
+	tables    []*lobbyTable
+	handlers  []EventHandler // Registered on every table, see OnEvent
+	balanceMu sync.Mutex     // Held while tables are balanced
+	final     bool           // Set once the final table is announced
+}
+
+// lobbyTable is a table of the lobby. Its lock is held while a hand is
+// played, so seats only change between hands.
+type lobbyTable struct {
+	mu     sync.Mutex
+	game   *Game
+	broken bool // Set once the table is closed by balancing
+}
+
+// NewLobby creates a lobby with no tables.
+func NewLobby() *Lobby {
+	return &Lobby{}
+}
+
+// AddTable adds a game to the lobby as table id, which becomes its TableID.
+// A player can only sit at one table at a time.
+func (l *Lobby) AddTable(id string, g *Game) error {
+	if id == "" {
+		return fmt.Errorf("lobby: table ID is empty")
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, t := range l.tables {
+		if t.game.TableID == id {
+			return fmt.Errorf("lobby: table %s already exists", id)
+		}
+		for _, p := range g.Players {
+			if t.seated(p.GetID()) {
+				return fmt.Errorf("lobby: %s is already seated at table %s", p.GetID(), t.game.TableID)
+			}
+		}
+	}
+	g.TableID = id
+	for _, handler := range l.handlers {
+		g.OnEvent(handler)
+	}
+	l.tables = append(l.tables, &lobbyTable{game: g})
+	return nil
+}
+
+// OnEvent registers a handler for the events of every table, including
+// tables added later and the lobby's own balancing events. It is called from
+// several goroutines at once while Run plays.
+func (l *Lobby) OnEvent(handler EventHandler) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.handlers = append(l.handlers, handler)
+	for _, t := range l.tables {
+		t.game.OnEvent(handler)
+	}
+}
+
+// seated reports whether a player is at the table. The caller must hold the
+// table's lock or know no hand is being played.
+func (t *lobbyTable) seated(playerID string) bool {
+	for _, p := range t.game.Players {
+		if p.GetID() == playerID {
+			return true
+		}
+	}
+	return false
+}
+
+// table returns the lobby table with the given ID, or nil.
+func (l *Lobby) table(id string) *lobbyTable {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, t := range l.tables {
+		if t.game.TableID == id {
+			return t
+		}
+	}
+	return nil
+}
+
+// Table returns the game played at table id.
+func (l *Lobby) Table(id string) (*Game, bool) {
+	t := l.table(id)
+	if t == nil {
+		return nil, false
+	}
+	return t.game, true
+}
+
+// TableIDs returns the IDs of the tables in the order they were added.
+func (l *Lobby) TableIDs() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	ids := make([]string, len(l.tables))
+	for i, t := range l.tables {
+		ids[i] = t.game.TableID
+	}
+	return ids
+}
+
+// Players returns the players seated at every table, table by table. It
+// waits for hands in progress to finish.
+func (l *Lobby) Players() []types.Player {
+	l.mu.Lock()
+	tables := append([]*lobbyTable(nil), l.tables...)
+	l.mu.Unlock()
+	players := []types.Player{}
+	for _, t := range tables {
+		t.mu.Lock()
+		players = append(players, t.game.Players...)
+		t.mu.Unlock()
+	}
+	return players
+}
+
+// TableOf returns the ID of the table a player sits at. It waits for hands
+// in progress to finish.
+func (l *Lobby) TableOf(playerID string) (string, bool) {
+	l.mu.Lock()
+	tables := append([]*lobbyTable(nil), l.tables...)
+	l.mu.Unlock()
+	for _, t := range tables {
+		t.mu.Lock()
+		seated := t.seated(playerID)
+		t.mu.Unlock()
+		if seated {
+			return t.game.TableID, true
+		}
+	}
+	return "", false
+}
+
+// Move takes a player from their table to the last seat of table to. If
+// either table is playing a hand, Move waits for it to finish.
+func (l *Lobby) Move(playerID, to string) error {
+	dst := l.table(to)
+	if dst == nil {
+		return fmt.Errorf("lobby: no table %s", to)
+	}
+	from, ok := l.TableOf(playerID)
+	if !ok {
+		return fmt.Errorf("lobby: %s is not seated", playerID)
+	}
+	if from == to {
+		return nil
+	}
+	src := l.table(from)
+
+	// Lock the tables in the order they were added so two moves in opposite
+	// directions cannot deadlock
+	first, second := src, dst
+	if l.index(dst) < l.index(src) {
+		first, second = dst, src
+	}
+	first.mu.Lock()
+	defer first.mu.Unlock()
+	second.mu.Lock()
+	defer second.mu.Unlock()
+
+	p, ok := src.game.Unseat(playerID)
+	if !ok {
+		return fmt.Errorf("lobby: %s left table %s", playerID, from)
+	}
+	dst.game.Seat(p)
+	return nil
+}
+
+// index returns the position of a table in the lobby.
+func (l *Lobby) index(t *lobbyTable) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for i, lt := range l.tables {
+		if lt == t {
+			return i
+		}
+	}
+	return -1
+}
+
+// Run plays every table at the same time, each until its game is over or
+// stopped, and returns once all of them have finished. Cancelling ctx
+// abandons the hands in progress, as with Game.PlayNextHandContext.
+func (l *Lobby) Run(ctx context.Context) {
+	l.mu.Lock()
+	tables := append([]*lobbyTable(nil), l.tables...)
+	l.mu.Unlock()
+	var wg sync.WaitGroup
+	for _, t := range tables {
+		wg.Add(1)
+		go func(t *lobbyTable) {
+			defer wg.Done()
+			l.run(ctx, t)
+		}(t)
+	}
+	wg.Wait()
+}
+
+// run plays hands at the table until its game is over or the table is
+// broken, balancing the lobby after each hand.
+func (l *Lobby) run(ctx context.Context, t *lobbyTable) {
+	for {
+		t.mu.Lock()
+		if t.broken {
+			t.mu.Unlock()
+			return
+		}
+		more := t.game.PlayNextHandContext(ctx) && !t.game.stopRequested.Load()
+		t.mu.Unlock()
+		if !more {
+			break
+		}
+		if l.MaxSeats > 0 {
+			l.balance()
+		}
+		t.game.waitWithLoader(ctx, t.game.GameSpeed*2)
+	}
+	t.game.emit(Event{Type: EventGameOver})
+}
@@ -0,0 +1,158 @@
+package game
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+	"testing"
+
+	"pokerclientv1/pkg/types"
+)
+
+// newLobbyTable creates a silent game for the players given, stopped after
+// the given number of hands.
+func newLobbyTable(hands int, players ...types.Player) *Game {
+	engine := NewEngine(Config{Players: players})
+	g := engine.Game()
+	g.OnEvent(func(e Event) {
+		if e.Type == EventHandEnded && e.HandNumber == hands {
+			g.Stop()
+		}
+	})
+	return g
+}
+
+// TestLobbyRunsTablesConcurrently checks that every table plays its own
+// hands and reports its ID with each event.
+func TestLobbyRunsTablesConcurrently(t *testing.T) {
+	lobby := NewLobby()
+	var mu sync.Mutex
+	handsEnded := map[string]int{}
+	for _, id := range []string{"A", "B"} {
+		g := newLobbyTable(3, NewMockPlayer(id+"1", 100, false), NewMockPlayer(id+"2", 100, false))
+		g.OnEvent(func(e Event) {
+			if e.Type == EventHandEnded {
+				mu.Lock()
+				handsEnded[e.Table]++
+				mu.Unlock()
+			}
+		})
+		if err := lobby.AddTable(id, g); err != nil {
+			t.Fatalf("AddTable(%s) error: %v", id, err)
+		}
+	}
+
+	lobby.Run(context.Background())
+
+	for _, id := range lobby.TableIDs() {
+		if handsEnded[id] != 3 {
+			t.Errorf("table %s ended %d hands, want 3", id, handsEnded[id])
+		}
+		g, _ := lobby.Table(id)
+		chips := 0
+		for _, p := range g.Players {
+			chips += p.GetChips()
+		}
+		if chips != 200 {
+			t.Errorf("table %s has %d chips, want 200", id, chips)
+		}
+	}
+}
+
+// TestLobbyMovesPlayers checks that players move between tables between
+// hands and can only sit at one table.
+func TestLobbyMovesPlayers(t *testing.T) {
+	lobby := NewLobby()
+	a := newLobbyTable(1, NewMockPlayer("P1", 100, false), NewMockPlayer("P2", 100, false), NewMockPlayer("P3", 100, false))
+	a.DealerPos = 2
+	b := newLobbyTable(1, NewMockPlayer("P4", 100, false))
+	if err := lobby.AddTable("A", a); err != nil {
+		t.Fatalf("AddTable(A) error: %v", err)
+	}
+	if err := lobby.AddTable("B", b); err != nil {
+		t.Fatalf("AddTable(B) error: %v", err)
+	}
+	if err := lobby.AddTable("A", newLobbyTable(1)); err == nil {
+		t.Errorf("AddTable(A) twice returned no error")
+	}
+	if err := lobby.AddTable("C", newLobbyTable(1, NewMockPlayer("P4", 100, false))); err == nil {
+		t.Errorf("AddTable(C) with P4 seated at B returned no error")
+	}
+
+	if err := lobby.Move("P1", "B"); err != nil {
+		t.Fatalf("Move(P1, B) error: %v", err)
+	}
+	if table, _ := lobby.TableOf("P1"); table != "B" {
+		t.Errorf("TableOf(P1) = %q, want B", table)
+	}
+	if len(a.Players) != 2 || len(b.Players) != 2 {
+		t.Errorf("tables have %d and %d players after the move, want 2 and 2", len(a.Players), len(b.Players))
+	}
+	if a.Players[a.DealerPos].GetID() != "P3" {
+		t.Errorf("button is on %s after the move, want it to stay on P3", a.Players[a.DealerPos].GetID())
+	}
+	if len(lobby.Players()) != 4 {
+		t.Errorf("Players() returned %d players, want 4", len(lobby.Players()))
+	}
+	if err := lobby.Move("P1", "Z"); err == nil {
+		t.Errorf("Move(P1, Z) to a missing table returned no error")
+	}
+	if err := lobby.Move("P9", "A"); err == nil {
+		t.Errorf("Move(P9, A) of an unseated player returned no error")
+	}
+}
+
+// newShover returns a player who moves all in at every turn.
+func newShover(id string) *MockPlayer {
+	p := NewMockPlayer(id, 100, false)
+	p.ActionQueue = []struct {
+		Action string
+		Amount int
+	}{{"raise", 1_000_000}, {"raise", 1_000_000}, {"raise", 1_000_000}}
+	return p
+}
+
+// TestLobbyBalancesToFinalTable plays a three-table tournament of players
+// who shove every hand and checks that tables are broken as players bust,
+// until a final table crowns a winner.
+func TestLobbyBalancesToFinalTable(t *testing.T) {
+	lobby := NewLobby()
+	lobby.MaxSeats = 4
+	tournament := NewTournament(100, nil, DefaultPayouts(12))
+	for _, id := range []string{"A", "B", "C"} {
+		players := []types.Player{}
+		for i := 1; i <= 4; i++ {
+			players = append(players, newShover(fmt.Sprintf("%s%d", id, i)))
+		}
+		g := NewEngine(Config{Players: players, Rng: rand.New(rand.NewSource(int64(len(id))))}).Game()
+		tournament.Attach(g)
+		if err := lobby.AddTable(id, g); err != nil {
+			t.Fatalf("AddTable(%s) error: %v", id, err)
+		}
+	}
+	var mu sync.Mutex
+	counts := map[EventType]int{}
+	lobby.OnEvent(func(e Event) {
+		mu.Lock()
+		defer mu.Unlock()
+		counts[e.Type]++
+		if e.Type == EventPlayerMoved && e.Table == e.Action {
+			t.Errorf("%s moved from table %s to itself", e.PlayerID, e.Table)
+		}
+	})
+
+	lobby.Run(context.Background())
+
+	if counts[EventTableBroken] != 2 || counts[EventFinalTable] != 1 {
+		t.Errorf("got %d tables broken and %d final tables, want 2 and 1", counts[EventTableBroken], counts[EventFinalTable])
+	}
+	if ids := lobby.TableIDs(); len(ids) != 1 {
+		t.Fatalf("TableIDs() = %v after the tournament, want the final table only", ids)
+	}
+	final, _ := lobby.Table(lobby.TableIDs()[0])
+	standings := tournament.Standings(final.getPlayersWithChips())
+	if len(standings) != 12 || standings[0].Chips != 1200 {
+		t.Errorf("Standings() = %+v, want 12 places and a winner with all 1200 chips", standings)
+	}
+}
@@ -0,0 +1,68 @@
+package game
+
+import (
+	"fmt"
+	"slices"
+)
+
+// PositionNames returns the names of the positions at a table of the given
+// number of players, starting from the button and going clockwise: BTN, SB,
+// BB, then UTG and the seats after it, with the last seats before the button
+// named LJ, HJ and CO. Heads-up the button posts the small blind, "BTN/SB".
+func PositionNames(seats int) []string {
+	switch {
+	case seats <= 0:
+		return nil
+	case seats == 1:
+		return []string{"BTN"}
+	case seats == 2:
+		return []string{"BTN/SB", "BB"}
+	}
+	names := []string{"BTN", "SB", "BB"}
+	early := seats - 3 // Seats from under the gun to the cutoff
+	late := []string{"LJ", "HJ", "CO"}
+	if early > 1 {
+		late = late[max(len(late)-(early-1), 0):]
+	} else {
+		late = nil
+	}
+	for i := 0; i < early-len(late); i++ {
+		if i == 0 {
+			names = append(names, "UTG")
+		} else {
+			names = append(names, fmt.Sprintf("UTG+%d", i))
+		}
+	}
+	return append(names, late...)
+}
+
+// positions names each player's position for the hand, counting from the
+// button. A dead small blind leaves the SB position out. A dead button
+// leaves BTN out too, and the player standing in for it to the right of the
+// blinds is named for their own seat, such as CO.
+func (g *Game) positions() map[string]string {
+	n := len(g.Players)
+	dead := 0
+	if g.SmallBlindPos < 0 {
+		dead++
+	}
+	if g.deadButton {
+		dead++
+	}
+	names := PositionNames(n)
+	if dead > 0 && n > 2 {
+		names = PositionNames(n + dead)
+		if g.SmallBlindPos < 0 {
+			names = slices.Delete(names, 1, 2)
+		}
+		if g.deadButton {
+			last := len(names) - 1
+			names = append([]string{names[last]}, names[1:last]...)
+		}
+	}
+	positions := make(map[string]string, n)
+	for i := range g.Players {
+		positions[g.Players[(g.DealerPos+i)%n].GetID()] = names[i]
+	}
+	return positions
+}
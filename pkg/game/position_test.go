@@ -0,0 +1,79 @@
+package game
+
+import (
+	"io"
+	"slices"
+	"testing"
+
+	"pokerclientv1/pkg/types"
+)
+
+// TestPositionNames checks the position names for each table size.
+func TestPositionNames(t *testing.T) {
+	tests := map[int][]string{
+		2:  {"BTN/SB", "BB"},
+		3:  {"BTN", "SB", "BB"},
+		4:  {"BTN", "SB", "BB", "UTG"},
+		5:  {"BTN", "SB", "BB", "UTG", "CO"},
+		6:  {"BTN", "SB", "BB", "UTG", "HJ", "CO"},
+		7:  {"BTN", "SB", "BB", "UTG", "LJ", "HJ", "CO"},
+		9:  {"BTN", "SB", "BB", "UTG", "UTG+1", "UTG+2", "LJ", "HJ", "CO"},
+		10: {"BTN", "SB", "BB", "UTG", "UTG+1", "UTG+2", "UTG+3", "LJ", "HJ", "CO"},
+	}
+	for seats, want := range tests {
+		if got := PositionNames(seats); !slices.Equal(got, want) {
+			t.Errorf("PositionNames(%d) = %v, want %v", seats, got, want)
+		}
+	}
+}
+
+// TestHandPositions checks that the table and the seated events name each
+// player's position, counting from the button and skipping a dead small blind.
+func TestHandPositions(t *testing.T) {
+	players := []types.Player{
+		NewMockPlayer("P1", 100, false), NewMockPlayer("P2", 100, false),
+		NewMockPlayer("P3", 100, false), NewMockPlayer("P4", 100, false),
+	}
+	g := NewGame(players, &MockUI{}, 0, DefaultGameConfig())
+	g.Out = io.Discard
+	g.DealerPos = 3
+	g.determineBlinds()
+	want := map[string]string{"P4": "BTN", "P1": "SB", "P2": "BB", "P3": "UTG"}
+	for id, position := range want {
+		if g.Table.Positions[id] != position {
+			t.Errorf("%s is in %q, want %s", id, g.Table.Positions[id], position)
+		}
+	}
+
+	g.SmallBlindPos = -1
+	g.DealerPos, g.BigBlindPos = 0, 1
+	positions := g.positions()
+	want = map[string]string{"P1": "BTN", "P2": "BB", "P3": "UTG", "P4": "CO"}
+	for id, position := range want {
+		if positions[id] != position {
+			t.Errorf("with a dead small blind %s is in %q, want %s", id, positions[id], position)
+		}
+	}
+
+	g.deadButton = true
+	g.DealerPos, g.SmallBlindPos, g.BigBlindPos = 0, 1, 2
+	positions = g.positions()
+	want = map[string]string{"P1": "CO", "P2": "SB", "P3": "BB", "P4": "UTG"}
+	for id, position := range want {
+		if positions[id] != position {
+			t.Errorf("with a dead button %s is in %q, want %s", id, positions[id], position)
+		}
+	}
+	g.deadButton = false
+
+	var seated []string
+	g.OnEvent(func(e Event) {
+		if e.Type == EventPlayerSeated {
+			seated = append(seated, e.PlayerID+" "+e.Position)
+		}
+	})
+	g.PlayNextHand()
+	if len(seated) != 4 || seated[0] != "P1 BTN" {
+		t.Errorf("seated events = %v, want four with P1 on the button", seated)
+	}
+}
@@ -0,0 +1,223 @@
+package game
+
+import (
+	"math"
+	"sort"
+
+	"pokerclientv1/pkg/types"
+)
+
+// Pot is a single main or side pot and the players who can win it.
+type Pot struct {
+	Amount   int
+	Eligible []types.Player
+}
+
+// Contribution is what one player has put into the pot during a hand.
+type Contribution struct {
+	PlayerID string         `json:"player"`
+	Total    int            `json:"total"`
+	Rounds   map[string]int `json:"rounds"` // Chips per betting round; blinds and antes count as pre-flop
+}
+
+// PotManager tracks how many chips each player has put in during the current
+// hand, in total and per betting round, and splits them into a main pot and
+// side pots when players are all-in for different amounts.
+type PotManager struct {
+	contributions map[types.Player]int
+	rounds        map[string]map[types.Player]int // Contributions per betting round
+	round         string                          // Round that added chips count towards
+}
+
+// NewPotManager creates an empty pot manager.
+func NewPotManager() *PotManager {
+	return &PotManager{contributions: make(map[types.Player]int), rounds: make(map[string]map[types.Player]int)}
+}
+
+// SetRound attributes chips added from now on to the named betting round.
+func (pm *PotManager) SetRound(round string) {
+	pm.round = round
+}
+
+// Add records chips a player has put into the pot in the current round.
+func (pm *PotManager) Add(p types.Player, amount int) {
+	pm.contributions[p] += amount
+	if pm.rounds[pm.round] == nil {
+		pm.rounds[pm.round] = make(map[types.Player]int)
+	}
+	pm.rounds[pm.round][p] += amount
+}
+
+// Refund takes chips back out of a player's contribution in the current
+// round, for a bet nobody called.
+func (pm *PotManager) Refund(p types.Player, amount int) {
+	pm.contributions[p] -= amount
+	if pm.rounds[pm.round] != nil {
+		pm.rounds[pm.round][p] -= amount
+	}
+}
+
+// Contribution returns the total a player has put in this hand.
+func (pm *PotManager) Contribution(p types.Player) int {
+	return pm.contributions[p]
+}
+
+// RoundContribution returns what a player put in during one betting round.
+func (pm *PotManager) RoundContribution(p types.Player, round string) int {
+	return pm.rounds[round][p]
+}
+
+// Contributions returns the contribution of every player who has put chips
+// in this hand, in the given seat order.
+func (pm *PotManager) Contributions(players []types.Player) []Contribution {
+	all := []Contribution{}
+	for _, p := range players {
+		if pm.contributions[p] == 0 {
+			continue
+		}
+		c := Contribution{PlayerID: p.GetID(), Total: pm.contributions[p], Rounds: map[string]int{}}
+		for round, amounts := range pm.rounds {
+			if amounts[p] != 0 {
+				c.Rounds[round] = amounts[p]
+			}
+		}
+		all = append(all, c)
+	}
+	return all
+}
+
+// Uncalled returns the player who has put in more than anyone else this hand
+// and by how much, or nil and 0 when the largest contribution was matched.
+// Folded players' chips count as matching up to what they put in.
+func (pm *PotManager) Uncalled(players []types.Player) (types.Player, int) {
+	var top types.Player
+	highest, second := 0, 0
+	for _, p := range players {
+		amount := pm.contributions[p]
+		switch {
+		case amount > highest:
+			top, highest, second = p, amount, highest
+		case amount > second:
+			second = amount
+		}
+	}
+	if highest == second {
+		return nil, 0
+	}
+	return top, highest - second
+}
+
+// ReturnUncalled takes the uncalled part of the largest contribution back out
+// of the pot, as when everyone folds to a bet or the only caller is all-in for
+// less. It returns the bettor and the chips to give back, or nil and 0.
+func (pm *PotManager) ReturnUncalled(players []types.Player) (types.Player, int) {
+	p, amount := pm.Uncalled(players)
+	if amount > 0 {
+		pm.Refund(p, amount)
+	}
+	return p, amount
+}
+
+// Total returns the sum of all contributions.
+func (pm *PotManager) Total() int {
+	total := 0
+	for _, amount := range pm.contributions {
+		total += amount
+	}
+	return total
+}
+
+// Reset clears all contributions for a new hand.
+func (pm *PotManager) Reset() {
+	pm.contributions = make(map[types.Player]int)
+	pm.rounds = make(map[string]map[types.Player]int)
+	pm.round = ""
+}
+
+// Pots builds the main pot followed by any side pots. Players are given in
+// seat order, which is kept in each pot's eligibility list. Folded players'
+// chips stay in the pots but they are never eligible to win them.
+func (pm *PotManager) Pots(players []types.Player) []Pot {
+	// Each distinct amount contributed by a player still in the hand caps a pot
+	levels := []int{}
+	seen := make(map[int]bool)
+	for _, p := range players {
+		amount := pm.contributions[p]
+		if !p.IsFolded() && amount > 0 && !seen[amount] {
+			seen[amount] = true
+			levels = append(levels, amount)
+		}
+	}
+	sort.Ints(levels)
+
+	pots := []Pot{}
+	previous := 0
+	for _, level := range levels {
+		pot := Pot{}
+		for _, p := range players {
+			pot.Amount += capContribution(pm.contributions[p], level) - capContribution(pm.contributions[p], previous)
+			if !p.IsFolded() && pm.contributions[p] >= level {
+				pot.Eligible = append(pot.Eligible, p)
+			}
+		}
+		pots = append(pots, pot)
+		previous = level
+	}
+
+	// Chips from folded players above the highest live contribution go to the last pot
+	leftover := 0
+	for _, p := range players {
+		if pm.contributions[p] > previous {
+			leftover += pm.contributions[p] - previous
+		}
+	}
+	if leftover > 0 && len(pots) > 0 {
+		pots[len(pots)-1].Amount += leftover
+	}
+	return pots
+}
+
+// LivePots builds the main pot and side pots as they stand during a betting
+// round. Unlike Pots, a pot is only capped where a player is all-in: players
+// who can still bet are eligible for every pot, since they may yet match the
+// bets in front of them, and everything above the largest all-in makes up
+// the last pot.
+func (pm *PotManager) LivePots(players []types.Player) []Pot {
+	levels := []int{}
+	seen := make(map[int]bool)
+	for _, p := range players {
+		amount := pm.contributions[p]
+		if p.IsAllIn() && amount > 0 && !seen[amount] {
+			seen[amount] = true
+			levels = append(levels, amount)
+		}
+	}
+	sort.Ints(levels)
+	levels = append(levels, math.MaxInt)
+
+	pots := []Pot{}
+	previous := 0
+	for _, level := range levels {
+		pot := Pot{}
+		for _, p := range players {
+			amount := pm.contributions[p]
+			pot.Amount += capContribution(amount, level) - capContribution(amount, previous)
+			if !p.IsFolded() && (!p.IsAllIn() || amount >= level) {
+				pot.Eligible = append(pot.Eligible, p)
+			}
+		}
+		if pot.Amount > 0 {
+			pots = append(pots, pot)
+		}
+		previous = level
+	}
+	return pots
+}
+
+// capContribution limits a contribution to the given pot level.
+func capContribution(amount int, level int) int {
+	if amount < level {
+		return amount
+	}
+	return level
+}
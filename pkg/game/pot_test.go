@@ -0,0 +1,166 @@
+package game
+
+import (
+	"io"
+	"pokerclientv1/pkg/types"
+	"testing"
+)
+
+// TestPotsSingleMainPot checks that equal contributions build one pot.
+func TestPotsSingleMainPot(t *testing.T) {
+	p1 := NewMockPlayer("P1", 0, true)
+	p2 := NewMockPlayer("P2", 0, false)
+	p3 := NewMockPlayer("P3", 0, false)
+	p3.Folded = true
+	pm := NewPotManager()
+	pm.Add(p1, 50)
+	pm.Add(p2, 50)
+	pm.Add(p3, 20)
+
+	pots := pm.Pots([]types.Player{p1, p2, p3})
+	if len(pots) != 1 {
+		t.Fatalf("Pots() built %d pots, want 1", len(pots))
+	}
+	if pots[0].Amount != 120 {
+		t.Errorf("Pots() main pot = %d, want 120", pots[0].Amount)
+	}
+	if len(pots[0].Eligible) != 2 {
+		t.Errorf("Pots() main pot has %d eligible players, want 2 (folded player excluded)", len(pots[0].Eligible))
+	}
+}
+
+// TestPotsSidePots checks main and side pots for players all-in for different amounts.
+func TestPotsSidePots(t *testing.T) {
+	short := NewMockPlayer("Short", 0, true)    // All-in for 30
+	medium := NewMockPlayer("Medium", 0, false) // All-in for 80
+	big := NewMockPlayer("Big", 100, false)     // Covers everyone
+	folder := NewMockPlayer("Folder", 100, false)
+	folder.Folded = true
+	pm := NewPotManager()
+	pm.Add(short, 30)
+	pm.Add(medium, 80)
+	pm.Add(big, 80)
+	pm.Add(folder, 50)
+
+	pots := pm.Pots([]types.Player{short, medium, big, folder})
+	if len(pots) != 2 {
+		t.Fatalf("Pots() built %d pots, want 2", len(pots))
+	}
+	// Main pot: 30 from each of the four players
+	if pots[0].Amount != 120 || len(pots[0].Eligible) != 3 {
+		t.Errorf("Pots() main pot = %d with %d eligible, want 120 with 3", pots[0].Amount, len(pots[0].Eligible))
+	}
+	// Side pot: 50 from Medium and Big plus 20 more from Folder
+	if pots[1].Amount != 120 || len(pots[1].Eligible) != 2 {
+		t.Errorf("Pots() side pot = %d with %d eligible, want 120 with 2", pots[1].Amount, len(pots[1].Eligible))
+	}
+	if pm.Total() != pots[0].Amount+pots[1].Amount {
+		t.Errorf("Pots() total %d does not match contributions %d", pots[0].Amount+pots[1].Amount, pm.Total())
+	}
+}
+
+// TestShowdownAwardsSidePots checks that a short all-in winner only takes the main pot.
+func TestShowdownAwardsSidePots(t *testing.T) {
+	short := NewMockPlayer("Short", 0, true)
+	big := NewMockPlayer("Big", 0, false)
+	other := NewMockPlayer("Other", 0, false)
+	game := NewGame([]types.Player{short, big, other}, &MockUI{}, 0, DefaultGameConfig())
+
+	// Short holds aces, Big holds kings, Other holds junk
+	short.Hand.Cards = []types.Card{{Suit: types.Spade, Rank: types.Ace}, {Suit: types.Heart, Rank: types.Ace}}
+	big.Hand.Cards = []types.Card{{Suit: types.Spade, Rank: types.King}, {Suit: types.Heart, Rank: types.King}}
+	other.Hand.Cards = []types.Card{{Suit: types.Club, Rank: types.Seven}, {Suit: types.Diamond, Rank: types.Two}}
+	game.Table.CommunityCards = []types.Card{
+		{Suit: types.Club, Rank: types.Three}, {Suit: types.Diamond, Rank: types.Eight},
+		{Suit: types.Spade, Rank: types.Nine}, {Suit: types.Heart, Rank: types.Jack},
+		{Suit: types.Club, Rank: types.Queen},
+	}
+	game.addToPot(short, 20)
+	game.addToPot(big, 100)
+	game.addToPot(other, 100)
+
+	game.showdown()
+
+	if short.GetChips() != 60 {
+		t.Errorf("showdown() Short won %d, want the 60 chip main pot", short.GetChips())
+	}
+	if big.GetChips() != 160 {
+		t.Errorf("showdown() Big won %d, want the 160 chip side pot", big.GetChips())
+	}
+	if other.GetChips() != 0 {
+		t.Errorf("showdown() Other won %d, want 0", other.GetChips())
+	}
+}
+
+// TestSplitPotOddChips checks that the chips left over when a pot does not
+// divide evenly go to the first winner left of the button, wherever it is.
+func TestSplitPotOddChips(t *testing.T) {
+	p1 := NewMockPlayer("P1", 0, false)
+	p2 := NewMockPlayer("P2", 0, false)
+	p3 := NewMockPlayer("P3", 0, false)
+	g := NewGame([]types.Player{p1, p2, p3}, nopUI{}, 0, DefaultGameConfig())
+	g.Out = io.Discard
+	g.DealerPos = 1
+
+	g.splitPot([]types.Player{p1, p2, p3}, 11, "main pot")
+	if p3.Chips != 5 || p1.Chips != 3 || p2.Chips != 3 {
+		t.Errorf("split 11 chips as P1 %d, P2 %d, P3 %d, want 3, 3, 5", p1.Chips, p2.Chips, p3.Chips)
+	}
+}
+
+// TestPotContributionsPerRound checks per-round totals and the uncalled part
+// of the largest contribution.
+func TestPotContributionsPerRound(t *testing.T) {
+	p1 := NewMockPlayer("P1", 0, false)
+	p2 := NewMockPlayer("P2", 0, false)
+	p3 := NewMockPlayer("P3", 0, false)
+	players := []types.Player{p1, p2, p3}
+	pm := NewPotManager()
+	pm.SetRound("Pre-flop")
+	pm.Add(p1, 10)
+	pm.Add(p2, 10)
+	pm.SetRound("Flop")
+	pm.Add(p1, 30)
+	pm.Add(p2, 30)
+	pm.Add(p1, 50)
+
+	if got := pm.RoundContribution(p1, "Flop"); got != 80 {
+		t.Errorf("RoundContribution(P1, Flop) = %d, want 80", got)
+	}
+	if p, amount := pm.Uncalled(players); p != p1 || amount != 50 {
+		t.Errorf("Uncalled() = %v, %d, want P1, 50", p, amount)
+	}
+	pm.Refund(p1, 50)
+	if p, amount := pm.Uncalled(players); p != nil || amount != 0 {
+		t.Errorf("Uncalled() after Refund = %v, %d, want nil, 0", p, amount)
+	}
+
+	got := pm.Contributions(players)
+	if len(got) != 2 {
+		t.Fatalf("Contributions() = %v, want P1 and P2 only", got)
+	}
+	if got[0].PlayerID != "P1" || got[0].Total != 40 || got[0].Rounds["Pre-flop"] != 10 || got[0].Rounds["Flop"] != 30 {
+		t.Errorf("Contributions()[0] = %+v, want P1 40 (Pre-flop 10, Flop 30)", got[0])
+	}
+}
+
+// TestReturnUncalledAllInForLess checks that a bet called all-in for less is
+// cut down to the call, and that a folded player's chips stay in the pot.
+func TestReturnUncalledAllInForLess(t *testing.T) {
+	bettor := NewMockPlayer("Bettor", 0, false)
+	short := NewMockPlayer("Short", 0, false)
+	folder := NewMockPlayer("Folder", 0, false)
+	folder.Folded = true
+	players := []types.Player{bettor, short, folder}
+	pm := NewPotManager()
+	pm.Add(folder, 10)
+	pm.Add(bettor, 100)
+	pm.Add(short, 40)
+
+	if p, amount := pm.ReturnUncalled(players); p != bettor || amount != 60 {
+		t.Errorf("ReturnUncalled() = %v, %d, want Bettor, 60", p, amount)
+	}
+	if pm.Contribution(bettor) != 40 || pm.Total() != 90 {
+		t.Errorf("after ReturnUncalled() Bettor has %d in a pot of %d, want 40 of 90", pm.Contribution(bettor), pm.Total())
+	}
+}
@@ -0,0 +1,121 @@
+package game
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"pokerclientv1/pkg/types"
+)
+
+// DefaultRevealPolicy is the reveal policy used when GameConfig.Reveal is empty.
+const DefaultRevealPolicy = "all"
+
+// RevealPolicy decides which hands are turned face up. Hands that are not
+// revealed are mucked: they still compete for the pot, but their cards are
+// not shown on the table, in events or in the hand history.
+type RevealPolicy interface {
+	// TableAllIns reports whether every hand is turned up as soon as action
+	// is closed with a player all in, before the board is run out. Hands
+	// tabled this way are shown at showdown whatever Reveal says.
+	TableAllIns() bool
+
+	// Reveal reports whether a player's hand is shown at showdown. Winner
+	// is true if the hand wins at least part of a pot.
+	Reveal(p types.Player, winner bool) bool
+}
+
+var (
+	revealMu       sync.RWMutex
+	revealPolicies = map[string]RevealPolicy{}
+)
+
+func init() {
+	RegisterRevealPolicy(DefaultRevealPolicy, showAll{})
+	RegisterRevealPolicy("winners", showWinners{})
+	RegisterRevealPolicy("all-in", showOnAllIn{})
+	RegisterRevealPolicy("choice", playerChoice{})
+}
+
+// RegisterRevealPolicy makes a reveal policy available under name, so games
+// can use it by setting GameConfig.Reveal. It is meant to be called from an
+// init function and panics if the name is empty or already registered.
+func RegisterRevealPolicy(name string, p RevealPolicy) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	revealMu.Lock()
+	defer revealMu.Unlock()
+	if name == "" || p == nil {
+		panic("game: RegisterRevealPolicy needs a name and a policy")
+	}
+	if _, dup := revealPolicies[name]; dup {
+		panic(fmt.Sprintf("game: reveal policy %q registered twice", name))
+	}
+	revealPolicies[name] = p
+}
+
+// LookupRevealPolicy returns the reveal policy registered under name,
+// case-insensitively. An empty name is the default policy.
+func LookupRevealPolicy(name string) (RevealPolicy, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		name = DefaultRevealPolicy
+	}
+	revealMu.RLock()
+	defer revealMu.RUnlock()
+	p, ok := revealPolicies[name]
+	return p, ok
+}
+
+// RevealPolicyNames returns the registered reveal policy names in alphabetical order.
+func RevealPolicyNames() []string {
+	revealMu.RLock()
+	defer revealMu.RUnlock()
+	names := make([]string, 0, len(revealPolicies))
+	for name := range revealPolicies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// showAll shows every hand at showdown, and tables them once players are
+// all in.
+type showAll struct{}
+
+func (showAll) TableAllIns() bool              { return true }
+func (showAll) Reveal(types.Player, bool) bool { return true }
+
+// showWinners shows only the hands that win a pot, as most online rooms do.
+type showWinners struct{}
+
+func (showWinners) TableAllIns() bool                       { return false }
+func (showWinners) Reveal(_ types.Player, winner bool) bool { return winner }
+
+// showOnAllIn is the tournament rule: hands are tabled once a player is all
+// in and called, otherwise only winners show.
+type showOnAllIn struct{}
+
+func (showOnAllIn) TableAllIns() bool                       { return true }
+func (showOnAllIn) Reveal(_ types.Player, winner bool) bool { return winner }
+
+// playerChoice shows winners and lets losing players implementing
+// types.Shower decide; other players show.
+type playerChoice struct{}
+
+func (playerChoice) TableAllIns() bool { return false }
+
+func (playerChoice) Reveal(p types.Player, winner bool) bool {
+	if s, ok := p.(types.Shower); ok && !winner {
+		return s.ShowHand()
+	}
+	return true
+}
+
+// revealPolicy returns the policy named by the game's config.
+func (g *Game) revealPolicy() RevealPolicy {
+	if p, ok := LookupRevealPolicy(g.Config.Reveal); ok {
+		return p
+	}
+	return showAll{}
+}
@@ -0,0 +1,136 @@
+package game_test
+
+import (
+	"slices"
+	"testing"
+
+	"pokerclientv1/pkg/game"
+	"pokerclientv1/pkg/gametest"
+)
+
+// mucker is a scripted player who mucks losing hands when allowed to.
+type mucker struct {
+	*gametest.ScriptedPlayer
+}
+
+func (mucker) ShowHand() bool { return false }
+
+// shown returns which players showed and which mucked at showdown.
+func shown(h *gametest.Harness) (showed, mucked map[string]bool) {
+	showed, mucked = map[string]bool{}, map[string]bool{}
+	for _, e := range h.Events {
+		switch e.Type {
+		case game.EventShowdown:
+			showed[e.PlayerID] = true
+		case game.EventHandMucked:
+			mucked[e.PlayerID] = true
+		}
+	}
+	return showed, mucked
+}
+
+// TestRevealPolicies plays the same checked-down hand and the same all-in
+// under each built-in policy. BTN's aces beat BB's kings both times.
+func TestRevealPolicies(t *testing.T) {
+	tests := []struct {
+		policy   string
+		allIn    bool
+		bbShows  bool
+		bbMucker bool
+	}{
+		{policy: "", bbShows: true},
+		{policy: "all", allIn: true, bbShows: true},
+		{policy: "winners", bbShows: false},
+		{policy: "winners", allIn: true, bbShows: false},
+		{policy: "all-in", bbShows: false},
+		{policy: "all-in", allIn: true, bbShows: true},
+		{policy: "choice", bbShows: true},
+		{policy: "choice", bbMucker: true, bbShows: false},
+		{policy: "choice", allIn: true, bbMucker: true, bbShows: false},
+	}
+	for _, tt := range tests {
+		btn := gametest.NewScriptedPlayer("BTN", 100, gametest.Call(), gametest.Check(), gametest.Check(), gametest.Check())
+		bb := gametest.NewScriptedPlayer("BB", 100, gametest.Check(), gametest.Check(), gametest.Check(), gametest.Check())
+		if tt.allIn {
+			btn = gametest.NewScriptedPlayer("BTN", 100, gametest.AllIn())
+			bb = gametest.NewScriptedPlayer("BB", 100, gametest.Call())
+		}
+		deck := gametest.Deck(t,
+			[][]string{{"As", "Ah"}, {"Ks", "Kh"}},
+			[]string{"3c", "8d", "9s", "Jh", "2c"})
+		h := gametest.New(t, deck, btn, bb)
+		h.Engine.Game().Config.Reveal = tt.policy
+		if tt.bbMucker {
+			// Seat the same script behind a player who mucks when allowed
+			h.Engine.Game().Players[1] = mucker{bb}
+		}
+		h.PlayHand()
+
+		showed, mucked := shown(h)
+		if !showed["BTN"] || mucked["BTN"] {
+			t.Errorf("%q all-in=%v: winner BTN showed %v, mucked %v, want shown", tt.policy, tt.allIn, showed["BTN"], mucked["BTN"])
+		}
+		if showed["BB"] != tt.bbShows || mucked["BB"] == tt.bbShows {
+			t.Errorf("%q all-in=%v mucker=%v: BB showed %v, mucked %v, want shown %v", tt.policy, tt.allIn, tt.bbMucker, showed["BB"], mucked["BB"], tt.bbShows)
+		}
+	}
+}
+
+// TestShowdownOrder checks that the river bettor shows first and that a
+// hand checked down is shown from the first player left of the button.
+func TestShowdownOrder(t *testing.T) {
+	tests := []struct {
+		name     string
+		bbBets   bool
+		want     []string
+		mucked   string
+		bbMucker bool
+	}{
+		{name: "checked down", want: []string{"SB", "BB", "BTN"}},
+		{name: "river bet", bbBets: true, want: []string{"BB", "BTN", "SB"}},
+		{name: "bettor mucks", bbBets: true, bbMucker: true, want: []string{"BTN", "SB"}, mucked: "BB"},
+	}
+	for _, tt := range tests {
+		check3 := []gametest.Step{gametest.Check(), gametest.Check(), gametest.Check()}
+		btn := gametest.NewScriptedPlayer("BTN", 100, append([]gametest.Step{gametest.Call()}, check3...)...)
+		sb := gametest.NewScriptedPlayer("SB", 100, append([]gametest.Step{gametest.Call()}, check3...)...)
+		bb := gametest.NewScriptedPlayer("BB", 100, gametest.Check(), gametest.Check(), gametest.Check(), gametest.Check())
+		if tt.bbBets {
+			btn.Script[3], sb.Script = gametest.Call(), append(sb.Script, gametest.Call())
+			bb.Script[3] = gametest.RaiseTo(10)
+		}
+		deck := gametest.Deck(t,
+			[][]string{{"Qs", "Qh"}, {"As", "Ah"}, {"Ks", "Kh"}},
+			[]string{"3c", "8d", "9s", "Jh", "2c"})
+		h := gametest.New(t, deck, btn, sb, bb)
+		h.Engine.Game().Config.Reveal = "choice"
+		if tt.bbMucker {
+			h.Engine.Game().Players[2] = mucker{bb}
+		}
+		h.PlayHand()
+
+		var order []string
+		mucked := ""
+		for _, e := range h.Events {
+			switch e.Type {
+			case game.EventShowdown:
+				order = append(order, e.PlayerID)
+			case game.EventHandMucked:
+				mucked = e.PlayerID
+			}
+		}
+		if !slices.Equal(order, tt.want) || mucked != tt.mucked {
+			t.Errorf("%s: shown %v, mucked %q, want %v and %q", tt.name, order, mucked, tt.want, tt.mucked)
+		}
+	}
+}
+
+// TestUnknownRevealPolicy checks that configs naming an unregistered
+// policy are rejected.
+func TestUnknownRevealPolicy(t *testing.T) {
+	cfg := game.DefaultGameConfig()
+	cfg.Reveal = "sometimes"
+	if err := cfg.Validate(); err == nil {
+		t.Errorf("Validate() with reveal policy %q returned no error", cfg.Reveal)
+	}
+}
@@ -0,0 +1,255 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"time"
+
+	"pokerclientv1/pkg/types"
+)
+
+// SaveVersion is the format version written by SaveGame. Version 2 added
+// seat numbers, last hand's blinds and players sitting out; LoadGame still
+// reads version 1 saves, whose blinds follow DealerPos again on resume.
+const SaveVersion = 2
+
+// SavedPlayer is a seated player in a saved game.
+type SavedPlayer struct {
+	ID         string `json:"id"`
+	Seat       int    `json:"seat,omitempty"` // Seat number, see Seat
+	Chips      int    `json:"chips"`
+	Human      bool   `json:"human"`
+	Difficulty string `json:"difficulty,omitempty"` // Bot difficulty, empty for humans
+	Rebuys     int    `json:"rebuys,omitempty"`     // Re-buys made so far, see RebuyPolicy.Limit
+
+	// A player sitting out, see Game.SitOut, and the blinds they owe
+	SittingOut  bool `json:"sittingOut,omitempty"`
+	Waiting     bool `json:"waiting,omitempty"`   // New to the table and waiting for the big blind
+	SittingIn   bool `json:"sittingIn,omitempty"` // Dealt back in from the next hand, see Game.SitIn
+	MissedSmall bool `json:"missedSmall,omitempty"`
+	MissedBig   bool `json:"missedBig,omitempty"`
+}
+
+// SavedSchedule is a blind schedule and how far it has progressed.
+type SavedSchedule struct {
+	Levels       []BlindLevel `json:"levels"`
+	Level        int          `json:"level"`
+	HandsAtLevel int          `json:"handsAtLevel"`
+}
+
+// SavedTournament is the tournament state needed to finish the standings.
+type SavedTournament struct {
+	BuyIn      int        `json:"buyIn"`
+	Payouts    []int      `json:"payouts"`
+	Entrants   int        `json:"entrants"`
+	Eliminated []Standing `json:"eliminated"`
+	CashedOut  []Standing `json:"cashedOut,omitempty"`
+}
+
+// SavedGame is everything needed to resume a game at the start of a hand.
+type SavedGame struct {
+	Version    int              `json:"version"`
+	Saved      time.Time        `json:"saved"`
+	Config     GameConfig       `json:"config"`
+	HandNumber int              `json:"handNumber"` // Hands completed before the save
+	DealerPos  int              `json:"dealerPos"`
+	Seats      int              `json:"seats,omitempty"` // Seats at the table, empty ones included
+	Players    []SavedPlayer    `json:"players"`
+	Schedule   *SavedSchedule   `json:"schedule,omitempty"`
+	Tournament *SavedTournament `json:"tournament,omitempty"`
+
+	// Who posted the blinds in the last hand played, and the big blind's
+	// seat, so the blinds move on from them instead of from DealerPos
+	LastBigBlind   string `json:"lastBigBlind,omitempty"`
+	LastBigBlindAt int    `json:"lastBigBlindAt,omitempty"`
+	LastSmallBlind string `json:"lastSmallBlind,omitempty"`
+}
+
+// difficultyReporter is implemented by bots so their difficulty can be saved.
+type difficultyReporter interface {
+	GetDifficulty() string
+}
+
+// Snapshot captures the game so it can be resumed later. If a hand is in
+// progress it is abandoned: the snapshot holds the chips and blind level from
+// before the hand, which will be dealt again on resume.
+func (g *Game) Snapshot() SavedGame {
+	midHand := g.handStartChips != nil
+	s := SavedGame{
+		Version:    SaveVersion,
+		Saved:      time.Now(),
+		Config:     g.Config,
+		HandNumber: g.HandNumber,
+		DealerPos:  g.DealerPos,
+		Seats:      len(g.Seats()),
+
+		// Only set once a hand ends, so a hand in progress has not changed them
+		LastBigBlind:   g.lastBigBlind,
+		LastBigBlindAt: g.lastBigBlindAt,
+		LastSmallBlind: g.lastSmallBlind,
+	}
+	if midHand {
+		s.HandNumber--
+	}
+	// Players dealt back in to post missed blinds are saved still sitting
+	// out, to be dealt in again when the hand is
+	sitting := maps.Clone(g.sitters)
+	if midHand {
+		for _, st := range g.posting {
+			if sitting == nil {
+				sitting = make(map[string]*sitter)
+			}
+			sitting[st.player.GetID()] = st
+		}
+	}
+	button, dealtIn := "", 0
+	if g.DealerPos >= 0 && g.DealerPos < len(g.Players) {
+		button = g.Players[g.DealerPos].GetID()
+	}
+	for _, seat := range g.Seats() {
+		if seat.Empty() {
+			continue
+		}
+		p := seat.Player
+		sp := SavedPlayer{ID: p.GetID(), Seat: seat.Number, Chips: p.GetChips(), Human: p.IsHuman(), Rebuys: g.rebuys[p.GetID()]}
+		if start, ok := g.handStartChips[p.GetID()]; ok && midHand {
+			sp.Chips = start
+		}
+		if bot, ok := p.(difficultyReporter); ok {
+			sp.Difficulty = bot.GetDifficulty()
+		}
+		if st, ok := sitting[p.GetID()]; ok {
+			sp.SittingOut, sp.Waiting, sp.SittingIn = true, st.waiting, st.back
+			sp.MissedSmall, sp.MissedBig = st.missedSmall, st.missedBig
+		} else {
+			if p.GetID() == button {
+				s.DealerPos = dealtIn
+			}
+			dealtIn++
+		}
+		s.Players = append(s.Players, sp)
+	}
+	if g.Schedule != nil {
+		s.Schedule = &SavedSchedule{Levels: g.Schedule.Levels, Level: g.Schedule.level, HandsAtLevel: g.Schedule.handsAtLevel}
+		if midHand && s.Schedule.HandsAtLevel > 0 {
+			s.Schedule.HandsAtLevel-- // The abandoned hand does not count towards the level
+		}
+	}
+	return s
+}
+
+// Restore continues a game from a snapshot. The game must have been created
+// with the saved players in the saved order; those saved sitting out are
+// taken out of Players again.
+func (g *Game) Restore(s SavedGame) error {
+	if len(g.Players) != len(s.Players) {
+		return fmt.Errorf("restore: game has %d players, save has %d", len(g.Players), len(s.Players))
+	}
+	for i, p := range g.Players {
+		if p.GetID() != s.Players[i].ID {
+			return fmt.Errorf("restore: seat %d is %s, save has %s", i, p.GetID(), s.Players[i].ID)
+		}
+	}
+	if s.Seats > 0 {
+		seats, err := s.seats(g.Players)
+		if err != nil {
+			return err
+		}
+		g.seats = seats
+	}
+	var dealtIn []types.Player
+	for i, sp := range s.Players {
+		if !sp.SittingOut {
+			dealtIn = append(dealtIn, g.Players[i])
+			continue
+		}
+		if g.sitters == nil {
+			g.sitters = make(map[string]*sitter)
+		}
+		g.sitters[sp.ID] = &sitter{player: g.Players[i], waiting: sp.Waiting, back: sp.SittingIn, missedSmall: sp.MissedSmall, missedBig: sp.MissedBig}
+	}
+	g.Players = dealtIn
+	g.HandNumber = s.HandNumber
+	g.DealerPos = s.DealerPos
+	g.lastBigBlind, g.lastBigBlindAt, g.lastSmallBlind = s.LastBigBlind, s.LastBigBlindAt, s.LastSmallBlind
+	for _, sp := range s.Players {
+		if sp.Rebuys > 0 {
+			if g.rebuys == nil {
+				g.rebuys = make(map[string]int)
+			}
+			g.rebuys[sp.ID] = sp.Rebuys
+		}
+	}
+	if s.Schedule != nil {
+		g.Schedule = NewBlindSchedule(s.Schedule.Levels)
+		g.Schedule.level = s.Schedule.Level
+		g.Schedule.handsAtLevel = s.Schedule.HandsAtLevel
+		level := g.Schedule.Current()
+		g.SmallBlind, g.BigBlind, g.Ante = level.SmallBlind, level.BigBlind, level.Ante
+	}
+	return nil
+}
+
+// seats seats players, the saved players in order, at their saved seat
+// numbers, leaving the seats between them empty.
+func (s SavedGame) seats(players []types.Player) ([]Seat, error) {
+	seats := make([]Seat, s.Seats)
+	for i := range seats {
+		seats[i].Number = i + 1
+	}
+	last := 0
+	for i, sp := range s.Players {
+		if sp.Seat <= last || sp.Seat > s.Seats {
+			return nil, fmt.Errorf("restore: %s is in seat %d, which is not after seat %d at a table of %d", sp.ID, sp.Seat, last, s.Seats)
+		}
+		seats[sp.Seat-1].Player = players[i]
+		last = sp.Seat
+	}
+	return seats, nil
+}
+
+// SaveRequested reports whether the game stopped because a player asked to save it.
+func (g *Game) SaveRequested() bool {
+	return g.saveRequested
+}
+
+// SaveGame writes a snapshot to path, replacing any previous save atomically.
+func SaveGame(path string, s SavedGame) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// LoadGame reads a snapshot written by SaveGame.
+func LoadGame(path string) (SavedGame, error) {
+	var s SavedGame
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return s, err
+	}
+	if err := json.Unmarshal(data, &s); err != nil {
+		return s, fmt.Errorf("load %s: %w", path, err)
+	}
+	if s.Version < 1 || s.Version > SaveVersion {
+		return s, fmt.Errorf("load %s: unsupported save version %d", path, s.Version)
+	}
+	if len(s.Players) < 2 {
+		return s, fmt.Errorf("load %s: a game needs at least 2 players, save has %d", path, len(s.Players))
+	}
+	if err := s.Config.Validate(); err != nil {
+		return s, fmt.Errorf("load %s: %w", path, err)
+	}
+	return s, nil
+}
@@ -0,0 +1,121 @@
+package game
+
+import (
+	"context"
+	"io"
+	"path/filepath"
+	"testing"
+
+	"pokerclientv1/pkg/types"
+)
+
+// TestSaveMidHandRestoresStartOfHand checks that saving during a hand keeps
+// the stacks and blind level from before the hand, and that a resumed game
+// continues from there.
+func TestSaveMidHandRestoresStartOfHand(t *testing.T) {
+	p1 := NewMockPlayer("P1", 100, true)
+	p2 := NewMockPlayer("P2", 100, false)
+	p3 := NewMockPlayer("P3", 100, false)
+	p1.ActionQueue = []struct {
+		Action string
+		Amount int
+	}{{"save", 0}}
+
+	g := NewGame([]types.Player{p1, p2, p3}, &MockUI{}, 0, DefaultGameConfig())
+	g.Out = io.Discard
+	g.Schedule = DoublingSchedule(1, 2, 5, 3)
+	g.Start(context.Background())
+
+	if !g.SaveRequested() {
+		t.Fatalf("SaveRequested() = false after the player saved")
+	}
+	saved := g.Snapshot()
+	if saved.HandNumber != 0 {
+		t.Errorf("Snapshot().HandNumber = %d, want 0 for a save during the first hand", saved.HandNumber)
+	}
+	for _, sp := range saved.Players {
+		if sp.Chips != 100 {
+			t.Errorf("Snapshot() %s chips = %d, want the 100 from before the blinds", sp.ID, sp.Chips)
+		}
+	}
+	if !saved.Players[0].Human || saved.Players[1].Human {
+		t.Errorf("Snapshot() human flags = %v, %v, want true, false", saved.Players[0].Human, saved.Players[1].Human)
+	}
+	if saved.Schedule == nil || saved.Schedule.HandsAtLevel != 0 {
+		t.Errorf("Snapshot().Schedule = %+v, want the abandoned hand not counted", saved.Schedule)
+	}
+
+	path := filepath.Join(t.TempDir(), "save.json")
+	if err := SaveGame(path, saved); err != nil {
+		t.Fatalf("SaveGame() error: %v", err)
+	}
+	loaded, err := LoadGame(path)
+	if err != nil {
+		t.Fatalf("LoadGame() error: %v", err)
+	}
+
+	resumed := NewGame([]types.Player{
+		NewMockPlayer("P1", 100, true), NewMockPlayer("P2", 100, false), NewMockPlayer("P3", 100, false),
+	}, &MockUI{}, 0, loaded.Config)
+	resumed.Out = io.Discard
+	if err := resumed.Restore(loaded); err != nil {
+		t.Fatalf("Restore() error: %v", err)
+	}
+	resumed.PlayNextHand()
+	if resumed.HandNumber != 1 {
+		t.Errorf("resumed HandNumber = %d, want 1", resumed.HandNumber)
+	}
+	if resumed.Schedule.handsAtLevel != 1 {
+		t.Errorf("resumed schedule hands at level = %d, want 1", resumed.Schedule.handsAtLevel)
+	}
+
+	wrongSeats := NewGame([]types.Player{NewMockPlayer("P2", 100, false), NewMockPlayer("P1", 100, true), NewMockPlayer("P3", 100, false)}, &MockUI{}, 0, loaded.Config)
+	if err := wrongSeats.Restore(loaded); err == nil {
+		t.Errorf("Restore() with players in the wrong seats = nil, want an error")
+	}
+}
+
+// TestSaveAfterBustKeepsBlinds checks that a game saved after the big blind
+// busts resumes with the blinds moving on from the empty seat, as the game
+// would have played on without the save.
+func TestSaveAfterBustKeepsBlinds(t *testing.T) {
+	g := NewGame([]types.Player{
+		NewMockPlayer("P1", 100, false), NewMockPlayer("P2", 100, false),
+		NewMockPlayer("P3", 100, false), NewMockPlayer("P4", 100, false),
+		NewMockPlayer("P5", 100, false),
+	}, &MockUI{}, 0, DefaultGameConfig())
+	g.Out = io.Discard
+	g.determineBlinds()
+	g.recordBlinds()
+	g.Players[g.BigBlindPos].Bet(100) // P3 busts in the big blind
+	g.removeBrokePlayers(context.Background())
+
+	path := filepath.Join(t.TempDir(), "save.json")
+	if err := SaveGame(path, g.Snapshot()); err != nil {
+		t.Fatalf("SaveGame() error: %v", err)
+	}
+	loaded, err := LoadGame(path)
+	if err != nil {
+		t.Fatalf("LoadGame() error: %v", err)
+	}
+	var players []types.Player
+	for _, sp := range loaded.Players {
+		players = append(players, NewMockPlayer(sp.ID, sp.Chips, sp.Human))
+	}
+	resumed := NewGame(players, &MockUI{}, 0, loaded.Config)
+	resumed.Out = io.Discard
+	if err := resumed.Restore(loaded); err != nil {
+		t.Fatalf("Restore() error: %v", err)
+	}
+	if n := resumed.SeatNumber("P4"); n != 4 {
+		t.Errorf("resumed SeatNumber(P4) = %d, want 4 with P3's seat left empty", n)
+	}
+
+	g.determineBlinds()
+	resumed.determineBlinds()
+	for _, game := range []*Game{g, resumed} {
+		if game.SmallBlindPos >= 0 || game.Players[game.DealerPos].GetID() != "P2" || game.Players[game.BigBlindPos].GetID() != "P4" {
+			t.Errorf("blinds after P3 busts: button %d, blinds %d/%d, want P2, a dead small blind and P4", game.DealerPos, game.SmallBlindPos, game.BigBlindPos)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package game
+
+import (
+	"slices"
+
+	"pokerclientv1/pkg/types"
+)
+
+// Seat is a numbered place at the table. Seat numbers are fixed for the
+// game: a player busting or leaving empties their seat instead of moving
+// everyone after them down one, so the button and blinds keep their places.
+type Seat struct {
+	Number int          // From 1, clockwise
+	Player types.Player // Nil when the seat is empty
+}
+
+// Empty reports whether nobody sits in the seat.
+func (s Seat) Empty() bool {
+	return s.Player == nil
+}
+
+// Seats returns the table's seats in order, empty ones included.
+func (g *Game) Seats() []Seat {
+	g.syncSeats()
+	return slices.Clone(g.seats)
+}
+
+// SeatNumber returns the number of the seat a player sits in, or 0 if they
+// are not seated.
+func (g *Game) SeatNumber(playerID string) int {
+	g.syncSeats()
+	for _, s := range g.seats {
+		if !s.Empty() && s.Player.GetID() == playerID {
+			return s.Number
+		}
+	}
+	return 0
+}
+
+// syncSeats brings the seats in line with Players, which lists the seated
+// players in seat order. The seats of players no longer in Players are
+// emptied, unless they are sitting out, and players without a seat take new
+// ones after the last. Callers may swap a player in Players for another with
+// the same ID, so each seat takes the player now going by its player's ID.
+func (g *Game) syncSeats() {
+	unseated := make(map[string]types.Player, len(g.Players)+len(g.sitters))
+	for _, p := range g.Players {
+		unseated[p.GetID()] = p
+	}
+	for id, s := range g.sitters {
+		unseated[id] = s.player
+	}
+	for i := range g.seats {
+		s := &g.seats[i]
+		if s.Empty() {
+			continue
+		}
+		id := s.Player.GetID()
+		s.Player = unseated[id]
+		delete(unseated, id)
+	}
+	for _, p := range g.Players {
+		if _, ok := unseated[p.GetID()]; ok {
+			g.seats = append(g.seats, Seat{Number: len(g.seats) + 1, Player: p})
+		}
+	}
+}
+
+// takeSeat seats a player in the first empty seat, or a new one after the
+// last, and returns the index they take in Players.
+func (g *Game) takeSeat(p types.Player) int {
+	g.syncSeats()
+	i := slices.IndexFunc(g.seats, Seat.Empty)
+	if i < 0 {
+		g.seats = append(g.seats, Seat{Number: len(g.seats) + 1})
+		i = len(g.seats) - 1
+	}
+	g.seats[i].Player = p
+	index := 0
+	for _, s := range g.seats[:i] {
+		if !s.Empty() {
+			index++
+		}
+	}
+	g.Players = slices.Insert(g.Players, index, p)
+	return index
+}
+
+// indexFromSeat returns the index in Players of the first player seated at
+// or after seat number n, going clockwise, or 0 if nobody is seated.
+func (g *Game) indexFromSeat(n int) int {
+	g.syncSeats()
+	for i := range g.seats {
+		s := g.seats[(max(n, 1)-1+i)%len(g.seats)]
+		if s.Empty() {
+			continue
+		}
+		id := s.Player.GetID()
+		if index := slices.IndexFunc(g.Players, func(p types.Player) bool { return p.GetID() == id }); index >= 0 {
+			return index
+		}
+	}
+	return 0
+}
+
+// buttonSeat returns the number of the seat DealerPos points at, or 0.
+func (g *Game) buttonSeat() int {
+	if g.DealerPos < 0 || g.DealerPos >= len(g.Players) {
+		return 0
+	}
+	return g.SeatNumber(g.Players[g.DealerPos].GetID())
+}
@@ -0,0 +1,213 @@
+package game
+
+import (
+	"fmt"
+	"slices"
+
+	"pokerclientv1/pkg/types"
+)
+
+// sitter is a player who keeps their seat and chips but is not dealt in:
+// sitting out, or new to a cash game under way and waiting for the big blind.
+type sitter struct {
+	player  types.Player
+	waiting bool // New to the table, dealt in as the big blind without owing anything
+	back    bool // Asked to sit in, dealt in from the next hand
+
+	// Blinds that passed the player while they were away, posted when they
+	// come back anywhere but in the big blind
+	missedSmall bool
+	missedBig   bool
+}
+
+// comingIn reports whether the sitter wants to be dealt in.
+func (s *sitter) comingIn() bool {
+	return s.waiting || s.back
+}
+
+// SitOut stops dealing a player in between hands of a cash game. They keep
+// their seat and chips, and the blinds that pass their seat while they are
+// away are owed when they come back; see SitIn. It returns false if the
+// player is not dealt in.
+func (g *Game) SitOut(playerID string) bool {
+	i := slices.IndexFunc(g.Players, func(p types.Player) bool { return p.GetID() == playerID })
+	if i < 0 {
+		return false
+	}
+	button := g.buttonSeat()
+	g.addSitter(&sitter{player: g.Players[i]})
+	g.Players = slices.Delete(g.Players, i, i+1)
+	g.DealerPos = g.indexFromSeat(button)
+	fmt.Fprintf(g.Out, "\n>> %s sits out.\n", playerID)
+	return true
+}
+
+// SitIn deals a player who is sitting out back in from the next hand. If
+// the big blind reaches their seat then they post it and owe nothing;
+// anywhere else they post the blinds they missed, a live big blind and a
+// dead small blind. A new player waiting for the big blind may sit in at
+// once by posting a big blind. It returns false if the player is not
+// sitting out.
+func (g *Game) SitIn(playerID string) bool {
+	s, ok := g.sitters[playerID]
+	if !ok {
+		return false
+	}
+	if s.waiting {
+		s.waiting, s.missedBig = false, true
+	}
+	s.back = true
+	return true
+}
+
+// SittingOut reports whether a seated player is not being dealt in, either
+// sitting out or waiting for the big blind.
+func (g *Game) SittingOut(playerID string) bool {
+	_, ok := g.sitters[playerID]
+	return ok
+}
+
+// addSitter keeps a player's seat while they are not dealt in.
+func (g *Game) addSitter(s *sitter) {
+	g.syncSeats()
+	if g.sitters == nil {
+		g.sitters = make(map[string]*sitter)
+	}
+	g.sitters[s.player.GetID()] = s
+}
+
+// seatWaiting seats a player who joins a cash game under way in the first
+// empty seat, to wait there for the big blind.
+func (g *Game) seatWaiting(p types.Player) {
+	g.addSitter(&sitter{player: p, waiting: true})
+	i := slices.IndexFunc(g.seats, Seat.Empty)
+	if i < 0 {
+		g.seats = append(g.seats, Seat{Number: len(g.seats) + 1})
+		i = len(g.seats) - 1
+	}
+	g.seats[i].Player = p
+	fmt.Fprintf(g.Out, "\n>> %s sits down and waits for the big blind.\n", p.GetID())
+}
+
+// returnSitters deals players back in before a hand. The big blind is due to
+// the first seat after last hand's big blind whose player has chips and is
+// not away: a player there who is waiting for it or coming back is dealt in
+// owing nothing, and the players away in the seats it passes miss it. Last
+// hand's big blind misses the small blind if they are away. Others coming
+// back are dealt in to post what they missed, see postMissedBlinds. Before
+// the first hand, or when fewer than two players would be dealt in, everyone
+// waiting or coming back is dealt in owing nothing.
+func (g *Game) returnSitters() {
+	g.posting = nil
+	if len(g.sitters) == 0 {
+		return
+	}
+	g.syncSeats()
+	sitters := g.sittersBySeat()
+	dealtIn := len(g.getPlayersWithChips())
+	for _, s := range sitters {
+		if s.back && s.player.GetChips() > 0 {
+			dealtIn++
+		}
+	}
+	if g.lastBigBlindAt == 0 || dealtIn < 2 {
+		for _, s := range sitters {
+			if s.comingIn() && s.player.GetChips() > 0 {
+				s.missedSmall, s.missedBig = false, false
+				g.dealIn(s)
+			}
+		}
+		return
+	}
+
+	if s, ok := g.sitters[g.lastBigBlind]; ok && !s.comingIn() {
+		s.missedSmall = true
+	}
+	for i := 1; i <= len(g.seats); i++ {
+		seat := g.seats[(g.lastBigBlindAt-1+i)%len(g.seats)]
+		if seat.Empty() || seat.Player.GetChips() == 0 {
+			continue
+		}
+		s, away := g.sitters[seat.Player.GetID()]
+		if !away {
+			break // A player already dealt in is due the big blind
+		}
+		if s.comingIn() {
+			s.missedSmall, s.missedBig = false, false
+			g.dealIn(s)
+			break
+		}
+		s.missedBig = true
+	}
+	for _, s := range sitters {
+		if s.back && s.player.GetChips() > 0 && g.SittingOut(s.player.GetID()) {
+			g.dealIn(s)
+		}
+	}
+}
+
+// sittersBySeat returns the sitters in seat order.
+func (g *Game) sittersBySeat() []*sitter {
+	var sitters []*sitter
+	for _, seat := range g.seats {
+		if seat.Empty() {
+			continue
+		}
+		if s, ok := g.sitters[seat.Player.GetID()]; ok {
+			sitters = append(sitters, s)
+		}
+	}
+	return sitters
+}
+
+// dealIn moves a sitter back into Players at their seat, to post the
+// blinds they missed in the coming hand.
+func (g *Game) dealIn(s *sitter) {
+	id := s.player.GetID()
+	index := 0
+	for _, seat := range g.seats {
+		if seat.Empty() {
+			continue
+		}
+		if seat.Player.GetID() == id {
+			break
+		}
+		if slices.ContainsFunc(g.Players, func(p types.Player) bool { return p.GetID() == seat.Player.GetID() }) {
+			index++
+		}
+	}
+	delete(g.sitters, id)
+	g.Players = slices.Insert(g.Players, index, s.player)
+	if index <= g.DealerPos && len(g.Players) > 1 {
+		g.DealerPos++
+	}
+	if s.missedSmall || s.missedBig {
+		g.posting = append(g.posting, s)
+	}
+	fmt.Fprintf(g.Out, "\n>> %s is dealt in.\n", id)
+}
+
+// postMissedBlinds makes the players dealt back in this hand post the
+// blinds they missed: a missed big blind live, counting towards their bet,
+// and a missed small blind dead, into the pot only. A player posting a
+// blind this hand owes nothing more.
+func (g *Game) postMissedBlinds() {
+	for _, s := range g.posting {
+		p := s.player
+		i := slices.Index(g.Players, p)
+		if i < 0 || i == g.BigBlindPos || i == g.SmallBlindPos {
+			continue
+		}
+		if s.missedBig {
+			amount := g.forceBet(p, g.BigBlind)
+			g.display().LogAction(p.GetID(), "posts missed big blind", amount)
+			g.emit(Event{Type: EventBlindPosted, PlayerID: p.GetID(), Action: "missed big blind", Amount: amount})
+		}
+		if s.missedSmall && p.GetChips() > 0 {
+			amount := p.Bet(g.SmallBlind) // Dead, like an ante
+			g.addToPot(p, amount)
+			g.display().LogAction(p.GetID(), "posts dead small blind", amount)
+			g.emit(Event{Type: EventBlindPosted, PlayerID: p.GetID(), Action: "dead small blind", Amount: amount})
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package game
+
+import (
+	"io"
+	"testing"
+
+	"pokerclientv1/pkg/types"
+)
+
+// newSitOutGame seats P1 to P5 with 100 chips and sets the first hand's
+// blinds: P1 on the button, P2 and P3 in the blinds.
+func newSitOutGame(t *testing.T) *Game {
+	t.Helper()
+	var players []types.Player
+	for _, id := range []string{"P1", "P2", "P3", "P4", "P5"} {
+		players = append(players, NewMockPlayer(id, 100, false))
+	}
+	g := NewGame(players, &MockUI{}, 0, DefaultGameConfig())
+	g.Out = io.Discard
+	g.determineBlinds()
+	g.recordBlinds()
+	return g
+}
+
+// nextHand deals in the players due to come back and plays the next hand's
+// blinds, as far as posting them.
+func nextHand(g *Game) {
+	g.returnSitters()
+	g.resetForNewHand()
+	g.determineBlinds()
+	g.postBlinds()
+	g.recordBlinds()
+}
+
+// TestNewPlayerWaitsForBigBlind checks that a player who sits down in a cash
+// game under way is dealt in when the big blind reaches their seat.
+func TestNewPlayerWaitsForBigBlind(t *testing.T) {
+	g := newSitOutGame(t)
+	g.Unseat("P5")
+	p6 := NewMockPlayer("P6", 100, false)
+	g.Seat(p6)
+	if !g.SittingOut("P6") || len(g.Players) != 4 || g.SeatNumber("P6") != 5 {
+		t.Fatalf("after P6 sits down: sitting out %v, %d dealt in, seat %d, want P6 waiting in seat 5", g.SittingOut("P6"), len(g.Players), g.SeatNumber("P6"))
+	}
+
+	// P4 has the big blind, P6 keeps waiting
+	nextHand(g)
+	if id := g.Players[g.BigBlindPos].GetID(); id != "P4" || !g.SittingOut("P6") {
+		t.Errorf("second hand: big blind %s, P6 sitting out %v, want P4 and true", id, g.SittingOut("P6"))
+	}
+
+	// The big blind reaches P6, who posts it and nothing more
+	nextHand(g)
+	if id := g.Players[g.BigBlindPos].GetID(); id != "P6" || g.SittingOut("P6") {
+		t.Fatalf("third hand: big blind %s, P6 sitting out %v, want P6 dealt in", id, g.SittingOut("P6"))
+	}
+	if p6.GetChips() != 98 || p6.GetCurrentBet() != 2 {
+		t.Errorf("P6 has %d chips and a bet of %d, want 98 and the big blind of 2", p6.GetChips(), p6.GetCurrentBet())
+	}
+
+	// In tournaments players who sit down are dealt in at once
+	tg := newSitOutGame(t)
+	tg.tournament = true
+	tg.Unseat("P5")
+	tg.Seat(NewMockPlayer("P6", 100, false))
+	if tg.SittingOut("P6") || len(tg.Players) != 5 {
+		t.Errorf("tournament: P6 sitting out %v with %d dealt in, want P6 dealt in", tg.SittingOut("P6"), len(tg.Players))
+	}
+}
+
+// TestSitOutOwesMissedBlinds checks that a player away when the big blind
+// passes their seat posts it live on coming back, and that last hand's big
+// blind who sits out leaves a dead small blind and owes it.
+func TestSitOutOwesMissedBlinds(t *testing.T) {
+	g := newSitOutGame(t)
+	p1, p4 := g.Players[0], g.Players[3]
+
+	// Hand 2: the big blind passes P4, sitting out, to P5
+	g.SitOut("P4")
+	nextHand(g)
+	if id := g.Players[g.BigBlindPos].GetID(); id != "P5" || len(g.Players) != 4 {
+		t.Fatalf("hand 2: big blind %s with %d dealt in, want P5 with P4 out", id, len(g.Players))
+	}
+
+	// Hand 3: P4 comes back between the button and the blinds and posts a live big blind
+	g.SitIn("P4")
+	nextHand(g)
+	if g.SittingOut("P4") || p4.GetChips() != 98 || p4.GetCurrentBet() != 2 {
+		t.Errorf("hand 3: P4 sitting out %v with %d chips and a bet of %d, want dealt in with 98 and 2", g.SittingOut("P4"), p4.GetChips(), p4.GetCurrentBet())
+	}
+	if id := g.Players[g.BigBlindPos].GetID(); id != "P1" {
+		t.Errorf("hand 3: big blind %s, want P1", id)
+	}
+
+	// Hand 4: P1, last hand's big blind, sits out, so the small blind is dead
+	p1.AddChips(100 - p1.GetChips())
+	g.SitOut("P1")
+	nextHand(g)
+	if g.SmallBlindPos >= 0 || g.Players[g.BigBlindPos].GetID() != "P2" {
+		t.Errorf("hand 4: small blind %d and big blind %s, want a dead small blind and P2", g.SmallBlindPos, g.Players[g.BigBlindPos].GetID())
+	}
+
+	// Hand 5: P1 comes back and posts the small blind they missed, dead
+	g.SitIn("P1")
+	pot := 0
+	g.OnEvent(func(e Event) {
+		if e.Type == EventBlindPosted && e.PlayerID == "P1" && e.Action == "dead small blind" {
+			pot += e.Amount
+		}
+	})
+	nextHand(g)
+	if p1.GetChips() != 99 || p1.GetCurrentBet() != 0 || pot != 1 {
+		t.Errorf("hand 5: P1 has %d chips and a bet of %d, posted %d dead, want 99, 0 and 1", p1.GetChips(), p1.GetCurrentBet(), pot)
+	}
+}
+
+// TestSaveSittingOut checks that a player sitting out is saved with the
+// blinds they owe and resumed still sitting out in their seat.
+func TestSaveSittingOut(t *testing.T) {
+	g := newSitOutGame(t)
+	g.SitOut("P4")
+	nextHand(g)
+	g.handStartChips = nil // Between hands
+
+	saved := g.Snapshot()
+	var players []types.Player
+	for _, sp := range saved.Players {
+		players = append(players, NewMockPlayer(sp.ID, sp.Chips, sp.Human))
+	}
+	resumed := NewGame(players, &MockUI{}, 0, saved.Config)
+	resumed.Out = io.Discard
+	if err := resumed.Restore(saved); err != nil {
+		t.Fatalf("Restore() error: %v", err)
+	}
+	if !resumed.SittingOut("P4") || len(resumed.Players) != 4 || resumed.SeatNumber("P4") != 4 {
+		t.Fatalf("resumed: P4 sitting out %v in seat %d with %d dealt in, want P4 out in seat 4", resumed.SittingOut("P4"), resumed.SeatNumber("P4"), len(resumed.Players))
+	}
+	if s := resumed.sitters["P4"]; !s.missedBig || s.missedSmall {
+		t.Errorf("resumed P4 owes %+v, want the big blind only", *s)
+	}
+	if got := resumed.Players[resumed.DealerPos].GetID(); got != g.Players[g.DealerPos].GetID() {
+		t.Errorf("resumed button = %s, want %s", got, g.Players[g.DealerPos].GetID())
+	}
+}
@@ -0,0 +1,104 @@
+package game
+
+import (
+	"slices"
+	"time"
+
+	"pokerclientv1/pkg/types"
+)
+
+// SeatState is one player as a viewer may see them. Cards are only filled in
+// for the viewer's own seat.
+type SeatState struct {
+	ID         string       `json:"id"`
+	Avatar     string       `json:"avatar,omitempty"`
+	Chips      int          `json:"chips"`
+	CurrentBet int          `json:"currentBet"`
+	Folded     bool         `json:"folded,omitempty"`
+	AllIn      bool         `json:"allIn,omitempty"`
+	Position   string       `json:"position,omitempty"`
+	Cards      []types.Card `json:"cards,omitempty"`
+}
+
+// TableState is a snapshot of the table as one viewer may see it, with every
+// other player's hole cards left out. Front ends that show a table to someone
+// other than the program running the game, such as the network server and its
+// web clients, should draw from it so privacy is enforced in one place.
+type TableState struct {
+	Stage          string       `json:"stage"`
+	Pot            int          `json:"pot"`
+	CurrentBet     int          `json:"currentBet"`
+	CommunityCards []types.Card `json:"communityCards"`
+	Players        []SeatState  `json:"players"`
+
+	// Main and side pots once a player is all-in for less than others have
+	// bet, see types.Table.Pots
+	Pots []types.TablePot `json:"pots,omitempty"`
+
+	// The player due to act and, with a turn clock, when their time runs out
+	// by the server's clock. TimeLeft is the same deadline in milliseconds
+	// from when the state was made, so clients can count down in step with
+	// the server even when their own clocks disagree with it.
+	Acting   string     `json:"acting,omitempty"`
+	Deadline *time.Time `json:"deadline,omitempty"`
+	TimeLeft int64      `json:"timeLeft,omitempty"`
+
+	// The hand and its stakes, see types.Table.HandNumber, and the time the
+	// session has lasted in milliseconds when the state was made
+	Hand       int   `json:"hand,omitempty"`
+	SmallBlind int   `json:"smallBlind,omitempty"`
+	BigBlind   int   `json:"bigBlind,omitempty"`
+	Ante       int   `json:"ante,omitempty"`
+	BlindLevel int   `json:"blindLevel,omitempty"`
+	Elapsed    int64 `json:"elapsed,omitempty"`
+}
+
+// StateFor returns the table as viewerID may see it: their own hole cards
+// and no one else's. An empty viewerID is a spectator, who sees none.
+func (g *Game) StateFor(viewerID string) *TableState {
+	return TableStateFor(viewerID, g.Table, g.Players, g.Table.Pot, g.Table.Round)
+}
+
+// TableStateFor is StateFor for the arguments a GameUI is given, so a UI can
+// redact the state it is asked to display.
+func TableStateFor(viewerID string, table *types.Table, players []types.Player, pot int, stage string) *TableState {
+	state := &TableState{
+		Stage:          stage,
+		Pot:            pot,
+		CurrentBet:     table.CurrentBet,
+		CommunityCards: append([]types.Card(nil), table.CommunityCards...),
+		Acting:         table.Acting,
+		Pots:           slices.Clone(table.Pots),
+		Hand:           table.HandNumber,
+		SmallBlind:     table.SmallBlind,
+		BigBlind:       table.BigBlind,
+		Ante:           table.Ante,
+		BlindLevel:     table.BlindLevel,
+	}
+	if !table.SessionStart.IsZero() {
+		state.Elapsed = time.Since(table.SessionStart).Milliseconds()
+	}
+	if !table.Deadline.IsZero() {
+		deadline := table.Deadline
+		state.Deadline = &deadline
+		state.TimeLeft = max(time.Until(deadline).Milliseconds(), 0)
+	}
+	for _, p := range players {
+		seat := SeatState{
+			ID:         p.GetID(),
+			Chips:      p.GetChips(),
+			CurrentBet: p.GetCurrentBet(),
+			Folded:     p.IsFolded(),
+			AllIn:      p.IsAllIn(),
+			Position:   table.Positions[p.GetID()],
+		}
+		if a, ok := p.(types.AvatarHolder); ok {
+			seat.Avatar = a.GetAvatar()
+		}
+		if viewerID != "" && p.GetID() == viewerID && p.GetHand() != nil {
+			seat.Cards = append([]types.Card(nil), p.GetHand().Cards...)
+		}
+		state.Players = append(state.Players, seat)
+	}
+	return state
+}
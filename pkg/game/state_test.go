@@ -0,0 +1,73 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"pokerclientv1/pkg/types"
+)
+
+// TestStateForRedactsHoleCards checks that a viewer sees only their own hole
+// cards and a spectator sees none.
+func TestStateForRedactsHoleCards(t *testing.T) {
+	p1 := NewMockPlayer("P1", 100, true)
+	p2 := NewMockPlayer("P2", 100, false)
+	g := NewGame([]types.Player{p1, p2}, &MockUI{}, 0, DefaultGameConfig())
+	g.dealHands(2)
+	g.Table.Round = "Pre-flop"
+
+	state := g.StateFor("P1")
+	if state.Stage != "Pre-flop" || len(state.Players) != 2 {
+		t.Fatalf("StateFor(P1) = %+v, want both seats at the pre-flop", state)
+	}
+	if len(state.Players[0].Cards) != 2 || len(state.Players[1].Cards) != 0 {
+		t.Errorf("StateFor(P1) shows %v and %v, want P1's cards only", state.Players[0].Cards, state.Players[1].Cards)
+	}
+	state.Players[0].Cards[0] = types.Card{}
+	if p1.Hand.Cards[0] == (types.Card{}) {
+		t.Errorf("StateFor(P1) shares its cards with the player's hand")
+	}
+
+	for _, seat := range g.StateFor("").Players {
+		if len(seat.Cards) != 0 {
+			t.Errorf("StateFor(\"\") shows %s's cards %v to a spectator", seat.ID, seat.Cards)
+		}
+	}
+}
+
+// TestStateForDeadline checks that the acting player's turn clock deadline
+// is in the state, along with the time left on it.
+func TestStateForDeadline(t *testing.T) {
+	g := NewGame([]types.Player{NewMockPlayer("P1", 100, true), NewMockPlayer("P2", 100, false)}, &MockUI{}, 0, DefaultGameConfig())
+	g.Table.Acting = "P2"
+	if state := g.StateFor("P1"); state.Acting != "P2" || state.Deadline != nil || state.TimeLeft != 0 {
+		t.Errorf("StateFor(P1) without a clock: acting %q, deadline %v, %dms left, want P2 and no deadline", state.Acting, state.Deadline, state.TimeLeft)
+	}
+
+	g.Table.Deadline = time.Now().Add(30 * time.Second)
+	state := g.StateFor("P1")
+	if state.Deadline == nil || !state.Deadline.Equal(g.Table.Deadline) {
+		t.Fatalf("StateFor(P1).Deadline = %v, want %v", state.Deadline, g.Table.Deadline)
+	}
+	if state.TimeLeft <= 29_000 || state.TimeLeft > 30_000 {
+		t.Errorf("StateFor(P1).TimeLeft = %dms, want about 30s", state.TimeLeft)
+	}
+}
+
+// TestStateForHeader checks that the hand, its blinds and the session's
+// length are in the state once a hand is under way.
+func TestStateForHeader(t *testing.T) {
+	g := NewGame([]types.Player{NewMockPlayer("P1", 100, true), NewMockPlayer("P2", 100, false)}, &MockUI{}, 0, DefaultGameConfig())
+	g.HandNumber = 4
+	g.updateHeader()
+	g.Table.SessionStart = g.Table.SessionStart.Add(-time.Minute)
+
+	state := g.StateFor("P1")
+	if state.Hand != 4 || state.SmallBlind != g.SmallBlind || state.BigBlind != g.BigBlind || state.BlindLevel != 0 {
+		t.Errorf("StateFor(P1) = hand %d, blinds %d/%d level %d, want hand 4, blinds %d/%d level 0",
+			state.Hand, state.SmallBlind, state.BigBlind, state.BlindLevel, g.SmallBlind, g.BigBlind)
+	}
+	if state.Elapsed < time.Minute.Milliseconds() {
+		t.Errorf("StateFor(P1).Elapsed = %dms, want at least a minute", state.Elapsed)
+	}
+}
@@ -0,0 +1,129 @@
+package game
+
+import (
+	"math/bits"
+
+	"pokerclientv1/pkg/types"
+)
+
+// HandStrength scores the best five-card hand in up to seven cards as one
+// number: the stronger hand has the higher strength and equal strengths tie,
+// exactly as CompareHands orders the values of EvaluateHand. It works on rank
+// bit masks without sorting or allocating, for Monte Carlo rollouts that
+// evaluate millions of hands. Use EvaluateHand to learn which cards make the
+// hand.
+//
+// The category is in the top bits, above five four-bit tie-break ranks.
+func HandStrength(cards []types.Card) uint32 {
+	var suits [4]uint16
+	var counts [types.Ace + 1]uint8
+	var all uint16
+	for _, c := range cards {
+		bit := uint16(1) << c.Rank
+		suits[c.Suit&3] |= bit
+		counts[c.Rank]++
+		all |= bit
+	}
+
+	var flush uint16
+	for _, mask := range suits {
+		if bits.OnesCount16(mask) >= 5 {
+			if high := straightHigh(mask); high != 0 {
+				return strength(StraightFlush, high)
+			}
+			flush = mask
+		}
+	}
+
+	// Ranks by how many of each there are, highest first
+	var quad types.Rank
+	var trips, pairs [3]types.Rank
+	nTrips, nPairs := 0, 0
+	for r := types.Ace; r >= types.Two; r-- {
+		switch counts[r] {
+		case 4:
+			quad = r
+		case 3:
+			if nTrips < len(trips) {
+				trips[nTrips] = r
+				nTrips++
+			}
+		case 2:
+			if nPairs < len(pairs) {
+				pairs[nPairs] = r
+				nPairs++
+			}
+		}
+	}
+
+	switch {
+	case quad != 0:
+		k := topRanks(all &^ (1 << quad))
+		return strength(FourOfAKind, quad, k[0])
+	case nTrips >= 2:
+		return strength(FullHouse, trips[0], trips[1])
+	case nTrips == 1 && nPairs > 0:
+		return strength(FullHouse, trips[0], pairs[0])
+	case flush != 0:
+		k := topRanks(flush)
+		return strength(Flush, k[0], k[1], k[2], k[3], k[4])
+	}
+	if high := straightHigh(all); high != 0 {
+		return strength(Straight, high)
+	}
+	switch {
+	case nTrips == 1:
+		k := topRanks(all &^ (1 << trips[0]))
+		return strength(ThreeOfAKind, trips[0], k[0], k[1])
+	case nPairs >= 2:
+		k := topRanks(all &^ (1<<pairs[0] | 1<<pairs[1]))
+		return strength(TwoPair, pairs[0], pairs[1], k[0])
+	case nPairs == 1:
+		k := topRanks(all &^ (1 << pairs[0]))
+		return strength(OnePair, pairs[0], k[0], k[1], k[2])
+	}
+	k := topRanks(all)
+	return strength(HighCard, k[0], k[1], k[2], k[3], k[4])
+}
+
+// strength packs a category and up to five tie-break ranks, most important
+// first.
+func strength(rank HandRank, ranks ...types.Rank) uint32 {
+	s := uint32(rank)
+	for i := 0; i < 5; i++ {
+		s <<= 4
+		if i < len(ranks) {
+			s |= uint32(ranks[i])
+		}
+	}
+	return s
+}
+
+// topRanks returns the five highest ranks in a rank mask, highest first and
+// padded with zeros when it has fewer.
+func topRanks(mask uint16) [5]types.Rank {
+	var ranks [5]types.Rank
+	for i := range ranks {
+		if mask == 0 {
+			break
+		}
+		high := 15 - bits.LeadingZeros16(mask)
+		ranks[i] = types.Rank(high)
+		mask &^= 1 << high
+	}
+	return ranks
+}
+
+// straightHigh returns the top rank of the highest straight in a rank mask,
+// Five for the wheel, or 0 when there is none.
+func straightHigh(mask uint16) types.Rank {
+	if mask&(1<<types.Ace) != 0 {
+		mask |= 1 << 1 // The ace also plays low
+	}
+	for high := types.Ace; high >= types.Five; high-- {
+		if run := uint16(0x1F) << (high - 4); mask&run == run {
+			return high
+		}
+	}
+	return 0
+}
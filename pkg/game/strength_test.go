@@ -0,0 +1,99 @@
+package game
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"pokerclientv1/pkg/types"
+)
+
+// randomCards deals n distinct cards from a fresh deck.
+func randomCards(rng *rand.Rand, n int) []types.Card {
+	deck := NewDeck().cards
+	rng.Shuffle(len(deck), func(i, j int) { deck[i], deck[j] = deck[j], deck[i] })
+	return append([]types.Card(nil), deck[:n]...)
+}
+
+// TestHandStrengthMatchesEvaluateHand checks that the fast evaluator orders
+// random hands of every size exactly as EvaluateHand and CompareHands do.
+func TestHandStrengthMatchesEvaluateHand(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 20000; i++ {
+		n := 5 + i%3
+		a, b := randomCards(rng, n), randomCards(rng, n)
+		want := CompareHands(EvaluateHand(a), EvaluateHand(b))
+		sa, sb := HandStrength(a), HandStrength(b)
+		got := 0
+		switch {
+		case sa > sb:
+			got = 1
+		case sa < sb:
+			got = -1
+		}
+		if got != want {
+			t.Fatalf("%v (%s) vs %v (%s): HandStrength orders them %d, CompareHands %d",
+				a, EvaluateHand(a).Describe(), b, EvaluateHand(b).Describe(), got, want)
+		}
+		if rank := HandRank(sa >> 20); rank != EvaluateHand(a).Rank {
+			t.Fatalf("HandStrength(%v) has category %s, want %s", a, rank, EvaluateHand(a).Rank)
+		}
+	}
+}
+
+// TestHandStrengthEdgeCases checks hands the bit masks could get wrong.
+func TestHandStrengthEdgeCases(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want int
+	}{
+		{"wheel loses to six-high straight", "As 2d 3c 4h 5s Kd Kc", "2d 3c 4h 5s 6s Kd Kc", -1},
+		{"steel wheel is a straight flush", "As 2s 3s 4s 5s Kd Kc", "Ks Qs Js Ts 8s 8d 8c", 1},
+		{"two trips make the best full house", "9s 9d 9c Ks Kd Kc 2h", "Ks Kd Kc 9s 9d 2c 2h", 0},
+		{"third pair can be the kicker", "Ks Kd 9s 9d 7c 7h 2c", "Ks Kd 9s 9d 6c 5h 2d", 1},
+		{"flush beats a straight", "2h 5h 9h Jh Kh Qd Tc", "9d Ts Jc Qd Kc 2s 3s", 1},
+		{"quads kicker", "8s 8d 8c 8h As 2d 3c", "8s 8d 8c 8h Ks Qd Jc", 1},
+	}
+	for _, tt := range tests {
+		a, b := HandStrength(parseCards(t, strings.Fields(tt.a)...)), HandStrength(parseCards(t, strings.Fields(tt.b)...))
+		got := 0
+		switch {
+		case a > b:
+			got = 1
+		case a < b:
+			got = -1
+		}
+		if got != tt.want {
+			t.Errorf("%s: got %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func BenchmarkEvaluateHand7(b *testing.B) {
+	hands := benchHands(1024)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		EvaluateHand(hands[i%len(hands)])
+	}
+	b.ReportMetric(float64(b.N)/b.Elapsed().Seconds(), "evals/s")
+}
+
+func BenchmarkHandStrength7(b *testing.B) {
+	hands := benchHands(1024)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		HandStrength(hands[i%len(hands)])
+	}
+	b.ReportMetric(float64(b.N)/b.Elapsed().Seconds(), "evals/s")
+}
+
+// benchHands deals n random seven-card hands.
+func benchHands(n int) [][]types.Card {
+	rng := rand.New(rand.NewSource(1))
+	hands := make([][]types.Card, n)
+	for i := range hands {
+		hands[i] = randomCards(rng, 7)
+	}
+	return hands
+}
@@ -0,0 +1,235 @@
+package game
+
+import (
+	"sort"
+	"sync"
+
+	"pokerclientv1/pkg/types"
+)
+
+// Standing is a player's finishing position in a tournament.
+type Standing struct {
+	Place    int
+	PlayerID string
+	Chips    int    // Chips at the end, 0 for eliminated players
+	Hand     int    // Hand number the player was eliminated in, 0 if still playing
+	Table    string // Table the player was eliminated at, in a multi-table tournament
+	Payout   int
+
+	CashedOut bool // Left the tournament for Payout before it was over, see Tournament.CashOuts
+}
+
+// Tournament tracks a freezeout: a blind schedule, the order players bust out
+// in and how the prize pool is paid. A multi-table tournament attaches every
+// table of its Lobby.
+type Tournament struct {
+	BuyIn      int
+	Payouts    []int // Percentage of the prize pool per finishing place, e.g. 50, 30, 20
+	Schedule   *BlindSchedule
+	mu         sync.Mutex // Tables report eliminations concurrently
+	entrants   int
+	resumed    bool // Entrants were restored from a save, not counted by Attach
+	tables     int  // Games attached so far
+	eliminated []Standing
+	startStack map[string]int // Chips each player had when their current hand started
+	stacks     map[string]int // Chips each player had when their last hand ended, for ICM
+	cashedOut  []Standing
+
+	// Experimental: between hands, offer each player their ICM value to leave
+	// the tournament. It is paid out of the prize pool and the places still
+	// to finish share what is left. Bots take the offer by their attitude to
+	// risk, humans are asked.
+	CashOuts bool
+}
+
+// NewTournament creates a tournament with the given buy-in, blind schedule and payout percentages.
+func NewTournament(buyIn int, schedule *BlindSchedule, payouts []int) *Tournament {
+	return &Tournament{
+		BuyIn:      buyIn,
+		Payouts:    payouts,
+		Schedule:   schedule,
+		startStack: make(map[string]int),
+		stacks:     make(map[string]int),
+	}
+}
+
+// Attach connects the tournament to a game: the game follows the blind
+// schedule and every elimination is recorded. Each table of a multi-table
+// tournament is attached, and every table after the first follows its own
+// copy of the schedule, since levels go up by the hands played at a table.
+func (t *Tournament) Attach(g *Game) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !t.resumed {
+		t.entrants += len(g.Players)
+	}
+	t.tables++
+	g.Schedule = t.Schedule
+	if t.tables > 1 && t.Schedule != nil {
+		g.Schedule = NewBlindSchedule(t.Schedule.Levels)
+	}
+	g.tournament = true
+	g.cashOutOffer = t.cashOutOffer
+	g.OnEvent(func(e Event) {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		switch e.Type {
+		case EventHandStarted:
+			for _, p := range g.Players {
+				t.startStack[p.GetID()] = p.GetChips()
+			}
+		case EventHandEnded:
+			for _, p := range g.Players {
+				t.stacks[p.GetID()] = p.GetChips()
+			}
+		case EventPlayerEliminated:
+			delete(t.stacks, e.PlayerID)
+			t.eliminated = append(t.eliminated, Standing{PlayerID: e.PlayerID, Hand: e.HandNumber, Table: e.Table})
+		case EventCashedOut:
+			delete(t.stacks, e.PlayerID)
+			t.cashedOut = append(t.cashedOut, Standing{PlayerID: e.PlayerID, Hand: e.HandNumber, Table: e.Table, Payout: e.Amount, CashedOut: true})
+		}
+	})
+}
+
+// cashOutOffer returns a player's ICM value and chip value when cash-outs
+// are on. Stacks at other tables are as of their last hand.
+func (t *Tournament) cashOutOffer(p types.Player) (value, chipValue int, ok bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !t.CashOuts || p.GetChips() == 0 {
+		return 0, 0, false
+	}
+	t.stacks[p.GetID()] = p.GetChips()
+	ids := []string{}
+	for id, chips := range t.stacks {
+		if chips > 0 {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	stacks := make([]int, len(ids))
+	total := 0
+	for i, id := range ids {
+		stacks[i] = t.stacks[id]
+		total += stacks[i]
+	}
+	prizes := t.prizes(len(ids))
+	pool := 0
+	for _, prize := range prizes {
+		pool += prize
+	}
+	equity := ICM(stacks, prizes)
+	value = int(equity[sort.SearchStrings(ids, p.GetID())])
+	return value, pool * p.GetChips() / total, value > 0
+}
+
+// prizes returns the payouts of the first n places from what is left of the
+// prize pool after cash-outs. The caller holds t.mu.
+func (t *Tournament) prizes(n int) []int {
+	pool := t.BuyIn * t.entrants
+	for _, s := range t.cashedOut {
+		pool -= s.Payout
+	}
+	prizes := []int{}
+	for i := 0; i < n && i < len(t.Payouts); i++ {
+		prizes = append(prizes, pool*t.Payouts[i]/100)
+	}
+	return prizes
+}
+
+// Save returns the tournament state for a saved game.
+func (t *Tournament) Save() *SavedTournament {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return &SavedTournament{BuyIn: t.BuyIn, Payouts: t.Payouts, Entrants: t.entrants, Eliminated: t.eliminated, CashedOut: t.cashedOut}
+}
+
+// ResumeTournament recreates a saved tournament. Attach it to the resumed
+// game, whose Schedule has been restored from the save.
+func ResumeTournament(s *SavedTournament, schedule *BlindSchedule) *Tournament {
+	t := NewTournament(s.BuyIn, schedule, s.Payouts)
+	t.entrants = s.Entrants
+	t.resumed = true
+	t.eliminated = s.Eliminated
+	t.cashedOut = s.CashedOut
+	return t
+}
+
+// PrizePool returns the total paid out to the finishers and to players who
+// cashed out.
+func (t *Tournament) PrizePool() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.BuyIn * t.entrants
+}
+
+// Standings ranks the players still seated by chip count, followed by the
+// eliminated players, latest bust-out first. Players busting in the same hand
+// are ranked by the stack they started that hand with. Players who cashed out
+// come last, in the order they left, with no place.
+func (t *Tournament) Standings(remaining []types.Player) []Standing {
+	pool := t.PrizePool()
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, s := range t.cashedOut {
+		pool -= s.Payout
+	}
+	standings := []Standing{}
+	for _, p := range remaining {
+		standings = append(standings, Standing{PlayerID: p.GetID(), Chips: p.GetChips()})
+	}
+	sort.SliceStable(standings, func(i, j int) bool { return standings[i].Chips > standings[j].Chips })
+
+	// Hand numbers are counted per table, so a hand's bust-outs are placed
+	// by when the first of them was recorded
+	out := make([]Standing, len(t.eliminated))
+	copy(out, t.eliminated)
+	type handKey struct {
+		table string
+		hand  int
+	}
+	busted := map[handKey]int{}
+	for i, s := range out {
+		if _, ok := busted[handKey{s.Table, s.Hand}]; !ok {
+			busted[handKey{s.Table, s.Hand}] = i
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		bi, bj := busted[handKey{out[i].Table, out[i].Hand}], busted[handKey{out[j].Table, out[j].Hand}]
+		if bi != bj {
+			return bi > bj
+		}
+		return t.startStack[out[i].PlayerID] > t.startStack[out[j].PlayerID]
+	})
+	standings = append(standings, out...)
+
+	// Assign places and pay out by percentage, rounding leftovers to first place
+	paid, percent := 0, 0
+	for _, share := range t.Payouts {
+		percent += share
+	}
+	for i := range standings {
+		standings[i].Place = i + 1
+		if i < len(t.Payouts) {
+			standings[i].Payout = pool * t.Payouts[i] / 100
+			paid += standings[i].Payout
+		}
+	}
+	if len(standings) > 0 && len(t.Payouts) > 0 {
+		standings[0].Payout += pool*percent/100 - paid
+	}
+	return append(standings, t.cashedOut...)
+}
+
+// DefaultPayouts returns a standard payout table for the number of entrants.
+func DefaultPayouts(entrants int) []int {
+	switch {
+	case entrants <= 3:
+		return []int{100}
+	case entrants <= 5:
+		return []int{65, 35}
+	default:
+		return []int{50, 30, 20}
+	}
+}
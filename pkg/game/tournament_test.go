@@ -0,0 +1,121 @@
+package game
+
+import (
+	"io"
+	"pokerclientv1/pkg/types"
+	"reflect"
+	"testing"
+)
+
+// TestBlindScheduleHandsPerLevel checks that blinds go up after the configured hands.
+func TestBlindScheduleHandsPerLevel(t *testing.T) {
+	schedule := DoublingSchedule(5, 10, 2, 3)
+	want := []struct {
+		sb, bb int
+		raised bool
+	}{
+		{5, 10, false}, {5, 10, false}, {10, 20, true}, {10, 20, false}, {20, 40, true}, {20, 40, false}, {20, 40, false},
+	}
+	for hand, w := range want {
+		level, raised := schedule.NextHand()
+		if level.SmallBlind != w.sb || level.BigBlind != w.bb || raised != w.raised {
+			t.Errorf("NextHand() hand %d = %d/%d raised=%v, want %d/%d raised=%v", hand+1, level.SmallBlind, level.BigBlind, raised, w.sb, w.bb, w.raised)
+		}
+	}
+}
+
+// TestGameFollowsBlindSchedule checks that the game posts blinds and antes from the schedule.
+func TestGameFollowsBlindSchedule(t *testing.T) {
+	mockP1 := NewMockPlayer("P1", 1000, false)
+	mockP2 := NewMockPlayer("P2", 1000, false)
+	game := NewGame([]types.Player{mockP1, mockP2}, &MockUI{}, 0, DefaultGameConfig())
+	game.Schedule = NewBlindSchedule([]BlindLevel{{SmallBlind: 25, BigBlind: 50, Ante: 5, Hands: 1}})
+
+	game.applyBlindSchedule()
+	game.determineBlinds()
+	game.postBlinds()
+
+	if game.Table.Pot != 25+50+2*5 {
+		t.Errorf("postBlinds() pot = %d, want %d", game.Table.Pot, 25+50+2*5)
+	}
+	if game.Table.CurrentBet != 50 {
+		t.Errorf("postBlinds() current bet = %d, want 50", game.Table.CurrentBet)
+	}
+	if mockP1.GetCurrentBet() != 25 {
+		t.Errorf("postBlinds() small blind bet = %d, want 25 (ante not counted)", mockP1.GetCurrentBet())
+	}
+}
+
+// TestTournamentStandings checks finishing order and payouts.
+func TestTournamentStandings(t *testing.T) {
+	p1 := NewMockPlayer("P1", 300, false)
+	p2 := NewMockPlayer("P2", 0, false)
+	p3 := NewMockPlayer("P3", 0, false)
+	p4 := NewMockPlayer("P4", 0, false)
+	game := NewGame([]types.Player{p1, p2, p3, p4}, &MockUI{}, 0, DefaultGameConfig())
+	tournament := NewTournament(100, DoublingSchedule(1, 2, 10, 5), []int{70, 30})
+	tournament.Attach(game)
+
+	// P4 busts in hand 2, P2 and P3 in hand 5 with P3 starting that hand deeper
+	tournament.startStack = map[string]int{"P2": 40, "P3": 80}
+	game.emit(Event{Type: EventPlayerEliminated, PlayerID: "P4", HandNumber: 2})
+	game.emit(Event{Type: EventPlayerEliminated, PlayerID: "P2", HandNumber: 5})
+	game.emit(Event{Type: EventPlayerEliminated, PlayerID: "P3", HandNumber: 5})
+
+	standings := tournament.Standings([]types.Player{p1})
+	wantOrder := []string{"P1", "P3", "P2", "P4"}
+	wantPayout := []int{280, 120, 0, 0}
+	for i, s := range standings {
+		if s.PlayerID != wantOrder[i] || s.Place != i+1 || s.Payout != wantPayout[i] {
+			t.Errorf("Standings()[%d] = %s place %d payout %d, want %s place %d payout %d", i, s.PlayerID, s.Place, s.Payout, wantOrder[i], i+1, wantPayout[i])
+		}
+	}
+}
+
+// cashingPlayer takes any cash-out offer and remembers it.
+type cashingPlayer struct {
+	*MockPlayer
+	offered, chipValue int
+}
+
+func (p *cashingPlayer) CashOut(value, chipValue int) bool {
+	p.offered, p.chipValue = value, chipValue
+	return true
+}
+
+// TestTournamentCashOut checks that a player cashing out leaves with their
+// ICM value and the places left share the rest of the prize pool.
+func TestTournamentCashOut(t *testing.T) {
+	p1 := NewMockPlayer("P1", 600, false)
+	p2 := &cashingPlayer{MockPlayer: NewMockPlayer("P2", 300, false)}
+	p3 := NewMockPlayer("P3", 300, false)
+	game := NewGame([]types.Player{p1, p2, p3}, &MockUI{}, 0, DefaultGameConfig())
+	game.Out = io.Discard
+	tournament := NewTournament(400, nil, []int{70, 30})
+	tournament.Attach(game)
+
+	game.offerCashOuts()
+	if len(game.Players) != 3 {
+		t.Fatalf("a player cashed out with cash-outs off")
+	}
+
+	tournament.CashOuts = true
+	game.emit(Event{Type: EventHandEnded})
+	game.offerCashOuts()
+	if p2.offered != 330 || p2.chipValue != 300 {
+		t.Errorf("P2 was offered %d with chips worth %d, want 330 and 300", p2.offered, p2.chipValue)
+	}
+	if len(game.Players) != 2 {
+		t.Fatalf("%d players seated after P2 cashed out, want 2", len(game.Players))
+	}
+
+	standings := tournament.Standings(game.Players)
+	want := []Standing{
+		{Place: 1, PlayerID: "P1", Chips: 600, Payout: 609},
+		{Place: 2, PlayerID: "P3", Chips: 300, Payout: 261},
+		{PlayerID: "P2", Payout: 330, CashedOut: true},
+	}
+	if !reflect.DeepEqual(standings, want) {
+		t.Errorf("Standings() = %+v, want %+v", standings, want)
+	}
+}
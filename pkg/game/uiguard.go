@@ -0,0 +1,126 @@
+package game
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"pokerclientv1/pkg/types"
+)
+
+// display returns the UI the game draws on. Calls go to Game.UI until it
+// fails, panics or takes longer than UITimeout, and from then on to the
+// fallback, so a broken or stuck UI cannot crash or hang the table.
+func (g *Game) display() types.GameUI {
+	return guardedUI{g}
+}
+
+// UIError returns why the game stopped drawing on its UI and switched to the
+// fallback, or nil while the UI works.
+func (g *Game) UIError() error {
+	return g.uiErr
+}
+
+// guardedUI forwards each call to the game's UI through callUI.
+type guardedUI struct {
+	g *Game
+}
+
+func (u guardedUI) DisplayGameState(table *types.Table, players []types.Player, pot int, stage string) {
+	u.g.callUI(func(ui types.GameUI) error {
+		if f, ok := ui.(types.FallibleUI); ok {
+			return f.TryDisplayGameState(table, players, pot, stage)
+		}
+		ui.DisplayGameState(table, players, pot, stage)
+		return nil
+	})
+}
+
+func (u guardedUI) LogAction(playerID string, action string, amount int) {
+	u.g.callUI(func(ui types.GameUI) error {
+		if f, ok := ui.(types.FallibleUI); ok {
+			return f.TryLogAction(playerID, action, amount)
+		}
+		ui.LogAction(playerID, action, amount)
+		return nil
+	})
+}
+
+func (u guardedUI) ClearScreen() {
+	u.g.callUI(func(ui types.GameUI) error {
+		if f, ok := ui.(types.FallibleUI); ok {
+			return f.TryClearScreen()
+		}
+		ui.ClearScreen()
+		return nil
+	})
+}
+
+// callUI makes a call on the UI, dropping the UI for the fallback the first
+// time a call fails. The failed call is repeated on the fallback so nothing
+// is lost from the log.
+func (g *Game) callUI(call func(types.GameUI) error) {
+	if g.uiErr == nil {
+		err := g.guardUI(func() error { return call(g.UI) })
+		if err == nil {
+			return
+		}
+		g.uiErr = err
+		fmt.Fprintf(g.Out, "\n>> The display failed (%v), continuing with a text log.\n", err)
+	}
+	fallback := g.UIFallback
+	if fallback == nil {
+		fallback = textUI{g.Out}
+	}
+	g.guardUI(func() error { return call(fallback) })
+}
+
+// guardUI runs a UI call, turning a panic into an error. With a UITimeout the
+// call runs on its own goroutine and is left behind if it does not return in
+// time; the UI is not called again after that.
+func (g *Game) guardUI(call func() error) (err error) {
+	if g.UITimeout <= 0 {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("panic: %v", r)
+			}
+		}()
+		return call()
+	}
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- fmt.Errorf("panic: %v", r)
+			}
+		}()
+		done <- call()
+	}()
+	timer := time.NewTimer(g.UITimeout)
+	defer timer.Stop()
+	select {
+	case err := <-done:
+		return err
+	case <-timer.C:
+		return fmt.Errorf("no response after %s", g.UITimeout)
+	}
+}
+
+// textUI is the fallback UI: a plain log of stages and actions.
+type textUI struct {
+	out io.Writer
+}
+
+func (u textUI) DisplayGameState(table *types.Table, players []types.Player, pot int, stage string) {
+	fmt.Fprintf(u.out, "--- %s --- Pot: %d --- Board: %v\n", stage, pot, table.CommunityCards)
+}
+
+func (u textUI) LogAction(playerID string, action string, amount int) {
+	if amount > 0 {
+		fmt.Fprintf(u.out, ">> %s %s (%d)\n", playerID, action, amount)
+	} else {
+		fmt.Fprintf(u.out, ">> %s %s\n", playerID, action)
+	}
+}
+
+func (u textUI) ClearScreen() {}
@@ -0,0 +1,83 @@
+package game
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"pokerclientv1/pkg/types"
+)
+
+// brokenUI fails, panics or blocks on its first call to show the state.
+type brokenUI struct {
+	MockUI
+	mode  string // "error", "panic" or "block"
+	calls atomic.Int32
+	block chan struct{}
+}
+
+func (u *brokenUI) TryDisplayGameState(table *types.Table, players []types.Player, pot int, stage string) error {
+	u.calls.Add(1)
+	switch u.mode {
+	case "panic":
+		panic("renderer crashed")
+	case "block":
+		<-u.block
+	}
+	return errors.New("connection reset")
+}
+
+func (u *brokenUI) TryLogAction(playerID string, action string, amount int) error {
+	u.calls.Add(1)
+	u.LogAction(playerID, action, amount)
+	return nil
+}
+
+func (u *brokenUI) TryClearScreen() error {
+	u.calls.Add(1)
+	return nil
+}
+
+// TestBrokenUIFallsBack checks that a UI that fails, panics or blocks is
+// dropped for a text log and the hand is played to the end.
+func TestBrokenUIFallsBack(t *testing.T) {
+	for _, mode := range []string{"error", "panic", "block"} {
+		ui := &brokenUI{mode: mode, block: make(chan struct{})}
+		p1 := NewMockPlayer("P1", 100, false)
+		p2 := NewMockPlayer("P2", 100, false)
+		p1.ActionQueue = []struct {
+			Action string
+			Amount int
+		}{{"call", 0}, {"check", 0}, {"check", 0}, {"check", 0}}
+		p2.ActionQueue = p1.ActionQueue
+
+		var out bytes.Buffer
+		g := NewGame([]types.Player{p1, p2}, ui, 0, DefaultGameConfig())
+		g.Out = &out
+		if mode == "block" {
+			g.UITimeout = 50 * time.Millisecond
+		}
+		if !g.PlayNextHand() {
+			t.Fatalf("%s: PlayNextHand() = false, want the hand played", mode)
+		}
+		close(ui.block)
+
+		if g.UIError() == nil {
+			t.Errorf("%s: UIError() = nil, want why the UI was dropped", mode)
+		}
+		if p1.Chips+p2.Chips != 200 {
+			t.Errorf("%s: players have %d chips, want 200", mode, p1.Chips+p2.Chips)
+		}
+		calls := ui.calls.Load()
+		g.display().LogAction("P1", "checks", 0)
+		if ui.calls.Load() != calls {
+			t.Errorf("%s: the dropped UI was called again", mode)
+		}
+		if !strings.Contains(out.String(), "The display failed") || !strings.Contains(out.String(), ">> P1 checks") {
+			t.Errorf("%s: output = %q, want the failure reported and actions logged as text", mode, out.String())
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package game
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"pokerclientv1/pkg/types"
+)
+
+// DefaultVariant is the variant played when GameConfig.Variant is empty.
+const DefaultVariant = "holdem"
+
+// Street is a round of community cards dealt after the pre-flop betting,
+// followed by its own betting round.
+type Street struct {
+	Name  string // Round name shown on the table, e.g. "Flop"
+	Cards int    // Community cards dealt
+}
+
+// Variant is a poker game the engine can deal: how many hole cards each
+// player gets, the community card streets, how a hand is scored and any
+// betting structure it imposes. Blinds, side pots and the betting rounds
+// themselves are shared by every variant.
+type Variant interface {
+	HoleCards() int                              // Cards dealt face down to each player
+	Streets() []Street                           // Community card streets in dealing order
+	Evaluate(hole, board []types.Card) HandValue // Best hand a player can make at showdown
+	PotLimit() bool                              // Raises are always capped at the pot, whatever the config says
+}
+
+var (
+	variantsMu sync.RWMutex
+	variants   = map[string]Variant{}
+)
+
+func init() {
+	RegisterVariant(DefaultVariant, holdem{})
+	RegisterVariant("omaha", omaha{})
+}
+
+// RegisterVariant makes a variant available under name, so games can play it
+// by setting GameConfig.Variant. It is meant to be called from an init
+// function and panics if the name is empty or already registered.
+func RegisterVariant(name string, v Variant) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	variantsMu.Lock()
+	defer variantsMu.Unlock()
+	if name == "" || v == nil {
+		panic("game: RegisterVariant needs a name and a variant")
+	}
+	if _, dup := variants[name]; dup {
+		panic(fmt.Sprintf("game: variant %q registered twice", name))
+	}
+	variants[name] = v
+}
+
+// LookupVariant returns the variant registered under name, case-insensitively.
+// An empty name is the default variant.
+func LookupVariant(name string) (Variant, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		name = DefaultVariant
+	}
+	variantsMu.RLock()
+	defer variantsMu.RUnlock()
+	v, ok := variants[name]
+	return v, ok
+}
+
+// VariantNames returns the registered variant names in alphabetical order.
+func VariantNames() []string {
+	variantsMu.RLock()
+	defer variantsMu.RUnlock()
+	names := make([]string, 0, len(variants))
+	for name := range variants {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// boardStreets are the flop, turn and river shared by the built-in variants.
+var boardStreets = []Street{{"Flop", 3}, {"Turn", 1}, {"River", 1}}
+
+// holdem is Texas Hold'em: two hole cards, any five of seven cards play.
+type holdem struct{}
+
+func (holdem) HoleCards() int    { return 2 }
+func (holdem) Streets() []Street { return boardStreets }
+func (holdem) PotLimit() bool    { return false }
+
+func (holdem) Evaluate(hole, board []types.Card) HandValue {
+	return EvaluateHand(append(append([]types.Card{}, hole...), board...))
+}
+
+// omaha is pot-limit Omaha: four hole cards, and a hand must use exactly two
+// of them with exactly three from the board.
+type omaha struct{}
+
+func (omaha) HoleCards() int    { return 4 }
+func (omaha) Streets() []Street { return boardStreets }
+func (omaha) PotLimit() bool    { return true }
+
+func (omaha) Evaluate(hole, board []types.Card) HandValue {
+	if len(board) < 3 {
+		return EvaluateHand(hole) // Only reached when a hand is scored before the flop
+	}
+	var best HandValue
+	found := false
+	for a := 0; a < len(hole); a++ {
+		for b := a + 1; b < len(hole); b++ {
+			for i := 0; i < len(board); i++ {
+				for j := i + 1; j < len(board); j++ {
+					for k := j + 1; k < len(board); k++ {
+						value := evaluateFive([]types.Card{hole[a], hole[b], board[i], board[j], board[k]})
+						if !found || CompareHands(value, best) > 0 {
+							best, found = value, true
+						}
+					}
+				}
+			}
+		}
+	}
+	return best
+}
@@ -0,0 +1,118 @@
+package game
+
+import (
+	"pokerclientv1/pkg/types"
+	"testing"
+)
+
+// bigFlop is a test variant with three hole cards and a single four-card street.
+type bigFlop struct{ holdem }
+
+func (bigFlop) HoleCards() int    { return 3 }
+func (bigFlop) Streets() []Street { return []Street{{"Big Flop", 4}} }
+
+func init() {
+	RegisterVariant("test-big-flop", bigFlop{})
+}
+
+// TestRegisteredVariantDeals checks that the engine deals a registered
+// variant's hole cards and streets.
+func TestRegisteredVariantDeals(t *testing.T) {
+	p1 := NewMockPlayer("P1", 100, false)
+	p2 := NewMockPlayer("P2", 100, false)
+	p1.ActionQueue = append(p1.ActionQueue, struct {
+		Action string
+		Amount int
+	}{"raise", 99})
+	p2.ActionQueue = append(p2.ActionQueue, struct {
+		Action string
+		Amount int
+	}{"call", 98})
+
+	rules := DefaultGameConfig()
+	rules.Variant = "Test-Big-Flop"
+	holes, streets := map[string]int{}, [][]types.Card{}
+	engine := NewEngine(Config{
+		Players: []types.Player{p1, p2},
+		Rules:   rules,
+		OnEvent: func(e Event) {
+			switch e.Type {
+			case EventHoleCardsDealt:
+				holes[e.PlayerID] = len(e.Cards)
+			case EventStreetDealt:
+				streets = append(streets, e.Cards)
+			}
+		},
+	})
+	engine.PlayHand()
+
+	if holes["P1"] != 3 || holes["P2"] != 3 {
+		t.Errorf("hole cards dealt = %v, want 3 each", holes)
+	}
+	if len(streets) != 1 || len(streets[0]) != 4 {
+		t.Errorf("streets dealt = %v, want one street of 4 cards", streets)
+	}
+	if p1.GetChips()+p2.GetChips() != 200 {
+		t.Errorf("chips not conserved, got %d total, want 200", p1.GetChips()+p2.GetChips())
+	}
+}
+
+// TestRegisterVariantDuplicatePanics checks that a name cannot be taken twice.
+func TestRegisterVariantDuplicatePanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("RegisterVariant(%q) twice did not panic", DefaultVariant)
+		}
+	}()
+	RegisterVariant(DefaultVariant, holdem{})
+}
+
+// TestLookupVariant checks the default, case-insensitive lookups and unknown names.
+func TestLookupVariant(t *testing.T) {
+	if v, ok := LookupVariant(""); !ok || v.HoleCards() != 2 {
+		t.Errorf("LookupVariant(\"\") = %v, %v, want Texas Hold'em", v, ok)
+	}
+	if v, ok := LookupVariant("OMAHA"); !ok || v.HoleCards() != 4 || !v.PotLimit() {
+		t.Errorf("LookupVariant(\"OMAHA\") = %v, %v, want pot-limit Omaha", v, ok)
+	}
+	if _, ok := LookupVariant("razz"); ok {
+		t.Errorf("LookupVariant(\"razz\") found a variant, want none")
+	}
+	config := DefaultGameConfig()
+	config.Variant = "razz"
+	if err := config.Validate(); err == nil {
+		t.Errorf("Validate() with an unknown variant returned nil, want an error")
+	}
+}
+
+// TestOmahaUsesTwoHoleCards checks that an Omaha hand takes exactly two hole
+// cards and three board cards.
+func TestOmahaUsesTwoHoleCards(t *testing.T) {
+	omahaVariant, _ := LookupVariant("omaha")
+	tests := []struct {
+		name  string
+		hole  []types.Card
+		board []types.Card
+		want  HandRank
+	}{
+		{"one suited hole card is no flush",
+			cards(types.Ace, types.Spade, types.King, types.Heart, types.Queen, types.Club, types.Two, types.Diamond),
+			cards(types.Nine, types.Spade, types.Seven, types.Spade, types.Five, types.Spade, types.Three, types.Spade, types.Eight, types.Heart),
+			HighCard},
+		{"four of a kind in hand plays as a pair",
+			cards(types.Nine, types.Spade, types.Nine, types.Heart, types.Nine, types.Club, types.Nine, types.Diamond),
+			cards(types.Ace, types.Spade, types.King, types.Heart, types.Jack, types.Club, types.Four, types.Diamond, types.Two, types.Heart),
+			OnePair},
+		{"two suited hole cards make the flush",
+			cards(types.Ace, types.Spade, types.King, types.Spade, types.Queen, types.Club, types.Two, types.Diamond),
+			cards(types.Nine, types.Spade, types.Seven, types.Spade, types.Five, types.Spade, types.Three, types.Heart, types.Eight, types.Heart),
+			Flush},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := omahaVariant.Evaluate(tt.hole, tt.board).Rank; got != tt.want {
+				t.Errorf("Evaluate() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package gametest
+
+import (
+	"testing"
+
+	"pokerclientv1/pkg/game"
+	"pokerclientv1/pkg/types"
+)
+
+// Deck returns a deck source that deals the given hole cards and board on every
+// hand. Hole cards are listed per seat in seat order, and the board holds up to
+// five cards (flop, turn, river). Burn cards and any missing board cards are
+// filled from the rest of the deck.
+func Deck(t testing.TB, holes [][]string, board []string) func() *game.Deck {
+	t.Helper()
+	used := make(map[types.Card]bool)
+	parse := func(notation string) types.Card {
+		card, err := types.ParseCard(notation)
+		if err != nil {
+			t.Fatalf("gametest.Deck: %v", err)
+		}
+		if used[card] {
+			t.Fatalf("gametest.Deck: card %s listed twice", notation)
+		}
+		used[card] = true
+		return card
+	}
+
+	holeCards := make([][]types.Card, len(holes))
+	for seat, hole := range holes {
+		for _, notation := range hole {
+			holeCards[seat] = append(holeCards[seat], parse(notation))
+		}
+	}
+	boardCards := []types.Card{}
+	for _, notation := range board {
+		boardCards = append(boardCards, parse(notation))
+	}
+
+	// Unused cards fill burns and the end of the deck in a fixed order
+	return func() *game.Deck {
+		return game.NewPresetDeck(holeCards, boardCards, nil)
+	}
+}
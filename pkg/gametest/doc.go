@@ -0,0 +1,18 @@
+// Package gametest provides helpers for writing end-to-end poker scenarios
+// against the game engine: stacked decks, players that follow a script and a
+// UI that captures everything the engine displays.
+//
+// A scenario reads top to bottom like a hand description:
+//
+//	btn := gametest.NewScriptedPlayer("BTN", 100, gametest.RaiseTo(6), gametest.Call(), gametest.Check())
+//	sb := gametest.NewScriptedPlayer("SB", 100, gametest.Fold())
+//	bb := gametest.NewScriptedPlayer("BB", 100, gametest.RaiseTo(18), gametest.Check())
+//	deck := gametest.Deck(t, [][]string{{"As", "Ah"}, {"7c", "2d"}, {"Ks", "Kh"}}, []string{"3c", "8d", "9s", "Jh", "Qc"})
+//	h := gametest.New(t, deck, btn, sb, bb)
+//	h.PlayHand()
+//	h.AssertStacks(gametest.Stacks{"BTN": 119, "SB": 99, "BB": 82})
+//
+// Players can also be read from decision files with LoadScriptedPlayer, which
+// script each street with conditions such as "facing raise"; see Streets. The
+// same files seat bots in poker simulate with --bots script:FILE.
+package gametest
@@ -0,0 +1,212 @@
+package gametest
+
+import "testing"
+
+// TestThreeBetPotToShowdown: BTN opens, SB folds, BB 3-bets, BTN calls and the
+// hand is checked down. BTN's aces hold against BB's kings.
+func TestThreeBetPotToShowdown(t *testing.T) {
+	btn := NewScriptedPlayer("BTN", 100, RaiseTo(6), Call(), Check(), Check(), Check())
+	sb := NewScriptedPlayer("SB", 100, Fold())
+	bb := NewScriptedPlayer("BB", 100, RaiseTo(18), Check(), Check(), Check())
+	deck := Deck(t,
+		[][]string{{"As", "Ah"}, {"7c", "2d"}, {"Ks", "Kh"}},
+		[]string{"3c", "8d", "9s", "Jh", "Qc"})
+
+	h := New(t, deck, btn, sb, bb)
+	h.PlayHand()
+
+	h.AssertScriptsFinished()
+	h.AssertPotAwarded(37)
+	h.AssertStacks(Stacks{"BTN": 119, "SB": 99, "BB": 82})
+}
+
+// TestAllInPreflopRunsOutBoard: BTN shoves, BB calls off a shorter stack and no
+// one is asked to act after the call.
+func TestAllInPreflopRunsOutBoard(t *testing.T) {
+	btn := NewScriptedPlayer("BTN", 100, AllIn())
+	sb := NewScriptedPlayer("SB", 100, Fold())
+	bb := NewScriptedPlayer("BB", 40, Call())
+	deck := Deck(t,
+		[][]string{{"Qs", "Qh"}, {"7c", "2d"}, {"Ac", "Kd"}},
+		[]string{"2s", "5h", "9d", "Tc", "Ad"})
+
+	h := New(t, deck, btn, sb, bb)
+	h.PlayHand()
+
+	h.AssertScriptsFinished()
+	// BB's ace pairs on the river and wins the 81 chip main pot; BTN gets the uncalled 60 back
+	h.AssertBetReturned("BTN", 60)
+	h.AssertPotAwarded(81)
+	h.AssertStacks(Stacks{"BTN": 60, "SB": 99, "BB": 81})
+}
+
+// TestTwoWayChopOddChip: BTN limps, SB folds its blind and the broadway board
+// plays for both BTN and BB. The 5 chip pot splits 2/3, the odd chip going to
+// BB as the first winner left of the button.
+func TestTwoWayChopOddChip(t *testing.T) {
+	btn := NewScriptedPlayer("BTN", 100, Call(), Check(), Check(), Check())
+	sb := NewScriptedPlayer("SB", 100, Fold())
+	bb := NewScriptedPlayer("BB", 100, Check(), Check(), Check(), Check())
+	deck := Deck(t,
+		[][]string{{"2c", "3d"}, {"7c", "8d"}, {"4h", "5s"}},
+		[]string{"As", "Kd", "Qc", "Jh", "Ts"})
+
+	h := New(t, deck, btn, sb, bb)
+	h.PlayHand()
+
+	h.AssertScriptsFinished()
+	h.AssertPotAwarded(5)
+	h.AssertStacks(Stacks{"BTN": 100, "SB": 99, "BB": 101})
+}
+
+// TestThreeWayChopOddChip: UTG and BTN limp, SB folds and the board plays for
+// the three players left. The 7 chip pot splits 3/2/2, BB getting the odd chip.
+func TestThreeWayChopOddChip(t *testing.T) {
+	btn := NewScriptedPlayer("BTN", 100, Call(), Check(), Check(), Check())
+	sb := NewScriptedPlayer("SB", 100, Fold())
+	bb := NewScriptedPlayer("BB", 100, Check(), Check(), Check(), Check())
+	utg := NewScriptedPlayer("UTG", 100, Call(), Check(), Check(), Check())
+	deck := Deck(t,
+		[][]string{{"2c", "3d"}, {"7c", "8d"}, {"4h", "5s"}, {"2h", "6d"}},
+		[]string{"As", "Kd", "Qc", "Jh", "Ts"})
+
+	h := New(t, deck, btn, sb, bb, utg)
+	h.PlayHand()
+
+	h.AssertScriptsFinished()
+	h.AssertPotAwarded(7)
+	h.AssertStacks(Stacks{"BTN": 100, "SB": 99, "BB": 101, "UTG": 100})
+}
+
+// TestUncalledRaiseReturned: BTN raises to 6 and both blinds fold. The 4 chips
+// nobody called go back to BTN before the 5 chip pot is awarded.
+func TestUncalledRaiseReturned(t *testing.T) {
+	btn := NewScriptedPlayer("BTN", 100, RaiseTo(6))
+	sb := NewScriptedPlayer("SB", 100, Fold())
+	bb := NewScriptedPlayer("BB", 100, Fold())
+	deck := Deck(t,
+		[][]string{{"As", "Ah"}, {"7c", "2d"}, {"Ks", "Kh"}},
+		[]string{"3c", "8d", "9s", "Jh", "Qc"})
+
+	h := New(t, deck, btn, sb, bb)
+	h.PlayHand()
+
+	h.AssertScriptsFinished()
+	h.AssertBetReturned("BTN", 4)
+	h.AssertPotAwarded(5)
+	h.AssertStacks(Stacks{"BTN": 103, "SB": 99, "BB": 98})
+}
+
+// TestReraiseFoldedToReturnsDifference: BTN opens to 6, BB 3-bets to 18 and
+// BTN folds. Only the 12 chips of BB's raise that BTN never matched go back;
+// BTN's 6 stay in the 13 chip pot BB wins.
+func TestReraiseFoldedToReturnsDifference(t *testing.T) {
+	btn := NewScriptedPlayer("BTN", 100, RaiseTo(6), Fold())
+	sb := NewScriptedPlayer("SB", 100, Fold())
+	bb := NewScriptedPlayer("BB", 100, RaiseTo(18))
+
+	h := New(t, nil, btn, sb, bb)
+	h.PlayHand()
+
+	h.AssertScriptsFinished()
+	h.AssertBetReturned("BB", 12)
+	h.AssertBetReturned("BTN", 0)
+	h.AssertPotAwarded(13)
+	h.AssertStacks(Stacks{"BTN": 94, "SB": 99, "BB": 107})
+}
+
+// TestFlopBetFoldedToReturnsBet: BTN bets 10 on the flop and BB folds. The
+// bet goes back to BTN and only the pre-flop chips are won.
+func TestFlopBetFoldedToReturnsBet(t *testing.T) {
+	btn := NewScriptedPlayer("BTN", 100, Call(), RaiseTo(10))
+	sb := NewScriptedPlayer("SB", 100, Fold())
+	bb := NewScriptedPlayer("BB", 100, Check(), Check(), Fold())
+
+	h := New(t, nil, btn, sb, bb)
+	h.PlayHand()
+
+	h.AssertScriptsFinished()
+	h.AssertBetReturned("BTN", 10)
+	h.AssertPotAwarded(5)
+	h.AssertStacks(Stacks{"BTN": 103, "SB": 99, "BB": 98})
+}
+
+// TestFlopBetCalledAllInForLess: BTN bets 50 on the flop and BB calls all-in
+// for 28. The 22 chips BB could not match go back to BTN before the showdown.
+func TestFlopBetCalledAllInForLess(t *testing.T) {
+	btn := NewScriptedPlayer("BTN", 100, Call(), RaiseTo(50))
+	sb := NewScriptedPlayer("SB", 100, Fold())
+	bb := NewScriptedPlayer("BB", 30, Check(), Check(), Call())
+	deck := Deck(t,
+		[][]string{{"As", "Ah"}, {"7c", "2d"}, {"Ks", "Kh"}},
+		[]string{"3c", "8d", "9s", "Jh", "Qc"})
+
+	h := New(t, deck, btn, sb, bb)
+	h.PlayHand()
+
+	h.AssertScriptsFinished()
+	h.AssertBetReturned("BTN", 22)
+	h.AssertPotAwarded(61)
+	h.AssertStacks(Stacks{"BTN": 131, "SB": 99, "BB": 0})
+}
+
+// TestAllInForLessWithSecondCaller: BB is all-in for less on the flop but SB
+// calls BTN's full bet, so nothing is returned and the excess builds a side
+// pot. BB's aces take the 90 chip main pot and BTN's kings the 44 chip side pot.
+func TestAllInForLessWithSecondCaller(t *testing.T) {
+	btn := NewScriptedPlayer("BTN", 100, Call(), RaiseTo(50), Check(), Check())
+	sb := NewScriptedPlayer("SB", 100, Call(), Check(), Call(), Check(), Check())
+	bb := NewScriptedPlayer("BB", 30, Check(), Check(), Call())
+	deck := Deck(t,
+		[][]string{{"Ks", "Kh"}, {"7c", "2d"}, {"As", "Ah"}},
+		[]string{"3c", "8d", "9s", "Jh", "Qc"})
+
+	h := New(t, deck, btn, sb, bb)
+	h.PlayHand()
+
+	h.AssertScriptsFinished()
+	h.AssertBetReturned("BTN", 0)
+	h.AssertPotAwarded(134)
+	h.AssertStacks(Stacks{"BTN": 92, "SB": 48, "BB": 90})
+}
+
+// TestDecisionFilesMultiwayAllIn: players read from decision files. BTN opens,
+// SB and BB shove over it for different amounts and BTN calls, making a main
+// pot for BB's shorter stack and a side pot between SB and BTN.
+func TestDecisionFilesMultiwayAllIn(t *testing.T) {
+	load := func(id string, chips int, file string) *ScriptedPlayer {
+		p, err := LoadScriptedPlayer(id, chips, "testdata/"+file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return p
+	}
+	btn := load("BTN", 100, "opener.json")
+	sb := load("SB", 60, "shover.json")
+	bb := load("BB", 30, "shover.json")
+	deck := Deck(t,
+		[][]string{{"7c", "7d"}, {"Ks", "Kh"}, {"As", "Ah"}},
+		[]string{"2c", "5d", "9s", "Jh", "Qc"})
+
+	h := New(t, deck, btn, sb, bb)
+	h.PlayHand()
+
+	h.AssertPotAwarded(150)
+	h.AssertStacks(Stacks{"BTN": 40, "SB": 60, "BB": 90})
+}
+
+// TestParseStreetsRejectsMistakes checks that typos in a decision file are
+// reported instead of played as folds.
+func TestParseStreetsRejectsMistakes(t *testing.T) {
+	for _, data := range []string{
+		`{"prefop": [{"action": "call"}]}`,
+		`{"flop": [{"action": "bet"}]}`,
+		`{"flop": [{"action": "raise"}]}`,
+		`{"flop": [{"if": "facing a raise", "action": "fold"}]}`,
+		`{"flop": {"action": "fold"}}`,
+	} {
+		if _, err := ParseStreets([]byte(data)); err == nil {
+			t.Errorf("ParseStreets(%s) succeeded, want an error", data)
+		}
+	}
+}
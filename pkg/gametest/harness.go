@@ -0,0 +1,135 @@
+package gametest
+
+import (
+	"testing"
+
+	"pokerclientv1/pkg/game"
+	"pokerclientv1/pkg/types"
+)
+
+// Stacks maps player IDs to expected chip counts.
+type Stacks map[string]int
+
+// Harness runs scripted hands through a real Engine and checks the results.
+type Harness struct {
+	T       testing.TB
+	Engine  *game.Engine
+	UI      *CaptureUI
+	Players []*ScriptedPlayer
+	Events  []game.Event // Events from the most recent hand
+	total   int          // Chips in play, which must never change
+}
+
+// New seats the players in the given order with the dealer button on the
+// first seat. deck may be nil to use a normally shuffled deck.
+func New(t testing.TB, deck func() *game.Deck, players ...*ScriptedPlayer) *Harness {
+	t.Helper()
+	h := &Harness{T: t, UI: &CaptureUI{}, Players: players}
+
+	seated := make([]types.Player, len(players))
+	for i, p := range players {
+		seated[i] = p
+		h.total += p.Chips
+	}
+	h.Engine = game.NewEngine(game.Config{
+		Players: seated,
+		UI:      h.UI,
+		Deck:    deck,
+		OnEvent: func(e game.Event) { h.Events = append(h.Events, e) },
+	})
+	return h
+}
+
+// PlayHand plays one hand and fails the test if any player's script ran out
+// or chips were created or lost.
+func (h *Harness) PlayHand() {
+	h.T.Helper()
+	h.Events = nil
+	if !h.Engine.PlayHand() {
+		h.T.Fatalf("PlayHand() could not start a hand, the game is over")
+	}
+	for _, p := range h.Players {
+		for _, msg := range p.errors {
+			h.T.Errorf("%s", msg)
+		}
+		p.errors = nil
+	}
+	h.AssertChipsConserved()
+}
+
+// AssertStacks checks the chip count of every listed player.
+func (h *Harness) AssertStacks(want Stacks) {
+	h.T.Helper()
+	for id, chips := range want {
+		p := h.Player(id)
+		if p == nil {
+			h.T.Errorf("AssertStacks: no player %q at the table", id)
+			continue
+		}
+		if p.Chips != chips {
+			h.T.Errorf("%s has %d chips, want %d", id, p.Chips, chips)
+		}
+	}
+}
+
+// AssertPotAwarded checks the total paid out to winners in the last hand.
+func (h *Harness) AssertPotAwarded(want int) {
+	h.T.Helper()
+	awarded := 0
+	for _, e := range h.Events {
+		if e.Type == game.EventPotAwarded {
+			awarded += e.Amount
+		}
+	}
+	if awarded != want {
+		h.T.Errorf("pot awarded %d chips, want %d", awarded, want)
+	}
+}
+
+// AssertBetReturned checks the chips of an uncalled bet given back to a
+// player in the last hand, 0 when none were.
+func (h *Harness) AssertBetReturned(id string, want int) {
+	h.T.Helper()
+	returned := 0
+	for _, e := range h.Events {
+		if e.Type == game.EventBetReturned && e.PlayerID == id {
+			returned += e.Amount
+		}
+	}
+	if returned != want {
+		h.T.Errorf("%s got %d uncalled chips back, want %d", id, returned, want)
+	}
+}
+
+// AssertScriptsFinished checks that every player used all of their scripted steps.
+func (h *Harness) AssertScriptsFinished() {
+	h.T.Helper()
+	for _, p := range h.Players {
+		if remaining := p.Remaining(); len(remaining) > 0 {
+			h.T.Errorf("%s did not play scripted steps %v", p.ID, remaining)
+		}
+	}
+}
+
+// AssertChipsConserved checks that the chips at the table still add up to the
+// amount the players sat down with.
+func (h *Harness) AssertChipsConserved() {
+	h.T.Helper()
+	total := 0
+	for _, p := range h.Players {
+		total += p.Chips
+	}
+	if total != h.total {
+		h.T.Errorf("chips at the table = %d, want %d", total, h.total)
+	}
+}
+
+// Player finds a seated player by ID.
+func (h *Harness) Player(id string) *ScriptedPlayer {
+	for _, p := range h.Players {
+		if p.ID == id {
+			return p
+		}
+	}
+	return nil
+}
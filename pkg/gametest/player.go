@@ -0,0 +1,124 @@
+package gametest
+
+import (
+	"context"
+	"fmt"
+
+	"pokerclientv1/pkg/types"
+)
+
+// Step is one scripted decision for a ScriptedPlayer.
+type Step struct {
+	Action string `json:"action"`          // fold, check, call, raise or all-in
+	Total  int    `json:"total,omitempty"` // Total bet for the round when raising
+	If     string `json:"if,omitempty"`    // Condition the step is played on, see Streets; always when empty
+}
+
+// Fold gives up the hand.
+func Fold() Step { return Step{Action: "fold"} }
+
+// Check passes when there is no bet to call.
+func Check() Step { return Step{Action: "check"} }
+
+// Call matches the current bet, or goes all-in if the player cannot cover it.
+func Call() Step { return Step{Action: "call"} }
+
+// RaiseTo raises so the player's total bet for the round is total.
+func RaiseTo(total int) Step { return Step{Action: "raise", Total: total} }
+
+// AllIn bets every remaining chip.
+func AllIn() Step { return Step{Action: "all-in"} }
+
+func (s Step) String() string {
+	action := s.Action
+	if s.Action == "raise" {
+		action = fmt.Sprintf("raise to %d", s.Total)
+	}
+	if s.If != "" {
+		return fmt.Sprintf("%s if %s", action, s.If)
+	}
+	return action
+}
+
+// ScriptedPlayer implements types.Player by playing its script one step per
+// turn. The script carries over from hand to hand, so one script can cover
+// several hands. A player given Streets instead plays those, as read from a
+// decision file by LoadScriptedPlayer.
+type ScriptedPlayer struct {
+	types.BasePlayer
+	Human     bool    // Reported by IsHuman, false by default
+	Avatar    string  // Reported by GetAvatar, none by default
+	Script    []Step  // Decisions in the order they will be taken
+	Streets   Streets // Decisions for each street of every hand, used instead of Script when set
+	MinRaises []int   // Minimum raise the engine offered on each turn
+	next      int
+	errors    []string
+
+	street   string // Street being played from Streets and the next step on it
+	onStreet int
+}
+
+// NewScriptedPlayer creates a player that follows the given script.
+func NewScriptedPlayer(id string, chips int, script ...Step) *ScriptedPlayer {
+	return &ScriptedPlayer{BasePlayer: types.NewBasePlayer(id, chips), Script: script}
+}
+
+func (p *ScriptedPlayer) IsHuman() bool     { return p.Human }
+func (p *ScriptedPlayer) GetAvatar() string { return p.Avatar }
+
+// Decide implements types.Decider by playing the next scripted step. A
+// player whose script has run out folds and records an error for the
+// harness to report.
+func (p *ScriptedPlayer) Decide(ctx context.Context, view types.TurnView) (types.Action, error) {
+	action, amount := p.turn(view.Table, view.CurrentBet, view.MinRaise)
+	return types.Action{Type: action, Amount: amount}, nil
+}
+
+// TakeTurn plays the next scripted step as Decide does.
+func (p *ScriptedPlayer) TakeTurn(table *types.Table, currentBet int, minRaise int) (action string, amount int) {
+	return p.turn(table, currentBet, minRaise)
+}
+
+// turn plays the next step of the script or of Streets.
+func (p *ScriptedPlayer) turn(table *types.Table, currentBet int, minRaise int) (action string, amount int) {
+	p.MinRaises = append(p.MinRaises, minRaise)
+	if p.Streets != nil {
+		return p.playStreet(table, currentBet, minRaise)
+	}
+	if p.next >= len(p.Script) {
+		p.errors = append(p.errors, fmt.Sprintf("%s ran out of scripted actions on the %s", p.ID, table.Round))
+		return "fold", 0
+	}
+	step := p.Script[p.next]
+	p.next++
+	return p.play(step, table, currentBet, minRaise)
+}
+
+// play turns a step into the action and chips the engine expects.
+func (p *ScriptedPlayer) play(step Step, table *types.Table, currentBet int, minRaise int) (action string, amount int) {
+	switch step.Action {
+	case "call":
+		return "call", p.Legal(table, currentBet, minRaise).ToCall
+	case "raise":
+		return "raise", step.Total - p.CurrentBet
+	case "all-in":
+		if p.CurrentBet+p.Chips > currentBet {
+			return "raise", p.Chips
+		}
+		return "call", p.Chips
+	default:
+		return step.Action, 0
+	}
+}
+
+// ResetForNewHand clears the hand and starts Streets over from the first
+// step of the pre-flop.
+func (p *ScriptedPlayer) ResetForNewHand() {
+	p.BasePlayer.ResetForNewHand()
+	p.street, p.onStreet = "", 0
+}
+
+// Remaining returns the scripted steps that have not been played yet.
+func (p *ScriptedPlayer) Remaining() []Step {
+	return p.Script[p.next:]
+}
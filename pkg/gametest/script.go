@@ -0,0 +1,129 @@
+package gametest
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"pokerclientv1/pkg/types"
+)
+
+// Streets holds a ScriptedPlayer's decisions for each street of a hand,
+// keyed preflop, flop, turn and river. Each hand starts every street from
+// its first step. On a turn the player plays the next step whose condition
+// holds, passing over those whose condition does not; once a street's steps
+// are used up, the first step under "default" whose condition holds is
+// played instead, as often as needed.
+//
+// A step's condition is one of "checked to", "facing bet", "facing raise",
+// "all-in to call" (the player cannot put in more than a call) and "can
+// raise". A bet is being raised when the high bet is more than the minimum
+// raise, as it is after any raise but not on the opening bet of a street or
+// facing only the big blind.
+//
+// A decision file is Streets as JSON:
+//
+//	{
+//	  "preflop": [{"if": "facing raise", "action": "fold"}, {"action": "raise", "total": 6}],
+//	  "flop":    [{"action": "all-in"}],
+//	  "default": [{"if": "checked to", "action": "check"}, {"action": "call"}]
+//	}
+type Streets map[string][]Step
+
+// Street names used as keys in Streets.
+var streetNames = []string{"preflop", "flop", "turn", "river", "default"}
+
+// conditions a Step may be played on, see Streets.
+var conditions = map[string]func(t turn) bool{
+	"checked to":     func(t turn) bool { return t.Legal.Can("check") },
+	"facing bet":     func(t turn) bool { return t.Legal.Can("call") },
+	"facing raise":   func(t turn) bool { return t.Legal.Can("call") && t.CurrentBet > t.MinRaise },
+	"all-in to call": func(t turn) bool { return t.Legal.Can("call") && !t.Legal.Can("raise") },
+	"can raise":      func(t turn) bool { return t.Legal.Can("raise") },
+}
+
+// turn is what a condition is checked against.
+type turn struct {
+	Table      *types.Table
+	CurrentBet int
+	MinRaise   int
+	Legal      types.LegalActions
+}
+
+// ParseStreets reads a decision file's contents, rejecting unknown streets,
+// actions and conditions.
+func ParseStreets(data []byte) (Streets, error) {
+	var streets Streets
+	if err := json.Unmarshal(data, &streets); err != nil {
+		return nil, fmt.Errorf("decision file: %w", err)
+	}
+	for street, steps := range streets {
+		if !slices.Contains(streetNames, street) {
+			return nil, fmt.Errorf("decision file: unknown street %q, want one of %s", street, strings.Join(streetNames, ", "))
+		}
+		for i, step := range steps {
+			switch step.Action {
+			case "fold", "check", "call", "all-in":
+			case "raise":
+				if step.Total <= 0 {
+					return nil, fmt.Errorf("decision file: %s step %d raises without a total", street, i+1)
+				}
+			default:
+				return nil, fmt.Errorf("decision file: %s step %d has unknown action %q", street, i+1, step.Action)
+			}
+			if _, ok := conditions[step.If]; step.If != "" && !ok {
+				return nil, fmt.Errorf("decision file: %s step %d has unknown condition %q", street, i+1, step.If)
+			}
+		}
+	}
+	return streets, nil
+}
+
+// LoadScriptedPlayer creates a player that plays the decision file at path.
+func LoadScriptedPlayer(id string, chips int, path string) (*ScriptedPlayer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	streets, err := ParseStreets(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	p := NewScriptedPlayer(id, chips)
+	p.Streets = streets
+	return p, nil
+}
+
+// holds reports whether the step may be played on this turn.
+func (s Step) holds(t turn) bool {
+	return s.If == "" || conditions[s.If] != nil && conditions[s.If](t)
+}
+
+// playStreet plays the next step for the street being dealt. A player with
+// no step that holds checks or folds and records an error for the harness
+// to report.
+func (p *ScriptedPlayer) playStreet(table *types.Table, currentBet int, minRaise int) (action string, amount int) {
+	street := strings.ToLower(strings.ReplaceAll(table.Round, "-", ""))
+	if street != p.street {
+		p.street, p.onStreet = street, 0
+	}
+	now := turn{Table: table, CurrentBet: currentBet, MinRaise: minRaise, Legal: p.Legal(table, currentBet, minRaise)}
+
+	steps := p.Streets[street]
+	for p.onStreet < len(steps) {
+		step := steps[p.onStreet]
+		p.onStreet++
+		if step.holds(now) {
+			return p.play(step, table, currentBet, minRaise)
+		}
+	}
+	for _, step := range p.Streets["default"] {
+		if step.holds(now) {
+			return p.play(step, table, currentBet, minRaise)
+		}
+	}
+	p.errors = append(p.errors, fmt.Sprintf("%s has no scripted action for this turn on the %s", p.ID, table.Round))
+	return now.Legal.Fit("check", 0)
+}
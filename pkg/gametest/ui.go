@@ -0,0 +1,32 @@
+package gametest
+
+import (
+	"fmt"
+
+	"pokerclientv1/pkg/types"
+)
+
+// CaptureUI implements types.GameUI and records everything the engine shows.
+type CaptureUI struct {
+	Stages  []string           // Stage names passed to DisplayGameState, in order
+	Pots    [][]types.TablePot // Side pots shown with each stage, nil while there was one pot
+	Actions []string           // Logged actions formatted as "<player> <action> (<amount>)"
+	Clears  int                // Number of ClearScreen calls
+}
+
+func (ui *CaptureUI) DisplayGameState(table *types.Table, players []types.Player, pot int, stage string) {
+	ui.Stages = append(ui.Stages, stage)
+	ui.Pots = append(ui.Pots, table.Pots)
+}
+
+func (ui *CaptureUI) LogAction(playerID string, action string, amount int) {
+	if amount > 0 {
+		ui.Actions = append(ui.Actions, fmt.Sprintf("%s %s (%d)", playerID, action, amount))
+	} else {
+		ui.Actions = append(ui.Actions, fmt.Sprintf("%s %s", playerID, action))
+	}
+}
+
+func (ui *CaptureUI) ClearScreen() {
+	ui.Clears++
+}
@@ -0,0 +1,102 @@
+package ranges
+
+import (
+	"strings"
+
+	"pokerclientv1/pkg/types"
+)
+
+// Chart is a pre-flop strategy: the hands to play from each position
+// depending on what was bet before.
+type Chart struct {
+	Open     map[string]Range // Raised when nobody has raised yet
+	Call     map[string]Range // Called against a single raise
+	ThreeBet map[string]Range // Re-raised against a single raise
+	FourBet  Range            // Re-raised against a re-raise; ThreeBet hands call it
+}
+
+// Default is the chart bots play from.
+var Default = NewChart(defaultOpen, defaultCall, defaultThreeBet, "KK+, AKs")
+
+// The ranges of Default by position group, see Group. The big blind is never
+// first in, so it raises only when everyone else has limped or folded, with
+// the hands it would re-raise.
+var (
+	defaultOpen = map[string]string{
+		"UTG": "66+, A9s+, KTs+, QTs+, JTs, T9s, AJo+, KQo",
+		"LJ":  "55+, A7s+, A5s, K9s+, Q9s+, J9s+, T9s, 98s, ATo+, KJo+",
+		"HJ":  "44+, A2s+, K8s+, Q9s+, J9s+, T8s+, 98s, 87s, ATo+, KTo+, QJo",
+		"CO":  "22+, A2s+, K6s+, Q8s+, J8s+, T8s+, 97s+, 87s, 76s, 65s, A8o+, KTo+, QTo+, JTo",
+		"BTN": "22+, A2s+, K2s+, Q5s+, J7s+, T7s+, 96s+, 86s+, 75s+, 64s+, 54s, A2o+, K8o+, Q9o+, J9o+, T9o, 98o",
+		"SB":  "22+, A2s+, K5s+, Q7s+, J8s+, T8s+, 97s+, 86s+, 76s, 65s, A5o+, K9o+, QTo+, JTo",
+		"BB":  "TT+, AJs+, KQs, AQo+, A5s, A4s",
+	}
+	defaultCall = map[string]string{
+		"UTG": "77-JJ, AJs-AQs, KQs, AQo",
+		"LJ":  "77-JJ, AJs-AQs, KQs, AQo",
+		"HJ":  "66-JJ, ATs-AQs, KJs+, QJs, AQo",
+		"CO":  "55-JJ, ATs-AQs, KJs+, QJs, JTs, AJo-AQo",
+		"BTN": "22-99, A9s-ATs, KTs+, QTs+, JTs, T9s, 98s, 87s, AJo-AQo, KQo",
+		"SB":  "66-99, ATs, KJs+, QJs, JTs, AJo, KQo",
+		"BB":  "22-99, A2s-ATs, K9s+, Q9s+, J9s+, T8s+, 97s+, 86s+, 75s+, 65s, 54s, A9o-AJo, KTo+, QTo+, JTo",
+	}
+	defaultThreeBet = map[string]string{
+		"UTG": "QQ+, AKs, AKo",
+		"LJ":  "QQ+, AKs, AKo",
+		"HJ":  "QQ+, AKs, AKo",
+		"CO":  "JJ+, AQs+, AKo, A5s",
+		"BTN": "TT+, AJs+, KQs, AQo+, A5s, A4s",
+		"SB":  "TT+, AJs+, KQs, AQo+, A5s",
+		"BB":  "TT+, AJs+, KQs, AQo+, A5s, A4s, 76s",
+	}
+)
+
+// NewChart reads a chart from range notation by position group. It panics
+// when a range cannot be read, as charts are written into the program.
+func NewChart(open, call, threeBet map[string]string, fourBet string) *Chart {
+	parse := func(notation map[string]string) map[string]Range {
+		charts := make(map[string]Range, len(notation))
+		for group, n := range notation {
+			charts[group] = MustParse(n)
+		}
+		return charts
+	}
+	return &Chart{Open: parse(open), Call: parse(call), ThreeBet: parse(threeBet), FourBet: MustParse(fourBet)}
+}
+
+// Play returns what the chart does with a hand of two cards from a
+// position, "raise", "call" or "fold", after the pot has been raised the
+// given number of times. ok is false for a position the chart has no ranges
+// for.
+func (c *Chart) Play(position string, a, b types.Card, raises int) (action string, ok bool) {
+	group := Group(position)
+	if _, ok := c.Open[group]; !ok {
+		return "", false
+	}
+	switch {
+	case raises == 0 && c.Open[group].Contains(a, b):
+		return "raise", true
+	case raises == 1 && c.ThreeBet[group].Contains(a, b), raises > 1 && c.FourBet.Contains(a, b):
+		return "raise", true
+	case raises == 1 && c.Call[group].Contains(a, b), raises > 1 && c.ThreeBet[group].Contains(a, b):
+		return "call", true
+	}
+	return "fold", true
+}
+
+// Group returns the position group a chart lists the hands of a position
+// under, such as UTG for UTG+1 or BTN for the heads-up button, or "" for
+// an unknown position.
+func Group(position string) string {
+	switch {
+	case strings.HasPrefix(position, "UTG"):
+		return "UTG"
+	case position == "BTN/SB":
+		return "BTN"
+	}
+	switch position {
+	case "LJ", "HJ", "CO", "BTN", "SB", "BB":
+		return position
+	}
+	return ""
+}
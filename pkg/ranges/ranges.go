@@ -0,0 +1,205 @@
+// Package ranges reads starting hand ranges in the notation players use,
+// such as "22+, ATs+, KQo", and holds the pre-flop charts bots play from.
+package ranges
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+
+	"pokerclientv1/pkg/types"
+)
+
+// rankLetters names the ranks from Two to Ace as they are written in a range.
+const rankLetters = "23456789TJQKA"
+
+// suits are every suit, for dealing out the combos of a hand.
+var suits = []types.Suit{types.Spade, types.Heart, types.Diamond, types.Club}
+
+// Combo is a pair of hole cards, the higher rank first.
+type Combo [2]types.Card
+
+// NewCombo returns the combo two cards make in either order.
+func NewCombo(a, b types.Card) Combo {
+	if b.Rank > a.Rank || b.Rank == a.Rank && b.Suit < a.Suit {
+		a, b = b, a
+	}
+	return Combo{a, b}
+}
+
+func (c Combo) String() string {
+	return letter(c[0].Rank) + suitLetter(c[0].Suit) + letter(c[1].Rank) + suitLetter(c[1].Suit)
+}
+
+// Class returns the starting hand two cards make: a pair such as "TT", or
+// the two ranks followed by s when suited and o when not, such as "AKs".
+func Class(a, b types.Card) string {
+	c := NewCombo(a, b)
+	switch {
+	case c[0].Rank == c[1].Rank:
+		return letter(c[0].Rank) + letter(c[1].Rank)
+	case c[0].Suit == c[1].Suit:
+		return letter(c[0].Rank) + letter(c[1].Rank) + "s"
+	}
+	return letter(c[0].Rank) + letter(c[1].Rank) + "o"
+}
+
+// Range is a set of starting hands, each combo of hole cards in it true.
+type Range map[Combo]bool
+
+// Parse reads a range written as hands separated by commas or spaces:
+//
+//   - a pair, "TT", or two ranks, "AK", suited only with s, "AKs", or
+//     offsuit only with o, "AKo"
+//   - a hand with +, every pair from it up to aces, "77+", or the same high
+//     card with every kicker from it up, "ATs+" for ATs, AJs, AQs and AKs
+//   - two hands joined by -, every hand between them, "22-55" or "A2s-A5s"
+//   - two cards, just that combo, "AsKs"
+func Parse(notation string) (Range, error) {
+	r := Range{}
+	terms := strings.FieldsFunc(notation, func(c rune) bool { return c == ',' || unicode.IsSpace(c) })
+	for _, term := range terms {
+		if err := r.add(term); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// MustParse is Parse for ranges written into the program, which panics
+// when notation cannot be read.
+func MustParse(notation string) Range {
+	r, err := Parse(notation)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// Contains reports whether the hand of two cards is in the range.
+func (r Range) Contains(a, b types.Card) bool {
+	return r[NewCombo(a, b)]
+}
+
+// Combos returns the combos in the range, the strongest ranks first.
+func (r Range) Combos() []Combo {
+	combos := make([]Combo, 0, len(r))
+	for c := range r {
+		combos = append(combos, c)
+	}
+	slices.SortFunc(combos, func(x, y Combo) int {
+		for i := range x {
+			if x[i].Rank != y[i].Rank {
+				return int(y[i].Rank - x[i].Rank)
+			}
+		}
+		if x[0].Suit != y[0].Suit {
+			return int(x[0].Suit - y[0].Suit)
+		}
+		return int(x[1].Suit - y[1].Suit)
+	})
+	return combos
+}
+
+// Share returns the part of the 1326 possible starting hands in the range.
+func (r Range) Share() float64 {
+	return float64(len(r)) / 1326
+}
+
+// hand is a starting hand in range notation: its two ranks and "s", "o" or
+// "" for either.
+type hand struct {
+	high, low types.Rank
+	suited    string
+}
+
+// add puts the hands of one term of a range into r.
+func (r Range) add(term string) error {
+	if cards, err := types.ParseCards(term); err == nil && len(cards) == 2 {
+		r[NewCombo(cards[0], cards[1])] = true
+		return nil
+	}
+	from, to, dashed := strings.Cut(term, "-")
+	plus := !dashed && strings.HasSuffix(from, "+")
+	first, err := parseHand(strings.TrimSuffix(from, "+"))
+	if err != nil {
+		return fmt.Errorf("invalid hand %q in range: %v", term, err)
+	}
+	last := first
+	switch {
+	case dashed:
+		if last, err = parseHand(to); err != nil {
+			return fmt.Errorf("invalid hand %q in range: %v", term, err)
+		}
+		pairs := first.high == first.low
+		if pairs != (last.high == last.low) || !pairs && first.high != last.high || first.suited != last.suited {
+			return fmt.Errorf("invalid range %q, join pairs or hands with the same high card", term)
+		}
+	case plus && first.high == first.low:
+		last.high, last.low = types.Ace, types.Ace
+	case plus:
+		last.low = first.high - 1
+	}
+	if first.low > last.low {
+		first, last = last, first
+	}
+	for ; first.low <= last.low; first.low++ {
+		if first.high != last.high {
+			first.high = first.low // Pairs move both ranks up together
+		}
+		r.addHand(first)
+	}
+	return nil
+}
+
+// addHand puts every combo of h into r.
+func (r Range) addHand(h hand) {
+	for i, s1 := range suits {
+		for j, s2 := range suits {
+			switch {
+			case h.high == h.low && j <= i:
+			case h.suited == "s" && s1 != s2, h.suited == "o" && s1 == s2:
+			default:
+				r[NewCombo(types.Card{Rank: h.high, Suit: s1}, types.Card{Rank: h.low, Suit: s2})] = true
+			}
+		}
+	}
+}
+
+// parseHand reads a hand such as "TT", "AK", "AKs" or "AKo".
+func parseHand(s string) (hand, error) {
+	if len(s) < 2 || len(s) > 3 {
+		return hand{}, fmt.Errorf("write hands like TT, AK, AKs or AKo")
+	}
+	high, ok1 := rankOf(s[0])
+	low, ok2 := rankOf(s[1])
+	if !ok1 || !ok2 {
+		return hand{}, fmt.Errorf("ranks are 2-9, T, J, Q, K and A")
+	}
+	if low > high {
+		high, low = low, high
+	}
+	h := hand{high: high, low: low, suited: strings.ToLower(s[2:])}
+	switch {
+	case h.suited != "" && h.suited != "s" && h.suited != "o":
+		return hand{}, fmt.Errorf("follow the ranks with s for suited or o for offsuit")
+	case high == low && h.suited != "":
+		return hand{}, fmt.Errorf("a pair cannot be suited or offsuit")
+	}
+	return h, nil
+}
+
+// rankOf reads a rank letter, in either case.
+func rankOf(c byte) (types.Rank, bool) {
+	i := strings.IndexByte(rankLetters, byte(unicode.ToUpper(rune(c))))
+	return types.Rank(i + 2), i >= 0
+}
+
+func letter(r types.Rank) string {
+	return rankLetters[r-2 : r-1]
+}
+
+func suitLetter(s types.Suit) string {
+	return map[types.Suit]string{types.Spade: "s", types.Heart: "h", types.Diamond: "d", types.Club: "c"}[s]
+}
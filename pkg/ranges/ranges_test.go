@@ -0,0 +1,109 @@
+package ranges
+
+import (
+	"testing"
+
+	"pokerclientv1/pkg/types"
+)
+
+func cards(t *testing.T, s string) (types.Card, types.Card) {
+	t.Helper()
+	c, err := types.ParseCards(s)
+	if err != nil || len(c) != 2 {
+		t.Fatalf("ParseCards(%q) = %v, %v", s, c, err)
+	}
+	return c[0], c[1]
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		notation string
+		combos   int
+		in, out  []string
+	}{
+		{"AA", 6, []string{"AsAh"}, []string{"KsKh"}},
+		{"AKs", 4, []string{"KdAd"}, []string{"AsKh"}},
+		{"AKo", 12, []string{"AsKh"}, []string{"AsKs"}},
+		{"AK", 16, []string{"AsKh", "AsKs"}, nil},
+		{"22+", 78, []string{"2s2h", "AsAh"}, []string{"AsKs"}},
+		{"ATs+", 16, []string{"AsTs", "AhKh"}, []string{"As9s", "AsTh"}},
+		{"22-55", 24, []string{"5s5h", "3c3d"}, []string{"6s6h"}},
+		{"A5s-A2s", 16, []string{"As3s"}, []string{"As6s"}},
+		{"22+, ATs+, KQo", 78 + 16 + 12, []string{"KsQh", "9d9c"}, []string{"KsQs"}},
+		{"AsKs kqs", 5, []string{"AsKs", "KhQh"}, []string{"AhKh"}},
+	}
+	for _, tt := range tests {
+		r, err := Parse(tt.notation)
+		if err != nil {
+			t.Errorf("Parse(%q) error: %v", tt.notation, err)
+			continue
+		}
+		if len(r) != tt.combos {
+			t.Errorf("Parse(%q) has %d combos, want %d", tt.notation, len(r), tt.combos)
+		}
+		for _, hand := range tt.in {
+			if !r.Contains(cards(t, hand)) {
+				t.Errorf("Parse(%q) does not contain %s", tt.notation, hand)
+			}
+		}
+		for _, hand := range tt.out {
+			if r.Contains(cards(t, hand)) {
+				t.Errorf("Parse(%q) contains %s", tt.notation, hand)
+			}
+		}
+	}
+
+	for _, bad := range []string{"AAs", "AX", "AKx", "A", "22-AKs", "AKs-QJs", "AsAs"} {
+		if _, err := Parse(bad); err == nil {
+			t.Errorf("Parse(%q) succeeded, want an error", bad)
+		}
+	}
+}
+
+func TestClassAndCombos(t *testing.T) {
+	for hand, want := range map[string]string{"KdAd": "AKs", "2c7h": "72o", "TsTh": "TT"} {
+		if got := Class(cards(t, hand)); got != want {
+			t.Errorf("Class(%s) = %s, want %s", hand, got, want)
+		}
+	}
+	combos := MustParse("AKs, KK").Combos()
+	if len(combos) != 10 || combos[0].String() != "AsKs" || combos[len(combos)-1].String() != "KdKc" {
+		t.Errorf("Combos() = %v, want AKs first and KK last", combos)
+	}
+}
+
+// TestDefaultChart checks a few hands against the default chart.
+func TestDefaultChart(t *testing.T) {
+	tests := []struct {
+		position string
+		hand     string
+		raises   int
+		want     string
+	}{
+		{"UTG+1", "AsKh", 0, "raise"},
+		{"UTG", "7s6s", 0, "fold"},
+		{"BTN", "7s6s", 0, "raise"},
+		{"BTN/SB", "Kd8c", 0, "raise"},
+		{"CO", "TsTh", 1, "call"},
+		{"CO", "JsJh", 1, "raise"},
+		{"BB", "5d4d", 1, "call"},
+		{"HJ", "7c2d", 1, "fold"},
+		{"BTN", "AsQs", 2, "call"},
+		{"SB", "KsKh", 2, "raise"},
+	}
+	for _, tt := range tests {
+		a, b := cards(t, tt.hand)
+		if got, ok := Default.Play(tt.position, a, b, tt.raises); !ok || got != tt.want {
+			t.Errorf("Play(%s, %s, %d raises) = %q, %v, want %s", tt.position, tt.hand, tt.raises, got, ok, tt.want)
+		}
+	}
+	a, b := cards(t, "AsAh")
+	if _, ok := Default.Play("", a, b, 0); ok {
+		t.Error("Play() of an unknown position is ok, want false")
+	}
+	for group, r := range Default.Open {
+		if r.Share() <= 0 || r.Share() > 0.6 {
+			t.Errorf("%s opens %.0f%% of hands", group, 100*r.Share())
+		}
+	}
+}
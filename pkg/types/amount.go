@@ -0,0 +1,123 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// amountSuffixes multiply an amount written in short form such as "1.5k".
+var amountSuffixes = map[byte]int{'k': 1_000, 'm': 1_000_000}
+
+// ParseAmount reads a chip amount the way players type it. Digits may be
+// grouped with commas, dots, spaces, underscores or apostrophes ("1,500",
+// "1.500", "1 500") and end in k or m for thousands and millions ("1.5k",
+// "2m"). A lone separator followed by exactly three digits groups thousands;
+// otherwise it is the decimal mark, so "1,5k" and "1.5k" are both 1500.
+// When both commas and dots appear, the last one is the decimal mark.
+// The result must be a whole, non-negative number of chips.
+func ParseAmount(s string) (int, error) {
+	in := s
+	s = strings.ToLower(strings.TrimSpace(s))
+	s = strings.NewReplacer(" ", "", "_", "", "'", "", "\u00a0", "", "\u202f", "").Replace(s)
+	if s == "" {
+		return 0, fmt.Errorf("empty amount")
+	}
+
+	mult := 1
+	if m, ok := amountSuffixes[s[len(s)-1]]; ok {
+		mult = m
+		s = s[:len(s)-1]
+	}
+
+	// Work out which separator, if any, is the decimal mark
+	decimal := byte(0)
+	lastDot, lastComma := strings.LastIndexByte(s, '.'), strings.LastIndexByte(s, ',')
+	switch {
+	case lastDot >= 0 && lastComma >= 0:
+		decimal = s[max(lastDot, lastComma)]
+	case lastDot >= 0 || lastComma >= 0:
+		i := max(lastDot, lastComma)
+		if strings.Count(s, s[i:i+1]) == 1 && (mult > 1 || len(s)-i-1 != 3) {
+			decimal = s[i]
+		}
+	}
+	whole, frac := s, ""
+	if decimal != 0 {
+		i := strings.LastIndexByte(s, decimal)
+		whole, frac = s[:i], s[i+1:]
+	}
+	whole = strings.NewReplacer(",", "", ".", "").Replace(whole)
+	if whole == "" && frac == "" || !allDigits(whole) || !allDigits(frac) {
+		return 0, fmt.Errorf("invalid amount %q", in)
+	}
+
+	n := 0
+	if whole != "" {
+		v, err := strconv.ParseUint(whole, 10, 63)
+		if err != nil || v > uint64(math.MaxInt/mult) {
+			return 0, fmt.Errorf("amount %q is too large", in)
+		}
+		n = int(v) * mult
+	}
+	// Scale the fraction by the suffix; what is left over must be zero
+	frac = strings.TrimRight(frac, "0")
+	for ; mult > 1 && frac != ""; mult /= 10 {
+		n += int(frac[0]-'0') * (mult / 10)
+		frac = frac[1:]
+	}
+	if frac != "" {
+		return 0, fmt.Errorf("amount %q is not a whole number of chips", in)
+	}
+	if n < 0 {
+		return 0, fmt.Errorf("amount %q is too large", in)
+	}
+	return n, nil
+}
+
+func allDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Amount is a chip count that can be written with ParseAmount notation in
+// flags and JSON. In JSON it may be a plain number or a string like "1.5k".
+type Amount int
+
+// String implements flag.Value.
+func (a *Amount) String() string {
+	if a == nil {
+		return "0"
+	}
+	return strconv.Itoa(int(*a))
+}
+
+// Set implements flag.Value.
+func (a *Amount) Set(s string) error {
+	n, err := ParseAmount(s)
+	if err != nil {
+		return err
+	}
+	*a = Amount(n)
+	return nil
+}
+
+// UnmarshalJSON accepts a JSON number or a string in ParseAmount notation.
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		return a.Set(s)
+	}
+	var n int
+	if err := json.Unmarshal(data, &n); err != nil {
+		return fmt.Errorf("amount must be a number or a string like \"1.5k\"")
+	}
+	*a = Amount(n)
+	return nil
+}
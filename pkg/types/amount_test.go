@@ -0,0 +1,60 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		in   string
+		want int
+		ok   bool
+	}{
+		{"50", 50, true},
+		{" 1500 ", 1500, true},
+		{"1,500", 1500, true},
+		{"1.500", 1500, true},
+		{"1 500", 1500, true},
+		{"1_000_000", 1000000, true},
+		{"1'250", 1250, true},
+		{"1,234,567", 1234567, true},
+		{"1.234.567", 1234567, true},
+		{"1,234.5k", 1234500, true},
+		{"1.234,5k", 1234500, true},
+		{"1.5k", 1500, true},
+		{"1,5K", 1500, true},
+		{".5k", 500, true},
+		{"2m", 2000000, true},
+		{"2.75M", 2750000, true},
+		{"2.00", 2, true},
+		{"1.5", 0, false},
+		{"1.2345k", 0, false},
+		{"-50", 0, false},
+		{"", 0, false},
+		{"k", 0, false},
+		{"12x", 0, false},
+		{"1.2.3,4", 0, false},
+		{"99999999999999999999", 0, false},
+		{"9999999999999999m", 0, false},
+	}
+	for _, tt := range tests {
+		got, err := ParseAmount(tt.in)
+		if tt.ok && (err != nil || got != tt.want) {
+			t.Errorf("ParseAmount(%q) = %d, %v, want %d", tt.in, got, err, tt.want)
+		}
+		if !tt.ok && err == nil {
+			t.Errorf("ParseAmount(%q) = %d, want an error", tt.in, got)
+		}
+	}
+}
+
+func TestAmountJSON(t *testing.T) {
+	var v struct{ A, B Amount }
+	if err := json.Unmarshal([]byte(`{"A":250,"B":"2.5k"}`), &v); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+	if v.A != 250 || v.B != 2500 {
+		t.Errorf("decoded %d and %d, want 250 and 2500", v.A, v.B)
+	}
+}
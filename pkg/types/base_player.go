@@ -0,0 +1,55 @@
+package types
+
+// BasePlayer is the state every player has at the table: chips, cards, the
+// bet in front of them and whether they folded. The engine keeps it up to
+// date through the Player interface. Player types embed it for every Player
+// method but TakeTurn and IsHuman, and only add how they decide.
+type BasePlayer struct {
+	ID         string
+	Chips      int
+	Hand       *Hand
+	Folded     bool
+	CurrentBet int // Amount bet in the current round
+}
+
+// NewBasePlayer seats a player with chips and an empty hand.
+func NewBasePlayer(id string, chips int) BasePlayer {
+	return BasePlayer{ID: id, Chips: chips, Hand: &Hand{}}
+}
+
+func (p *BasePlayer) GetID() string            { return p.ID }
+func (p *BasePlayer) GetHand() *Hand           { return p.Hand }
+func (p *BasePlayer) SetHand(hand *Hand)       { p.Hand = hand }
+func (p *BasePlayer) AddChips(amount int)      { p.Chips += amount }
+func (p *BasePlayer) GetChips() int            { return p.Chips }
+func (p *BasePlayer) IsFolded() bool           { return p.Folded }
+func (p *BasePlayer) SetFolded(folded bool)    { p.Folded = folded }
+func (p *BasePlayer) IsAllIn() bool            { return !p.Folded && p.Chips == 0 }
+func (p *BasePlayer) GetCurrentBet() int       { return p.CurrentBet }
+func (p *BasePlayer) SetCurrentBet(amount int) { p.CurrentBet = amount }
+func (p *BasePlayer) ResetBet()                { p.CurrentBet = 0 }
+
+// Bet takes up to amount chips from the stack, all of them when the player
+// has no more, and returns how many it took.
+func (p *BasePlayer) Bet(amount int) int {
+	amount = min(amount, p.Chips)
+	p.Chips -= amount
+	return amount
+}
+
+// Legal returns the actions open to the player on this turn: the game's
+// answer in table.Legal, or one worked out from the bets when the game gave
+// none, as when TakeTurn is called outside a game.
+func (p *BasePlayer) Legal(table *Table, currentBet int, minRaise int) LegalActions {
+	if table != nil && table.Legal != nil && table.Legal.PlayerID == p.ID {
+		return *table.Legal
+	}
+	return NewLegalActions(p.ID, currentBet, p.CurrentBet, p.Chips, minRaise)
+}
+
+// ResetForNewHand clears the cards, bet and fold. Chips carry over.
+func (p *BasePlayer) ResetForNewHand() {
+	p.Hand = &Hand{}
+	p.Folded = false
+	p.CurrentBet = 0
+}
@@ -0,0 +1,18 @@
+package types
+
+import "testing"
+
+func TestBasePlayerBet(t *testing.T) {
+	p := NewBasePlayer("P1", 50)
+	if got := p.Bet(20); got != 20 || p.Chips != 30 || p.IsAllIn() {
+		t.Errorf("Bet(20) = %d leaving %d chips, want 20 leaving 30", got, p.Chips)
+	}
+	if got := p.Bet(100); got != 30 || p.Chips != 0 || !p.IsAllIn() {
+		t.Errorf("Bet(100) = %d leaving %d chips, want all-in for 30", got, p.Chips)
+	}
+	p.SetFolded(true)
+	p.ResetForNewHand()
+	if p.Folded || p.CurrentBet != 0 || len(p.Hand.Cards) != 0 {
+		t.Errorf("ResetForNewHand() left %+v", p)
+	}
+}
@@ -0,0 +1,73 @@
+package types
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Commands are the words a player types at the action prompt, which
+// Bindings may give other names.
+var Commands = []string{"fold", "check", "call", "raise", "all-in", "stats", "why", "log", "auto", "save", "exit"}
+
+// Bindings give commands extra keys or aliases, such as "f" for fold, keyed
+// by the command. A binding only replaces the first word typed, so "r 50"
+// raises to 50 when "r" is bound to raise.
+type Bindings map[string][]string
+
+// DefaultBindings are the single-key actions of speed-run mode.
+var DefaultBindings = Bindings{
+	"fold":   {"f"},
+	"check":  {"k"},
+	"call":   {"c"},
+	"raise":  {"r"},
+	"all-in": {"a"},
+}
+
+// Validate checks that every binding names a known command and that no key
+// is bound twice or hides a command's own name.
+func (b Bindings) Validate() error {
+	bound := map[string]string{}
+	for _, command := range Commands {
+		for _, key := range b[command] {
+			if key == "" || strings.ContainsFunc(key, func(r rune) bool { return r == ' ' || r == '\t' }) {
+				return fmt.Errorf("key %q for %s must be a single word", key, command)
+			}
+			key = strings.ToLower(key)
+			if slices.Contains(Commands, key) {
+				return fmt.Errorf("key %q for %s is already a command", key, command)
+			}
+			if other, ok := bound[key]; ok {
+				return fmt.Errorf("key %q is bound to both %s and %s", key, other, command)
+			}
+			bound[key] = command
+		}
+	}
+	for command := range b {
+		if !slices.Contains(Commands, command) {
+			return fmt.Errorf("cannot bind keys to unknown command %q", command)
+		}
+	}
+	return nil
+}
+
+// Resolve returns the command a word typed at the prompt stands for, and
+// whether it was a bound key. Other words are returned as they are.
+func (b Bindings) Resolve(word string) (string, bool) {
+	for command, keys := range b {
+		for _, key := range keys {
+			if strings.EqualFold(key, word) {
+				return command, true
+			}
+		}
+	}
+	return word, false
+}
+
+// Key returns the first key bound to a command, or the command itself.
+func (b Bindings) Key(command string) string {
+	if keys := b[command]; len(keys) > 0 {
+		return strings.ToLower(keys[0])
+	}
+	return command
+}
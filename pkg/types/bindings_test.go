@@ -0,0 +1,38 @@
+package types
+
+import "testing"
+
+func TestBindingsValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		b    Bindings
+		ok   bool
+	}{
+		{"defaults", DefaultBindings, true},
+		{"aliases", Bindings{"fold": {"x", "muck"}, "all-in": {"shove", "jam"}}, true},
+		{"unknown command", Bindings{"limp": {"l"}}, false},
+		{"bound twice", Bindings{"fold": {"f"}, "call": {"F"}}, false},
+		{"hides a command", Bindings{"fold": {"check"}}, false},
+		{"empty key", Bindings{"fold": {""}}, false},
+		{"spaces", Bindings{"fold": {"give up"}}, false},
+	}
+	for _, tt := range tests {
+		err := tt.b.Validate()
+		if (err == nil) != tt.ok {
+			t.Errorf("%s: Validate() error = %v, want ok %v", tt.name, err, tt.ok)
+		}
+	}
+}
+
+func TestBindingsResolve(t *testing.T) {
+	b := Bindings{"fold": {"x"}, "raise": {"R"}}
+	if command, ok := b.Resolve("r"); command != "raise" || !ok {
+		t.Errorf("Resolve(r) = %q, %v, want raise, true", command, ok)
+	}
+	if command, ok := b.Resolve("call"); command != "call" || ok {
+		t.Errorf("Resolve(call) = %q, %v, want call, false", command, ok)
+	}
+	if b.Key("fold") != "x" || b.Key("check") != "check" {
+		t.Errorf("Key() = %q, %q, want x and check", b.Key("fold"), b.Key("check"))
+	}
+}
@@ -0,0 +1,106 @@
+package types
+
+import (
+	"context"
+	"time"
+)
+
+// Action is a decision taken on a turn, as Player.TakeTurn returns it: fold,
+// check, call or raise, with the chips a call or raise adds to the bet.
+type Action struct {
+	Type   string
+	Amount int
+}
+
+// TurnView is what a player is shown when it is their turn.
+type TurnView struct {
+	Table      *Table
+	CurrentBet int // Highest bet this round
+	ToCall     int // Chips needed to call, at most the player's stack
+	MinRaise   int // Smallest raise above CurrentBet
+	Chips      int // The player's stack
+	Legal      LegalActions
+}
+
+// Decider is how a player chooses their actions, apart from the state kept
+// in BasePlayer. The game asks players that implement it to Decide instead
+// of calling TakeTurn, giving the turn clock's deadline, if there is one, in
+// ctx. A player whose Decide fails checks when nothing is owed and folds
+// otherwise.
+type Decider interface {
+	Decide(ctx context.Context, view TurnView) (Action, error)
+}
+
+// DecideFunc lets an ordinary function be used as a Decider.
+type DecideFunc func(ctx context.Context, view TurnView) (Action, error)
+
+// Decide calls f.
+func (f DecideFunc) Decide(ctx context.Context, view TurnView) (Action, error) {
+	return f(ctx, view)
+}
+
+// View returns what the player is shown on a turn TakeTurn was asked for.
+func (p *BasePlayer) View(table *Table, currentBet int, minRaise int) TurnView {
+	legal := p.Legal(table, currentBet, minRaise)
+	return TurnView{
+		Table:      table,
+		CurrentBet: currentBet,
+		ToCall:     legal.ToCall,
+		MinRaise:   minRaise,
+		Chips:      p.Chips,
+		Legal:      legal,
+	}
+}
+
+// StrategyPlayer is a Player made of a BasePlayer and a Decider, so a new
+// kind of player is written as a Decide method alone. Decisions are fitted
+// to the legal actions.
+type StrategyPlayer struct {
+	BasePlayer
+	Strategy Decider
+	Human    bool            // Reported by IsHuman
+	Context  context.Context // Optional parent of the context each turn is decided in outside a game
+
+	deadline time.Time
+}
+
+// NewStrategyPlayer seats a player who acts as strategy decides.
+func NewStrategyPlayer(id string, chips int, strategy Decider) *StrategyPlayer {
+	return &StrategyPlayer{BasePlayer: NewBasePlayer(id, chips), Strategy: strategy}
+}
+
+func (p *StrategyPlayer) IsHuman() bool { return p.Human }
+
+// SetTurnDeadline implements Clocked.
+func (p *StrategyPlayer) SetTurnDeadline(deadline time.Time) { p.deadline = deadline }
+
+// Decide implements Decider by asking the strategy, fitting its decision to
+// the legal actions.
+func (p *StrategyPlayer) Decide(ctx context.Context, view TurnView) (Action, error) {
+	decision, err := p.Strategy.Decide(ctx, view)
+	if err != nil {
+		return Action{}, err
+	}
+	action, amount := view.Legal.Fit(decision.Type, view.Legal.Bet+decision.Amount)
+	return Action{Type: action, Amount: amount}, nil
+}
+
+// TakeTurn asks the strategy for the action when the player is used outside
+// a game, within the deadline SetTurnDeadline gave.
+func (p *StrategyPlayer) TakeTurn(table *Table, currentBet int, minRaise int) (action string, amount int) {
+	ctx := p.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if !p.deadline.IsZero() {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithDeadline(ctx, p.deadline)
+		defer cancel()
+	}
+	view := p.View(table, currentBet, minRaise)
+	decision, err := p.Decide(ctx, view)
+	if err != nil {
+		return view.Legal.Fit("check", 0)
+	}
+	return decision.Type, decision.Amount
+}
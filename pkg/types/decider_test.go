@@ -0,0 +1,67 @@
+package types
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestStrategyPlayerTurns checks the view a strategy is given, that its
+// decisions are fitted to the legal actions and that a failing strategy
+// checks or folds.
+func TestStrategyPlayerTurns(t *testing.T) {
+	var seen TurnView
+	fail, raise := false, 0
+	p := NewStrategyPlayer("Bot", 100, DecideFunc(func(ctx context.Context, view TurnView) (Action, error) {
+		seen = view
+		if fail {
+			return Action{}, errors.New("no idea")
+		}
+		return Action{Type: "raise", Amount: raise}, nil
+	}))
+	p.CurrentBet = 2
+
+	raise = 58
+	if action, amount := p.TakeTurn(&Table{}, 50, 10); action != "raise" || amount != 58 {
+		t.Errorf("TakeTurn() = %s %d, want raise 58", action, amount)
+	}
+	if seen.ToCall != 48 || seen.CurrentBet != 50 || seen.MinRaise != 10 || seen.Chips != 100 || seen.Legal.MaxRaise != 102 {
+		t.Errorf("strategy saw %+v, want 48 to call and raises up to 102", seen)
+	}
+	raise = 500
+	if action, amount := p.TakeTurn(&Table{}, 50, 10); action != "raise" || amount != 100 {
+		t.Errorf("TakeTurn() raising 500 = %s %d, want raise 100, all-in", action, amount)
+	}
+	if action, amount := p.TakeTurn(&Table{}, 150, 10); action != "call" || amount != 100 {
+		t.Errorf("TakeTurn() raising when short = %s %d, want call 100", action, amount)
+	}
+	table := &Table{Legal: &LegalActions{PlayerID: "Bot", Actions: []string{"fold", "call"}, Bet: 2, ToCall: 48}}
+	if action, amount := p.TakeTurn(table, 50, 10); action != "call" || amount != 48 {
+		t.Errorf("TakeTurn() when the game allows no raise = %s %d, want call 48", action, amount)
+	}
+
+	fail = true
+	if action, _ := p.TakeTurn(&Table{}, 50, 10); action != "fold" {
+		t.Errorf("failing strategy facing a bet = %s, want fold", action)
+	}
+	if action, _ := p.TakeTurn(&Table{}, 2, 10); action != "check" {
+		t.Errorf("failing strategy with nothing to call = %s, want check", action)
+	}
+}
+
+// TestStrategyPlayerDeadline checks that the turn clock reaches the strategy
+// as the context's deadline.
+func TestStrategyPlayerDeadline(t *testing.T) {
+	deadline := time.Now().Add(time.Minute)
+	var got time.Time
+	p := NewStrategyPlayer("Bot", 30, DecideFunc(func(ctx context.Context, view TurnView) (Action, error) {
+		got, _ = ctx.Deadline()
+		return Action{Type: "check"}, nil
+	}))
+	p.SetTurnDeadline(deadline)
+	p.TakeTurn(&Table{}, 0, 2)
+	if !got.Equal(deadline) {
+		t.Errorf("strategy's deadline = %v, want %v", got, deadline)
+	}
+}
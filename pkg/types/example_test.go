@@ -0,0 +1,28 @@
+package types_test
+
+import (
+	"fmt"
+
+	"pokerclientv1/pkg/types"
+)
+
+func ExampleParseCards() {
+	cards, err := types.ParseCards("As Kd 7h")
+	fmt.Println(cards, err)
+	_, err = types.ParseCards("AsAs")
+	fmt.Println(err)
+	// Output:
+	// [A♠ K♦ 7♥] <nil>
+	// card As is listed twice
+}
+
+func ExampleParseAmount() {
+	for _, typed := range []string{"1,500", "2.5k", "1m"} {
+		chips, _ := types.ParseAmount(typed)
+		fmt.Println(chips)
+	}
+	// Output:
+	// 1500
+	// 2500
+	// 1000000
+}
@@ -0,0 +1,341 @@
+// Package types defines the shared data types and interfaces used by the
+// poker engine: cards, hands, the table, players and the game UI.
+package types
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// GameUI defines the interface for game display and logging
+type GameUI interface {
+	DisplayGameState(table *Table, players []Player, pot int, stage string)
+	LogAction(playerID string, action string, amount int)
+	ClearScreen() // Added to clear console
+}
+
+// FallibleUI is a GameUI that reports when it fails to draw, such as one
+// writing to a closed pipe or drawing over a network. When a game's UI
+// implements it the game calls these methods instead of their GameUI
+// counterparts, and swaps a UI that fails for a plain text log so the table
+// carries on.
+type FallibleUI interface {
+	GameUI
+	TryDisplayGameState(table *Table, players []Player, pot int, stage string) error
+	TryLogAction(playerID string, action string, amount int) error
+	TryClearScreen() error
+}
+
+// Player defines the interface for any player (human or bot). The game
+// keeps the seat's state through it; players that implement Decider are
+// asked for their actions through Decide instead of TakeTurn.
+type Player interface {
+	GetID() string
+	GetHand() *Hand
+	TakeTurn(table *Table, currentBet int, minRaise int) (action string, amount int)
+	AddChips(amount int)
+	Bet(amount int) int // Takes up to amount chips for a bet and returns how many, all of them when the player has no more
+	GetChips() int
+	SetHand(hand *Hand)
+	IsFolded() bool
+	SetFolded(folded bool)
+	IsAllIn() bool // True when the player is still in the hand with no chips behind
+	ResetForNewHand()
+	GetCurrentBet() int
+	SetCurrentBet(amount int)
+	ResetBet()
+	IsHuman() bool // Added to distinguish player types
+}
+
+// Thinker is implemented by players that simulate thinking before they act,
+// such as bots. The game pauses for ThinkTime before asking them for an action,
+// except at instant game speed.
+type Thinker interface {
+	ThinkTime() time.Duration
+}
+
+// Clocked is implemented by players who can keep to a turn clock. When the
+// game runs one it calls SetTurnDeadline before each TakeTurn, and the player
+// should answer by the deadline; the game checks or folds for players who
+// answer late. A zero deadline means there is no clock.
+type Clocked interface {
+	SetTurnDeadline(deadline time.Time)
+}
+
+// TimeBanked is implemented by players with a time bank: extra time to act
+// once the turn clock runs out, which has to last them the whole game. The
+// game adds what is left of it to their turn clock; spending it is up to the
+// player.
+type TimeBanked interface {
+	TimeBank() time.Duration
+}
+
+// AvatarHolder is implemented by players shown with an emoji or short glyph
+// next to their name. Avatars are purely cosmetic.
+type AvatarHolder interface {
+	GetAvatar() string
+}
+
+// Rebuyer is implemented by players who decide for themselves whether to buy
+// more chips between hands of a cash game, such as the human at the console.
+// Rebuy is offered at most max chips and returns how many to buy, 0 to decline.
+type Rebuyer interface {
+	Rebuy(max int) int
+}
+
+// CashOuter is implemented by players who decide for themselves whether to
+// take an offer to leave a tournament between hands for value chips' worth of
+// the prize pool. chipValue is what their chips would be worth if each chip
+// were paid at the same rate; the offer is usually below it for a big stack
+// and above it for a short one.
+type CashOuter interface {
+	CashOut(value, chipValue int) bool
+}
+
+// Shower is implemented by players who decide for themselves whether to show
+// a losing hand at showdown or muck it, when the table's reveal policy
+// leaves it to them. Winning hands are always shown.
+type Shower interface {
+	ShowHand() bool
+}
+
+// DisplayName returns the player's ID, preceded by their avatar if they have one.
+func DisplayName(p Player) string {
+	if a, ok := p.(AvatarHolder); ok && a.GetAvatar() != "" {
+		return a.GetAvatar() + " " + p.GetID()
+	}
+	return p.GetID()
+}
+
+// ValidateAvatar checks that an avatar is short enough to sit next to a
+// name: at most eight code points, enough for emoji joined from several, and
+// no spaces or control characters.
+func ValidateAvatar(avatar string) error {
+	if utf8.RuneCountInString(avatar) > 8 || len(avatar) > 32 {
+		return fmt.Errorf("avatar %q is too long, use an emoji or a short glyph", avatar)
+	}
+	for _, r := range avatar {
+		if unicode.IsSpace(r) || unicode.IsControl(r) || r == utf8.RuneError {
+			return fmt.Errorf("avatar %q contains spaces or control characters", avatar)
+		}
+	}
+	return nil
+}
+
+// Table represents the shared state of the poker table
+type Table struct {
+	CommunityCards []Card
+	CurrentBet     int
+	Round          string
+	Pot            int    // Chips in the pot, all bet this hand so far
+	PlayersInHand  int    // Players who have not folded, including the one to act
+	Position       int    // Seats the player to act sits after the dealer button, 0 on the button
+	Seats          int    // Players dealt into the hand
+	Acting         string // ID of the player whose turn is next, empty when nobody is due to act
+
+	// When the acting player's turn clock runs out, by the clock of the
+	// program running the game. Zero when there is no clock or nobody is
+	// being asked to act.
+	Deadline time.Time
+
+	// Position of each player dealt in, such as BTN, SB, BB or UTG, by ID
+	Positions map[string]string
+
+	// The main pot and side pots once a player is all-in for less than
+	// others have bet, with who can still win each. Empty while there is
+	// a single pot.
+	Pots []TablePot
+
+	// What the player being asked to act may do, worked out by the game with
+	// its limits and raise rules. Set only while a player decides.
+	Legal *LegalActions
+
+	// Whether each player still in at showdown showed their hand, true, or
+	// mucked it, false, by ID. Nil until the hands are shown.
+	Shown map[string]bool
+
+	// The hand being played, starting at 1, with its blinds and ante and the
+	// level of the blind schedule, 0 without one. All are 0 for a table that
+	// is not part of a game.
+	HandNumber int
+	SmallBlind int
+	BigBlind   int
+	Ante       int
+	BlindLevel int
+
+	// When the first hand of the session began, by the clock of the program
+	// running the game. Zero before it does.
+	SessionStart time.Time
+}
+
+// TablePot is a main or side pot as shown at the table.
+type TablePot struct {
+	Name     string   `json:"name"` // "main pot", "side pot 1" and so on
+	Amount   int      `json:"amount"`
+	Eligible []string `json:"eligible"` // IDs of the players who can win it, in seat order
+}
+
+// PotsOf returns the names of the pots a player can win, or nil when there
+// is a single pot.
+func (t *Table) PotsOf(playerID string) []string {
+	var names []string
+	for _, pot := range t.Pots {
+		if slices.Contains(pot.Eligible, playerID) {
+			names = append(names, pot.Name)
+		}
+	}
+	return names
+}
+
+// Hand represents a player's hand of cards
+type Hand struct {
+	Cards []Card
+}
+
+// Card represents a playing card
+type Card struct {
+	Suit Suit
+	Rank Rank
+}
+
+// Suit represents the suit of a card
+type Suit int
+
+// Rank represents the rank of a card
+type Rank int
+
+// Constants for Suit
+const (
+	Spade Suit = iota
+	Heart
+	Diamond
+	Club
+)
+
+// Constants for Rank
+const (
+	Two Rank = iota + 2
+	Three
+	Four
+	Five
+	Six
+	Seven
+	Eight
+	Nine
+	Ten
+	Jack
+	Queen
+	King
+	Ace
+)
+
+// String methods for Card, Suit, and Rank
+func (s Suit) String() string {
+	// Use Unicode symbols for suits
+	return [...]string{"♠", "♥", "♦", "♣"}[s]
+}
+
+func (r Rank) String() string {
+	switch r {
+	case Jack:
+		return "J"
+	case Queen:
+		return "Q"
+	case King:
+		return "K"
+	case Ace:
+		return "A"
+	default:
+		return fmt.Sprintf("%d", r)
+	}
+}
+
+func (c Card) String() string {
+	// Combine rank and Unicode suit symbol
+	return fmt.Sprintf("%s%s", c.Rank.String(), c.Suit.String()) // Corrected: use c.Suit
+}
+
+// ParseCard reads a card in short notation such as "As", "Td" or "2c".
+// The rank is one of 2-9, T, J, Q, K, A and the suit one of s, h, d, c.
+func ParseCard(s string) (Card, error) {
+	s = strings.TrimSpace(s)
+	if len(s) != 2 {
+		return Card{}, fmt.Errorf("invalid card %q", s)
+	}
+	rank, ok := map[byte]Rank{
+		'2': Two, '3': Three, '4': Four, '5': Five, '6': Six, '7': Seven, '8': Eight, '9': Nine,
+		'T': Ten, 'J': Jack, 'Q': Queen, 'K': King, 'A': Ace,
+	}[strings.ToUpper(s[:1])[0]]
+	if !ok {
+		return Card{}, fmt.Errorf("invalid rank in card %q", s)
+	}
+	suit, ok := map[byte]Suit{'s': Spade, 'h': Heart, 'd': Diamond, 'c': Club}[strings.ToLower(s[1:])[0]]
+	if !ok {
+		return Card{}, fmt.Errorf("invalid suit in card %q", s)
+	}
+	return Card{Suit: suit, Rank: rank}, nil
+}
+
+// ParseCards reads cards in short notation written back to back or apart,
+// e.g. "AsKd" or "2c 7h 9d". A card may only be listed once.
+func ParseCards(s string) ([]Card, error) {
+	s = strings.NewReplacer(" ", "", ",", "").Replace(s)
+	if len(s)%2 != 0 {
+		return nil, fmt.Errorf("cannot read cards %q, write each as rank and suit like As or Td", s)
+	}
+	cards := []Card{}
+	seen := make(map[Card]bool)
+	for i := 0; i < len(s); i += 2 {
+		card, err := ParseCard(s[i : i+2])
+		if err != nil {
+			return nil, err
+		}
+		if seen[card] {
+			return nil, fmt.Errorf("card %s is listed twice", s[i:i+2])
+		}
+		seen[card] = true
+		cards = append(cards, card)
+	}
+	return cards, nil
+}
+
+func (h *Hand) String() string {
+	if h == nil || len(h.Cards) == 0 {
+		return "[ ]"
+	}
+	cards := make([]string, len(h.Cards))
+	for i, card := range h.Cards {
+		cards[i] = card.String()
+	}
+	return fmt.Sprintf("[ %s ]", strings.Join(cards, " "))
+}
+
+func (h *Hand) AddCard(card Card) {
+	if h.Cards == nil {
+		h.Cards = make([]Card, 0, 2)
+	}
+	h.Cards = append(h.Cards, card)
+}
+
+func (t *Table) ResetForNewHand() {
+	t.CommunityCards = make([]Card, 0, 5)
+	t.CurrentBet = 0
+	t.Round = ""
+	t.Pot = 0
+	t.PlayersInHand = 0
+	t.Position = 0
+	t.Seats = 0
+	t.Acting = ""
+	t.Positions = nil
+	t.Pots = nil
+	t.Legal = nil
+	t.Shown = nil
+}
+
+func (t *Table) AddCommunityCard(card Card) {
+	t.CommunityCards = append(t.CommunityCards, card)
+}
@@ -0,0 +1,78 @@
+package types
+
+import "slices"
+
+// LegalActions describes what a player may do if it were their turn now.
+// Raise bounds are totals for the betting round, the number a player would
+// type after "raise", so front ends can show them without redoing the math.
+type LegalActions struct {
+	PlayerID string   `json:"player"`
+	Actions  []string `json:"actions"`            // Subset of fold, check, call and raise, in that order
+	Bet      int      `json:"bet,omitempty"`      // Chips the player has already bet this round
+	ToCall   int      `json:"toCall,omitempty"`   // Chips a call adds, capped at the player's stack
+	MinRaise int      `json:"minRaise,omitempty"` // Smallest total a raise may bet
+	MaxRaise int      `json:"maxRaise,omitempty"` // Largest total a raise may bet, all-in or the table cap
+}
+
+// NewLegalActions works out the actions open to a player from the bets
+// alone: the high bet, the player's bet and stack, and the minimum raise
+// above the high bet. It knows nothing of table limits or of a short all-in
+// that did not reopen the action; the game's own answer, which does, is
+// passed to players in Table.Legal.
+func NewLegalActions(playerID string, currentBet, playerBet, chips, minRaise int) LegalActions {
+	legal := LegalActions{PlayerID: playerID, Bet: playerBet, Actions: []string{"fold"}}
+	toCall := currentBet - playerBet
+	if toCall <= 0 {
+		legal.Actions = append(legal.Actions, "check")
+	} else {
+		legal.ToCall = min(toCall, chips)
+		legal.Actions = append(legal.Actions, "call")
+	}
+	if chips <= toCall {
+		return legal
+	}
+	legal.MaxRaise = playerBet + chips
+	legal.MinRaise = min(currentBet+minRaise, legal.MaxRaise)
+	legal.Actions = append(legal.Actions, "raise")
+	return legal
+}
+
+// Can reports whether action is one of the legal actions.
+func (l LegalActions) Can(action string) bool {
+	return slices.Contains(l.Actions, action)
+}
+
+// WithoutRaise returns l with raising taken away.
+func (l LegalActions) WithoutRaise() LegalActions {
+	if !l.Can("raise") {
+		return l
+	}
+	l.Actions = slices.Clip(l.Actions[:len(l.Actions)-1])
+	l.MinRaise, l.MaxRaise = 0, 0
+	return l
+}
+
+// Fit turns a decision into the nearest legal action and the chips it adds
+// to the bet, as Player.TakeTurn returns them. total is the round total a
+// raise bets and is kept between MinRaise and MaxRaise. A raise that is not
+// allowed calls, a call with nothing to call checks, and a check facing a
+// bet or any other action folds.
+func (l LegalActions) Fit(action string, total int) (string, int) {
+	switch action {
+	case "raise":
+		if l.Can("raise") {
+			return "raise", min(max(total, l.MinRaise), l.MaxRaise) - l.Bet
+		}
+		fallthrough
+	case "call":
+		if l.Can("call") {
+			return "call", l.ToCall
+		}
+		return "check", 0
+	case "check":
+		if l.Can("check") {
+			return "check", 0
+		}
+	}
+	return "fold", 0
+}
@@ -0,0 +1,42 @@
+package types
+
+import "strings"
+
+// Preferences are a human player's standing instructions for their own
+// turns, kept in their profile between games.
+type Preferences struct {
+	AutoCheck    bool `json:"autoCheck,omitempty"`    // Check at once whenever it is free
+	AutoFoldJunk bool `json:"autoFoldJunk,omitempty"` // Offer to fold weak starting hands facing a bet pre-flop
+	AutoMuck     bool `json:"autoMuck,omitempty"`     // Muck losing hands at showdown without being asked
+}
+
+// PreferenceNames are the short names Toggle and String use, in order.
+var PreferenceNames = []string{"check", "fold", "muck"}
+
+// Toggle switches the preference with the given short name and reports
+// whether there is one.
+func (p *Preferences) Toggle(name string) bool {
+	switch strings.ToLower(name) {
+	case "check":
+		p.AutoCheck = !p.AutoCheck
+	case "fold":
+		p.AutoFoldJunk = !p.AutoFoldJunk
+	case "muck":
+		p.AutoMuck = !p.AutoMuck
+	default:
+		return false
+	}
+	return true
+}
+
+// String lists each preference as on or off, e.g.
+// "auto-check off, auto-fold junk on, auto-muck off".
+func (p Preferences) String() string {
+	onOff := func(on bool) string {
+		if on {
+			return "on"
+		}
+		return "off"
+	}
+	return "auto-check " + onOff(p.AutoCheck) + ", auto-fold junk " + onOff(p.AutoFoldJunk) + ", auto-muck " + onOff(p.AutoMuck)
+}